@@ -19,13 +19,19 @@ This command performs a complete OpenFrame setup by running:
 This is equivalent to running both commands sequentially but provides
 a streamlined experience for getting started with OpenFrame.
 
+With --bundle, the images, charts and Git repository come from a bundle built
+by 'openframe bundle create', so no network access is needed. The bundle
+installs the oss-tenant deployment mode, and its Git repository is served to
+ArgoCD on port 8780 while bootstrap runs.
+
 Examples:
   openframe bootstrap                                    # Interactive mode (default)
   openframe bootstrap my-cluster                        # Bootstrap with custom cluster name
   openframe bootstrap --deployment-mode=oss-tenant     # Skip deployment selection
   openframe bootstrap --deployment-mode=saas-shared --non-interactive  # Full CI/CD mode
   openframe bootstrap --verbose                         # Show detailed logs including ArgoCD sync progress
-  openframe bootstrap -v --deployment-mode=oss-tenant  # Verbose mode with pre-selected deployment`,
+  openframe bootstrap -v --deployment-mode=oss-tenant  # Verbose mode with pre-selected deployment
+  openframe bootstrap --bundle openframe-bundle.tar    # Offline install from a bundle`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Logo will be shown by cluster wrapper before prerequisites
@@ -37,6 +43,7 @@ Examples:
 	cmd.Flags().String("deployment-mode", "", "Deployment mode: oss-tenant, saas-tenant, saas-shared (skips deployment selection)")
 	cmd.Flags().Bool("non-interactive", false, "Skip all prompts, use existing helm-values.yaml")
 	cmd.Flags().BoolP("verbose", "v", false, "Show detailed logging including ArgoCD sync progress")
+	cmd.Flags().String("bundle", "", "Install offline from a bundle archive built by 'openframe bundle create'")
 
 	return cmd
 }
@@ -63,3 +63,13 @@ func TestBootstrapArgumentValidation(t *testing.T) {
 		assert.Error(t, err, "Should reject more than one argument")
 	}
 }
+
+func TestBootstrapBundleFlag(t *testing.T) {
+	cmd := GetBootstrapCmd()
+
+	flag := cmd.Flags().Lookup("bundle")
+	if assert.NotNil(t, flag, "Bootstrap should have a bundle flag") {
+		assert.Empty(t, flag.DefValue)
+	}
+	assert.Contains(t, cmd.Long, "openframe bootstrap --bundle openframe-bundle.tar")
+}
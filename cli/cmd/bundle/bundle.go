@@ -0,0 +1,51 @@
+package bundle
+
+import (
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/spf13/cobra"
+)
+
+// GetBundleCmd returns the bundle command and its subcommands
+func GetBundleCmd() *cobra.Command {
+	bundleCmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Build and serve bundles for offline installs",
+		Long: `Bundle - Install OpenFrame without network access
+
+A bundle is one archive holding everything bootstrap downloads: the
+openframe-oss-tenant repository with the app-of-apps chart and the manifests
+tree, the argo-cd chart, and every container image the rendered manifests
+reference. Create it while online, then bootstrap from it anywhere:
+  • create - Build a bundle archive
+  • serve - Serve the Git repository of a bundle to ArgoCD
+
+  openframe bootstrap --bundle openframe-bundle.tar
+
+ArgoCD syncs from the Git repository of the bundle, served on port 8780 of
+this machine, so 'openframe bundle serve' has to run whenever ArgoCD syncs
+after bootstrap has returned.
+
+Examples:
+  openframe bundle create
+  openframe bundle create -o demo.tar --branch release-1.4 -f demo-values.yaml
+  openframe bundle serve demo.tar`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Show logo for subcommands, but not for the root bundle command
+			if cmd.Use != "bundle" {
+				ui.ShowLogoWithContext(cmd.Context())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ui.ShowLogoWithContext(cmd.Context())
+			return cmd.Help()
+		},
+	}
+
+	bundleCmd.AddCommand(
+		getCreateCmd(),
+		getServeCmd(),
+	)
+
+	return bundleCmd
+}
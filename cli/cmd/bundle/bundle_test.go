@@ -0,0 +1,77 @@
+package bundle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBundleCmd(t *testing.T) {
+	cmd := GetBundleCmd()
+
+	assert.Equal(t, "bundle", cmd.Use)
+	assert.Contains(t, cmd.Long, "openframe bootstrap --bundle")
+
+	names := make([]string, 0)
+	for _, subcmd := range cmd.Commands() {
+		names = append(names, subcmd.Name())
+	}
+	assert.ElementsMatch(t, []string{"create", "serve"}, names)
+}
+
+// subcommand returns the named subcommand with its flags parsed, as cobra would
+func subcommand(t *testing.T, name string, flags ...string) *cobra.Command {
+	t.Helper()
+	root := GetBundleCmd()
+	cmd, _, err := root.Find([]string{name})
+	require.NoError(t, err)
+	require.NoError(t, cmd.ParseFlags(flags))
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestCreateOptions_Defaults(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	opts, err := createOptions(subcommand(t, "create"))
+	require.NoError(t, err)
+	assert.Equal(t, "openframe-bundle.tar", opts.Output)
+	assert.Equal(t, "https://github.com/flamingo-stack/openframe-oss-tenant", opts.Repository)
+	assert.Equal(t, "main", opts.Branch)
+	assert.Empty(t, opts.ValuesFiles, "no helm-values.yaml to render with")
+
+	require.NoError(t, os.WriteFile("helm-values.yaml", []byte("{}"), 0o644))
+	opts, err = createOptions(subcommand(t, "create"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./helm-values.yaml"}, opts.ValuesFiles)
+}
+
+func TestCreateOptions_Flags(t *testing.T) {
+	dir := t.TempDir()
+	values := filepath.Join(dir, "demo-values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte("{}"), 0o644))
+
+	opts, err := createOptions(subcommand(t, "create", "-o", "demo.tar", "--branch", "release-1.4", "-f", values))
+	require.NoError(t, err)
+	assert.Equal(t, "demo.tar", opts.Output)
+	assert.Equal(t, "release-1.4", opts.Branch)
+	assert.Equal(t, []string{values}, opts.ValuesFiles)
+
+	_, err = createOptions(subcommand(t, "create", "-f", filepath.Join(dir, "missing.yaml")))
+	assert.EqualError(t, err, "values file "+filepath.Join(dir, "missing.yaml")+" does not exist")
+}
+
+func TestRunServe_NoBundle(t *testing.T) {
+	cmd := subcommand(t, "serve")
+	err := runServe(cmd, []string{filepath.Join(t.TempDir(), "missing.tar")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open bundle")
+}
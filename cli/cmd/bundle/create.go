@@ -0,0 +1,137 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/bundle"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/config"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/k3d"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getCreateCmd returns the create subcommand
+func getCreateCmd() *cobra.Command {
+	defaults := models.NewAppOfAppsConfig()
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Build a bundle archive for offline installs",
+		Long: `Build a bundle archive for offline installs
+
+Clones the repository, renders the argo-cd chart and the app-of-apps chart
+with your Helm values, and follows every ArgoCD Application they render into
+the repository, rendering its Helm chart, kustomization or manifests in turn.
+Chart dependencies are downloaded and committed into the bundled copy of the
+repository. Every image the rendered manifests reference is pulled and saved,
+together with the k3s, k3d proxy and k3d tools images clusters are created from.
+
+Kubernetes pulls images tagged latest on every start, which fails offline.
+Values that tag an image latest are set to the openframe-bundle tag, with pull
+policy IfNotPresent, and the image is saved under that tag. bootstrap --bundle
+installs with the same values. The bundle is refused when a container would
+still pull its image on every start.
+
+Render the bundle with the values you bootstrap with: images chosen by values,
+such as a private registry, are only bundled when the values select them.
+Applications whose source is outside the repository are reported and not
+bundled. Images that operators or init scripts pull at runtime, and not from
+a manifest, are not bundled either.
+
+Needs git, helm, kubectl, docker and k3d, and network access.
+
+Examples:
+  openframe bundle create
+  openframe bundle create -o demo.tar -f demo-values.yaml
+  openframe bundle create --branch release-1.4`,
+		Args:          cobra.NoArgs,
+		RunE:          runCreate,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().StringP("output", "o", "openframe-bundle.tar", "Path of the bundle archive")
+	cmd.Flags().String("repo", defaults.GitHubRepo, "Repository holding the app-of-apps chart and the manifests tree")
+	cmd.Flags().String("branch", defaults.GitHubBranch, "Branch of the repository")
+	cmd.Flags().StringArrayP("values", "f", nil, "Helm values to render app-of-apps with (default: ./helm-values.yaml when it exists)")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	opts, err := createOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exec := executor.NewRealCommandExecutor(false, false)
+	creator := bundle.NewCreator(exec, k3d.NewK3dManager(exec, false).HostImages).
+		WithProgress(func(step string) { pterm.Info.Println(step) })
+
+	manifest, err := creator.Create(ctx, opts)
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	for _, skipped := range manifest.Skipped {
+		pterm.Warning.Printf("Not bundled: %s\n", skipped)
+	}
+	pterm.Success.Printf("Bundled %s at %s with %d cluster and %d node images into %s\n",
+		manifest.Branch, shortCommit(manifest.Commit), len(manifest.ClusterImages), len(manifest.HostImages), opts.Output)
+	pterm.Info.Printf("Install it with: openframe bootstrap --bundle %s\n", opts.Output)
+	return nil
+}
+
+// createOptions reads the flags of the create command
+func createOptions(cmd *cobra.Command) (bundle.CreateOptions, error) {
+	var opts bundle.CreateOptions
+	var err error
+	if opts.Output, err = cmd.Flags().GetString("output"); err != nil {
+		return opts, err
+	}
+	if opts.Repository, err = cmd.Flags().GetString("repo"); err != nil {
+		return opts, err
+	}
+	if opts.Branch, err = cmd.Flags().GetString("branch"); err != nil {
+		return opts, err
+	}
+	if opts.ValuesFiles, err = cmd.Flags().GetStringArray("values"); err != nil {
+		return opts, err
+	}
+
+	if !cmd.Flags().Changed("values") {
+		if values := config.NewPathResolver().GetHelmValuesFile(); fileExists(values) {
+			opts.ValuesFiles = []string{values}
+		}
+	}
+	for _, file := range opts.ValuesFiles {
+		if !fileExists(file) {
+			return opts, fmt.Errorf("values file %s does not exist", file)
+		}
+	}
+	return opts, nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
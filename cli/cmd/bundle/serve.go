@@ -0,0 +1,61 @@
+package bundle
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/bundle"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getServeCmd returns the serve subcommand
+func getServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve FILE",
+		Short: "Serve the Git repository of a bundle to ArgoCD",
+		Long: `Serve the Git repository of a bundle to ArgoCD
+
+Serves the Git repository of a bundle read-only over HTTP until interrupted.
+A cluster bootstrapped from the bundle has ArgoCD sync from
+http://host.k3d.internal:8780/repo.git, which is this server: bootstrap runs it
+while it installs, and this command runs it afterwards, so that ArgoCD can
+sync again after a restart or a change to an application.
+
+Examples:
+  openframe bundle serve openframe-bundle.tar`,
+		Args:          cobra.ExactArgs(1),
+		RunE:          runServe,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().Int("port", bundle.DefaultPort, "Port to serve on, which the installed applications expect to be 8780")
+
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	port, err := cmd.Flags().GetInt("port")
+	if err != nil {
+		return err
+	}
+
+	b, err := bundle.OpenRepository(args[0])
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pterm.Info.Printf("Serving %s@%s of %s at %s\n", b.Manifest.Branch, shortCommit(b.Manifest.Commit), args[0], bundle.RepoURL(port))
+	pterm.Info.Println("Press Ctrl+C to stop")
+	if err := b.Serve(ctx, port); err != nil {
+		return err
+	}
+	pterm.Success.Println("Stopped serving the bundle")
+	return nil
+}
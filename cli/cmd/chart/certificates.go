@@ -0,0 +1,185 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/certificates"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// certificateExpiryWarning is how close to expiry the status output starts warning
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// getCertificatesCmd returns the certificates subcommand
+func getCertificatesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "certificates",
+		Aliases: []string{"certs"},
+		Short:   "Manage the local certificate authority",
+		Long: `Manage the local certificate authority used for the ingress certificate
+
+OpenFrame generates its own root CA in ~/.config/openframe/certs and uses it to
+issue the localhost certificate installed with the chart. The certificate is only
+reissued when it is close to expiry or does not cover the requested hosts.
+
+Examples:
+  openframe chart certificates status
+  openframe chart certificates renew --host openframe.test --host '*.openframe.test'
+  openframe chart certificates export ./openframe-ca.pem`,
+		// Certificates do not need a cluster, so skip the chart prerequisites check
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ui.ShowLogoWithContext(cmd.Context())
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCertificatesStatus(cmd, args)
+		},
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show the CA and certificate expiry",
+			Args:  cobra.NoArgs,
+			RunE:  runCertificatesStatus,
+		},
+		&cobra.Command{
+			Use:   "export <path>",
+			Short: "Export the root CA certificate for manual trust",
+			Args:  cobra.ExactArgs(1),
+			RunE:  runCertificatesExport,
+		},
+		getCertificatesRenewCmd(),
+	)
+
+	return cmd
+}
+
+// getCertificatesRenewCmd returns the renew subcommand
+func getCertificatesRenewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "renew",
+		Short: "Issue the certificate for additional hosts or renew it",
+		Long: `Issue the ingress certificate for additional hosts
+
+localhost, 127.0.0.1 and ::1 are always included. Hosts can be DNS names, IP
+addresses or wildcards such as '*.openframe.test'. Without --force the existing
+certificate is kept when it is still valid and already covers every host.
+
+--regenerate-ca replaces the root CA itself, e.g. when its key was lost. The new
+root CA is trusted again and every certificate it signed before stops working.`,
+		Args: cobra.NoArgs,
+		RunE: runCertificatesRenew,
+	}
+
+	cmd.Flags().StringSlice("host", nil, "Additional host for the certificate (repeatable)")
+	cmd.Flags().Bool("force", false, "Reissue the certificate even if it is still valid")
+	cmd.Flags().Bool("regenerate-ca", false, "Replace the root CA and reissue the certificate")
+	return cmd
+}
+
+func runCertificatesStatus(cmd *cobra.Command, args []string) error {
+	status, err := certificates.NewCertificateInstaller().Status()
+	if err != nil {
+		return err
+	}
+
+	if status.CA == nil {
+		pterm.Warning.Println("No local certificate authority has been generated yet")
+		pterm.Info.Println("It is created by 'openframe chart install' or 'openframe chart certificates renew'")
+		return nil
+	}
+
+	now := time.Now()
+	tableData := pterm.TableData{{"CERTIFICATE", "HOSTS", "EXPIRES", "PATH"}}
+	tableData = append(tableData, certificateRow("Root CA", status.CA, now))
+	if status.Leaf != nil {
+		tableData = append(tableData, certificateRow("Ingress", status.Leaf, now))
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		for _, row := range tableData[1:] {
+			fmt.Printf("%s\t%s\t%s\t%s\n", row[0], row[1], row[2], row[3])
+		}
+	}
+
+	if status.Leaf == nil {
+		pterm.Warning.Println("No certificate has been issued yet, run 'openframe chart certificates renew'")
+	}
+	pterm.Info.Printf("Root CA fingerprint (SHA-256): %s\n", status.CA.Fingerprint)
+	return nil
+}
+
+func certificateRow(name string, info *certificates.CertificateInfo, now time.Time) []string {
+	expires := info.NotAfter.Format("2006-01-02")
+	switch {
+	case info.ExpiresWithin(now, 0):
+		expires = pterm.Red(expires + " (expired)")
+	case info.ExpiresWithin(now, certificateExpiryWarning):
+		expires = pterm.Yellow(expires + " (renewal due)")
+	}
+
+	hosts := strings.Join(info.Hosts, ", ")
+	if hosts == "" {
+		hosts = "-"
+	}
+	return []string{name, hosts, expires, info.Path}
+}
+
+func runCertificatesExport(cmd *cobra.Command, args []string) error {
+	if err := certificates.NewCertificateInstaller().ExportCABundle(args[0]); err != nil {
+		return err
+	}
+	pterm.Success.Printf("Exported the root CA certificate to %s\n", args[0])
+	pterm.Info.Println("Import it as a trusted root authority in your browser or operating system")
+	return nil
+}
+
+func runCertificatesRenew(cmd *cobra.Command, args []string) error {
+	hosts, err := cmd.Flags().GetStringSlice("host")
+	if err != nil {
+		return err
+	}
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+	regenerateCA, err := cmd.Flags().GetBool("regenerate-ca")
+	if err != nil {
+		return err
+	}
+	if _, err := certificates.NormalizeHosts(hosts); err != nil {
+		return err
+	}
+
+	installer := certificates.NewCertificateInstaller().WithHosts(hosts...)
+	if regenerateCA {
+		if err := installer.RegenerateCA(); err != nil {
+			return err
+		}
+		pterm.Success.Println("Root CA replaced and certificate reissued")
+		return nil
+	}
+	if force {
+		if err := installer.ForceRegenerate(); err != nil {
+			return err
+		}
+		pterm.Success.Println("Certificate reissued")
+		return nil
+	}
+
+	reason, err := installer.EnsureCertificates()
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		pterm.Info.Println("Certificate is valid and covers every host, nothing to renew")
+		return nil
+	}
+	pterm.Success.Printf("Certificate issued (%s)\n", reason)
+	return nil
+}
@@ -0,0 +1,48 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificatesCommand(t *testing.T) {
+	cmd := getCertificatesCmd()
+
+	assert.Equal(t, "certificates", cmd.Name())
+	assert.Contains(t, cmd.Aliases, "certs")
+	assert.NotNil(t, cmd.PersistentPreRunE, "certificates should not run the cluster prerequisites check")
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"status", "export", "renew"}, names)
+}
+
+func TestCertificatesRenewFlags(t *testing.T) {
+	cmd := getCertificatesRenewCmd()
+
+	require.NoError(t, cmd.ParseFlags([]string{"--host", "openframe.test", "--host", "*.openframe.test", "--force", "--regenerate-ca"}))
+
+	hosts, err := cmd.Flags().GetStringSlice("host")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"openframe.test", "*.openframe.test"}, hosts)
+
+	force, err := cmd.Flags().GetBool("force")
+	require.NoError(t, err)
+	assert.True(t, force)
+
+	regenerateCA, err := cmd.Flags().GetBool("regenerate-ca")
+	require.NoError(t, err)
+	assert.True(t, regenerateCA)
+}
+
+func TestCertificatesRenewRejectsInvalidHost(t *testing.T) {
+	cmd := getCertificatesRenewCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--host", "a.*.test"}))
+
+	err := runCertificatesRenew(cmd, nil)
+	assert.ErrorContains(t, err, "wildcards are only allowed as the first label")
+}
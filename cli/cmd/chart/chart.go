@@ -16,12 +16,19 @@ func GetChartCmd() *cobra.Command {
 
 This command group provides ArgoCD chart lifecycle management:
   • install - Install ArgoCD on a cluster
+  • status - Show the app-of-apps installs and tenants of the cluster
+  • uninstall - Remove a saas-shared tenant from the cluster
+  • certificates - Manage the local certificate authority
+  • validate - Check helm-values.yaml against the values schema
+  • values - Print the effective values after merging -f files and --set
+  • wizard - Record the configuration wizard answers for non-interactive installs
 
 Requires an existing cluster created with 'openframe cluster create'.
 
 Examples:
   openframe chart install
-  openframe chart install my-cluster`,
+  openframe chart install my-cluster
+  openframe chart install --tenant acme`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Show logo for subcommands, but not for the root chart command
 			if cmd.Use != "chart" {
@@ -36,6 +43,6 @@ Examples:
 		},
 	}
 
-	cmd.AddCommand(getInstallCmd())
+	cmd.AddCommand(getInstallCmd(), getCertificatesCmd(), getValidateCmd(), getValuesCmd(), getWizardCmd(), getStatusCmd(), getUninstallCmd())
 	return cmd
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +27,10 @@ func TestChartRootCommand(t *testing.T) {
 	assert.Contains(t, cmd.Short, "Manage Helm charts")
 	assert.Contains(t, cmd.Long, "chart lifecycle management")
 }
+
+func TestTenantCommandsTakeCluster(t *testing.T) {
+	for _, cmd := range []*cobra.Command{getStatusCmd(), getUninstallCmd()} {
+		assert.NoError(t, cmd.Args(cmd, []string{"my-cluster"}), cmd.Name())
+		assert.Error(t, cmd.Args(cmd, []string{"a", "b"}), cmd.Name())
+	}
+}
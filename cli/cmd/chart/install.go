@@ -2,11 +2,16 @@ package chart
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/services"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	sharedErrors "github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // getInstallCmd returns the install subcommand
@@ -21,14 +26,19 @@ This command installs:
 2. App-of-apps from GitHub repository (configurable)
 
 The cluster must exist before running this command.
-Certificates are automatically regenerated during installation.
+Certificates are renewed during installation when they are close to expiry.
 
 Examples:
   openframe chart install                                    # Interactive mode (default)
   openframe chart install my-cluster                        # Install on specific cluster
   openframe chart install --deployment-mode=oss-tenant     # Skip deployment selection
   openframe chart install --deployment-mode=saas-shared --non-interactive  # Full CI/CD mode
-  openframe chart install --github-branch develop          # Use develop branch`,
+  openframe chart install --answers answers.yaml           # Answer every wizard question from a file
+  openframe chart install --skip-verify=ngrok               # Don't check the ngrok credentials
+  openframe chart install --tenant acme                     # Add the saas-shared tenant acme to the cluster
+  openframe chart install --github-branch develop          # Use develop branch
+  openframe chart install -f base.yaml -f local.yaml       # Merge values files in order
+  openframe chart install --set deployment.oss.repository.branch=feature-x`,
 		RunE:          runInstallCommand,
 		SilenceErrors: true, // Errors are handled by our custom error handler
 		SilenceUsage:  true, // Don't show usage on errors
@@ -64,6 +74,11 @@ func runInstallCommand(cmd *cobra.Command, args []string) error {
 		CertDir:        flags.CertDir,
 		DeploymentMode: flags.DeploymentMode,
 		NonInteractive: flags.NonInteractive,
+		ValuesFiles:    flags.ValuesFiles,
+		SetValues:      flags.SetValues,
+		AnswersFile:    flags.AnswersFile,
+		SkipVerify:     flags.SkipVerify,
+		Tenant:         flags.Tenant,
 	}
 
 	err = services.InstallChartsWithConfig(req)
@@ -83,6 +98,11 @@ type InstallFlags struct {
 	CertDir        string
 	DeploymentMode string
 	NonInteractive bool
+	ValuesFiles    []string
+	SetValues      []string
+	AnswersFile    string
+	SkipVerify     []string
+	Tenant         string
 }
 
 // extractInstallFlags extracts install flags from cobra command
@@ -118,6 +138,47 @@ func extractInstallFlags(cmd *cobra.Command) (*InstallFlags, error) {
 		return nil, err
 	}
 
+	if flags.ValuesFiles, err = cmd.Flags().GetStringArray("values"); err != nil {
+		return nil, err
+	}
+	if err := validateValuesFiles(flags.ValuesFiles); err != nil {
+		return nil, err
+	}
+
+	if flags.SetValues, err = cmd.Flags().GetStringArray("set"); err != nil {
+		return nil, err
+	}
+
+	if flags.AnswersFile, err = cmd.Flags().GetString("answers"); err != nil {
+		return nil, err
+	}
+
+	if flags.SkipVerify, err = cmd.Flags().GetStringSlice("skip-verify"); err != nil {
+		return nil, err
+	}
+	if err := services.ValidateCredentialChecks(flags.SkipVerify); err != nil {
+		return nil, err
+	}
+
+	if flags.Tenant, err = cmd.Flags().GetString("tenant"); err != nil {
+		return nil, err
+	}
+	if flags.Tenant != "" {
+		if err := models.ValidateTenantID(flags.Tenant); err != nil {
+			return nil, err
+		}
+		// Only the saas-shared deployment installs tenants
+		if flags.DeploymentMode != "" && flags.DeploymentMode != "saas-shared" {
+			return nil, fmt.Errorf("--tenant requires --deployment-mode=saas-shared, got %s", flags.DeploymentMode)
+		}
+		flags.DeploymentMode = "saas-shared"
+	}
+
+	// An answers file replaces every prompt
+	if flags.AnswersFile != "" {
+		flags.NonInteractive = true
+	}
+
 	// Validate deployment mode
 	if flags.DeploymentMode != "" {
 		validModes := []string{"oss-tenant", "saas-tenant", "saas-shared"}
@@ -133,8 +194,8 @@ func extractInstallFlags(cmd *cobra.Command) (*InstallFlags, error) {
 		}
 	}
 
-	// Validate non-interactive requires deployment mode
-	if flags.NonInteractive && flags.DeploymentMode == "" {
+	// Validate non-interactive requires deployment mode, unless the answers provide it
+	if flags.NonInteractive && flags.DeploymentMode == "" && flags.AnswersFile == "" && os.Getenv("OPENFRAME_DEPLOYMENT_MODE") == "" {
 		return nil, fmt.Errorf("--deployment-mode is required when using --non-interactive")
 	}
 
@@ -161,11 +222,51 @@ func getVerboseFlag(cmd *cobra.Command) bool {
 
 // addInstallFlags adds all install flags to the command
 func addInstallFlags(cmd *cobra.Command) {
-	cmd.Flags().BoolP("force", "f", false, "Force installation even if charts already exist")
+	cmd.Flags().Bool("force", false, "Force installation even if charts already exist")
 	cmd.Flags().Bool("dry-run", false, "Show what would be installed without executing")
 	cmd.Flags().String("github-repo", "https://github.com/flamingo-stack/openframe-oss-tenant", "GitHub repository URL")
 	cmd.Flags().String("github-branch", "main", "GitHub repository branch")
 	cmd.Flags().String("cert-dir", "", "Certificate directory (auto-detected if not provided)")
 	cmd.Flags().String("deployment-mode", "", "Deployment mode: oss-tenant, saas-tenant, saas-shared (skips deployment selection)")
 	cmd.Flags().Bool("non-interactive", false, "Skip all prompts, use existing helm-values.yaml")
+	cmd.Flags().String("answers", "", "Answers file for the configuration wizard, implies --non-interactive")
+	cmd.Flags().StringSlice("skip-verify", nil, "Skip credential checks: registry, ngrok, git, or all when given without a value")
+	cmd.Flags().Lookup("skip-verify").NoOptDefVal = services.CredentialCheckAll
+	cmd.Flags().String("tenant", "", "Install an isolated saas-shared tenant with this ID next to the other tenants of the cluster")
+	addValueLayerFlags(cmd)
+}
+
+// addValueLayerFlags adds the -f and --set flags that build the values from layers
+func addValueLayerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayP("values", "f", nil, "Values file, repeatable, later files take precedence (default: helm-values.yaml)")
+	cmd.Flags().StringArray("set", nil, "Set values on top of the values files (repeatable, e.g. a.b=x,c.d=true)")
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		if strings.Contains(err.Error(), "'f' in -f") {
+			return fmt.Errorf("%w\n%s", err, forceShorthandHint)
+		}
+		return err
+	})
+}
+
+// forceShorthandHint is for scripts that still use -f, which used to be the shorthand for --force
+const forceShorthandHint = "-f is the shorthand for --values and takes a values file, use --force to force the installation"
+
+// validateValuesFiles rejects -f arguments that are not values files. Files without a
+// .yaml or .yml extension have to exist and hold a YAML mapping.
+func validateValuesFiles(files []string) error {
+	for _, file := range files {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml":
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("values file %s: %w\n%s", file, err, forceShorthandHint)
+		}
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("values file %s is not YAML: %w\n%s", file, err, forceShorthandHint)
+		}
+	}
+	return nil
 }
@@ -2,6 +2,9 @@ package chart
 
 import (
 	"context"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -33,9 +36,11 @@ func TestInstallCommandFlags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("force"), "Should have force flag")
 	assert.NotNil(t, cmd.Flags().Lookup("dry-run"), "Should have dry-run flag")
 
-	// Test flag shorthand
+	// Test flag shorthand, -f selects values files like helm
 	forceFlag := cmd.Flags().Lookup("force")
-	assert.Equal(t, "f", forceFlag.Shorthand, "Force flag should have 'f' shorthand")
+	assert.Empty(t, forceFlag.Shorthand, "Force flag should not have a shorthand")
+	valuesFlag := cmd.Flags().Lookup("values")
+	assert.Equal(t, "f", valuesFlag.Shorthand, "Values flag should have 'f' shorthand")
 
 	// Test flag defaults
 	forceDefault, _ := cmd.Flags().GetBool("force")
@@ -96,6 +101,9 @@ func TestInstallCommandFlagHandling(t *testing.T) {
 				GitHubRepo:   "https://github.com/flamingo-stack/openframe-oss-tenant",
 				GitHubBranch: "main",
 				CertDir:      "",
+				ValuesFiles:  []string{},
+				SetValues:    []string{},
+				SkipVerify:   []string{},
 			},
 		},
 		{
@@ -111,6 +119,9 @@ func TestInstallCommandFlagHandling(t *testing.T) {
 				GitHubRepo:   "https://github.com/flamingo-stack/openframe-oss-tenant",
 				GitHubBranch: "develop",
 				CertDir:      "",
+				ValuesFiles:  []string{},
+				SetValues:    []string{},
+				SkipVerify:   []string{},
 			},
 		},
 	}
@@ -192,3 +203,96 @@ func TestRunInstallCommand(t *testing.T) {
 	assert.True(t, flags.Force, "Should extract force flag correctly")
 	assert.Equal(t, "develop", flags.GitHubBranch, "Should extract github-branch flag correctly")
 }
+
+func TestExtractInstallFlags_ValueLayers(t *testing.T) {
+	cmd := getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{
+		"-f", "base.yaml", "-f", "team.yaml", "--values", "local.yaml",
+		"--set", "deployment.oss.repository.branch=x", "--set", "environment=staging",
+	}))
+
+	flags, err := extractInstallFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"base.yaml", "team.yaml", "local.yaml"}, flags.ValuesFiles)
+	assert.Equal(t, []string{"deployment.oss.repository.branch=x", "environment=staging"}, flags.SetValues)
+}
+
+func TestExtractInstallFlags_ForceShorthandHint(t *testing.T) {
+	dir := t.TempDir()
+	valuesFile := filepath.Join(dir, "values")
+	require.NoError(t, os.WriteFile(valuesFile, []byte("global:\n  repoBranch: main\n"), 0644))
+	notValues := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(notValues, []byte("- not\n- a mapping\n"), 0644))
+
+	for _, args := range [][]string{{"-f", "my-cluster"}, {"-f", notValues}} {
+		cmd := getInstallCmd()
+		require.NoError(t, cmd.ParseFlags(args))
+		_, err := extractInstallFlags(cmd)
+		assert.ErrorContains(t, err, "use --force", args)
+	}
+
+	cmd := getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"-f", valuesFile}))
+	_, err := extractInstallFlags(cmd)
+	assert.NoError(t, err, "a values file without an extension is accepted")
+
+	cmd = getInstallCmd()
+	cmd.SetArgs([]string{"-f"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	assert.ErrorContains(t, cmd.Execute(), "use --force")
+}
+
+func TestExtractInstallFlags_Answers(t *testing.T) {
+	cmd := getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--answers", "answers.yaml"}))
+
+	flags, err := extractInstallFlags(cmd)
+	require.NoError(t, err, "The answers file can provide the deployment mode")
+	assert.Equal(t, "answers.yaml", flags.AnswersFile)
+	assert.True(t, flags.NonInteractive, "An answers file implies --non-interactive")
+
+	cmd = getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--non-interactive"}))
+	t.Setenv("OPENFRAME_DEPLOYMENT_MODE", "oss-tenant")
+	_, err = extractInstallFlags(cmd)
+	assert.NoError(t, err, "OPENFRAME_DEPLOYMENT_MODE replaces --deployment-mode")
+}
+
+func TestExtractInstallFlags_SkipVerify(t *testing.T) {
+	cmd := getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--skip-verify"}))
+	flags, err := extractInstallFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all"}, flags.SkipVerify, "--skip-verify without a value skips every check")
+
+	cmd = getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--skip-verify=ngrok,git"}))
+	flags, err = extractInstallFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ngrok", "git"}, flags.SkipVerify)
+
+	cmd = getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--skip-verify=dns"}))
+	_, err = extractInstallFlags(cmd)
+	assert.ErrorContains(t, err, "unknown credential check: dns")
+}
+
+func TestExtractInstallFlags_Tenant(t *testing.T) {
+	cmd := getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--tenant", "acme"}))
+	flags, err := extractInstallFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", flags.Tenant)
+	assert.Equal(t, "saas-shared", flags.DeploymentMode, "--tenant implies the saas-shared deployment mode")
+
+	cmd = getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--tenant", "acme", "--deployment-mode", "oss-tenant"}))
+	_, err = extractInstallFlags(cmd)
+	assert.ErrorContains(t, err, "--tenant requires --deployment-mode=saas-shared")
+
+	cmd = getInstallCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--tenant", "Acme_Corp"}))
+	_, err = extractInstallFlags(cmd)
+	assert.ErrorContains(t, err, `invalid tenant ID "Acme_Corp"`)
+}
@@ -0,0 +1,120 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/services"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getStatusCmd returns the status subcommand
+func getStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [cluster-name]",
+		Short: "Show the app-of-apps installs of a cluster",
+		Long: `Show the app-of-apps installs of a cluster
+
+The cluster is selected like in 'chart install': by name, or interactively.
+
+Without --tenant, lists the app-of-apps releases of the cluster: the
+single-tenant install and every tenant added with 'chart install --tenant'.
+
+With --tenant, shows the release of that tenant, the health and sync status of
+the ArgoCD applications in its project and the namespaces it deployed to.
+
+Examples:
+  openframe chart status
+  openframe chart status my-cluster
+  openframe chart status my-cluster --tenant acme`,
+		Args:          cobra.MaximumNArgs(1),
+		RunE:          runStatus,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().String("tenant", "", "Show the status of this saas-shared tenant")
+	return cmd
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	tenant, err := cmd.Flags().GetString("tenant")
+	if err != nil {
+		return err
+	}
+	if tenant != "" {
+		if err := models.ValidateTenantID(tenant); err != nil {
+			return err
+		}
+	}
+
+	verbose := getVerboseFlag(cmd)
+	clusterName, err := services.SelectAndTargetCluster(args, verbose)
+	if err != nil || clusterName == "" {
+		return err
+	}
+
+	manager := services.NewTenantManager(executor.NewRealCommandExecutor(false, verbose))
+	if tenant == "" {
+		tenants, err := manager.List(cmd.Context())
+		if err != nil {
+			return err
+		}
+		showTenantList(tenants)
+		return nil
+	}
+
+	status, err := manager.Status(cmd.Context(), tenant)
+	if err != nil {
+		return err
+	}
+	showTenantStatus(status)
+	return nil
+}
+
+// showTenantList prints one line per app-of-apps release
+func showTenantList(tenants []services.TenantStatus) {
+	if len(tenants) == 0 {
+		pterm.Info.Println("No app-of-apps release found, install one with 'openframe chart install'")
+		return
+	}
+
+	data := pterm.TableData{{"TENANT", "RELEASE", "STATUS"}}
+	for _, tenant := range tenants {
+		id := tenant.ID
+		if id == "" {
+			id = "(single tenant)"
+		}
+		data = append(data, []string{id, tenant.Release, tenant.ReleaseStatus})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+// showTenantStatus prints the release, applications and namespaces of a tenant
+func showTenantStatus(status *services.TenantStatus) {
+	pterm.Printf("Tenant:     %s\n", status.ID)
+	pterm.Printf("Release:    %s (%s)\n", status.Release, status.ReleaseStatus)
+	pterm.Printf("Project:    %s\n", status.Project)
+	pterm.Printf("Host:       %s\n", models.TenantHost(status.ID))
+	fmt.Println()
+
+	if len(status.Applications) == 0 {
+		pterm.Info.Println("No ArgoCD applications in the tenant project yet")
+	} else {
+		data := pterm.TableData{{"APPLICATION", "HEALTH", "SYNC"}}
+		for _, application := range status.Applications {
+			data = append(data, []string{application.Name, application.Health, application.Sync})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+	fmt.Println()
+
+	if len(status.Namespaces) == 0 {
+		pterm.Info.Printf("No namespaces labelled openframe.io/tenant=%s yet\n", status.ID)
+		return
+	}
+	pterm.Printf("Namespaces: %s\n", strings.Join(status.Namespaces, ", "))
+}
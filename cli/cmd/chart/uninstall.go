@@ -0,0 +1,81 @@
+package chart
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/services"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getUninstallCmd returns the uninstall subcommand
+func getUninstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uninstall [cluster-name] --tenant <id>",
+		Short: "Remove a saas-shared tenant from a cluster",
+		Long: `Remove a saas-shared tenant from a cluster
+
+The cluster is selected like in 'chart install': by name, or interactively.
+
+Removes what 'chart install --tenant' created for the tenant: its app-of-apps
+release, the ArgoCD applications left in its project, the project itself and
+the namespaces labelled openframe.io/tenant=<id>. ArgoCD and the other tenants
+keep running.
+
+Examples:
+  openframe chart uninstall --tenant acme
+  openframe chart uninstall my-cluster --tenant acme
+  openframe chart uninstall my-cluster --tenant acme --force   # Don't ask for confirmation`,
+		Args:          cobra.MaximumNArgs(1),
+		RunE:          runUninstall,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().String("tenant", "", "Tenant to remove (required)")
+	cmd.Flags().Bool("force", false, "Skip the confirmation prompt")
+	_ = cmd.MarkFlagRequired("tenant")
+	return cmd
+}
+
+func runUninstall(cmd *cobra.Command, args []string) error {
+	tenant, err := cmd.Flags().GetString("tenant")
+	if err != nil {
+		return err
+	}
+	if err := models.ValidateTenantID(tenant); err != nil {
+		return err
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+	verbose := getVerboseFlag(cmd)
+	clusterName, err := services.SelectAndTargetCluster(args, verbose)
+	if err != nil || clusterName == "" {
+		return err
+	}
+
+	if !force {
+		confirmed, err := sharedUI.ConfirmActionInteractive(fmt.Sprintf("Are you sure you want to delete tenant '%s' from cluster '%s'?", tenant, clusterName), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			pterm.Info.Println("Uninstall cancelled.")
+			return nil
+		}
+	}
+
+	manager := services.NewTenantManager(executor.NewRealCommandExecutor(false, verbose))
+	if err := manager.Uninstall(cmd.Context(), tenant); err != nil {
+		return fmt.Errorf("failed to uninstall tenant %s: %w", tenant, err)
+	}
+
+	pterm.Success.Printf("Tenant %s uninstalled\n", tenant)
+	return nil
+}
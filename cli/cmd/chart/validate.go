@@ -0,0 +1,90 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getValidateCmd returns the validate subcommand
+func getValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check helm-values.yaml against the values schema",
+		Long: `Check values against the app-of-apps values schema
+
+Reports unknown keys, wrong types and invalid values with the file they come
+from, their YAML path, line and column, and suggests the closest key for likely
+typos. The same check runs before every 'openframe chart install'.
+
+Values files given with -f and --set overrides are merged like 'chart install'
+merges them, and the result is validated. SOPS and age encrypted values are
+decrypted in memory first.
+
+The schema built into the CLI is used unless --schema points to another one,
+such as the values.schema.json of a chart checkout.
+
+Examples:
+  openframe chart validate
+  openframe chart validate -f ./my-values.yaml
+  openframe chart validate -f base.yaml -f local.yaml --set environment=staging
+  openframe chart validate --schema ../openframe/manifests/app-of-apps/values.schema.json`,
+		Args: cobra.NoArgs,
+		// Validation does not need a cluster, so skip the chart prerequisites check
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ui.ShowLogoWithContext(cmd.Context())
+			return nil
+		},
+		RunE:          runValidate,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	addValueLayerFlags(cmd)
+	cmd.Flags().String("schema", "", "JSON schema to validate against (default: built-in schema)")
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	schemaFile, err := cmd.Flags().GetString("schema")
+	if err != nil {
+		return err
+	}
+
+	schema := helmvalues.DefaultSchema()
+	if schemaFile != "" {
+		if schema, err = helmvalues.LoadSchema(schemaFile); err != nil {
+			return fmt.Errorf("failed to load schema: %w", err)
+		}
+	}
+
+	// Decrypt so that encrypted files are checked with their real types
+	layers, err := loadValueLayers(cmd, secrets.NewDefaultManager())
+	if err != nil {
+		return err
+	}
+	if len(layers) == 0 {
+		return fmt.Errorf("no values to validate: helm-values.yaml not found, pass a values file with -f")
+	}
+	values, origins := helmvalues.Merge(layers)
+
+	var violations helmvalues.ValidationErrors
+	if err := schema.Validate(values); !errors.As(err, &violations) {
+		pterm.Success.Println("Values match the values schema")
+		return nil
+	}
+
+	for _, violation := range violations {
+		if source := origins.Source(violation.Path); source != "" {
+			pterm.Error.Printf("%s: %s\n", source, violation.Error())
+		} else {
+			pterm.Error.Println(violation.Error())
+		}
+	}
+	return fmt.Errorf("found %d schema violation(s)", len(violations))
+}
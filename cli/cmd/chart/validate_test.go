@@ -0,0 +1,55 @@
+package chart
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runValidateWithFlags(t *testing.T, flags ...string) error {
+	t.Helper()
+	cmd := getValidateCmd()
+	require.NoError(t, cmd.ParseFlags(flags))
+	return runValidate(cmd, nil)
+}
+
+func TestValidateCommand(t *testing.T) {
+	cmd := getValidateCmd()
+
+	assert.Equal(t, "validate", cmd.Name())
+	assert.NotNil(t, cmd.PersistentPreRunE, "validate should not run the cluster prerequisites check")
+	assert.NotNil(t, cmd.Flags().ShorthandLookup("f"))
+	assert.NotNil(t, cmd.Flags().Lookup("set"))
+}
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte("deployment:\n  oss:\n    enabled: true\n"), 0644))
+	require.NoError(t, os.WriteFile(invalid, []byte("deployment:\n  oss:\n    ingres: {}\n    enabled: maybe\n"), 0644))
+
+	assert.NoError(t, runValidateWithFlags(t, "-f", valid))
+	assert.EqualError(t, runValidateWithFlags(t, "-f", invalid), "found 2 schema violation(s)")
+	assert.ErrorContains(t, runValidateWithFlags(t, "-f", filepath.Join(dir, "missing.yaml")), "not found")
+
+	// Layers are merged before validating
+	assert.EqualError(t, runValidateWithFlags(t, "-f", valid, "--set", "deployment.oss.enabled=yes"), "found 1 schema violation(s)")
+	assert.NoError(t, runValidateWithFlags(t, "-f", invalid, "--set", "deployment.oss.enabled=true,deployment.oss.ingres=null"))
+
+	schema := filepath.Join(dir, "values.schema.json")
+	require.NoError(t, os.WriteFile(schema, []byte(`{"properties": {"deployment": {"type": "string"}}}`), 0644))
+	assert.Error(t, runValidateWithFlags(t, "-f", valid, "--schema", schema))
+}
+
+func TestRunValidate_NoValuesFile(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	assert.ErrorContains(t, runValidateWithFlags(t), "helm-values.yaml not found")
+}
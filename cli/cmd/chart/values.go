@@ -0,0 +1,106 @@
+package chart
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
+	"github.com/spf13/cobra"
+)
+
+// getValuesCmd returns the values subcommand
+func getValuesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "values",
+		Short: "Print the effective values after merging -f files and --set",
+		Long: `Print the values 'chart install' starts from
+
+Values files are deep-merged in the order given, followed by the --set
+overrides, with Helm semantics: mappings are merged key by key, while scalars,
+lists and nulls replace the earlier value. Without -f, helm-values.yaml from the
+current directory is used.
+
+Each value is annotated with the file or --set layer it came from. The choices
+made in the 'chart install' wizard are applied on top of this result.
+
+Encrypted secrets are printed as stored unless --decrypt is given, see
+'openframe secrets'.
+
+Examples:
+  openframe chart values
+  openframe chart values -f base.yaml -f team.yaml -f local.yaml
+  openframe chart values -f base.yaml --set deployment.oss.repository.branch=feature-x
+  openframe chart values --no-origins > merged-values.yaml
+  openframe chart values --decrypt`,
+		Args: cobra.NoArgs,
+		// Output is meant for piping, so skip the logo and the prerequisites check
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+		RunE:          runValues,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	addValueLayerFlags(cmd)
+	cmd.Flags().Bool("no-origins", false, "Print plain YAML without the source of each value")
+	cmd.Flags().Bool("decrypt", false, "Decrypt SOPS and age encrypted values with the local age key")
+	return cmd
+}
+
+func runValues(cmd *cobra.Command, args []string) error {
+	noOrigins, err := cmd.Flags().GetBool("no-origins")
+	if err != nil {
+		return err
+	}
+
+	decrypt, err := cmd.Flags().GetBool("decrypt")
+	if err != nil {
+		return err
+	}
+
+	var decrypter templates.ValuesDecrypter
+	if decrypt {
+		decrypter = secrets.NewDefaultManager()
+	}
+	layers, err := loadValueLayers(cmd, decrypter)
+	if err != nil {
+		return err
+	}
+	values, origins := helmvalues.Merge(layers)
+
+	var out []byte
+	if noOrigins {
+		out, err = values.Bytes()
+	} else {
+		out, err = helmvalues.RenderWithOrigins(values, origins)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprint(cmd.OutOrStdout(), string(out))
+	return err
+}
+
+// loadValueLayers loads the layers selected by the -f and --set flags, lowest priority first.
+// Encrypted layers are decrypted when decrypter is not nil.
+func loadValueLayers(cmd *cobra.Command, decrypter templates.ValuesDecrypter) ([]helmvalues.Layer, error) {
+	files, err := cmd.Flags().GetStringArray("values")
+	if err != nil {
+		return nil, err
+	}
+	if err := validateValuesFiles(files); err != nil {
+		return nil, err
+	}
+	sets, err := cmd.Flags().GetStringArray("set")
+	if err != nil {
+		return nil, err
+	}
+	modifier := templates.NewHelmValuesModifier().WithValueLayers(files, sets)
+	if decrypter != nil {
+		modifier.WithDecrypter(decrypter)
+	}
+	return modifier.LoadValueLayers()
+}
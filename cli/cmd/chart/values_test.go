@@ -0,0 +1,39 @@
+package chart
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunValues(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	local := filepath.Join(dir, "local.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("# base\ndeployment:\n  oss:\n    enabled: true\n    repository:\n      branch: main\n"), 0644))
+	require.NoError(t, os.WriteFile(local, []byte("deployment:\n  oss:\n    repository:\n      branch: develop\n"), 0644))
+
+	run := func(flags ...string) string {
+		cmd := getValuesCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		require.NoError(t, cmd.ParseFlags(flags))
+		require.NoError(t, runValues(cmd, nil))
+		return out.String()
+	}
+
+	assert.Equal(t, `deployment:
+  oss:
+    enabled: true # `+base+`
+    repository:
+      branch: feature-x # --set
+environment: staging # --set
+`, run("-f", base, "-f", local, "--set", "deployment.oss.repository.branch=feature-x,environment=staging"))
+
+	assert.Equal(t, "# base\ndeployment:\n  oss:\n    enabled: true\n    repository:\n      branch: develop\n",
+		run("-f", base, "-f", local, "--no-origins"))
+}
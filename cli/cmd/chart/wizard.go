@@ -0,0 +1,97 @@
+package chart
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/configuration"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getWizardCmd returns the wizard subcommand
+func getWizardCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wizard",
+		Short: "Record the configuration wizard answers for non-interactive installs",
+		Long: `Record the configuration wizard answers for non-interactive installs
+
+Runs the same configuration wizard as 'chart install' without installing
+anything, and saves the answers to the file given with --record. Pass that file
+to 'chart install --answers' to repeat the configuration without prompts, for
+example in CI.
+
+The answers file holds the registry passwords, ngrok credentials and SaaS
+repository token in plain text and is only readable by the current user. Use
+--omit-secrets to leave them out, they are then taken from the OPENFRAME_*
+environment variables or the values files at install time.
+
+Examples:
+  openframe chart wizard --record answers.yaml
+  openframe chart wizard --record answers.yaml --omit-secrets
+  openframe chart install --answers answers.yaml`,
+		Args: cobra.NoArgs,
+		// Nothing is installed, so the prerequisites check is skipped
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			ui.ShowLogoWithContext(cmd.Context())
+			return nil
+		},
+		RunE:          runWizard,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	addValueLayerFlags(cmd)
+	cmd.Flags().String("record", "", "File to save the answers to")
+	cmd.Flags().Bool("omit-secrets", false, "Leave passwords, tokens and API keys out of the answers file")
+	_ = cmd.MarkFlagRequired("record")
+	return cmd
+}
+
+func runWizard(cmd *cobra.Command, args []string) error {
+	record, err := cmd.Flags().GetString("record")
+	if err != nil {
+		return err
+	}
+	omitSecrets, err := cmd.Flags().GetBool("omit-secrets")
+	if err != nil {
+		return err
+	}
+	files, err := cmd.Flags().GetStringArray("values")
+	if err != nil {
+		return err
+	}
+	if err := validateValuesFiles(files); err != nil {
+		return err
+	}
+	sets, err := cmd.Flags().GetStringArray("set")
+	if err != nil {
+		return err
+	}
+
+	wizard := configuration.NewConfigurationWizard().WithValueLayers(files, sets).WithDecrypter(secrets.NewDefaultManager())
+	config, err := wizard.ConfigureHelmValues()
+	if err != nil {
+		return fmt.Errorf("configuration wizard failed: %w", err)
+	}
+	// Only the answers are kept, the values are built again at install time
+	if config.TempHelmValuesPath != "" {
+		_ = os.Remove(config.TempHelmValuesPath)
+	}
+
+	answers := configuration.AnswersFromConfiguration(config)
+	if omitSecrets {
+		answers.OmitSecrets()
+	}
+	if err := answers.Write(record); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Saved the answers to %s, install with 'openframe chart install --answers %s'\n", record, record)
+	if !omitSecrets {
+		pterm.Warning.Println("The answers file holds credentials in plain text, do not commit it unencrypted")
+	}
+	return nil
+}
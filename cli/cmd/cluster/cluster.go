@@ -24,9 +24,17 @@ This command group provides cluster lifecycle management functionality:
   • delete - Remove a cluster and clean up resources  
   • list - Show all managed clusters
   • status - Display detailed cluster information
+  • scale - Add or remove agent nodes of a running cluster
+  • upgrade - Upgrade Kubernetes of a running cluster in place
+  • ports - Show the host ports and URLs of a cluster
+  • kubeconfig - Print, save or merge the kubeconfig of a cluster
+  • use - Switch kubectl and telepresence to a cluster
+  • register - Register an existing cluster by kubeconfig context
   • cleanup - Remove unused images and resources
+  • reap - Delete expired clusters and leftover resources
 
-Supports K3d clusters for local development.
+Supports K3d clusters for local development, and existing clusters registered
+by kubeconfig context.
 
 Examples:
   openframe cluster create
@@ -51,7 +59,14 @@ Examples:
 		getDeleteCmd(),
 		getListCmd(),
 		getStatusCmd(),
+		getScaleCmd(),
+		getUpgradeCmd(),
+		getPortsCmd(),
+		getKubeconfigCmd(),
+		getUseCmd(),
+		getRegisterCmd(),
 		getCleanupCmd(),
+		getReapCmd(),
 	)
 
 	// Add global flags
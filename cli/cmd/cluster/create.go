@@ -27,11 +27,25 @@ Creates a local cluster for OpenFrame development. Existing clusters
 with the same name will be recreated. Use bootstrap command to install
 OpenFrame components after creation.
 
+The API, HTTP and HTTPS host ports of each cluster are saved and reused when
+the cluster is recreated. Reserve specific ports with --api-port, --http-port
+and --https-port, and list them with 'openframe cluster ports'.
+
+The kubectl context switches to the new cluster unless --no-switch-context is
+given; switch later with 'openframe cluster use'.
+
+Clusters for CI can be given a lifetime with --ttl and labels with --label;
+'openframe cluster reap' deletes them once they expire, and
+'openframe cluster list --label' finds them.
+
 Examples:
   openframe cluster create                    # Show creation mode selection
   openframe cluster create my-cluster        # Show selection with custom name
   openframe cluster create --skip-wizard     # Direct creation with defaults
-  openframe cluster create --nodes 3 --type k3d --skip-wizard`,
+  openframe cluster create --nodes 3 --type k3d --skip-wizard
+  openframe cluster create dev --http-port 8080 --https-port 8443 --skip-wizard
+  openframe cluster create scratch --no-switch-context --skip-wizard
+  openframe cluster create e2e-123 --ttl 2h --label ci-run=123 --skip-wizard`,
 		Args: cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			utils.SyncGlobalFlags()
@@ -62,6 +76,11 @@ func runCreateCluster(cmd *cobra.Command, args []string) error {
 
 	var config models.ClusterConfig
 
+	labels, err := models.ParseLabels(globalFlags.Create.Labels)
+	if err != nil {
+		return err
+	}
+
 	// Check if we should use interactive mode
 	if !globalFlags.Create.SkipWizard {
 		// Use UI layer to handle cluster configuration
@@ -82,6 +101,10 @@ func runCreateCluster(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
+		config.Ports = globalFlags.Create.Ports
+		config.NoSwitchContext = globalFlags.Create.NoSwitchContext
+		config.TTL = globalFlags.Create.TTL
+		config.Labels = labels
 	} else {
 		// Non-interactive mode - build config from flags and args
 		clusterName := ""
@@ -106,10 +129,14 @@ func runCreateCluster(cmd *cobra.Command, args []string) error {
 		}
 
 		config = models.ClusterConfig{
-			Name:       clusterName,
-			Type:       models.ClusterType(globalFlags.Create.ClusterType),
-			K8sVersion: globalFlags.Create.K8sVersion,
-			NodeCount:  nodeCount,
+			Name:            clusterName,
+			Type:            models.ClusterType(globalFlags.Create.ClusterType),
+			K8sVersion:      globalFlags.Create.K8sVersion,
+			NodeCount:       nodeCount,
+			Ports:           globalFlags.Create.Ports,
+			NoSwitchContext: globalFlags.Create.NoSwitchContext,
+			TTL:             globalFlags.Create.TTL,
+			Labels:          labels,
 		}
 
 		// Set defaults if needed
@@ -0,0 +1,100 @@
+package cluster
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getKubeconfigCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	kubeconfigCmd := &cobra.Command{
+		Use:   "kubeconfig [NAME]",
+		Short: "Print, save or merge the kubeconfig of a cluster",
+		Long: `Print the kubeconfig of a cluster, write it to a file or merge it into the
+default kubeconfig.
+
+The kubeconfig is self-contained: certificates are embedded and its current
+context is the cluster's, so it can be handed to other tools with
+KUBECONFIG=<file>. Files are written readable by the owner only.
+
+--merge adds the cluster's context to the default kubeconfig ($KUBECONFIG or
+~/.kube/config) without changing its current context; use 'openframe cluster
+use' to switch to it.
+
+Examples:
+  openframe cluster kubeconfig my-cluster
+  openframe cluster kubeconfig my-cluster --output ~/.kube/my-cluster.yaml
+  openframe cluster kubeconfig my-cluster --merge`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			if err := utils.ValidateGlobalFlags(); err != nil {
+				return err
+			}
+			return models.ValidateKubeconfigFlags(utils.GetGlobalFlags().Kubeconfig)
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runClusterKubeconfig),
+	}
+
+	// Add kubeconfig-specific flags
+	models.AddKubeconfigFlags(kubeconfigCmd, utils.GetGlobalFlags().Kubeconfig)
+
+	return kubeconfigCmd
+}
+
+func runClusterKubeconfig(cmd *cobra.Command, args []string) error {
+	service := utils.GetCommandService()
+	operationsUI := ui.NewOperationsUI()
+	flags := utils.GetGlobalFlags().Kubeconfig
+
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterName, err := operationsUI.SelectClusterForOperation(clusters, args, "get the kubeconfig of")
+	if err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	switch {
+	case flags.Merge:
+		path, err := service.MergeKubeconfig(clusterName)
+		if err != nil {
+			return err
+		}
+		contextName, _, err := service.KubeContext(clusterName)
+		if err != nil {
+			return err
+		}
+		pterm.Success.Printf("Merged context %s into %s\n", contextName, path)
+		pterm.Info.Printf("Switch to it with: openframe cluster use %s\n", clusterName)
+	case flags.Output != "":
+		path, err := filepath.Abs(expandHome(flags.Output))
+		if err != nil {
+			return fmt.Errorf("invalid --output: %w", err)
+		}
+		if err := service.WriteKubeconfig(clusterName, path); err != nil {
+			return err
+		}
+		pterm.Success.Printf("Wrote kubeconfig of cluster '%s' to %s\n", clusterName, path)
+	default:
+		content, err := service.GetKubeconfig(clusterName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), content)
+	}
+	return nil
+}
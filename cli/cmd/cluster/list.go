@@ -17,13 +17,16 @@ func getListCmd() *cobra.Command {
 		Short: "List all Kubernetes clusters",
 		Long: `List all Kubernetes clusters managed by OpenFrame CLI.
 
-Displays cluster information including name, type, status, and node count
-from all registered providers in a formatted table.
+Displays cluster information including name, type, status, node count and,
+for clusters created with --ttl, when they expire, from all registered
+providers in a formatted table. --label only lists the clusters created with
+the given labels.
 
 Examples:
   openframe cluster list
   openframe cluster list --verbose
-  openframe cluster list --quiet`,
+  openframe cluster list --quiet
+  openframe cluster list --label ci-run=123`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			utils.SyncGlobalFlags()
 			if err := utils.ValidateGlobalFlags(); err != nil {
@@ -56,7 +59,13 @@ func runListClusters(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list clusters: %w", err)
 	}
 
-	// Use the service to display the clusters
 	globalFlags := utils.GetGlobalFlags()
+	selector, err := models.ParseLabels(globalFlags.List.Labels)
+	if err != nil {
+		return err
+	}
+	clusters = models.FilterClustersByLabels(clusters, selector)
+
+	// Use the service to display the clusters
 	return service.DisplayClusterList(clusters, globalFlags.List.Quiet, globalFlags.Global.Verbose)
 }
@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getPortsCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	portsCmd := &cobra.Command{
+		Use:   "ports [NAME]",
+		Short: "Show the host ports and URLs of a cluster",
+		Long: `Show every host port a cluster publishes and the URLs they serve.
+
+Lists the host to container port mappings of the cluster nodes, followed by
+the ingress URLs and the Kubernetes API address of the kubeconfig. The API,
+HTTP and HTTPS ports are saved when a cluster is created and reused when it
+is recreated; reserve other ports with 'openframe cluster create --api-port,
+--http-port and --https-port'. When DOCKER_HOST points at another machine,
+the URLs use its address.
+
+Examples:
+  openframe cluster ports my-cluster
+  openframe cluster ports  # interactive selection`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runClusterPorts),
+	}
+
+	return portsCmd
+}
+
+func runClusterPorts(cmd *cobra.Command, args []string) error {
+	service := utils.GetCommandService()
+	operationsUI := ui.NewOperationsUI()
+
+	// Get all available clusters
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterName, err := operationsUI.SelectClusterForOperation(clusters, args, "show ports of")
+	if err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	bindings, err := service.GetPortBindings(clusterName)
+	if err != nil {
+		return err
+	}
+	showPortBindings(clusterName, service.DockerHost(), bindings)
+	return nil
+}
+
+// showPortBindings prints the port mappings of a cluster and the URLs they serve on
+// dockerHost, empty for this machine
+func showPortBindings(clusterName, dockerHost string, bindings []models.PortBinding) {
+	if len(bindings) == 0 {
+		pterm.Info.Printf("Cluster %s publishes no host ports\n", clusterName)
+		return
+	}
+
+	ports := models.PortAssignment{Host: dockerHost}
+	data := pterm.TableData{{"HOST", "CONTAINER", "NODE", "PURPOSE"}}
+	for _, binding := range bindings {
+		hostIP := binding.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		data = append(data, []string{
+			hostIP + ":" + strconv.Itoa(binding.HostPort),
+			binding.ContainerPort,
+			binding.Node,
+			binding.Purpose(),
+		})
+
+		switch binding.Purpose() {
+		case "Kubernetes API":
+			ports.API = binding.HostPort
+		case "Ingress HTTP":
+			ports.HTTP = binding.HostPort
+		case "Ingress HTTPS":
+			ports.HTTPS = binding.HostPort
+		}
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	fmt.Println()
+
+	if ports.HTTP != 0 {
+		pterm.Printf("Ingress (HTTP):   %s\n", ports.HTTPURL())
+	}
+	if ports.HTTPS != 0 {
+		pterm.Printf("Ingress (HTTPS):  %s\n", ports.HTTPSURL())
+	}
+	if ports.API != 0 {
+		pterm.Printf("Kubernetes API:   %s\n", ports.APIServerURL())
+	}
+}
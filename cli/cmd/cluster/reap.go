@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getReapCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	reapCmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Delete expired clusters and leftover resources",
+		Long: `Delete expired or broken clusters and the resources leaked by killed jobs.
+
+Removes, without asking:
+  • k3d clusters created with --ttl whose lifetime is over
+  • k3d clusters without a server node, left by an interrupted create or delete
+  • k3d Docker networks and volumes older than ten minutes whose cluster no longer exists
+  • openframe-chart-* and k3d-config-* temporary files older than an hour
+
+Clusters without a TTL and registered external clusters are never reaped.
+Run it at the start or end of CI jobs, or on a schedule on shared runners.
+With --dry-run, lists what would be removed.
+
+Examples:
+  openframe cluster reap
+  openframe cluster reap --dry-run`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runClusterReap),
+	}
+
+	return reapCmd
+}
+
+func runClusterReap(cmd *cobra.Command, args []string) error {
+	globalFlags := utils.GetGlobalFlags()
+
+	// Listing changes nothing, so it also runs in dry runs
+	plan, err := utils.GetInspectionService().PlanReap()
+	if err != nil {
+		return err
+	}
+	if plan.Empty() {
+		pterm.Info.Println("Nothing to reap")
+		return nil
+	}
+
+	if globalFlags.Global.DryRun {
+		pterm.Info.Println("Would reap:")
+		showReapPlan(plan)
+		return nil
+	}
+
+	reaped, err := utils.GetCommandService().Reap(plan)
+	showReapPlan(reaped)
+	return err
+}
+
+// showReapPlan lists the clusters, Docker resources and files of a plan
+func showReapPlan(plan models.ReapPlan) {
+	for _, cluster := range plan.Clusters {
+		pterm.Printf("  - cluster %s (%s)\n", cluster.Name, cluster.Reason)
+	}
+	for _, network := range plan.Networks {
+		pterm.Printf("  - network %s\n", network)
+	}
+	for _, volume := range plan.Volumes {
+		pterm.Printf("  - volume %s\n", volume)
+	}
+	for _, path := range plan.TempFiles {
+		pterm.Printf("  - file %s\n", path)
+	}
+}
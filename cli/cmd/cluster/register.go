@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/external"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getRegisterCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	registerCmd := &cobra.Command{
+		Use:   "register NAME",
+		Short: "Register an existing cluster by kubeconfig context",
+		Long: `Register a cluster created outside OpenFrame, such as a shared k3s VM or the
+Docker Desktop cluster, by its kubeconfig context.
+
+Registered clusters have the type 'external'. They are listed with the k3d
+clusters and can be used with 'chart install', 'cluster status', 'dev intercept'
+and 'cluster cleanup'. 'cluster delete' only unregisters them: the cluster
+itself is never stopped or removed.
+
+After registering, the Kubernetes version, the default StorageClass and the
+ingress controller of the cluster are checked. Failed checks are reported as
+warnings; fix them before installing OpenFrame.
+
+Examples:
+  openframe cluster register desktop --context docker-desktop
+  openframe cluster register shared-vm --context default --kubeconfig ~/.kube/shared-vm.yaml`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			if err := utils.ValidateGlobalFlags(); err != nil {
+				return err
+			}
+			return models.ValidateRegisterFlags(utils.GetGlobalFlags().Register)
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runRegisterCluster),
+	}
+
+	// Add register-specific flags
+	models.AddRegisterFlags(registerCmd, utils.GetGlobalFlags().Register)
+
+	return registerCmd
+}
+
+func runRegisterCluster(cmd *cobra.Command, args []string) error {
+	globalFlags := utils.GetGlobalFlags()
+	name := strings.TrimSpace(args[0])
+	if err := models.ValidateClusterName(name); err != nil {
+		return err
+	}
+
+	registration := external.Registration{
+		Name:    name,
+		Context: strings.TrimSpace(globalFlags.Register.Context),
+	}
+	if globalFlags.Register.Kubeconfig != "" {
+		kubeconfig, err := filepath.Abs(expandHome(globalFlags.Register.Kubeconfig))
+		if err != nil {
+			return fmt.Errorf("invalid --kubeconfig: %w", err)
+		}
+		registration.Kubeconfig = kubeconfig
+	}
+
+	if globalFlags.Global.DryRun {
+		pterm.Info.Printf("Would register cluster '%s' with context %s\n", registration.Name, registration.Context)
+		return nil
+	}
+
+	capabilities, err := utils.GetCommandService().RegisterCluster(registration)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Registered external cluster '%s' (context %s)\n", registration.Name, registration.Context)
+	fmt.Println()
+	pterm.Info.Printf("Capabilities:\n")
+	cluster.ShowCapabilities(capabilities)
+
+	for _, capability := range capabilities {
+		if !capability.OK {
+			fmt.Println()
+			pterm.Warning.Println("Some checks failed, OpenFrame may not install or be reachable until they are fixed")
+			break
+		}
+	}
+
+	fmt.Println()
+	pterm.Info.Printf("Next: openframe chart install %s\n", registration.Name)
+	return nil
+}
+
+// expandHome replaces a leading ~ with the home directory, for paths quoted past the shell
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
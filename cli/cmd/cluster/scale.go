@@ -0,0 +1,126 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getScaleCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	scaleCmd := &cobra.Command{
+		Use:   "scale [NAME] --agents N",
+		Short: "Add or remove agent nodes of a running cluster",
+		Long: `Add or remove agent nodes of a running k3d cluster without recreating it.
+
+Scaling up creates agent nodes with the image of the cluster servers and waits
+until Kubernetes reports them Ready. Scaling down cordons and drains the newest
+agents, then deletes them; DaemonSet pods are left to go with their node.
+
+Afterwards the pods per node are listed, with the pods that could not be
+scheduled anywhere, so you can tell whether the cluster has enough room.
+
+Examples:
+  openframe cluster scale my-cluster --agents 5
+  openframe cluster scale my-cluster --agents 1
+  openframe cluster scale --agents 4  # interactive selection`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			if err := utils.ValidateGlobalFlags(); err != nil {
+				return err
+			}
+			return models.ValidateScaleFlags(utils.GetGlobalFlags().Scale)
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runClusterScale),
+	}
+
+	// Add scale-specific flags
+	models.AddScaleFlags(scaleCmd, utils.GetGlobalFlags().Scale)
+
+	return scaleCmd
+}
+
+func runClusterScale(cmd *cobra.Command, args []string) error {
+	service := utils.GetCommandService()
+	operationsUI := ui.NewOperationsUI()
+	globalFlags := utils.GetGlobalFlags()
+	agents := globalFlags.Scale.Agents
+
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterName, err := operationsUI.SelectClusterForOperation(clusters, args, "scale")
+	if err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	if globalFlags.Global.DryRun {
+		pterm.Info.Printf("Would scale cluster '%s' to %d agents\n", clusterName, agents)
+		return nil
+	}
+
+	result, err := service.ScaleCluster(clusterName, agents)
+	if err != nil {
+		return err
+	}
+	showScaleResult(result)
+	return nil
+}
+
+// showScaleResult reports the nodes that changed and where the pods run now
+func showScaleResult(result models.ScaleResult) {
+	if len(result.Added) == 0 && len(result.Removed) == 0 {
+		pterm.Info.Println("The cluster already runs that many agents, nothing changed")
+	}
+	for _, node := range result.Added {
+		pterm.Printf("  + %s (Ready)\n", node)
+	}
+	for _, node := range result.Removed {
+		pterm.Printf("  - %s\n", node)
+	}
+
+	if len(result.Evicted) > 0 {
+		fmt.Println()
+		pterm.Info.Printf("Rescheduled %d pods from the removed nodes:\n", len(result.Evicted))
+		for _, pod := range result.Evicted {
+			pterm.Printf("  %s\n", pod)
+		}
+	}
+
+	if len(result.PodsPerNode) > 0 {
+		nodes := make([]string, 0, len(result.PodsPerNode))
+		for node := range result.PodsPerNode {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+
+		fmt.Println()
+		data := pterm.TableData{{"NODE", "PODS"}}
+		for _, node := range nodes {
+			data = append(data, []string{node, strconv.Itoa(result.PodsPerNode[node])})
+		}
+		_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	}
+
+	if len(result.Pending) > 0 {
+		fmt.Println()
+		pterm.Warning.Printf("%d pods are waiting for a node with enough room:\n", len(result.Pending))
+		for _, pod := range result.Pending {
+			pterm.Printf("  %s\n", pod)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getUpgradeCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	upgradeCmd := &cobra.Command{
+		Use:   "upgrade [NAME] --version VERSION",
+		Short: "Upgrade Kubernetes of a running cluster in place",
+		Long: `Upgrade the k3s release of a running k3d cluster without recreating it.
+
+Server nodes are upgraded first, then agents, one node at a time: the node is
+drained, its container replaced by one of the new k3s image that keeps the
+volumes, and the node waited for until it is Ready on the new version. Argo CD
+applications that were Healthy before must be Healthy again before the next
+node is upgraded.
+
+The version is checked against the Kubernetes versions OpenFrame and argo-cd
+chart 8.2.7 are tested with. Downgrades and skipping a minor version are
+refused. Nodes already on the version are skipped, so a failed upgrade can be
+run again.
+
+Examples:
+  openframe cluster upgrade my-cluster --version v1.32.2-k3s1
+  openframe cluster upgrade my-cluster --version v1.33.1-k3s1 --allow-untested
+  openframe cluster upgrade --version v1.32.2-k3s1  # interactive selection`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			if err := utils.ValidateGlobalFlags(); err != nil {
+				return err
+			}
+			return models.ValidateUpgradeFlags(utils.GetGlobalFlags().Upgrade)
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runClusterUpgrade),
+	}
+
+	// Add upgrade-specific flags
+	models.AddUpgradeFlags(upgradeCmd, utils.GetGlobalFlags().Upgrade)
+
+	return upgradeCmd
+}
+
+func runClusterUpgrade(cmd *cobra.Command, args []string) error {
+	service := utils.GetCommandService()
+	operationsUI := ui.NewOperationsUI()
+	globalFlags := utils.GetGlobalFlags()
+	flags := globalFlags.Upgrade
+
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterName, err := operationsUI.SelectClusterForOperation(clusters, args, "upgrade")
+	if err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	if globalFlags.Global.DryRun {
+		pterm.Info.Printf("Would upgrade cluster '%s' to %s\n", clusterName, flags.Version)
+		return nil
+	}
+
+	if !globalFlags.Global.Force {
+		confirmed, err := sharedUI.ConfirmActionInteractive(
+			fmt.Sprintf("Upgrade cluster '%s' to %s? Its nodes restart one at a time", pterm.Cyan(clusterName), flags.Version), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			pterm.Info.Println("Upgrade cancelled.")
+			return nil
+		}
+	}
+
+	result, err := service.UpgradeCluster(clusterName, flags.Version, flags.AllowUntested)
+	if err != nil {
+		return err
+	}
+	showUpgradeResult(result)
+	return nil
+}
+
+// showUpgradeResult reports the nodes that were upgraded and the applications watched
+func showUpgradeResult(result models.UpgradeResult) {
+	if len(result.Upgraded) == 0 {
+		pterm.Info.Printf("All nodes already run %s, nothing changed\n", result.To)
+		return
+	}
+
+	pterm.Printf("  %s → %s\n", result.From, result.To)
+	for _, node := range result.Upgraded {
+		pterm.Printf("  ↑ %s (Ready)\n", node)
+	}
+	for _, node := range result.Skipped {
+		pterm.Printf("  = %s (already on %s)\n", node, result.To)
+	}
+	if len(result.Applications) > 0 {
+		fmt.Println()
+		pterm.Info.Printf("%d Argo CD applications stayed Healthy\n", len(result.Applications))
+	}
+}
@@ -0,0 +1,70 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func getUseCmd() *cobra.Command {
+	// Ensure global flags are initialized
+	utils.InitGlobalFlags()
+
+	useCmd := &cobra.Command{
+		Use:   "use [NAME]",
+		Short: "Switch kubectl and telepresence to a cluster",
+		Long: `Make a cluster the current kubectl context and move a connected telepresence
+session over to it.
+
+The cluster's context is merged into the default kubeconfig ($KUBECONFIG or
+~/.kube/config) first, so clusters registered from another kubeconfig file and
+recreated k3d clusters with new ports work with plain kubectl afterwards.
+
+Other OpenFrame commands do not depend on the current context: they always use
+the kubeconfig of the cluster they act on.
+
+Examples:
+  openframe cluster use my-cluster
+  openframe cluster use  # interactive selection`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			utils.SyncGlobalFlags()
+			return utils.ValidateGlobalFlags()
+		},
+		RunE: utils.WrapCommandWithCommonSetup(runClusterUse),
+	}
+
+	return useCmd
+}
+
+func runClusterUse(cmd *cobra.Command, args []string) error {
+	service := utils.GetCommandService()
+	operationsUI := ui.NewOperationsUI()
+
+	clusters, err := service.ListClusters()
+	if err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	clusterName, err := operationsUI.SelectClusterForOperation(clusters, args, "switch to")
+	if err != nil {
+		return err
+	}
+	if clusterName == "" {
+		return nil
+	}
+
+	contextName, _, err := service.KubeContext(clusterName)
+	if err != nil {
+		return err
+	}
+	if err := service.UseClusterContext(clusterName); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("Switched to cluster '%s' (context %s)\n", clusterName, contextName)
+	return nil
+}
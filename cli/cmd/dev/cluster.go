@@ -0,0 +1,80 @@
+package dev
+
+import (
+	"fmt"
+
+	clusterUI "github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
+	clusterUtils "github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/pterm/pterm"
+)
+
+// selectCluster returns the cluster named by name, the only cluster there is, or the one the
+// user picks. It returns "" when there is no cluster to select.
+func selectCluster(operation, name string, verbose bool) (string, error) {
+	// Create cluster service using the same pattern as chart install
+	clusterService := clusterUtils.GetCommandService()
+
+	// Get list of clusters
+	clusters, err := clusterService.ListClusters()
+	if err != nil {
+		if verbose {
+			pterm.Error.Printf("Failed to list clusters: %v\n", err)
+		}
+		// Show the same error message as chart install
+		pterm.Error.Println("No clusters found. Create a cluster first with: openframe cluster create")
+		return "", nil // Return nil error like chart install does
+	}
+
+	// Check if we have any clusters
+	if len(clusters) == 0 {
+		if verbose {
+			pterm.Info.Printf("Found 0 clusters\n")
+		}
+		// Show the same error message as chart install
+		pterm.Error.Println("No clusters found. Create a cluster first with: openframe cluster create")
+		return "", nil // Return nil error like chart install does
+	}
+
+	if verbose {
+		pterm.Info.Printf("Found %d clusters\n", len(clusters))
+		for _, cluster := range clusters {
+			pterm.Info.Printf("  - %s (%s)\n", cluster.Name, cluster.Status)
+		}
+	}
+
+	// A single cluster needs no prompt, which keeps piped stdin free for kafka produce
+	args := []string{}
+	if name != "" {
+		args = []string{name}
+	} else if len(clusters) == 1 {
+		args = []string{clusters[0].Name}
+	}
+
+	// Use cluster selector UI - same as chart install, cluster delete, cluster status, cluster cleanup
+	selector := clusterUI.NewSelector(operation)
+	return selector.SelectCluster(clusters, args)
+}
+
+// targetCluster points the kubectl, helm and telepresence commands of this process at the
+// selected cluster, leaving the user's current kubectl context alone
+func targetCluster(clusterName string, verbose bool) error {
+	if verbose {
+		pterm.Info.Printf("Using kubeconfig of cluster: %s\n", clusterName)
+	}
+
+	return clusterUtils.GetCommandService().TargetCluster(clusterName)
+}
+
+// useCluster selects a cluster like selectCluster and targets it. It returns "" when there
+// is no cluster to use.
+func useCluster(operation, name string, verbose bool) (string, error) {
+	clusterName, err := selectCluster(operation, name, verbose)
+	if err != nil || clusterName == "" {
+		return "", err
+	}
+
+	if err := targetCluster(clusterName, verbose); err != nil {
+		return "", fmt.Errorf("failed to target cluster %s: %w", clusterName, err)
+	}
+	return clusterName, nil
+}
@@ -0,0 +1,53 @@
+package dev
+
+import (
+	"testing"
+
+	clusterUtils "github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setClusters makes the cluster commands of the dev commands see the given k3d cluster list
+func setClusters(t *testing.T, clustersJSON string) {
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("k3d cluster list", &executor.CommandResult{Stdout: clustersJSON})
+	clusterUtils.SetTestExecutor(mockExec)
+	t.Cleanup(clusterUtils.ResetGlobalFlags)
+}
+
+func TestSelectCluster(t *testing.T) {
+	setClusters(t, `[{"name":"openframe-dev","serversCount":1,"serversRunning":1}]`)
+
+	clusterName, err := selectCluster("logs", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "openframe-dev", clusterName, "the only cluster is used without a prompt")
+
+	clusterName, err = selectCluster("logs", "openframe-dev", false)
+	require.NoError(t, err)
+	assert.Equal(t, "openframe-dev", clusterName)
+
+	_, err = selectCluster("logs", "missing", false)
+	assert.EqualError(t, err, "cluster 'missing' not found")
+}
+
+func TestUseCluster_NoClusters(t *testing.T) {
+	setClusters(t, `[]`)
+
+	clusterName, err := useCluster("db", "", false)
+	require.NoError(t, err)
+	assert.Empty(t, clusterName, "nothing is targeted without a cluster")
+}
+
+func TestDevCommandsTakeCluster(t *testing.T) {
+	kafkaTopics, _, err := getKafkaCmd().Find([]string{"topics"})
+	require.NoError(t, err)
+	imageSet, _, err := getImageCmd().Find([]string{"set"})
+	require.NoError(t, err)
+
+	assert.NotNil(t, getLogsCmd().Flags().Lookup("cluster"), "logs")
+	assert.NotNil(t, getDBCmd().Flags().Lookup("cluster"), "db")
+	assert.NotNil(t, kafkaTopics.InheritedFlags().Lookup("cluster"), "kafka topics")
+	assert.NotNil(t, imageSet.Flags().Lookup("cluster"), "image set")
+}
@@ -0,0 +1,88 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/providers/kubectl"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/datasource"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/spf13/cobra"
+)
+
+// getDBCmd returns the db command
+func getDBCmd() *cobra.Command {
+	flags := &models.DatabaseFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "db <mongo|redis|cassandra> [-- client-args...]",
+		Short: "Open a database client shell inside the cluster",
+		Long: `Database Shell - Open mongosh, redis-cli or cqlsh in the datasources namespace
+
+This command finds the running database pod deployed by the OpenFrame charts and
+execs its client shell interactively. Credentials are read from the
+chart-generated Kubernetes secrets, so no passwords need to be typed.
+
+Supported databases:
+  • mongo     - mongosh authenticated with the mongodb secret
+  • redis     - redis-cli on the Redis master
+  • cassandra - cqlsh on the Cassandra node
+
+Arguments after -- are passed to the client, which allows one-off commands.
+
+Examples:
+  openframe dev db mongo
+  openframe dev db redis -- KEYS '*'
+  openframe dev db cassandra -- -e "DESCRIBE KEYSPACES"
+  openframe dev db mongo --cluster openframe-dev`,
+		Args:      cobra.MinimumNArgs(1),
+		ValidArgs: datasource.DatabaseNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDB(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Cluster, "cluster", "", "Cluster the databases run in (defaults to the only cluster, otherwise prompts)")
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", datasource.DefaultNamespace, "Namespace the databases are deployed to")
+
+	return cmd
+}
+
+// runDB handles the db command execution
+func runDB(cmd *cobra.Command, args []string, flags *models.DatabaseFlags) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	service, err := newDatasourceService(ctx, "db", flags.Cluster, verbose, dryRun)
+	if err != nil || service == nil {
+		return err
+	}
+
+	return service.OpenShell(ctx, args[0], args[1:], flags)
+}
+
+// newDatasourceService targets the selected cluster and wires the datasource service to a
+// connected kubectl provider. It returns nil when there is no cluster to use.
+func newDatasourceService(ctx context.Context, operation, cluster string, verbose, dryRun bool) (*datasource.Service, error) {
+	clusterName, err := useCluster(operation, cluster, verbose)
+	if err != nil || clusterName == "" {
+		return nil, err
+	}
+
+	// Lookups are read-only, so only the attached command honours dry-run
+	exec := executor.NewRealCommandExecutor(false, verbose)
+	kubectlProvider := kubectl.NewProvider(exec, verbose)
+
+	if err := kubectlProvider.CheckConnection(ctx); err != nil {
+		return nil, fmt.Errorf("kubectl is not connected to cluster: %w", err)
+	}
+
+	return datasource.NewService(kubectlProvider, datasource.NewAttachedRunner(dryRun), verbose), nil
+}
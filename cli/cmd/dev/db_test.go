@@ -0,0 +1,24 @@
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDBCmd(t *testing.T) {
+	cmd := getDBCmd()
+
+	assert.Equal(t, "db", cmd.Name())
+	assert.Equal(t, "Open a database client shell inside the cluster", cmd.Short)
+	assert.Contains(t, cmd.Long, "mongosh")
+	assert.Contains(t, cmd.Long, "openframe dev db mongo")
+	assert.ElementsMatch(t, []string{"mongo", "redis", "cassandra"}, cmd.ValidArgs)
+
+	assert.Error(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"redis", "KEYS", "*"}))
+
+	namespace := cmd.Flags().Lookup("namespace")
+	assert.NotNil(t, namespace)
+	assert.Equal(t, "datasources", namespace.DefValue)
+}
@@ -18,12 +18,20 @@ func GetDevCmd() *cobra.Command {
 This command group provides development workflow functionality:
   • intercept - Intercept traffic from cluster services to local development
   • skaffold - Deploy development versions of services with live reloading
+  • logs - Stream logs from every pod of an application, namespace or selector
+  • db - Open mongosh, redis-cli or cqlsh inside the cluster
+  • kafka - List topics, consume, produce and check Debezium connectors
+  • image - Run a locally built image in place of an application's image
 
 Supports Telepresence for traffic interception and custom Skaffold workflows.
 
 Examples:
   openframe dev intercept my-service
-  openframe dev skaffold my-service`,
+  openframe dev skaffold my-service
+  openframe dev logs openframe-api
+  openframe dev db mongo
+  openframe dev kafka topics
+  openframe dev image set openframe-api=./Dockerfile`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Show logo for subcommands, but not for the root dev command
 			if cmd.Use != "dev" {
@@ -49,6 +57,10 @@ Examples:
 	devCmd.AddCommand(
 		getInterceptCmd(),
 		getScaffoldCmd(),
+		getLogsCmd(),
+		getDBCmd(),
+		getKafkaCmd(),
+		getImageCmd(),
 	)
 
 	// Add global flags following cluster pattern
@@ -20,21 +20,37 @@ func TestGetDevCmd(t *testing.T) {
 
 	// Test subcommands exist
 	subcommands := cmd.Commands()
-	assert.Len(t, subcommands, 2) // intercept and skaffold commands
+	assert.Len(t, subcommands, 6) // intercept, skaffold, logs, db, kafka and image commands
 
 	var interceptCmd *cobra.Command
 	var skaffoldCmd *cobra.Command
+	var logsCmd *cobra.Command
+	var dbCmd *cobra.Command
+	var kafkaCmd *cobra.Command
+	var imageCmd *cobra.Command
 	for _, subcmd := range subcommands {
 		switch subcmd.Name() {
 		case "intercept":
 			interceptCmd = subcmd
 		case "skaffold":
 			skaffoldCmd = subcmd
+		case "logs":
+			logsCmd = subcmd
+		case "db":
+			dbCmd = subcmd
+		case "kafka":
+			kafkaCmd = subcmd
+		case "image":
+			imageCmd = subcmd
 		}
 	}
 
 	assert.NotNil(t, interceptCmd, "intercept subcommand should exist")
 	assert.NotNil(t, skaffoldCmd, "skaffold subcommand should exist")
+	assert.NotNil(t, logsCmd, "logs subcommand should exist")
+	assert.NotNil(t, dbCmd, "db subcommand should exist")
+	assert.NotNil(t, kafkaCmd, "kafka subcommand should exist")
+	assert.NotNil(t, imageCmd, "image subcommand should exist")
 
 	// Test that the dev command has the expected global flags by trying to get them
 	_, err := cmd.PersistentFlags().GetBool("verbose")
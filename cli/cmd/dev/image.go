@@ -0,0 +1,123 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/providers/kubectl"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/image"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/spf13/cobra"
+)
+
+// getImageCmd returns the image command and its subcommands
+func getImageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "image",
+		Short: "Run locally built images in the cluster",
+		Long: `Image Swap - Replace an application's image with a local build
+
+This command group is a lightweight alternative to skaffold for one-off tests.
+It builds the image with docker, imports it into the k3d nodes and points the
+ArgoCD Application's Helm parameters at the new tag. No registry is needed.
+
+While any image is swapped, automated sync of the argocd-apps application is
+paused so ArgoCD does not revert the change. Reset restores it.
+
+Subcommands:
+  • set - Build or retag images and deploy them
+  • reset - Restore the original images
+
+Examples:
+  openframe dev image set openframe-api=./openframe-api/Dockerfile
+  openframe dev image set openframe-api=./openframe-api openframe-gateway=my-gateway:test
+  openframe dev image reset openframe-api
+  openframe dev image reset`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		getImageSetCmd(),
+		getImageResetCmd(),
+	)
+
+	return cmd
+}
+
+// getImageSetCmd returns the image set command
+func getImageSetCmd() *cobra.Command {
+	flags := &models.ImageFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "set <app>=<Dockerfile|directory|image>...",
+		Short: "Deploy a locally built image for an application",
+		Long: `Deploy a locally built image for one or more ArgoCD applications
+
+The source can be a Dockerfile, a directory containing a Dockerfile or an
+image already present in the local docker daemon. Every image is tagged as
+openframe-dev/<app>:dev-<timestamp> so the Deployment always rolls.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImage(cmd, flags.Cluster, func(ctx context.Context, service *image.Service) error {
+				return service.Set(ctx, args, flags)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Cluster, "cluster", "", "k3d cluster to import images into and patch (defaults to the current k3d context)")
+	cmd.Flags().StringVar(&flags.RepoParam, "repo-param", image.DefaultRepoParam, "Helm parameter holding the image repository")
+	cmd.Flags().StringVar(&flags.TagParam, "tag-param", image.DefaultTagParam, "Helm parameter holding the image tag")
+
+	return cmd
+}
+
+// getImageResetCmd returns the image reset command
+func getImageResetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset [app...]",
+		Short: "Restore the original images of applications",
+		Long: `Restore the original Helm parameters of swapped applications
+
+Without arguments every swapped application is restored. Automated sync of
+argocd-apps resumes once no application uses a local image.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImage(cmd, "", func(ctx context.Context, service *image.Service) error {
+				return service.Reset(ctx, args)
+			})
+		},
+	}
+}
+
+// runImage targets the named cluster, if any, wires the image service and runs an action
+// with interrupt handling
+func runImage(cmd *cobra.Command, cluster string, action func(ctx context.Context, service *image.Service) error) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Without --cluster the current k3d context is used, so nothing is selected here
+	if cluster != "" {
+		if err := targetCluster(cluster, verbose); err != nil {
+			return fmt.Errorf("failed to target cluster %s: %w", cluster, err)
+		}
+	}
+
+	// Lookups must run even in dry-run, so the service decides what to skip
+	exec := executor.NewRealCommandExecutor(false, verbose)
+	kubectlProvider := kubectl.NewProvider(exec, verbose)
+
+	if err := kubectlProvider.CheckConnection(ctx); err != nil {
+		return fmt.Errorf("kubectl is not connected to cluster: %w", err)
+	}
+
+	service := image.NewService(kubectlProvider, image.NewDockerBuilder(exec), verbose, dryRun)
+	return action(ctx, service)
+}
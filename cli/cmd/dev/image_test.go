@@ -0,0 +1,46 @@
+package dev
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetImageCmd(t *testing.T) {
+	cmd := getImageCmd()
+
+	assert.Equal(t, "image", cmd.Use)
+	assert.Contains(t, cmd.Long, "k3d")
+
+	subcommands := map[string]*cobra.Command{}
+	for _, sub := range cmd.Commands() {
+		subcommands[sub.Name()] = sub
+	}
+	require.Len(t, subcommands, 2)
+	require.Contains(t, subcommands, "set")
+	require.Contains(t, subcommands, "reset")
+
+	// set needs at least one swap, reset works on all swapped apps without arguments
+	assert.Error(t, subcommands["set"].Args(subcommands["set"], []string{}))
+	assert.NoError(t, subcommands["set"].Args(subcommands["set"], []string{"openframe-api=./Dockerfile"}))
+	assert.Nil(t, subcommands["reset"].Args)
+}
+
+func TestImageSetCmd_Flags(t *testing.T) {
+	cmd := getImageCmd()
+	set, _, err := cmd.Find([]string{"set"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "image.repo", set.Flags().Lookup("repo-param").DefValue)
+	assert.Equal(t, "image.tag", set.Flags().Lookup("tag-param").DefValue)
+
+	err = set.ParseFlags([]string{"--cluster", "dev", "--repo-param", "image.repository"})
+	require.NoError(t, err)
+
+	cluster, _ := set.Flags().GetString("cluster")
+	assert.Equal(t, "dev", cluster)
+	repoParam, _ := set.Flags().GetString("repo-param")
+	assert.Equal(t, "image.repository", repoParam)
+}
@@ -4,14 +4,11 @@ import (
 	"context"
 	"fmt"
 
-	clusterUI "github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
-	clusterUtils "github.com/flamingo-stack/openframe/openframe/internal/cluster/utils"
 	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
 	"github.com/flamingo-stack/openframe/openframe/internal/dev/providers/kubectl"
 	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/intercept"
 	"github.com/flamingo-stack/openframe/openframe/internal/dev/ui"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -79,17 +76,12 @@ func runIntercept(cmd *cobra.Command, args []string, flags *models.InterceptFlag
 
 // runInteractiveIntercept runs the interactive intercept flow with cluster selection
 func runInteractiveIntercept(ctx context.Context, verbose, dryRun bool) error {
-	// Step 1: Select cluster using existing cluster service
-	clusterName, err := selectClusterForIntercept(verbose)
+	// Step 1-2: Select a cluster and point kubectl and telepresence at it
+	clusterName, err := useCluster("intercept", "", verbose)
 	if err != nil || clusterName == "" {
 		return err
 	}
 
-	// Step 2: Set kubectl context to the selected cluster
-	if err := setKubectlContext(ctx, clusterName, verbose); err != nil {
-		return fmt.Errorf("failed to set kubectl context: %w", err)
-	}
-
 	// Step 3: Create real kubectl provider
 	exec := executor.NewRealCommandExecutor(dryRun, verbose)
 	kubectlProvider := kubectl.NewProvider(exec, verbose)
@@ -127,59 +119,3 @@ func runInteractiveIntercept(ctx context.Context, verbose, dryRun bool) error {
 	// Start the intercept
 	return interceptService.StartIntercept(setup.ServiceName, flags)
 }
-
-// selectClusterForIntercept handles cluster selection for intercept
-func selectClusterForIntercept(verbose bool) (string, error) {
-	// Create cluster service using the same pattern as chart install
-	clusterService := clusterUtils.GetCommandService()
-
-	// Get list of clusters
-	clusters, err := clusterService.ListClusters()
-	if err != nil {
-		if verbose {
-			pterm.Error.Printf("Failed to list clusters: %v\n", err)
-		}
-		// Show the same error message as chart install
-		pterm.Error.Println("No clusters found. Create a cluster first with: openframe cluster create")
-		return "", nil // Return nil error like chart install does
-	}
-
-	// Check if we have any clusters
-	if len(clusters) == 0 {
-		if verbose {
-			pterm.Info.Printf("Found 0 clusters\n")
-		}
-		// Show the same error message as chart install
-		pterm.Error.Println("No clusters found. Create a cluster first with: openframe cluster create")
-		return "", nil // Return nil error like chart install does
-	}
-
-	if verbose {
-		pterm.Info.Printf("Found %d clusters\n", len(clusters))
-		for _, cluster := range clusters {
-			pterm.Info.Printf("  - %s (%s)\n", cluster.Name, cluster.Status)
-		}
-	}
-
-	// Use cluster selector UI - same as chart install, cluster delete, cluster status, cluster cleanup
-	selector := clusterUI.NewSelector("intercept")
-	return selector.SelectCluster(clusters, []string{})
-}
-
-// setKubectlContext switches kubectl context to the selected cluster
-func setKubectlContext(ctx context.Context, clusterName string, verbose bool) error {
-	// K3d cluster context format: k3d-<cluster-name>
-	contextName := fmt.Sprintf("k3d-%s", clusterName)
-
-	if verbose {
-		pterm.Info.Printf("Setting kubectl context to: %s\n", contextName)
-	}
-
-	exec := executor.NewRealCommandExecutor(false, verbose)
-	_, err := exec.Execute(ctx, "kubectl", "config", "use-context", contextName)
-	if err != nil {
-		return fmt.Errorf("failed to switch kubectl context to %s: %w", contextName, err)
-	}
-
-	return nil
-}
@@ -0,0 +1,132 @@
+package dev
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/datasource"
+	"github.com/spf13/cobra"
+)
+
+// getKafkaCmd returns the kafka command and its subcommands
+func getKafkaCmd() *cobra.Command {
+	flags := &models.KafkaFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "kafka",
+		Short: "Run Kafka and Debezium Connect tools inside the cluster",
+		Long: `Kafka Tools - Inspect topics and connectors of the local stack
+
+This command group runs the Kafka CLI tools inside a broker pod, so nothing
+needs to be installed locally and no ports need to be exposed.
+
+Subcommands:
+  • topics - List topics
+  • consume - Print messages of a topic
+  • produce - Send messages to a topic from stdin
+  • connectors - Show Debezium Connect connector status
+
+Examples:
+  openframe dev kafka topics
+  openframe dev kafka consume devices-topic --from-beginning
+  echo '{"id":1}' | openframe dev kafka produce devices-topic
+  openframe dev kafka connectors
+  openframe dev kafka topics --cluster openframe-dev`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&flags.Cluster, "cluster", "", "Cluster Kafka runs in (defaults to the only cluster, otherwise prompts)")
+	cmd.PersistentFlags().StringVarP(&flags.Namespace, "namespace", "n", datasource.DefaultNamespace, "Namespace Kafka is deployed to")
+
+	cmd.AddCommand(
+		getKafkaTopicsCmd(flags),
+		getKafkaConsumeCmd(flags),
+		getKafkaProduceCmd(flags),
+		getKafkaConnectorsCmd(flags),
+	)
+
+	return cmd
+}
+
+// getKafkaTopicsCmd returns the kafka topics command
+func getKafkaTopicsCmd(flags *models.KafkaFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "topics",
+		Short: "List Kafka topics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKafka(cmd, flags.Cluster, func(ctx context.Context, service *datasource.Service) error {
+				return service.KafkaTopics(ctx, flags)
+			})
+		},
+	}
+}
+
+// getKafkaConsumeCmd returns the kafka consume command
+func getKafkaConsumeCmd(flags *models.KafkaFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "consume <topic>",
+		Short: "Print messages of a Kafka topic",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKafka(cmd, flags.Cluster, func(ctx context.Context, service *datasource.Service) error {
+				return service.KafkaConsume(ctx, args[0], flags)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.FromBeginning, "from-beginning", false, "Consume from the earliest offset")
+	cmd.Flags().IntVar(&flags.MaxMessages, "max-messages", 0, "Exit after this many messages (0 means unlimited)")
+	cmd.Flags().StringVar(&flags.Group, "group", "", "Consumer group to join")
+
+	return cmd
+}
+
+// getKafkaProduceCmd returns the kafka produce command
+func getKafkaProduceCmd(flags *models.KafkaFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "produce <topic>",
+		Short: "Send messages read from stdin to a Kafka topic",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKafka(cmd, flags.Cluster, func(ctx context.Context, service *datasource.Service) error {
+				return service.KafkaProduce(ctx, args[0], flags)
+			})
+		},
+	}
+}
+
+// getKafkaConnectorsCmd returns the kafka connectors command
+func getKafkaConnectorsCmd(flags *models.KafkaFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "connectors",
+		Short: "Show Debezium Connect connector status",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKafka(cmd, flags.Cluster, func(ctx context.Context, service *datasource.Service) error {
+				return service.KafkaConnectors(ctx, flags)
+			})
+		},
+	}
+}
+
+// runKafka creates the datasource service and runs one kafka action with Ctrl+C handling
+func runKafka(cmd *cobra.Command, cluster string, action func(context.Context, *datasource.Service) error) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	service, err := newDatasourceService(ctx, "kafka", cluster, verbose, dryRun)
+	if err != nil || service == nil {
+		return err
+	}
+
+	return action(ctx, service)
+}
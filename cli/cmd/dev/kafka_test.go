@@ -0,0 +1,50 @@
+package dev
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKafkaCmd(t *testing.T) {
+	cmd := getKafkaCmd()
+
+	assert.Equal(t, "kafka", cmd.Use)
+	assert.Contains(t, cmd.Long, "Debezium Connect")
+
+	subcommands := map[string]*cobra.Command{}
+	for _, sub := range cmd.Commands() {
+		subcommands[sub.Name()] = sub
+	}
+	require.Len(t, subcommands, 4)
+	for _, name := range []string{"topics", "consume", "produce", "connectors"} {
+		assert.Contains(t, subcommands, name)
+	}
+
+	namespace := cmd.PersistentFlags().Lookup("namespace")
+	require.NotNil(t, namespace)
+	assert.Equal(t, "datasources", namespace.DefValue)
+
+	// Topic commands need exactly one topic
+	assert.Error(t, subcommands["consume"].Args(subcommands["consume"], []string{}))
+	assert.NoError(t, subcommands["produce"].Args(subcommands["produce"], []string{"devices-topic"}))
+	assert.Error(t, subcommands["topics"].Args(subcommands["topics"], []string{"extra"}))
+}
+
+func TestKafkaConsumeCmd_Flags(t *testing.T) {
+	cmd := getKafkaCmd()
+	consume, _, err := cmd.Find([]string{"consume"})
+	require.NoError(t, err)
+
+	err = consume.ParseFlags([]string{"--from-beginning", "--max-messages", "5", "--group", "debug"})
+	require.NoError(t, err)
+
+	fromBeginning, _ := consume.Flags().GetBool("from-beginning")
+	assert.True(t, fromBeginning)
+	maxMessages, _ := consume.Flags().GetInt("max-messages")
+	assert.Equal(t, 5, maxMessages)
+	group, _ := consume.Flags().GetString("group")
+	assert.Equal(t, "debug", group)
+}
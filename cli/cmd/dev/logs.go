@@ -0,0 +1,84 @@
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/providers/kubectl"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/logs"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/spf13/cobra"
+)
+
+// getLogsCmd returns the logs command
+func getLogsCmd() *cobra.Command {
+	flags := &models.LogsFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "logs <app|namespace|selector>",
+		Short: "Stream logs from every pod of an application",
+		Long: `Stream Logs - Tail every pod and container that matches a target
+
+This command follows logs from all matching pods at once, with a colour-coded
+prefix per pod. New pods are picked up automatically, so the stream survives
+rollouts and ArgoCD syncs.
+
+Logs are read from the cluster named by --cluster, the only cluster there is,
+or the one picked from a list. Your current kubectl context is left alone.
+
+The target is resolved in this order:
+  • Label selector (anything containing '=' or '!'), e.g. app=openframe-api
+  • ArgoCD application name, resolved to its namespace and workload selectors
+  • Namespace name, streaming every pod in the namespace
+
+Examples:
+  openframe dev logs openframe-api
+  openframe dev logs microservices --since 10m
+  openframe dev logs app=openframe-stream --grep ERROR
+  openframe dev logs openframe-gateway --json
+  openframe dev logs openframe-api --cluster openframe-dev`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(cmd, args, flags)
+		},
+	}
+
+	// Add logs-specific flags
+	cmd.Flags().StringVar(&flags.Cluster, "cluster", "", "Cluster to stream from (defaults to the only cluster, otherwise prompts)")
+	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", "", "Namespace for label selectors (defaults to all namespaces)")
+	cmd.Flags().StringVarP(&flags.Container, "container", "c", "", "Only stream containers with this name")
+	cmd.Flags().StringVar(&flags.Since, "since", "", "Only show logs newer than a relative duration like 5m or 1h")
+	cmd.Flags().StringVar(&flags.Grep, "grep", "", "Only show lines matching this regular expression")
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output one JSON object per log line")
+	cmd.Flags().BoolVarP(&flags.Follow, "follow", "f", true, "Keep streaming and follow new pods")
+
+	return cmd
+}
+
+// runLogs handles the logs command execution
+func runLogs(cmd *cobra.Command, args []string, flags *models.LogsFlags) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	clusterName, err := useCluster("logs", flags.Cluster, verbose)
+	if err != nil || clusterName == "" {
+		return err
+	}
+
+	// Logs are read-only, so dry-run does not apply to the executor
+	exec := executor.NewRealCommandExecutor(false, verbose)
+	kubectlProvider := kubectl.NewProvider(exec, verbose)
+
+	if err := kubectlProvider.CheckConnection(ctx); err != nil {
+		return fmt.Errorf("kubectl is not connected to cluster: %w", err)
+	}
+
+	service := logs.NewService(kubectlProvider, kubectlProvider, kubectlProvider, verbose)
+	return service.StreamLogs(ctx, args[0], flags)
+}
@@ -0,0 +1,65 @@
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLogsCmd(t *testing.T) {
+	cmd := getLogsCmd()
+
+	assert.Equal(t, "logs <app|namespace|selector>", cmd.Use)
+	assert.Equal(t, "Stream logs from every pod of an application", cmd.Short)
+	assert.Contains(t, cmd.Long, "ArgoCD application")
+	assert.Contains(t, cmd.Long, "openframe dev logs openframe-api")
+	assert.NotNil(t, cmd.Args)
+	assert.NotNil(t, cmd.RunE)
+
+	// Exactly one target is required
+	assert.Error(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"openframe-api"}))
+	assert.Error(t, cmd.Args(cmd, []string{"a", "b"}))
+}
+
+func TestLogsCmd_FlagDefaults(t *testing.T) {
+	cmd := getLogsCmd()
+
+	for _, name := range []string{"namespace", "container", "since", "grep", "json", "follow"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "Flag %s should be present", name)
+	}
+
+	assert.Equal(t, "", cmd.Flags().Lookup("namespace").DefValue)
+	assert.Equal(t, "false", cmd.Flags().Lookup("json").DefValue)
+	assert.Equal(t, "true", cmd.Flags().Lookup("follow").DefValue)
+	assert.Equal(t, "n", cmd.Flags().Lookup("namespace").Shorthand)
+	assert.Equal(t, "f", cmd.Flags().Lookup("follow").Shorthand)
+}
+
+func TestLogsCmd_FlagParsing(t *testing.T) {
+	cmd := getLogsCmd()
+
+	err := cmd.ParseFlags([]string{
+		"--namespace", "microservices",
+		"--container", "openframe-api",
+		"--since", "10m",
+		"--grep", "ERROR",
+		"--json",
+		"--follow=false",
+	})
+	require.NoError(t, err)
+
+	namespace, _ := cmd.Flags().GetString("namespace")
+	assert.Equal(t, "microservices", namespace)
+	container, _ := cmd.Flags().GetString("container")
+	assert.Equal(t, "openframe-api", container)
+	since, _ := cmd.Flags().GetString("since")
+	assert.Equal(t, "10m", since)
+	grep, _ := cmd.Flags().GetString("grep")
+	assert.Equal(t, "ERROR", grep)
+	asJSON, _ := cmd.Flags().GetBool("json")
+	assert.True(t, asJSON)
+	follow, _ := cmd.Flags().GetBool("follow")
+	assert.False(t, follow)
+}
@@ -14,8 +14,9 @@ func getScaffoldCmd() *cobra.Command {
 	flags := &models.ScaffoldFlags{}
 
 	cmd := &cobra.Command{
-		Use:   "skaffold [cluster-name]",
-		Short: "Deploy development versions of services with live reloading",
+		Use:     "skaffold [cluster-name]",
+		Aliases: []string{"scaffold"},
+		Short:   "Deploy development versions of services with live reloading",
 		Long: `Scaffold Development Environment - Deploy services with hot reloading
 		
 This command sets up a complete development environment by:
@@ -29,10 +30,15 @@ The scaffold command manages the full development lifecycle:
   • Live reloading and hot deployment capabilities
   • Integration with existing OpenFrame infrastructure
 
+'init' is the subcommand that generates skaffold.yaml, so a cluster named init
+cannot be given as cluster-name. Leave cluster-name out and select it instead.
+
 Examples:
   openframe dev skaffold                    # Interactive cluster creation and scaffolding
   openframe dev skaffold my-dev-cluster    # Scaffold with specific cluster name
-  openframe dev skaffold --port 8080       # Custom local development port`,
+  openframe dev skaffold --port 8080       # Custom local development port
+  openframe dev skaffold --services openframe-api,openframe-gateway  # Run several services together
+  openframe dev skaffold init openframe-api # Generate skaffold.yaml for a service`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runScaffold(cmd, args, flags)
@@ -47,6 +53,42 @@ Examples:
 	cmd.Flags().StringVar(&flags.SyncRemote, "sync-remote", "", "Remote directory to sync files to")
 	cmd.Flags().BoolVar(&flags.SkipBootstrap, "skip-bootstrap", false, "Skip bootstrapping cluster")
 	cmd.Flags().StringVar(&flags.HelmValuesFile, "helm-values", "", "Custom Helm values file for bootstrap")
+	cmd.Flags().StringSliceVar(&flags.Services, "services", nil, "Run several services together, e.g. openframe-api,openframe-gateway")
+
+	cmd.AddCommand(getScaffoldInitCmd())
+
+	return cmd
+}
+
+// getScaffoldInitCmd returns the skaffold init command
+func getScaffoldInitCmd() *cobra.Command {
+	flags := &models.ScaffoldInitFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "init <service>",
+		Short: "Generate a skaffold.yaml for a service",
+		Long: `Generate skaffold.yaml - Create a Skaffold config from a service's chart and sources
+
+The config is derived from:
+  • manifests/microservices/<service> - image repository, Deployment name and ports
+  • openframe/services/<service> - Dockerfile and Maven, Gradle or npm build
+
+It includes file sync rules and port-forwards for every container port, and
+is validated with 'skaffold diagnose' when skaffold is installed.
+
+Examples:
+  openframe dev skaffold init openframe-frontend
+  openframe dev skaffold init openframe-api --force
+  openframe dev skaffold init openframe-gateway --stdout`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScaffoldInit(cmd, args, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Source, "source", "", "Service source directory (defaults to openframe/services/<service>)")
+	cmd.Flags().BoolVar(&flags.Force, "force", false, "Overwrite an existing skaffold.yaml")
+	cmd.Flags().BoolVar(&flags.Stdout, "stdout", false, "Print the generated config instead of writing it")
 
 	return cmd
 }
@@ -63,3 +105,19 @@ func runScaffold(cmd *cobra.Command, args []string, flags *models.ScaffoldFlags)
 
 	return service.RunScaffoldWorkflow(ctx, args, flags)
 }
+
+// runScaffoldInit handles the skaffold init command execution
+func runScaffoldInit(cmd *cobra.Command, args []string, flags *models.ScaffoldInitFlags) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Dry-run shows the config without writing it
+	if dryRun {
+		flags.Stdout = true
+	}
+
+	exec := executor.NewRealCommandExecutor(false, verbose)
+	service := scaffoldService.NewService(exec, verbose)
+
+	return service.InitSkaffoldConfig(context.Background(), args[0], flags)
+}
@@ -226,3 +226,41 @@ func TestRunScaffold_FunctionExists(t *testing.T) {
 	// We can't easily test the actual function execution without mocking
 	// the entire service layer, but we can verify it's wired up correctly
 }
+
+func TestScaffoldInitCmd(t *testing.T) {
+	cmd := getScaffoldCmd()
+	assert.Contains(t, cmd.Aliases, "scaffold")
+
+	initCmd, remaining, err := cmd.Find([]string{"init", "openframe-api"})
+	require.NoError(t, err)
+	assert.Equal(t, "init", initCmd.Name())
+	assert.Equal(t, []string{"openframe-api"}, remaining)
+
+	assert.Error(t, initCmd.Args(initCmd, []string{}))
+	assert.NoError(t, initCmd.Args(initCmd, []string{"openframe-api"}))
+
+	err = initCmd.ParseFlags([]string{"--source", "./svc", "--force", "--stdout"})
+	require.NoError(t, err)
+	source, _ := initCmd.Flags().GetString("source")
+	assert.Equal(t, "./svc", source)
+	force, _ := initCmd.Flags().GetBool("force")
+	assert.True(t, force)
+	stdout, _ := initCmd.Flags().GetBool("stdout")
+	assert.True(t, stdout)
+
+	// A cluster name still runs the skaffold workflow itself
+	found, _, err := cmd.Find([]string{"my-cluster"})
+	require.NoError(t, err)
+	assert.Equal(t, cmd, found)
+}
+
+func TestScaffoldCmd_ServicesFlag(t *testing.T) {
+	cmd := getScaffoldCmd()
+
+	err := cmd.ParseFlags([]string{"--services", "openframe-api,openframe-gateway", "--services", "openframe-stream"})
+	require.NoError(t, err)
+
+	services, err := cmd.Flags().GetStringSlice("services")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"openframe-api", "openframe-gateway", "openframe-stream"}, services)
+}
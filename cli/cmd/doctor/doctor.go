@@ -0,0 +1,130 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/doctor"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetDoctorCmd returns the doctor command
+func GetDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the environment and report what needs fixing",
+		Long: `Doctor - Check everything the cluster, chart and dev commands depend on
+
+Runs every check at once and prints a pass/warn/fail table with hints:
+  • Tools - docker, k3d, kubectl, helm and git, and telepresence, skaffold and
+    jq for the dev commands, installed in a supported version
+  • Docker - the daemon is reachable and has enough CPUs and memory
+  • Host - the default cluster ports 6550, 80 and 443 are free, enough memory,
+    and on Linux the inotify limits
+  • Certificates - the local CA and the ingress certificate are valid
+  • Kubernetes - the current kubectl context reaches its API server
+  • Dev - the telepresence daemons are consistent
+  • Network - localhost and the ingress certificate hosts resolve
+
+Fails when any check fails. Attach the output of -o json to bug reports.
+
+Examples:
+  openframe doctor
+  openframe doctor --host openframe.test
+  openframe doctor -o json > doctor.json`,
+		Args:          cobra.NoArgs,
+		RunE:          runDoctor,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().StringArray("host", []string{}, "Additional ingress host to resolve (repeatable)")
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	if output != "table" && output != "json" {
+		return fmt.Errorf("--output must be table or json, got %q", output)
+	}
+	hosts, err := cmd.Flags().GetStringArray("host")
+	if err != nil {
+		return err
+	}
+
+	doc := doctor.NewDoctor(executor.NewRealCommandExecutor(false, false)).WithHosts(hosts...)
+	if output == "json" {
+		report := doc.Run(cmd.Context())
+		report.Version = cmd.Root().Version
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+		return reportError(report)
+	}
+
+	ui.ShowLogoWithContext(cmd.Context())
+	spinner, _ := pterm.DefaultSpinner.Start("Checking the environment...")
+	report := doc.Run(cmd.Context())
+	if spinner != nil {
+		_ = spinner.Stop()
+	}
+	showReport(report)
+	return reportError(report)
+}
+
+// reportError fails the command when a check failed
+func reportError(report doctor.Report) error {
+	if failed := report.Count(doctor.StatusFail); failed > 0 {
+		return fmt.Errorf("%d checks failed", failed)
+	}
+	return nil
+}
+
+// showReport prints the checks as a table, the hints of the checks that did not pass
+// and a summary
+func showReport(report doctor.Report) {
+	data := [][]string{{"CHECK", "STATUS", "DETAILS"}}
+	for _, check := range report.Checks {
+		data = append(data, []string{check.Category + " / " + check.Name, statusText(check.Status), check.Message})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	var hinted bool
+	for _, check := range report.Checks {
+		if check.Status == doctor.StatusPass || check.Hint == "" {
+			continue
+		}
+		if !hinted {
+			fmt.Println()
+			pterm.Info.Println("To fix:")
+			hinted = true
+		}
+		pterm.Printf("  - %s / %s: %s\n", check.Category, check.Name, check.Hint)
+	}
+
+	fmt.Println()
+	pterm.Printf("%d passed, %d warnings, %d failed\n",
+		report.Count(doctor.StatusPass), report.Count(doctor.StatusWarn), report.Count(doctor.StatusFail))
+}
+
+// statusText colors a check status for the table
+func statusText(status doctor.Status) string {
+	switch status {
+	case doctor.StatusPass:
+		return pterm.Green(string(status))
+	case doctor.StatusWarn:
+		return pterm.Yellow(string(status))
+	default:
+		return pterm.Red(string(status))
+	}
+}
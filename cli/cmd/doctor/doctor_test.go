@@ -0,0 +1,45 @@
+package doctor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/doctor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDoctorCmd(t *testing.T) {
+	cmd := GetDoctorCmd()
+
+	assert.Equal(t, "doctor", cmd.Use)
+	assert.True(t, cmd.SilenceErrors)
+	assert.True(t, cmd.SilenceUsage)
+
+	output := cmd.Flags().Lookup("output")
+	require.NotNil(t, output)
+	assert.Equal(t, "o", output.Shorthand)
+	assert.Equal(t, "table", output.DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("host"))
+	assert.Error(t, cmd.Args(cmd, []string{"extra"}))
+}
+
+func TestRunDoctor_InvalidOutput(t *testing.T) {
+	cmd := GetDoctorCmd()
+	cmd.SetContext(context.Background())
+	require.NoError(t, cmd.ParseFlags([]string{"-o", "yaml"}))
+
+	err := runDoctor(cmd, nil)
+	assert.EqualError(t, err, `--output must be table or json, got "yaml"`)
+}
+
+func TestReportError(t *testing.T) {
+	report := doctor.Report{Checks: []doctor.Result{
+		{Status: doctor.StatusPass},
+		{Status: doctor.StatusWarn},
+	}}
+	assert.NoError(t, reportError(report), "warnings do not fail the command")
+
+	report.Checks = append(report.Checks, doctor.Result{Status: doctor.StatusFail}, doctor.Result{Status: doctor.StatusFail})
+	assert.EqualError(t, reportError(report), "2 checks failed")
+}
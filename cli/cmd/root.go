@@ -5,10 +5,15 @@ import (
 	"os"
 
 	"github.com/flamingo-stack/openframe/openframe/cmd/bootstrap"
+	"github.com/flamingo-stack/openframe/openframe/cmd/bundle"
 	"github.com/flamingo-stack/openframe/openframe/cmd/chart"
 	"github.com/flamingo-stack/openframe/openframe/cmd/cluster"
 	"github.com/flamingo-stack/openframe/openframe/cmd/dev"
+	"github.com/flamingo-stack/openframe/openframe/cmd/doctor"
+	"github.com/flamingo-stack/openframe/openframe/cmd/secrets"
+	"github.com/flamingo-stack/openframe/openframe/cmd/tools"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/config"
+	sharedTools "github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
 	"github.com/spf13/cobra"
 )
@@ -48,7 +53,11 @@ Key Features:
   - Cluster Management - K3d, Kind, and cloud provider support
   - Helm Integration - App-of-Apps pattern with ArgoCD
   - Developer Tools - Telepresence intercepts and scaffold deployments
+  - Encrypted Secrets - Commit helm-values.yaml with its credentials encrypted
+  - Pinned Tools - Checksummed installs of the tools the CLI runs
   - Prerequisite Checking - Validates tools before running
+  - Environment Doctor - One report of everything that needs fixing
+  - Offline Bundles - Bootstrap without network access from one archive
 
 The CLI provides both interactive modes for new users and flag-based
 operation for automation and power users.`,
@@ -68,6 +77,10 @@ operation for automation and power users.`,
 	rootCmd.AddCommand(getChartCmd())
 	rootCmd.AddCommand(getBootstrapCmd())
 	rootCmd.AddCommand(getDevCmd())
+	rootCmd.AddCommand(getSecretsCmd())
+	rootCmd.AddCommand(getToolsCmd())
+	rootCmd.AddCommand(getDoctorCmd())
+	rootCmd.AddCommand(getBundleCmd())
 
 	// Add global flags following cluster pattern
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
@@ -120,6 +133,11 @@ func ExecuteWithVersion(versionInfo VersionInfo) error {
 		fmt.Fprintf(os.Stderr, "Warning: initialization failed: %v\n", err)
 	}
 
+	// Tools installed by 'openframe tools install' win over the system ones
+	if err := sharedTools.UseBinDir(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to add the tools directory to PATH: %v\n", err)
+	}
+
 	return rootCmd.Execute()
 }
 
@@ -142,3 +160,23 @@ func getBootstrapCmd() *cobra.Command {
 func getDevCmd() *cobra.Command {
 	return dev.GetDevCmd()
 }
+
+// getSecretsCmd returns the secrets command
+func getSecretsCmd() *cobra.Command {
+	return secrets.GetSecretsCmd()
+}
+
+// getToolsCmd returns the tools command
+func getToolsCmd() *cobra.Command {
+	return tools.GetToolsCmd()
+}
+
+// getDoctorCmd returns the doctor command
+func getDoctorCmd() *cobra.Command {
+	return doctor.GetDoctorCmd()
+}
+
+// getBundleCmd returns the bundle command
+func getBundleCmd() *cobra.Command {
+	return bundle.GetBundleCmd()
+}
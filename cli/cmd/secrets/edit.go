@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getEditCmd returns the edit subcommand
+func getEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit an encrypted values file in your editor",
+		Long: `Edit an encrypted values file in your editor
+
+Opens the values file decrypted in $VISUAL or $EDITOR. The plaintext is written
+to a private temporary directory that is removed when the editor exits. On save,
+values that were encrypted before and the default credentials are encrypted
+again before the file is written back.
+
+SOPS encrypted files are handed to 'sops', which decrypts and re-encrypts them
+the same way.
+
+Examples:
+  openframe secrets edit
+  EDITOR="code --wait" openframe secrets edit -f values-prod.yaml`,
+		Args:          cobra.NoArgs,
+		RunE:          runEdit,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().StringP("file", "f", defaultValuesFile, "Values file to edit")
+	return cmd
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	values, err := helmvalues.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", file, err)
+	}
+
+	manager, err := newManager(cmd)
+	if err != nil {
+		return err
+	}
+
+	if secrets.IsSOPS(values) {
+		sops := exec.CommandContext(cmd.Context(), "sops", file)
+		sops.Env = append(os.Environ(), secrets.KeyFileEnv+"="+manager.KeyFile())
+		sops.Stdin, sops.Stdout, sops.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := sops.Run(); err != nil {
+			return fmt.Errorf("sops failed to edit %s: %w", file, err)
+		}
+		return nil
+	}
+
+	changed, err := manager.Edit(cmd.Context(), file, runEditor)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		pterm.Info.Println("No changes made")
+		return nil
+	}
+	pterm.Success.Printf("Saved %s with its credentials encrypted\n", file)
+	return nil
+}
+
+// runEditor opens file in the user's editor and waits for it to exit
+func runEditor(file string) error {
+	editor := editorCommand()
+	editorCmd := exec.Command(editor[0], append(editor[1:], file)...)
+	editorCmd.Stdin, editorCmd.Stdout, editorCmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+	return nil
+}
+
+// editorCommand returns $VISUAL or $EDITOR split into its arguments, falling back to vi,
+// or notepad on Windows
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
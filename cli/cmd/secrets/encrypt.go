@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getEncryptCmd returns the encrypt subcommand
+func getEncryptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the credentials of a values file in place",
+		Long: `Encrypt the credentials of a values file in place
+
+Replaces these values with ENC[age:...] ciphertext for the public key of the
+age key file, keeping the rest of the file, its comments and layout as they are:
+  registry.docker.password
+  registry.ghcr.username, registry.ghcr.password
+  deployment.oss.ingress.ngrok.credentials.apiKey, .authtoken
+  deployment.saas.repository.password
+
+Empty and already encrypted values are skipped, so running it again is safe.
+Add more values with --path. A new age key is generated when the key file does
+not exist yet; back it up, the values cannot be decrypted without it.
+
+Examples:
+  openframe secrets encrypt
+  openframe secrets encrypt -f values-prod.yaml
+  openframe secrets encrypt --path deployment.saas.ingress.gcp.tenantID`,
+		Args:          cobra.NoArgs,
+		RunE:          runEncrypt,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().StringP("file", "f", defaultValuesFile, "Values file to encrypt")
+	cmd.Flags().StringArray("path", []string{}, "Additional value to encrypt, as a dotted path (repeatable)")
+	return cmd
+}
+
+func runEncrypt(cmd *cobra.Command, args []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	extraPaths, err := cmd.Flags().GetStringArray("path")
+	if err != nil {
+		return err
+	}
+
+	values, err := helmvalues.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", file, err)
+	}
+	if secrets.IsSOPS(values) {
+		return fmt.Errorf("%s is already encrypted with SOPS", file)
+	}
+
+	manager, err := newManager(cmd)
+	if err != nil {
+		return err
+	}
+	created, err := manager.EnsureKey(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if created {
+		pterm.Warning.Printf("Generated a new age key in %s, back it up: encrypted values cannot be recovered without it\n", manager.KeyFile())
+	}
+
+	encrypted, err := manager.Encrypt(cmd.Context(), values, append(append([]string(nil), secrets.SecretPaths...), extraPaths...))
+	if err != nil {
+		return err
+	}
+	if len(encrypted) == 0 {
+		pterm.Info.Printf("No plaintext credentials found in %s\n", file)
+		return nil
+	}
+
+	if err := values.Write(file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	for _, path := range encrypted {
+		pterm.Success.Printf("Encrypted %s\n", path)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package secrets
+
+import (
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultValuesFile is the values file the secrets commands work on without -f
+const defaultValuesFile = "helm-values.yaml"
+
+// GetSecretsCmd returns the secrets command and its subcommands
+func GetSecretsCmd() *cobra.Command {
+	secretsCmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Encrypt the credentials in helm-values.yaml",
+		Long: `Secrets - Keep registry passwords, tokens and API keys encrypted at rest
+
+helm-values.yaml holds the Docker and GHCR registry credentials, the ngrok
+API key and authtoken and the SaaS repository token. These commands encrypt
+them with a local age key, so the file can be committed:
+  • encrypt - Encrypt the credentials as ENC[age:...] values
+  • edit - Edit the file decrypted, it is encrypted again on save
+
+'chart install', 'chart validate' and 'chart values --decrypt' decrypt
+ENC[age:...] values and SOPS encrypted files in memory. The decrypted values
+only reach the disk in the temporary values file of the install, readable by
+the current user alone and removed when the install ends.
+
+The age key is read from $SOPS_AGE_KEY_FILE, or ~/.config/openframe/age/keys.txt
+which 'secrets encrypt' creates when it is missing. The age and age-keygen
+binaries are required, and sops for SOPS encrypted files.
+
+Examples:
+  openframe secrets encrypt
+  openframe secrets encrypt -f values-prod.yaml --path deployment.saas.ingress.gcp.tenantID
+  openframe secrets edit`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Show logo for subcommands, but not for the root secrets command
+			if cmd.Use != "secrets" {
+				ui.ShowLogoWithContext(cmd.Context())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ui.ShowLogoWithContext(cmd.Context())
+			return cmd.Help()
+		},
+	}
+
+	secretsCmd.AddCommand(
+		getEncryptCmd(),
+		getEditCmd(),
+	)
+
+	secretsCmd.PersistentFlags().String("key-file", "", "age key file (default: $SOPS_AGE_KEY_FILE or ~/.config/openframe/age/keys.txt)")
+
+	return secretsCmd
+}
+
+// newManager creates a secrets manager for the key file selected by --key-file
+func newManager(cmd *cobra.Command) (*secrets.Manager, error) {
+	keyFile, err := cmd.Flags().GetString("key-file")
+	if err != nil {
+		return nil, err
+	}
+	if keyFile == "" {
+		if keyFile, err = secrets.DefaultKeyFile(); err != nil {
+			return nil, err
+		}
+	}
+	return secrets.NewManager(executor.NewRealCommandExecutor(false, false), keyFile), nil
+}
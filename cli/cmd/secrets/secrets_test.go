@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSecretsCmd(t *testing.T) {
+	cmd := GetSecretsCmd()
+
+	assert.Equal(t, "secrets", cmd.Use)
+	assert.Contains(t, cmd.Long, "SOPS")
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("key-file"))
+
+	names := make([]string, 0)
+	for _, subcmd := range cmd.Commands() {
+		names = append(names, subcmd.Name())
+		file := subcmd.Flags().ShorthandLookup("f")
+		require.NotNil(t, file, subcmd.Name())
+		assert.Equal(t, "helm-values.yaml", file.DefValue)
+	}
+	assert.ElementsMatch(t, []string{"encrypt", "edit"}, names)
+}
+
+// subcommand returns the named subcommand with its flags parsed, as cobra would
+func subcommand(t *testing.T, name string, flags ...string) *cobra.Command {
+	t.Helper()
+	root := GetSecretsCmd()
+	cmd, _, err := root.Find([]string{name})
+	require.NoError(t, err)
+	require.NoError(t, cmd.ParseFlags(flags))
+	return cmd
+}
+
+func TestRunEncrypt_Errors(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "keys.txt")
+
+	err := runEncrypt(subcommand(t, "encrypt", "-f", filepath.Join(dir, "missing.yaml"), "--key-file", keyFile), nil)
+	assert.ErrorContains(t, err, "failed to load")
+
+	sopsFile := filepath.Join(dir, "sops.yaml")
+	require.NoError(t, os.WriteFile(sopsFile, []byte("a: ENC[AES256_GCM,data:abc]\nsops:\n  mac: x\n"), 0644))
+	err = runEncrypt(subcommand(t, "encrypt", "-f", sopsFile, "--key-file", keyFile), nil)
+	assert.EqualError(t, err, sopsFile+" is already encrypted with SOPS")
+	assert.NoFileExists(t, keyFile, "no key is generated when nothing can be encrypted")
+}
+
+func TestNewManager_KeyFile(t *testing.T) {
+	t.Setenv("SOPS_AGE_KEY_FILE", "/env/keys.txt")
+
+	manager, err := newManager(subcommand(t, "edit"))
+	require.NoError(t, err)
+	assert.Equal(t, "/env/keys.txt", manager.KeyFile())
+
+	manager, err = newManager(subcommand(t, "edit", "--key-file", "/flag/keys.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "/flag/keys.txt", manager.KeyFile())
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, editorCommand())
+
+	t.Setenv("VISUAL", "nano")
+	assert.Equal(t, []string{"nano"}, editorCommand())
+}
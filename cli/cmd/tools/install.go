@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getInstallCmd returns the install subcommand
+func getInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install [tool...]",
+		Short: "Download, check and install the pinned tools",
+		Long: `Download, check and install the pinned tools
+
+Downloads the pinned build of each tool for this platform, checks its SHA-256
+against the tool manifest and installs the binary into
+~/.local/share/openframe/bin. A download whose checksum does not match is
+discarded, and builds the manifest has no checksum for are never installed.
+
+Without arguments every tool the manifest pins for this platform is installed.
+Tools already installed at their pinned version are skipped unless --force.
+
+Examples:
+  openframe tools install
+  openframe tools install kubectl helm
+  openframe tools install k3d --force
+  openframe tools install --base-url http://localhost:8000`,
+		RunE:          runInstall,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().Bool("force", false, "Download and install again even when the pinned version is installed")
+	return cmd
+}
+
+func runInstall(cmd *cobra.Command, args []string) error {
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+	manager, err := newManager(cmd)
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		statuses, err := manager.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			switch status.State {
+			case tools.StateUnpinned:
+				pterm.Warning.Printf("Skipping %s: the tool manifest has no SHA-256 for this platform\n", status.Name)
+			case tools.StateUnsupported:
+				pterm.Info.Printf("Skipping %s: no build for this platform\n", status.Name)
+			default:
+				names = append(names, status.Name)
+			}
+		}
+	}
+
+	var errs []error
+	for _, name := range names {
+		spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Installing %s...", name))
+		status, err := manager.Install(cmd.Context(), name, force)
+		if err != nil {
+			if spinner != nil {
+				spinner.Fail(err.Error())
+			}
+			errs = append(errs, err)
+			continue
+		}
+		if spinner != nil {
+			spinner.Success(fmt.Sprintf("%s %s installed in %s", name, status.Installed, status.Path))
+		}
+	}
+	return errors.Join(errs...)
+}
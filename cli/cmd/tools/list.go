@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getListCmd returns the list subcommand
+func getListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show the pinned and installed version of every tool",
+		Long: `Show the pinned and installed version of every tool
+
+States:
+  installed   - the pinned version is installed
+  missing     - not installed yet, 'openframe tools install' installs it
+  outdated    - installed from an older pin, install it again
+  unpinned    - the manifest has no SHA-256 for this platform, it cannot be installed
+  unsupported - the manifest has no build for this platform
+
+The installed binaries are not hashed, 'openframe tools verify' does that.
+
+Examples:
+  openframe tools list`,
+		Args:          cobra.NoArgs,
+		RunE:          runList,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	manager, err := newManager(cmd)
+	if err != nil {
+		return err
+	}
+	statuses, err := manager.Status()
+	if err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Tools directory: %s\n", manager.BinDir())
+	showStatuses(statuses)
+	return nil
+}
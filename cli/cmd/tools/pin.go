@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getPinCmd returns the hidden pin subcommand maintainers record checksums with
+func getPinCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "pin",
+		Short:  "Record the SHA-256 of the manifest's artifacts",
+		Hidden: true,
+		Long: `Record the SHA-256 of the manifest's artifacts
+
+Downloads every artifact of a tool manifest file that has no sha256 yet, or
+all of them with --all, and writes their checksums into the file. Review the
+diff: the checksums are only as trustworthy as the downloads they were taken
+from.
+
+Examples:
+  openframe tools pin --manifest internal/shared/tools/manifest.yaml`,
+		Args:          cobra.NoArgs,
+		RunE:          runPin,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cmd.Flags().String("manifest", "", "Tool manifest file to write the checksums into")
+	cmd.Flags().Bool("all", false, "Download and record again the artifacts that already have a checksum")
+	_ = cmd.MarkFlagRequired("manifest")
+	return cmd
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	path, err := cmd.Flags().GetString("manifest")
+	if err != nil {
+		return err
+	}
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+	baseURL, err := cmd.Flags().GetString("base-url")
+	if err != nil {
+		return err
+	}
+
+	manifest, err := tools.LoadManifestFile(path)
+	if err != nil {
+		return err
+	}
+	checksums, err := tools.NewManager(manifest, "", baseURL).Checksums(cmd.Context(), all)
+	// Keep what was downloaded before a failure
+	if writeErr := tools.SetChecksums(path, checksums); writeErr != nil {
+		return writeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, platforms := range checksums {
+		count += len(platforms)
+	}
+	pterm.Success.Printf("Recorded %d checksums in %s\n", count, path)
+	return nil
+}
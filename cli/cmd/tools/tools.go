@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetToolsCmd returns the tools command and its subcommands
+func GetToolsCmd() *cobra.Command {
+	toolsCmd := &cobra.Command{
+		Use:   "tools",
+		Short: "Install pinned, checksummed versions of the required tools",
+		Long: `Tools - Manage the tools the CLI runs at pinned, verified versions
+
+The tool manifest built into the CLI pins the version, the download of every
+platform and its SHA-256 for docker (the CLI only), k3d, kubectl, helm,
+telepresence, skaffold and jq. These commands install them into
+~/.local/share/openframe/bin:
+  • list - Show the pinned and the installed version of every tool
+  • install - Download, check and install the pinned tools
+  • verify - Check the installed tools against what was installed
+
+The CLI puts ~/.local/share/openframe/bin first on the PATH of every command it
+runs, so the installed tools are used before the system ones.
+
+Downloads come from the upstream release pages, or from a mirror serving
+<mirror>/<tool>/<path> given with --base-url or $OPENFRAME_TOOLS_BASE_URL.
+$OPENFRAME_TOOLS_MANIFEST replaces the built-in manifest with a file.
+
+Examples:
+  openframe tools list
+  openframe tools install
+  openframe tools install kubectl helm --base-url http://mirror.local/tools
+  openframe tools verify`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Show logo for subcommands, but not for the root tools command
+			if cmd.Use != "tools" {
+				ui.ShowLogoWithContext(cmd.Context())
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ui.ShowLogoWithContext(cmd.Context())
+			return cmd.Help()
+		},
+	}
+
+	toolsCmd.AddCommand(
+		getListCmd(),
+		getInstallCmd(),
+		getVerifyCmd(),
+		getPinCmd(),
+	)
+
+	toolsCmd.PersistentFlags().String("base-url", "", "Mirror to download from, as <base-url>/<tool>/<path> (default: $OPENFRAME_TOOLS_BASE_URL)")
+
+	return toolsCmd
+}
+
+// newManager creates a tools manager downloading from the mirror selected by --base-url
+func newManager(cmd *cobra.Command) (*tools.Manager, error) {
+	baseURL, err := cmd.Flags().GetString("base-url")
+	if err != nil {
+		return nil, err
+	}
+	return tools.NewDefaultManager(baseURL)
+}
+
+// showStatuses prints a table of tool states
+func showStatuses(statuses []tools.Status) {
+	data := [][]string{{"TOOL", "PINNED", "INSTALLED", "STATE"}}
+	for _, status := range statuses {
+		installed := status.Installed
+		if installed == "" {
+			installed = "-"
+		}
+		data = append(data, []string{status.Name, status.Version, installed, stateText(status.State)})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+// stateText colors a tool state for the table
+func stateText(state tools.State) string {
+	switch state {
+	case tools.StateInstalled:
+		return pterm.Green(string(state))
+	case tools.StateOutdated, tools.StateModified:
+		return pterm.Red(string(state))
+	case tools.StateMissing:
+		return pterm.Yellow(string(state))
+	default:
+		return pterm.Gray(string(state))
+	}
+}
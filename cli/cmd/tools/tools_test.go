@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	sharedTools "github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetToolsCmd(t *testing.T) {
+	cmd := GetToolsCmd()
+
+	assert.Equal(t, "tools", cmd.Use)
+	assert.Contains(t, cmd.Long, "~/.local/share/openframe/bin")
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("base-url"))
+
+	names := make([]string, 0)
+	for _, subcmd := range cmd.Commands() {
+		names = append(names, subcmd.Name())
+		assert.Equal(t, subcmd.Name() == "pin", subcmd.Hidden, subcmd.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "install", "verify", "pin"}, names)
+}
+
+// subcommand returns the named subcommand with its flags parsed, as cobra would
+func subcommand(t *testing.T, name string, flags ...string) *cobra.Command {
+	t.Helper()
+	root := GetToolsCmd()
+	cmd, _, err := root.Find([]string{name})
+	require.NoError(t, err)
+	require.NoError(t, cmd.ParseFlags(flags))
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+// serveTool serves a jq binary from a local file server and returns the server URL and
+// a manifest file for it, with the checksum pinned when pinned is set
+func serveTool(t *testing.T, pinned bool) (string, string) {
+	t.Helper()
+	binary := []byte("jq binary")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jq/jq-1.7.1/jq" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(binary)
+	}))
+	t.Cleanup(server.Close)
+
+	sum := sha256.Sum256(binary)
+	artifact := "{path: jq-1.7.1/jq}"
+	if pinned {
+		artifact = "{path: jq-1.7.1/jq, sha256: " + hex.EncodeToString(sum[:]) + "}"
+	}
+	manifest := filepath.Join(t.TempDir(), "tools.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(`tools:
+  - name: jq
+    version: 1.7.1
+    baseURL: https://github.com/jqlang/jq/releases/download
+    platforms:
+      `+runtime.GOOS+"/"+runtime.GOARCH+`: `+artifact+`
+`), 0644))
+	return server.URL, manifest
+}
+
+func TestInstallAndVerify(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	baseURL, manifest := serveTool(t, true)
+	t.Setenv(sharedTools.ManifestEnv, manifest)
+
+	require.NoError(t, runInstall(subcommand(t, "install", "--base-url", baseURL), nil))
+	require.NoError(t, runVerify(subcommand(t, "verify"), nil))
+
+	binDir, err := sharedTools.DefaultBinDir()
+	require.NoError(t, err)
+	binary := filepath.Join(binDir, "jq")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	require.NoError(t, os.WriteFile(binary, []byte("tampered"), 0755))
+	assert.EqualError(t, runVerify(subcommand(t, "verify"), nil), "1 tools failed verification, reinstall them with 'openframe tools install --force'")
+
+	require.NoError(t, runInstall(subcommand(t, "install", "--force", "--base-url", baseURL), []string{"jq"}))
+	require.NoError(t, runVerify(subcommand(t, "verify"), nil))
+}
+
+func TestInstall_Errors(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	baseURL, manifest := serveTool(t, false)
+	t.Setenv(sharedTools.ManifestEnv, manifest)
+
+	err := runInstall(subcommand(t, "install", "--base-url", baseURL), []string{"jq", "yq"})
+	assert.ErrorContains(t, err, "refusing to install an unverified download")
+	assert.ErrorContains(t, err, "unknown tool yq")
+
+	assert.NoError(t, runInstall(subcommand(t, "install", "--base-url", baseURL), nil), "unpinned tools are skipped when installing everything")
+}
+
+func TestRunPin(t *testing.T) {
+	baseURL, manifest := serveTool(t, false)
+
+	require.NoError(t, runPin(subcommand(t, "pin", "--manifest", manifest, "--base-url", baseURL), nil))
+
+	pinned, err := sharedTools.LoadManifestFile(manifest)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte("jq binary"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), pinned.Tools[0].Platforms[runtime.GOOS+"/"+runtime.GOARCH].SHA256)
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// getVerifyCmd returns the verify subcommand
+func getVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Check the installed tools against what was installed",
+		Long: `Check the installed tools against what was installed
+
+Hashes every binary in ~/.local/share/openframe/bin and compares it with the
+checksum recorded when it was installed, and the installed versions with the
+tool manifest. Fails when a binary was changed since ('modified') or was
+installed from an older pin ('outdated'); 'openframe tools install --force'
+installs them again. Tools that are not installed are only listed.
+
+Examples:
+  openframe tools verify`,
+		Args:          cobra.NoArgs,
+		RunE:          runVerify,
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	manager, err := newManager(cmd)
+	if err != nil {
+		return err
+	}
+	statuses, err := manager.Verify()
+	if err != nil {
+		return err
+	}
+	showStatuses(statuses)
+
+	failed := 0
+	for _, status := range statuses {
+		if status.State == tools.StateModified || status.State == tools.StateOutdated {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d tools failed verification, reinstall them with 'openframe tools install --force'", failed)
+	}
+	pterm.Success.Println("All installed tools match their pins")
+	return nil
+}
@@ -1,14 +1,24 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/bundle"
 	chartServices "github.com/flamingo-stack/openframe/openframe/internal/chart/services"
 	utilTypes "github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster"
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/docker"
 	sharedErrors "github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
@@ -59,6 +69,16 @@ func (s *Service) Execute(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	bundlePath, err := cmd.Flags().GetString("bundle")
+	if err != nil {
+		bundlePath = ""
+	}
+	if bundlePath != "" {
+		if deploymentMode, err = bundleDeploymentMode(deploymentMode); err != nil {
+			return err
+		}
+	}
+
 	// Validate non-interactive requires deployment mode
 	if nonInteractive && deploymentMode == "" {
 		return fmt.Errorf("--deployment-mode is required when using --non-interactive")
@@ -70,7 +90,11 @@ func (s *Service) Execute(cmd *cobra.Command, args []string) error {
 		clusterName = strings.TrimSpace(args[0])
 	}
 
-	err = s.bootstrap(clusterName, deploymentMode, nonInteractive, verbose)
+	if bundlePath != "" {
+		err = s.bootstrapFromBundle(clusterName, bundlePath, nonInteractive, verbose)
+	} else {
+		err = s.bootstrap(clusterName, deploymentMode, nonInteractive, verbose)
+	}
 	if err != nil {
 		// Use shared error handler for consistent error display (same as chart install)
 		return sharedErrors.HandleGlobalError(err, verbose)
@@ -101,6 +125,89 @@ func (s *Service) bootstrap(clusterName, deploymentMode string, nonInteractive,
 	return nil
 }
 
+// bootstrapFromBundle executes cluster create and chart install with the images, charts and
+// Git repository of a bundle, without network access
+func (s *Service) bootstrapFromBundle(clusterName, bundlePath string, nonInteractive, verbose bool) error {
+	// The cluster reaches the served repository through the Docker host
+	if host := docker.RemoteHost(); host != "" {
+		return fmt.Errorf("--bundle needs Docker on this machine, but %s points at %s", docker.HostEnv, host)
+	}
+
+	b, err := bundle.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Claim the port first: the installed applications can only sync from this one
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(bundle.DefaultPort))
+	if err != nil {
+		return fmt.Errorf("failed to serve the bundle on port %d: %w", bundle.DefaultPort, err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- b.ServeListener(ctx, listener) }()
+	defer func() {
+		stop()
+		<-served
+	}()
+
+	config := s.buildClusterConfig(clusterName)
+	actualClusterName := config.Name
+	importer := bundle.NewImporter(executor.NewRealCommandExecutor(false, verbose))
+
+	pterm.Info.Printf("Loading %d node images from %s...\n", len(b.Manifest.HostImages), bundlePath)
+	if err := importer.LoadHostImages(ctx, b); err != nil {
+		return err
+	}
+
+	// Step 1: Create cluster with suppressed UI
+	if err := s.createClusterSuppressed(actualClusterName, verbose, nonInteractive); err != nil {
+		return fmt.Errorf("failed to create cluster: %w", err)
+	}
+
+	pterm.Info.Printf("Importing %d images into cluster %s...\n", len(b.Manifest.ClusterImages), actualClusterName)
+	if err := importer.ImportClusterImages(ctx, b, actualClusterName); err != nil {
+		return err
+	}
+
+	// Add spacing between commands
+	fmt.Println()
+	fmt.Println()
+
+	// Step 2: Install charts from the bundle on the created cluster
+	err = chartServices.InstallChartsWithConfig(utilTypes.InstallationRequest{
+		Args:           []string{actualClusterName},
+		Verbose:        verbose,
+		GitHubRepo:     "file://" + b.RepoPath(),
+		GitHubBranch:   b.Manifest.Branch,
+		DeploymentMode: "oss-tenant",
+		NonInteractive: nonInteractive,
+		SetValues:      b.Manifest.SetValues, // Retag "latest" images to the ones the bundle holds
+		SkipVerify:     []string{"all"},      // Registries, ngrok and GitHub are out of reach
+		ArgoCDChart:    b.ArgoCDChartPath(),
+		ServedRepo:     bundle.RepoURL(bundle.DefaultPort),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install charts: %w", err)
+	}
+
+	pterm.Info.Printf("ArgoCD syncs from %s. Run 'openframe bundle serve %s' whenever it needs to sync again.\n",
+		bundle.RepoURL(bundle.DefaultPort), bundlePath)
+	return nil
+}
+
+// bundleDeploymentMode returns the deployment mode of a bundle install, which bundles
+// the oss-tenant repository only
+func bundleDeploymentMode(deploymentMode string) (string, error) {
+	if deploymentMode != "" && deploymentMode != "oss-tenant" {
+		return "", fmt.Errorf("--bundle installs the oss-tenant deployment mode only, not %s", deploymentMode)
+	}
+	return "oss-tenant", nil
+}
+
 // createClusterSuppressed creates a cluster with suppressed UI elements
 func (s *Service) createClusterSuppressed(clusterName string, verbose bool, nonInteractive bool) error {
 	// Use the wrapper function that includes prerequisite checks
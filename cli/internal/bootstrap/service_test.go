@@ -144,3 +144,23 @@ func TestServiceVerboseFlagHandling(t *testing.T) {
 // testing. The service coordinates existing cluster and chart commands, so
 // testing focuses on structure and method availability rather than end-to-end
 // execution which would require complex mocking of the underlying commands.
+
+func TestBundleDeploymentMode(t *testing.T) {
+	mode, err := bundleDeploymentMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, "oss-tenant", mode, "a bundle defaults to the mode it bundles")
+
+	mode, err = bundleDeploymentMode("oss-tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, "oss-tenant", mode)
+
+	_, err = bundleDeploymentMode("saas-shared")
+	assert.EqualError(t, err, "--bundle installs the oss-tenant deployment mode only, not saas-shared")
+}
+
+func TestBootstrapFromBundle_RemoteDockerHost(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "ssh://dev@buildbox")
+
+	err := NewService().bootstrapFromBundle("demo", "openframe-bundle.tar", true, false)
+	assert.EqualError(t, err, "--bundle needs Docker on this machine, but DOCKER_HOST points at buildbox")
+}
@@ -0,0 +1,50 @@
+package bundle
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "bundle.json"), "{}")
+	writeFile(t, filepath.Join(dir, "repo.git", "objects", "pack", "pack-1.pack"), "pack")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "repo.git", "refs", "tags"), 0o755))
+
+	output := filepath.Join(t.TempDir(), "bundle.tar")
+	require.NoError(t, writeArchive(dir, output))
+
+	target := t.TempDir()
+	require.NoError(t, extractArchive(output, target, nil))
+	data, err := os.ReadFile(filepath.Join(target, "repo.git", "objects", "pack", "pack-1.pack"))
+	require.NoError(t, err)
+	assert.Equal(t, "pack", string(data))
+	assert.DirExists(t, filepath.Join(target, "repo.git", "refs", "tags"), "empty directories of a bare repository are kept")
+
+	entries, err := os.ReadDir(filepath.Dir(output))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary archive is left behind")
+}
+
+func TestExtractArchive_RejectsTraversal(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "evil.tar")
+	f, err := os.Create(output)
+	require.NoError(t, err)
+	tw := tar.NewWriter(f)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0o644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+
+	target := filepath.Join(t.TempDir(), "target")
+	err = extractArchive(output, target, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside of it")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(target), "escape"))
+}
@@ -0,0 +1,148 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout of a bundle archive
+const (
+	manifestFile      = "bundle.json"
+	repoDir           = "repo.git"           // Bare Git repository ArgoCD syncs from
+	chartsDir         = "charts"             // argo-cd chart archive
+	clusterImagesFile = "images/cluster.tar" // Images of the workloads, imported into the cluster nodes
+	hostImagesFile    = "images/host.tar"    // Images of the cluster itself, loaded into Docker
+)
+
+// formatVersion is the version of the bundle layout, raised when it changes incompatibly
+const formatVersion = 1
+
+// DefaultPort is the port the Git repository of a bundle is served on. Its URL is written
+// into the ArgoCD applications, so it has to stay the same while they exist.
+const DefaultPort = 8780
+
+// RepoURL returns the URL ArgoCD reaches the Git repository of a bundle at, served on port
+// of the machine running Docker
+func RepoURL(port int) string {
+	return "http://host.k3d.internal:" + strconv.Itoa(port) + "/" + repoDir
+}
+
+// Manifest describes the contents of a bundle
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	Created       time.Time `json:"created"`
+	Repository    string    `json:"repository"` // Repository the manifests were taken from
+	Branch        string    `json:"branch"`
+	Commit        string    `json:"commit"`      // Bundled commit, chart dependencies vendored
+	ArgoCDChart   string    `json:"argocdChart"` // Path of the argo-cd chart archive in the bundle
+	ClusterImages []string  `json:"clusterImages"`
+	HostImages    []string  `json:"hostImages"`
+	SetValues     []string  `json:"setValues,omitempty"` // Install values retagging "latest" images to ImageTag
+	Skipped       []string  `json:"skipped,omitempty"`   // Application sources whose images are not bundled
+}
+
+// Bundle is a bundle archive extracted into a directory
+type Bundle struct {
+	Dir      string
+	Manifest Manifest
+}
+
+// Open extracts the bundle archive at path into a temporary directory. With parts, only
+// the manifest and those parts of the layout are extracted. Close removes the directory.
+func Open(path string, parts ...string) (*Bundle, error) {
+	dir, err := os.MkdirTemp("", "openframe-bundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	var only func(name string) bool
+	if len(parts) > 0 {
+		only = func(name string) bool {
+			if name == manifestFile {
+				return true
+			}
+			for _, part := range parts {
+				if name == part || strings.HasPrefix(name, part+"/") {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	if err := extractArchive(path, dir, only); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	manifest, err := readManifest(filepath.Join(dir, manifestFile))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("%s is no OpenFrame bundle: %w", path, err)
+	}
+	return &Bundle{Dir: dir, Manifest: manifest}, nil
+}
+
+// OpenRepository extracts only the manifest and the Git repository of the bundle archive
+// at path, all serving it needs
+func OpenRepository(path string) (*Bundle, error) {
+	return Open(path, repoDir)
+}
+
+// Close removes the extracted bundle
+func (b *Bundle) Close() error {
+	return os.RemoveAll(b.Dir)
+}
+
+// RepoPath returns the bare Git repository of the bundle
+func (b *Bundle) RepoPath() string {
+	return filepath.Join(b.Dir, repoDir)
+}
+
+// ArgoCDChartPath returns the argo-cd chart archive of the bundle
+func (b *Bundle) ArgoCDChartPath() string {
+	return filepath.Join(b.Dir, filepath.FromSlash(b.Manifest.ArgoCDChart))
+}
+
+// ClusterImagesPath returns the archive of the images the workloads run
+func (b *Bundle) ClusterImagesPath() string {
+	return filepath.Join(b.Dir, filepath.FromSlash(clusterImagesFile))
+}
+
+// HostImagesPath returns the archive of the images the cluster nodes run
+func (b *Bundle) HostImagesPath() string {
+	return filepath.Join(b.Dir, filepath.FromSlash(hostImagesFile))
+}
+
+// ServedRoot returns the directory to serve over Git HTTP, holding the repository at the
+// path RepoURL names
+func (b *Bundle) ServedRoot() string {
+	return b.Dir
+}
+
+func readManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read %s: %w", manifestFile, err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse %s: %w", manifestFile, err)
+	}
+	if manifest.FormatVersion != formatVersion {
+		return Manifest{}, fmt.Errorf("bundle format %d is not supported by this CLI, which reads format %d", manifest.FormatVersion, formatVersion)
+	}
+	return manifest, nil
+}
+
+func writeManifest(path string, manifest Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", manifestFile, err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
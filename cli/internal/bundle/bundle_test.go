@@ -0,0 +1,142 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutor answers commands with handle, the first argument deciding, and records
+// every command line
+type fakeExecutor struct {
+	mu       sync.Mutex
+	handle   func(name string, args []string) (string, error)
+	commands []string
+}
+
+func (f *fakeExecutor) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	f.mu.Lock()
+	f.commands = append(f.commands, strings.Join(append([]string{name}, args...), " "))
+	f.mu.Unlock()
+
+	stdout, err := f.handle(name, args)
+	if err != nil {
+		return &executor.CommandResult{ExitCode: 1, Stderr: err.Error()}, err
+	}
+	return &executor.CommandResult{Stdout: stdout}, nil
+}
+
+func (f *fakeExecutor) ExecuteWithOptions(ctx context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	return f.Execute(ctx, options.Command, options.Args...)
+}
+
+func (f *fakeExecutor) ran(prefix string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, command := range f.commands {
+		if strings.HasPrefix(command, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// writeTestBundle writes a bundle archive with a manifest of formatVersion
+func writeTestBundle(t *testing.T, version int) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, writeManifest(filepath.Join(dir, manifestFile), Manifest{
+		FormatVersion: version,
+		Branch:        "main",
+		ArgoCDChart:   "charts/argo-cd-8.2.7.tgz",
+	}))
+	writeFile(t, filepath.Join(dir, "repo.git", "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(dir, "charts", "argo-cd-8.2.7.tgz"), "chart")
+	writeFile(t, filepath.Join(dir, "images", "cluster.tar"), "images")
+
+	output := filepath.Join(t.TempDir(), "bundle.tar")
+	require.NoError(t, writeArchive(dir, output))
+	return output
+}
+
+func TestOpen(t *testing.T) {
+	b, err := Open(writeTestBundle(t, formatVersion))
+	require.NoError(t, err)
+	defer b.Close()
+
+	assert.Equal(t, "main", b.Manifest.Branch)
+	data, err := os.ReadFile(b.ArgoCDChartPath())
+	require.NoError(t, err)
+	assert.Equal(t, "chart", string(data))
+	assert.FileExists(t, filepath.Join(b.RepoPath(), "HEAD"))
+	assert.FileExists(t, b.ClusterImagesPath())
+	assert.NoFileExists(t, b.HostImagesPath())
+
+	require.NoError(t, b.Close())
+	assert.NoDirExists(t, b.Dir)
+}
+
+func TestOpenRepository(t *testing.T) {
+	b, err := OpenRepository(writeTestBundle(t, formatVersion))
+	require.NoError(t, err)
+	defer b.Close()
+
+	assert.FileExists(t, filepath.Join(b.RepoPath(), "HEAD"))
+	assert.NoFileExists(t, b.ArgoCDChartPath())
+	assert.NoFileExists(t, b.ClusterImagesPath())
+}
+
+func TestOpen_FormatVersion(t *testing.T) {
+	_, err := Open(writeTestBundle(t, formatVersion+1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bundle format 2 is not supported by this CLI, which reads format 1")
+}
+
+func TestOpen_NoBundle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "README.md"), "not a bundle")
+	output := filepath.Join(t.TempDir(), "other.tar")
+	require.NoError(t, writeArchive(dir, output))
+
+	_, err := Open(output)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is no OpenFrame bundle")
+}
+
+func TestRepoURL(t *testing.T) {
+	assert.Equal(t, "http://host.k3d.internal:8780/repo.git", RepoURL(DefaultPort))
+}
+
+func TestImporter(t *testing.T) {
+	b, err := Open(writeTestBundle(t, formatVersion))
+	require.NoError(t, err)
+	defer b.Close()
+
+	exec := &fakeExecutor{handle: func(name string, args []string) (string, error) { return "", nil }}
+	importer := NewImporter(exec)
+
+	require.NoError(t, importer.LoadHostImages(context.Background(), b))
+	assert.False(t, exec.ran("docker load"), "a bundle without host images loads nothing")
+
+	require.NoError(t, importer.ImportClusterImages(context.Background(), b, "demo"))
+	assert.True(t, exec.ran("k3d image import "+b.ClusterImagesPath()+" --cluster demo"))
+
+	exec.handle = func(name string, args []string) (string, error) { return "", errors.New("no such cluster") }
+	err = importer.ImportClusterImages(context.Background(), b, "demo")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no such cluster")
+}
@@ -0,0 +1,243 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/argocd"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// CreateOptions selects what goes into a bundle
+type CreateOptions struct {
+	Output      string   // Path of the bundle archive
+	Repository  string   // Repository holding the app-of-apps chart and the manifests tree
+	Branch      string   // Branch of the repository
+	ValuesFiles []string // Helm values the app-of-apps chart is rendered with
+}
+
+// HostImagesFunc returns the images Docker needs to create a cluster
+type HostImagesFunc func(ctx context.Context) ([]string, error)
+
+// Creator builds bundle archives
+type Creator struct {
+	executor   executor.CommandExecutor
+	hostImages HostImagesFunc
+	progress   func(step string)
+}
+
+// NewCreator creates a bundle creator that bundles the images hostImages returns for the
+// cluster nodes
+func NewCreator(exec executor.CommandExecutor, hostImages HostImagesFunc) *Creator {
+	return &Creator{
+		executor:   exec,
+		hostImages: hostImages,
+		progress:   func(string) {},
+	}
+}
+
+// WithProgress reports each step of Create to fn
+func (c *Creator) WithProgress(fn func(step string)) *Creator {
+	c.progress = fn
+	return c
+}
+
+// Create renders the app-of-apps chart and every Application it leads to, and writes the
+// repository, the argo-cd chart and every image they need into a bundle archive
+func (c *Creator) Create(ctx context.Context, opts CreateOptions) (*Manifest, error) {
+	work, err := os.MkdirTemp("", "openframe-bundle-create-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(work)
+
+	source := filepath.Join(work, "source")
+	out := filepath.Join(work, "bundle")
+	if err := os.MkdirAll(filepath.Join(out, chartsDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create bundle directory: %w", err)
+	}
+
+	c.progress(fmt.Sprintf("Cloning %s@%s", opts.Repository, opts.Branch))
+	if err := c.run(ctx, "git", "clone", "--depth", "1", "--single-branch", "--no-tags", "--branch", opts.Branch, opts.Repository, source); err != nil {
+		return nil, err
+	}
+
+	c.progress("Downloading the argo-cd chart " + helm.ArgoCDChartVersion)
+	if err := c.run(ctx, "helm", "pull", "argo-cd", "--repo", helm.ArgoCDChartRepository, "--version", helm.ArgoCDChartVersion, "-d", filepath.Join(out, chartsDir)); err != nil {
+		return nil, err
+	}
+	argoCDChart := chartsDir + "/argo-cd-" + helm.ArgoCDChartVersion + ".tgz"
+
+	c.progress("Rendering manifests")
+	r := newRenderer(c.executor, source, RepoURL(DefaultPort))
+	if err := c.render(ctx, r, filepath.Join(out, filepath.FromSlash(argoCDChart)), source, opts); err != nil {
+		return nil, err
+	}
+	if len(r.pulling) > 0 {
+		return nil, fmt.Errorf("these containers pull their image on every start, which fails offline; "+
+			"give their images a tag other than latest or set imagePullPolicy: IfNotPresent:\n  %s", strings.Join(r.pulling, "\n  "))
+	}
+
+	commit, err := c.commitDependencies(ctx, source, r.vendored)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.run(ctx, "git", "clone", "--bare", "--no-tags", source, filepath.Join(out, repoDir)); err != nil {
+		return nil, err
+	}
+
+	hostImages, err := c.hostImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusterImages := r.Images()
+	if err := c.saveImages(ctx, clusterImages, filepath.Join(out, filepath.FromSlash(clusterImagesFile))); err != nil {
+		return nil, err
+	}
+	if err := c.saveImages(ctx, hostImages, filepath.Join(out, filepath.FromSlash(hostImagesFile))); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		FormatVersion: formatVersion,
+		Created:       time.Now().UTC(),
+		Repository:    opts.Repository,
+		Branch:        opts.Branch,
+		Commit:        commit,
+		ArgoCDChart:   argoCDChart,
+		ClusterImages: clusterImages,
+		HostImages:    hostImages,
+		SetValues:     r.SetValues(),
+		Skipped:       r.skipped,
+	}
+	if err := writeManifest(filepath.Join(out, manifestFile), *manifest); err != nil {
+		return nil, err
+	}
+
+	c.progress("Writing " + opts.Output)
+	if err := writeArchive(out, opts.Output); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// render renders ArgoCD and the app-of-apps chart the way chart install installs them,
+// with ArgoCD syncing from the repository of the bundle. Images tagged "latest" are pulled
+// on every start, so when the first pass finds some, everything is rendered again with
+// the install values that retag them to ImageTag.
+func (c *Creator) render(ctx context.Context, r *renderer, argoCDChart, source string, opts CreateOptions) error {
+	if err := c.renderOnce(ctx, r, argoCDChart, source, opts); err != nil || len(r.values) == 0 {
+		return err
+	}
+	r.reset()
+	return c.renderOnce(ctx, r, argoCDChart, source, opts)
+}
+
+func (c *Creator) renderOnce(ctx context.Context, r *renderer, argoCDChart, source string, opts CreateOptions) error {
+	values, err := writeValues(argocd.GetArgoCDValues())
+	if err != nil {
+		return err
+	}
+	defer os.Remove(values)
+	if err := r.renderChart(ctx, "argo-cd", argoCDChart, "argocd", noValuePath, "-f", values); err != nil {
+		return err
+	}
+
+	appOfApps := models.NewAppOfAppsConfig()
+	chart := filepath.Join(source, filepath.FromSlash(appOfApps.ChartPath))
+	if _, err := os.Stat(chart); err != nil {
+		return fmt.Errorf("chart path '%s' does not exist in repository", appOfApps.ChartPath)
+	}
+	var args []string
+	for _, file := range opts.ValuesFiles {
+		args = append(args, "-f", file)
+	}
+	args = append(args,
+		"--set-string", "deployment.oss.repository.URL="+RepoURL(DefaultPort),
+		"--set-string", "deployment.oss.repository.branch="+opts.Branch,
+	)
+	for _, value := range r.SetValues() {
+		args = append(args, "--set-string", value)
+	}
+	return r.renderChart(ctx, "app-of-apps", chart, appOfApps.Namespace, installValuePath, args...)
+}
+
+// commitDependencies commits the chart dependencies helm downloaded, so that ArgoCD finds
+// them in the repository instead of fetching them, and returns the bundled commit
+func (c *Creator) commitDependencies(ctx context.Context, source string, charts []string) (string, error) {
+	if len(charts) > 0 {
+		args := []string{"-C", source, "add", "--force", "--all", "--"}
+		for _, chart := range charts {
+			if rel, err := filepath.Rel(source, chart); err == nil && !strings.HasPrefix(rel, "..") {
+				args = append(args, rel)
+			}
+		}
+		if err := c.run(ctx, "git", args...); err != nil {
+			return "", err
+		}
+
+		status, err := c.executor.Execute(ctx, "git", "-C", source, "status", "--porcelain")
+		if err != nil {
+			return "", fmt.Errorf("failed to read repository status: %w", err)
+		}
+		if strings.TrimSpace(status.Stdout) != "" {
+			if err := c.run(ctx, "git", "-C", source, "-c", "user.name=OpenFrame CLI", "-c", "user.email=openframe-cli@localhost",
+				"commit", "--quiet", "--no-verify", "-m", "Vendor chart dependencies for an offline install"); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	result, err := c.executor.Execute(ctx, "git", "-C", source, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to read the bundled commit: %w", err)
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// saveImages pulls images and saves them into one archive, none when there are no images.
+// Images tagged ImageTag are pulled as "latest" and retagged.
+func (c *Creator) saveImages(ctx context.Context, images []string, file string) error {
+	if len(images) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return fmt.Errorf("failed to create image directory: %w", err)
+	}
+	for i, image := range images {
+		c.progress(fmt.Sprintf("Pulling image %d/%d: %s", i+1, len(images), image))
+		source, pinned := pinnedSource(image)
+		if !pinned {
+			source = image
+		}
+		if err := c.run(ctx, "docker", "pull", "--quiet", source); err != nil {
+			return err
+		}
+		if pinned {
+			if err := c.run(ctx, "docker", "tag", source, image); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.progress(fmt.Sprintf("Saving %d images", len(images)))
+	return c.run(ctx, "docker", append([]string{"save", "-o", file}, images...)...)
+}
+
+// run runs a command, with its output in the error when it fails
+func (c *Creator) run(ctx context.Context, name string, args ...string) error {
+	result, err := c.executor.Execute(ctx, name, args...)
+	if err != nil {
+		if result != nil && strings.TrimSpace(result.Stderr) != "" {
+			return fmt.Errorf("failed to run %s: %w\n%s", name, err, strings.TrimSpace(result.Stderr))
+		}
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// newCreateExecutor fakes git, helm and docker well enough for Create, writing the files
+// the real commands would
+func newCreateExecutor(t *testing.T) *fakeExecutor {
+	return &fakeExecutor{handle: func(name string, args []string) (string, error) {
+		command := name + " " + strings.Join(args, " ")
+		switch {
+		case strings.HasPrefix(command, "git clone --depth 1"):
+			source := args[len(args)-1]
+			writeFile(t, filepath.Join(source, "manifests", "app-of-apps", "Chart.yaml"), "name: app-of-apps\n")
+			writeFile(t, filepath.Join(source, "manifests", "apps", "api", "deployment.yaml"), "kind: Deployment\nspec:\n  image: ghcr.io/flamingo-stack/openframe-api:1.2.0\n")
+			return "", nil
+		case strings.HasPrefix(command, "git clone --bare"):
+			writeFile(t, filepath.Join(args[len(args)-1], "HEAD"), "ref: refs/heads/main\n")
+			return "", nil
+		case strings.HasSuffix(command, "rev-parse HEAD"):
+			return "3f1c2a9\n", nil
+		case strings.HasPrefix(command, "helm pull"):
+			dir := args[len(args)-1]
+			writeFile(t, filepath.Join(dir, "argo-cd-8.2.7.tgz"), "chart")
+			return "", nil
+		case strings.HasPrefix(command, "helm template argo-cd"):
+			return "kind: Deployment\nspec:\n  image: quay.io/argoproj/argocd:v3.0.12\n", nil
+		case strings.HasPrefix(command, "helm template app-of-apps"):
+			return `
+kind: Application
+metadata: {name: api}
+spec:
+  source:
+    repoURL: ` + testRepoURL + `
+    path: manifests/apps/api
+---
+kind: Application
+metadata: {name: external}
+spec:
+  source:
+    repoURL: https://github.com/example/other
+    path: deploy
+`, nil
+		case strings.HasPrefix(command, "docker pull"):
+			return "", nil
+		case strings.HasPrefix(command, "docker save"):
+			writeFile(t, args[2], strings.Join(args[3:], ","))
+			return "", nil
+		}
+		return "", errors.New("unexpected command: " + command)
+	}}
+}
+
+func TestCreator_Create(t *testing.T) {
+	exec := newCreateExecutor(t)
+	var steps []string
+	creator := NewCreator(exec, func(ctx context.Context) ([]string, error) {
+		return []string{"rancher/k3s:v1.31.5-k3s1", "ghcr.io/k3d-io/k3d-proxy:5.8.3"}, nil
+	}).WithProgress(func(step string) { steps = append(steps, step) })
+
+	output := filepath.Join(t.TempDir(), "openframe-bundle.tar")
+	manifest, err := creator.Create(context.Background(), CreateOptions{
+		Output:      output,
+		Repository:  "https://github.com/flamingo-stack/openframe-oss-tenant",
+		Branch:      "main",
+		ValuesFiles: []string{"helm-values.yaml"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "3f1c2a9", manifest.Commit)
+	assert.Equal(t, []string{"ghcr.io/flamingo-stack/openframe-api:1.2.0", "quay.io/argoproj/argocd:v3.0.12"}, manifest.ClusterImages)
+	assert.Equal(t, []string{"external: https://github.com/example/other is not in the bundled repository"}, manifest.Skipped)
+	assert.True(t, exec.ran("helm pull argo-cd --repo https://argoproj.github.io/argo-helm --version 8.2.7"))
+	assert.True(t, exec.ran("docker pull --quiet rancher/k3s:v1.31.5-k3s1"))
+	assert.Contains(t, strings.Join(exec.commands, "\n"), " --skip-tests --namespace argocd -f helm-values.yaml --set-string deployment.oss.repository.URL="+testRepoURL+" --set-string deployment.oss.repository.branch=main")
+	assert.NotContains(t, strings.Join(exec.commands, "\n"), " commit ", "no chart has dependencies to vendor")
+	assert.Contains(t, steps, "Writing "+output)
+
+	b, err := Open(output)
+	require.NoError(t, err)
+	defer b.Close()
+	assert.Equal(t, manifest.Commit, b.Manifest.Commit)
+	assert.FileExists(t, b.ArgoCDChartPath())
+	assert.FileExists(t, filepath.Join(b.RepoPath(), "HEAD"))
+	hostImages, err := os.ReadFile(b.HostImagesPath())
+	require.NoError(t, err)
+	assert.Equal(t, "rancher/k3s:v1.31.5-k3s1,ghcr.io/k3d-io/k3d-proxy:5.8.3", string(hostImages))
+}
+
+func TestCreator_Create_Fails(t *testing.T) {
+	exec := newCreateExecutor(t)
+	handle := exec.handle
+	exec.handle = func(name string, args []string) (string, error) {
+		if name == "docker" && args[0] == "pull" {
+			return "", errors.New("pull access denied for private/image")
+		}
+		return handle(name, args)
+	}
+	creator := NewCreator(exec, func(ctx context.Context) ([]string, error) { return nil, nil })
+
+	output := filepath.Join(t.TempDir(), "openframe-bundle.tar")
+	_, err := creator.Create(context.Background(), CreateOptions{Output: output, Repository: "repo", Branch: "main"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pull access denied")
+	assert.NoFileExists(t, output, "a failed run leaves no bundle")
+}
+
+// newLatestExecutor fakes Create for a tree like the OpenFrame one, with helm passing the
+// values of app-of-apps on to the apps chart and apps.<name>.values of the apps chart on
+// to the chart of each app. The values of the apps chart tag the api image "latest", and
+// extra is appended to the containers of the api Deployment.
+func newLatestExecutor(t *testing.T, extra string) *fakeExecutor {
+	create := newCreateExecutor(t)
+	return &fakeExecutor{handle: func(name string, args []string) (string, error) {
+		command := name + " " + strings.Join(args, " ")
+		switch {
+		case strings.HasPrefix(command, "git clone --depth 1"):
+			source := args[len(args)-1]
+			writeFile(t, filepath.Join(source, "manifests", "app-of-apps", "Chart.yaml"), "name: app-of-apps\n")
+			writeFile(t, filepath.Join(source, "manifests", "apps", "Chart.yaml"), "name: apps\n")
+			writeFile(t, filepath.Join(source, "manifests", "apps", "values.yaml"), "apps:\n  api:\n    enabled: true\n")
+			writeFile(t, filepath.Join(source, "manifests", "apps", "values-dev.yaml"), "apps:\n  api:\n    values:\n      image:\n        tag: latest\n")
+			writeFile(t, filepath.Join(source, "manifests", "microservices", "api", "Chart.yaml"), "name: api\n")
+			writeFile(t, filepath.Join(source, "manifests", "microservices", "api", "values.yaml"), "image:\n  repo: ghcr.io/flamingo-stack/openframe-api\n  tag: 1.2.0\n")
+			return "", nil
+		case strings.HasPrefix(command, "helm template app-of-apps"):
+			return testApplication("argocd-apps", "manifests/apps", []string{"values-dev.yaml"}, testHelmValues(t, "", args)), nil
+		case strings.HasPrefix(command, "helm template argocd-apps"):
+			apps, _ := testHelmValues(t, args[2], args)["apps"].(map[string]interface{})
+			var out string
+			for app, values := range apps {
+				appValues, _ := values.(map[string]interface{})["values"].(map[string]interface{})
+				out += testApplication(app, "manifests/microservices/"+app, nil, appValues)
+			}
+			return out, nil
+		case strings.HasPrefix(command, "helm template api"):
+			image, _ := testHelmValues(t, args[2], args)["image"].(map[string]interface{})
+			out := fmt.Sprintf("kind: Deployment\nmetadata: {name: api}\nspec:\n  template:\n    spec:\n      containers:\n        - name: api\n          image: %v:%v\n", image["repo"], image["tag"])
+			if policy, ok := image["pullPolicy"]; ok {
+				out += fmt.Sprintf("          imagePullPolicy: %v\n", policy)
+			}
+			return out + extra, nil
+		case strings.HasPrefix(command, "docker tag"):
+			return "", nil
+		}
+		return create.handle(name, args)
+	}}
+}
+
+// testHelmValues merges the values helm renders chart with: its values.yaml, the -f files
+// and the --set-string values of args
+func testHelmValues(t *testing.T, chart string, args []string) map[string]interface{} {
+	t.Helper()
+	values := map[string]interface{}{}
+	read := func(file string) {
+		if data, err := os.ReadFile(file); err == nil {
+			require.NoError(t, mergeYAML(values, data))
+		}
+	}
+	if chart != "" {
+		read(filepath.Join(chart, "values.yaml"))
+	}
+	for i := 0; i+1 < len(args); i++ {
+		switch args[i] {
+		case "-f":
+			read(args[i+1])
+		case "--set-string":
+			key, value, _ := strings.Cut(args[i+1], "=")
+			keys := strings.Split(key, ".")
+			set := map[string]interface{}{keys[len(keys)-1]: value}
+			for j := len(keys) - 2; j >= 0; j-- {
+				set = map[string]interface{}{keys[j]: set}
+			}
+			mergeValues(values, set)
+		}
+	}
+	return values
+}
+
+func testApplication(name, path string, valueFiles []string, values map[string]interface{}) string {
+	data, _ := yaml.Marshal(map[string]interface{}{
+		"kind":     "Application",
+		"metadata": map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{"source": map[string]interface{}{
+			"repoURL": testRepoURL,
+			"path":    path,
+			"helm":    map[string]interface{}{"valueFiles": valueFiles, "valuesObject": values},
+		}},
+	})
+	return "---\n" + string(data)
+}
+
+func TestCreator_Create_PinsLatestImages(t *testing.T) {
+	exec := newLatestExecutor(t, "")
+	creator := NewCreator(exec, func(ctx context.Context) ([]string, error) { return nil, nil })
+
+	manifest, err := creator.Create(context.Background(), CreateOptions{Output: filepath.Join(t.TempDir(), "openframe-bundle.tar"), Repository: "repo", Branch: "main"})
+	require.NoError(t, err, "no workload of the bundle pulls its image on every start")
+
+	assert.Equal(t, []string{"ghcr.io/flamingo-stack/openframe-api:openframe-bundle", "quay.io/argoproj/argocd:v3.0.12"}, manifest.ClusterImages)
+	assert.Equal(t, []string{"apps.api.values.image.pullPolicy=IfNotPresent", "apps.api.values.image.tag=openframe-bundle"}, manifest.SetValues)
+	assert.True(t, exec.ran("docker pull --quiet ghcr.io/flamingo-stack/openframe-api:latest"))
+	assert.True(t, exec.ran("docker tag ghcr.io/flamingo-stack/openframe-api:latest ghcr.io/flamingo-stack/openframe-api:openframe-bundle"))
+	assert.Contains(t, strings.Join(exec.commands, "\n"), " --set-string apps.api.values.image.pullPolicy=IfNotPresent --set-string apps.api.values.image.tag=openframe-bundle",
+		"the app-of-apps chart is rendered with the values bootstrap installs it with")
+}
+
+func TestCreator_Create_PullAlways(t *testing.T) {
+	exec := newLatestExecutor(t, "        - name: wait\n          image: busybox\n        - name: tools\n          image: example/tools:1.0\n          imagePullPolicy: Always\n")
+	creator := NewCreator(exec, func(ctx context.Context) ([]string, error) { return nil, nil })
+
+	output := filepath.Join(t.TempDir(), "openframe-bundle.tar")
+	_, err := creator.Create(context.Background(), CreateOptions{Output: output, Repository: "repo", Branch: "main"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Deployment/api tools (example/tools:1.0)\n  Deployment/api wait (busybox)")
+	assert.NotContains(t, err.Error(), "openframe-api", "retagged images are not pulled on every start")
+	assert.NoFileExists(t, output)
+}
+
+func TestCreator_CommitDependencies(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	source := t.TempDir()
+	writeFile(t, filepath.Join(source, ".gitignore"), "charts/\n")
+	writeFile(t, filepath.Join(source, "manifests", "redis", "Chart.yaml"), "name: redis\n")
+	gitCommand(t, source, "init", "--quiet", "--initial-branch", "main")
+	gitCommand(t, source, "add", ".")
+	gitCommand(t, source, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial")
+	initial := gitCommand(t, source, "rev-parse", "HEAD")
+
+	writeFile(t, filepath.Join(source, "manifests", "redis", "Chart.lock"), "dependencies: []\n")
+	writeFile(t, filepath.Join(source, "manifests", "redis", "charts", "redis-20.0.0.tgz"), "chart")
+
+	creator := NewCreator(executor.NewRealCommandExecutor(false, false), nil)
+	commit, err := creator.commitDependencies(context.Background(), source, []string{filepath.Join(source, "manifests", "redis")})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, initial, commit)
+	assert.Equal(t, commit, gitCommand(t, source, "rev-parse", "HEAD"))
+	files := gitCommand(t, source, "ls-files")
+	assert.Contains(t, files, "manifests/redis/charts/redis-20.0.0.tgz", "ignored dependencies are committed")
+	assert.Contains(t, files, "manifests/redis/Chart.lock")
+}
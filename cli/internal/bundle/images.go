@@ -0,0 +1,172 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ImageTag replaces the "latest" tag of the images a bundle pins. Kubernetes pulls
+	// "latest" images on every start, which fails offline.
+	ImageTag = "openframe-bundle"
+	// imagePullPolicy is set next to every pinned tag, for charts that template it
+	imagePullPolicy = "IfNotPresent"
+)
+
+// latestImagePaths returns the dotted paths of the image maps in values whose tag is
+// "latest", e.g. "server.image" for server.image.tag
+func latestImagePaths(values map[string]interface{}) []string {
+	var paths []string
+	var walk func(prefix string, node map[string]interface{})
+	walk = func(prefix string, node map[string]interface{}) {
+		for key, value := range node {
+			child, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if key == "image" && child["tag"] == "latest" {
+				paths = append(paths, prefix+key)
+				continue
+			}
+			walk(prefix+key+".", child)
+		}
+	}
+	walk("", values)
+	sort.Strings(paths)
+	return paths
+}
+
+// chartValues merges the values a Helm source of an Application renders with, in the
+// order Helm applies them: the values.yaml of the chart, the value files, inline values
+func chartValues(dir string, source applicationSource) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	files := append([]string{"values.yaml"}, source.Helm.ValueFiles...)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if err := mergeYAML(values, data); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+	}
+	if err := mergeYAML(values, []byte(source.Helm.Values)); err != nil {
+		return nil, fmt.Errorf("failed to parse inline values: %w", err)
+	}
+	mergeValues(values, source.Helm.ValuesObject)
+	return values, nil
+}
+
+// mergeYAML merges a YAML values document into dst
+func mergeYAML(dst map[string]interface{}, data []byte) error {
+	var src map[string]interface{}
+	if err := yaml.Unmarshal(data, &src); err != nil {
+		return err
+	}
+	mergeValues(dst, src)
+	return nil
+}
+
+// mergeValues merges src into dst the way Helm merges values, maps key by key
+func mergeValues(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeValues(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// pullingContainers returns the containers of a rendered workload that pull their image
+// on every start, as "<kind>/<name> <container> (<image>)"
+func pullingContainers(doc *yaml.Node) []string {
+	var resource struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+	}
+	var tree interface{}
+	if doc.Decode(&resource) != nil || doc.Decode(&tree) != nil {
+		return nil
+	}
+
+	var pulling []string
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			for key, value := range n {
+				if key == "containers" || key == "initContainers" {
+					for _, item := range asList(value) {
+						container, _ := item.(map[string]interface{})
+						image, _ := container["image"].(string)
+						policy, _ := container["imagePullPolicy"].(string)
+						if isImage(image) && pullPolicy(image, policy) == "Always" {
+							pulling = append(pulling, fmt.Sprintf("%s/%s %v (%s)", resource.Kind, resource.Metadata.Name, container["name"], image))
+						}
+					}
+					continue
+				}
+				walk(value)
+			}
+		case []interface{}:
+			for _, item := range n {
+				walk(item)
+			}
+		}
+	}
+	walk(tree)
+	sort.Strings(pulling)
+	return pulling
+}
+
+func asList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+// pullPolicy returns the pull policy Kubernetes gives a container: the one it sets, or
+// Always for images without a tag or tagged "latest" and IfNotPresent for the rest
+func pullPolicy(image, policy string) string {
+	if policy != "" {
+		return policy
+	}
+	if strings.Contains(image, "@") {
+		return "IfNotPresent"
+	}
+	if tag := imageTagOf(image); tag == "" || tag == "latest" {
+		return "Always"
+	}
+	return "IfNotPresent"
+}
+
+// imageTagOf returns the tag of an image reference, empty when it has none. A colon
+// before the last slash belongs to a registry port.
+func imageTagOf(image string) string {
+	colon := strings.LastIndex(image, ":")
+	if colon < 0 || colon < strings.LastIndex(image, "/") {
+		return ""
+	}
+	return image[colon+1:]
+}
+
+// pinnedSource returns the image a pinned image is retagged from, the same repository
+// tagged "latest"
+func pinnedSource(image string) (string, bool) {
+	if imageTagOf(image) != ImageTag {
+		return "", false
+	}
+	return strings.TrimSuffix(image, ImageTag) + "latest", true
+}
@@ -0,0 +1,44 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullPolicy(t *testing.T) {
+	for image, want := range map[string]string{
+		"busybox":                       "Always",
+		"redis:latest":                  "Always",
+		"localhost:5000/api":            "Always",
+		"localhost:5000/api:1.2.0":      "IfNotPresent",
+		"redis:7.4":                     "IfNotPresent",
+		"redis@sha256:0123456789abcdef": "IfNotPresent",
+		"api:" + ImageTag:               "IfNotPresent",
+	} {
+		assert.Equal(t, want, pullPolicy(image, ""), image)
+	}
+	assert.Equal(t, "Always", pullPolicy("redis:7.4", "Always"), "an explicit policy wins")
+	assert.Equal(t, "IfNotPresent", pullPolicy("redis:latest", "IfNotPresent"))
+}
+
+func TestLatestImagePaths(t *testing.T) {
+	values := map[string]interface{}{
+		"image": map[string]interface{}{"repo": "api", "tag": "latest"},
+		"tactical": map[string]interface{}{
+			"base":     map[string]interface{}{"image": map[string]interface{}{"tag": "latest"}},
+			"frontend": map[string]interface{}{"image": map[string]interface{}{"tag": "1.0"}},
+		},
+		"redis": map[string]interface{}{"image": "redis:latest"},
+	}
+	assert.Equal(t, []string{"image", "tactical.base.image"}, latestImagePaths(values))
+}
+
+func TestPinnedSource(t *testing.T) {
+	source, ok := pinnedSource("localhost:5000/api:" + ImageTag)
+	assert.True(t, ok)
+	assert.Equal(t, "localhost:5000/api:latest", source)
+
+	_, ok = pinnedSource("redis:7.4")
+	assert.False(t, ok)
+}
@@ -0,0 +1,46 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// Importer loads the images of a bundle into Docker and into a cluster
+type Importer struct {
+	executor executor.CommandExecutor
+}
+
+// NewImporter creates a bundle image importer
+func NewImporter(exec executor.CommandExecutor) *Importer {
+	return &Importer{executor: exec}
+}
+
+// LoadHostImages loads the images the cluster nodes run into Docker, so that creating the
+// cluster pulls nothing
+func (i *Importer) LoadHostImages(ctx context.Context, b *Bundle) error {
+	return i.run(ctx, b.HostImagesPath(), "docker", "load", "--quiet", "-i", b.HostImagesPath())
+}
+
+// ImportClusterImages imports the images of the workloads into every node of a k3d cluster
+func (i *Importer) ImportClusterImages(ctx context.Context, b *Bundle, clusterName string) error {
+	return i.run(ctx, b.ClusterImagesPath(), "k3d", "image", "import", b.ClusterImagesPath(), "--cluster", clusterName)
+}
+
+// run runs a command on an image archive of the bundle, which is missing when the bundle
+// holds no such images
+func (i *Importer) run(ctx context.Context, archive, name string, args ...string) error {
+	if _, err := os.Stat(archive); os.IsNotExist(err) {
+		return nil
+	}
+	result, err := i.executor.Execute(ctx, name, args...)
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return fmt.Errorf("failed to import images from %s: %w\n%s", archive, err, result.Stderr)
+		}
+		return fmt.Errorf("failed to import images from %s: %w", archive, err)
+	}
+	return nil
+}
@@ -0,0 +1,403 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"gopkg.in/yaml.v3"
+)
+
+// application is the part of an ArgoCD Application that says what to render
+type application struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Source      *applicationSource  `yaml:"source"`
+		Sources     []applicationSource `yaml:"sources"`
+		Destination struct {
+			Namespace string `yaml:"namespace"`
+		} `yaml:"destination"`
+	} `yaml:"spec"`
+}
+
+type applicationSource struct {
+	RepoURL string `yaml:"repoURL"`
+	Path    string `yaml:"path"`
+	Chart   string `yaml:"chart"`
+	Helm    struct {
+		ReleaseName  string                 `yaml:"releaseName"`
+		ValueFiles   []string               `yaml:"valueFiles"`
+		Values       string                 `yaml:"values"`
+		ValuesObject map[string]interface{} `yaml:"valuesObject"`
+	} `yaml:"helm"`
+}
+
+// sources returns the sources of the application, whichever of source and sources it uses
+func (a application) sources() []applicationSource {
+	if a.Spec.Source != nil {
+		return append([]applicationSource{*a.Spec.Source}, a.Spec.Sources...)
+	}
+	return a.Spec.Sources
+}
+
+// parseManifests returns the images, the ArgoCD Applications and the containers that pull
+// their image on every start in multi-document YAML
+func parseManifests(data []byte) ([]string, []application, []string, error) {
+	var images []string
+	var applications []application
+	var pulling []string
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to parse rendered manifests: %w", err)
+		}
+
+		images = appendImages(images, &doc)
+		pulling = append(pulling, pullingContainers(&doc)...)
+
+		var app application
+		if err := doc.Decode(&app); err == nil && app.Kind == "Application" {
+			applications = append(applications, app)
+		}
+	}
+	return images, applications, pulling, nil
+}
+
+// appendImages appends the value of every "image" key holding a string, which is where
+// pods, and the custom resources of operators, name their images
+func appendImages(images []string, node *yaml.Node) []string {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			images = appendImages(images, child)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "image" && value.Kind == yaml.ScalarNode && isImage(value.Value) {
+				images = append(images, value.Value)
+				continue
+			}
+			images = appendImages(images, value)
+		}
+	}
+	return images
+}
+
+func isImage(value string) bool {
+	return value != "" && !strings.ContainsAny(value, " \t\n{}")
+}
+
+// renderer renders ArgoCD Applications the way ArgoCD would, following the Applications
+// they render in turn, and collects the images of everything rendered
+type renderer struct {
+	executor  executor.CommandExecutor
+	sourceDir string // Checkout of the repository the Applications point at
+	repoURL   string // URL the Applications use for that repository
+
+	images   map[string]bool
+	rendered map[string]bool
+	vendored []string // Chart directories whose dependencies were downloaded
+	skipped  []string
+	pulling  []string          // Containers that pull their image on every start
+	values   map[string]string // Install values of the app-of-apps chart that pin "latest" images
+}
+
+// valuePath returns the path in the install values of the app-of-apps chart that the
+// chart values of the named Application are set at, false when they do not reach them
+type valuePath func(app string) (string, bool)
+
+// noValuePath is the valuePath of the Applications install values do not reach
+func noValuePath(string) (string, bool) { return "", false }
+
+// installValuePath is the valuePath of the Applications of the app-of-apps chart, which
+// passes its values on to them
+func installValuePath(string) (string, bool) { return "", true }
+
+func newRenderer(exec executor.CommandExecutor, sourceDir, repoURL string) *renderer {
+	return &renderer{
+		executor:  exec,
+		sourceDir: sourceDir,
+		repoURL:   repoURL,
+		images:    map[string]bool{},
+		rendered:  map[string]bool{},
+		values:    map[string]string{},
+	}
+}
+
+// reset forgets what was rendered, to render again with the install values found
+func (r *renderer) reset() {
+	r.images = map[string]bool{}
+	r.rendered = map[string]bool{}
+	r.skipped = nil
+	r.pulling = nil
+}
+
+// renderChart renders a Helm chart with extra helm template arguments and follows the
+// Applications in its output, whose chart values are set at the install values paths
+// returns
+func (r *renderer) renderChart(ctx context.Context, release, chart, namespace string, paths valuePath, args ...string) error {
+	output, err := r.template(ctx, release, chart, namespace, args...)
+	if err != nil {
+		return err
+	}
+	return r.collect(ctx, output, paths)
+}
+
+// SetValues returns the install values that pin "latest" images, as sorted key=value pairs
+func (r *renderer) SetValues() []string {
+	values := make([]string, 0, len(r.values))
+	for key, value := range r.values {
+		values = append(values, key+"="+value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Images returns the images of everything rendered, sorted
+func (r *renderer) Images() []string {
+	images := make([]string, 0, len(r.images))
+	for image := range r.images {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+func (r *renderer) collect(ctx context.Context, output []byte, paths valuePath) error {
+	images, applications, pulling, err := parseManifests(output)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		r.images[image] = true
+	}
+	r.pulling = append(r.pulling, pulling...)
+
+	for _, app := range applications {
+		prefix, ok := paths(app.Metadata.Name)
+		for _, source := range app.sources() {
+			if err := r.renderSource(ctx, app, source, prefix, ok); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderSource renders one source of an Application. Sources outside the bundled
+// repository are skipped and reported, a bundle cannot serve them. When ok, the chart
+// values of the Application are set at prefix in the install values.
+func (r *renderer) renderSource(ctx context.Context, app application, source applicationSource, prefix string, ok bool) error {
+	name := app.Metadata.Name
+	if source.Chart != "" || !sameRepository(source.RepoURL, r.repoURL) {
+		r.skip(fmt.Sprintf("%s: %s is not in the bundled repository", name, strings.TrimSuffix(source.RepoURL+" "+source.Chart, " ")))
+		return nil
+	}
+
+	clean := path.Clean("/" + source.Path)
+	if r.rendered[clean] {
+		return nil
+	}
+	r.rendered[clean] = true
+	dir := filepath.Join(r.sourceDir, filepath.FromSlash(clean))
+
+	var output []byte
+	var err error
+	paths := valuePath(noValuePath)
+	switch {
+	case fileExists(filepath.Join(dir, "Chart.yaml")):
+		if ok {
+			paths, err = r.pinLatest(dir, source, prefix)
+			if err != nil {
+				r.skip(fmt.Sprintf("%s: %v", name, err))
+				return nil
+			}
+		}
+		output, err = r.templateSource(ctx, app, source, dir)
+	case fileExists(filepath.Join(dir, "kustomization.yaml")), fileExists(filepath.Join(dir, "kustomization.yml")), fileExists(filepath.Join(dir, "Kustomization")):
+		var result *executor.CommandResult
+		result, err = r.executor.Execute(ctx, "kubectl", "kustomize", dir)
+		if err == nil {
+			output = []byte(result.Stdout)
+		}
+	default:
+		output, err = readManifestDir(dir)
+	}
+	if err != nil {
+		r.skip(fmt.Sprintf("%s: %v", name, err))
+		return nil
+	}
+	return r.collect(ctx, output, paths)
+}
+
+// pinLatest records the install values that retag the "latest" images of a chart, whose
+// values are set at prefix, to ImageTag. It returns the valuePath of the Applications the
+// chart renders, the apps of the apps chart.
+func (r *renderer) pinLatest(dir string, source applicationSource, prefix string) (valuePath, error) {
+	values, err := chartValues(dir, source)
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range latestImagePaths(values) {
+		r.values[prefix+image+".tag"] = ImageTag
+		r.values[prefix+image+".pullPolicy"] = imagePullPolicy
+	}
+
+	apps, _ := values["apps"].(map[string]interface{})
+	return func(app string) (string, bool) {
+		if _, ok := apps[app]; ok {
+			return prefix + "apps." + app + ".values.", true
+		}
+		return "", false
+	}, nil
+}
+
+// templateSource renders a Helm chart source with its value files and inline values
+func (r *renderer) templateSource(ctx context.Context, app application, source applicationSource, dir string) ([]byte, error) {
+	var args []string
+	for _, file := range source.Helm.ValueFiles {
+		args = append(args, "-f", filepath.Join(dir, filepath.FromSlash(file)))
+	}
+	for _, values := range []interface{}{source.Helm.Values, source.Helm.ValuesObject} {
+		file, err := writeValues(values)
+		if err != nil {
+			return nil, err
+		}
+		if file != "" {
+			defer os.Remove(file)
+			args = append(args, "-f", file)
+		}
+	}
+
+	release := source.Helm.ReleaseName
+	if release == "" {
+		release = app.Metadata.Name
+	}
+	return r.template(ctx, release, dir, app.Spec.Destination.Namespace, args...)
+}
+
+// template runs helm template, downloading the dependencies of local charts first so that
+// they end up in the bundled repository
+func (r *renderer) template(ctx context.Context, release, chart, namespace string, args ...string) ([]byte, error) {
+	if hasDependencies(chart) && !slices.Contains(r.vendored, chart) {
+		if _, err := r.executor.Execute(ctx, "helm", "dependency", "update", chart); err != nil {
+			return nil, fmt.Errorf("failed to download the dependencies of %s: %w", chart, err)
+		}
+		r.vendored = append(r.vendored, chart)
+	}
+
+	templateArgs := []string{"template", release, chart, "--skip-tests"}
+	if namespace != "" {
+		templateArgs = append(templateArgs, "--namespace", namespace)
+	}
+	result, err := r.executor.Execute(ctx, "helm", append(templateArgs, args...)...)
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return nil, fmt.Errorf("failed to render %s: %w\nHelm output: %s", chart, err, result.Stderr)
+		}
+		return nil, fmt.Errorf("failed to render %s: %w", chart, err)
+	}
+	return []byte(result.Stdout), nil
+}
+
+func (r *renderer) skip(reason string) {
+	r.skipped = append(r.skipped, reason)
+}
+
+// writeValues writes inline Helm values to a temporary file, empty when there are none
+func writeValues(values interface{}) (string, error) {
+	var data []byte
+	switch v := values.(type) {
+	case string:
+		data = []byte(v)
+	case map[string]interface{}:
+		if len(v) > 0 {
+			var err error
+			if data, err = yaml.Marshal(v); err != nil {
+				return "", fmt.Errorf("failed to encode inline values: %w", err)
+			}
+		}
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return "", nil
+	}
+
+	file, err := os.CreateTemp("", "openframe-bundle-values-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create values file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write values file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// readManifestDir reads the plain manifests of a directory source, which ArgoCD does not
+// recurse into by default
+func readManifestDir(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	var out bytes.Buffer
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("---\n")
+		out.Write(data)
+		out.WriteString("\n")
+	}
+	return out.Bytes(), nil
+}
+
+// hasDependencies reports whether the chart in dir declares dependencies
+func hasDependencies(dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return false
+	}
+	var chart struct {
+		Dependencies []interface{} `yaml:"dependencies"`
+	}
+	return yaml.Unmarshal(data, &chart) == nil && len(chart.Dependencies) > 0
+}
+
+// sameRepository compares Git URLs, ignoring a trailing slash and .git
+func sameRepository(a, b string) bool {
+	normalize := func(url string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(url), "/"), ".git")
+	}
+	return a != "" && normalize(a) == normalize(b)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
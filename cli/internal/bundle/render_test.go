@@ -0,0 +1,185 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseManifests(t *testing.T) {
+	images, applications, pulling, err := parseManifests([]byte(`
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      initContainers:
+        - image: busybox:1.36
+      containers:
+        - name: api
+          image: ghcr.io/flamingo-stack/openframe-api:1.2.0
+---
+apiVersion: operator.example.com/v1
+kind: Cluster
+spec:
+  image: ghcr.io/example/operand:3.0
+  image_note: "image: not this one"
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: redis
+spec:
+  source:
+    repoURL: http://host.k3d.internal:8780/repo.git
+    path: manifests/apps/redis
+  destination:
+    namespace: datasources
+---
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"busybox:1.36", "ghcr.io/flamingo-stack/openframe-api:1.2.0", "ghcr.io/example/operand:3.0"}, images)
+	require.Len(t, applications, 1)
+	assert.Equal(t, "redis", applications[0].Metadata.Name)
+	assert.Equal(t, "datasources", applications[0].Spec.Destination.Namespace)
+	assert.Equal(t, "manifests/apps/redis", applications[0].sources()[0].Path)
+	assert.Empty(t, pulling)
+}
+
+func TestParseManifests_ImageMaps(t *testing.T) {
+	images, _, _, err := parseManifests([]byte(`
+kind: Unrendered
+spec:
+  image:
+    repository: nginx
+    tag: "1.27"
+  other:
+    image: "{{ .Values.image }}"
+`))
+	require.NoError(t, err)
+	assert.Empty(t, images, "only plain image references are collected")
+}
+
+func TestSameRepository(t *testing.T) {
+	assert.True(t, sameRepository("http://host.k3d.internal:8780/repo.git/", "http://host.k3d.internal:8780/repo"))
+	assert.True(t, sameRepository("https://github.com/Flamingo-Stack/openframe-oss-tenant.git", "https://github.com/flamingo-stack/openframe-oss-tenant"))
+	assert.False(t, sameRepository("", ""))
+	assert.False(t, sameRepository("https://github.com/other/repo", "https://github.com/flamingo-stack/openframe-oss-tenant"))
+}
+
+const testRepoURL = "http://host.k3d.internal:8780/repo.git"
+
+func TestRenderer_FollowsApplications(t *testing.T) {
+	source := t.TempDir()
+	writeFile(t, filepath.Join(source, "manifests", "platform", "Chart.yaml"), "name: platform\n")
+	writeFile(t, filepath.Join(source, "manifests", "platform", "values-offline.yaml"), "offline: true\n")
+	writeFile(t, filepath.Join(source, "manifests", "redis", "Chart.yaml"), "name: redis\ndependencies:\n  - name: redis\n    repository: https://charts.bitnami.com/bitnami\n")
+	writeFile(t, filepath.Join(source, "manifests", "crds", "operator.yaml"), "kind: Deployment\nspec:\n  image: example/operator:2.0\n")
+	writeFile(t, filepath.Join(source, "manifests", "crds", "README.md"), "image: not/a-manifest\n")
+	writeFile(t, filepath.Join(source, "manifests", "ingress", "kustomization.yaml"), "resources: []\n")
+
+	exec := &fakeExecutor{handle: func(name string, args []string) (string, error) {
+		command := name + " " + strings.Join(args, " ")
+		switch {
+		case name == "helm" && args[0] == "dependency":
+			return "", nil
+		case strings.HasPrefix(command, "helm template platform "):
+			return `
+kind: Application
+metadata: {name: platform}
+spec:
+  sources:
+    - repoURL: ` + testRepoURL + `
+      path: manifests/redis
+      helm:
+        releaseName: cache
+        valuesObject: {replicas: 1}
+    - repoURL: https://charts.jetstack.io
+      chart: cert-manager
+    - repoURL: ` + testRepoURL + `
+      path: manifests/crds
+    - repoURL: ` + testRepoURL + `
+      path: manifests/ingress
+    - repoURL: ` + testRepoURL + `
+      path: manifests/missing
+    - repoURL: ` + testRepoURL + `
+      path: manifests/../manifests/redis
+`, nil
+		case strings.HasPrefix(command, "helm template cache "):
+			return "kind: StatefulSet\nspec:\n  image: redis:7.4\n", nil
+		case name == "kubectl":
+			return "kind: Deployment\nspec:\n  image: nginx:1.27\n", nil
+		}
+		return "", errors.New("unexpected command: " + command)
+	}}
+
+	r := newRenderer(exec, source, testRepoURL)
+	platform := `
+kind: Application
+metadata: {name: platform}
+spec:
+  destination: {namespace: platform}
+  source:
+    repoURL: ` + testRepoURL + `/
+    path: manifests/platform
+    helm:
+      valueFiles: [values-offline.yaml]
+      values: |
+        debug: false
+`
+	require.NoError(t, r.collect(context.Background(), []byte(platform), noValuePath))
+
+	assert.Equal(t, []string{"example/operator:2.0", "nginx:1.27", "redis:7.4"}, r.Images())
+	assert.Equal(t, []string{filepath.Join(source, "manifests", "redis")}, r.vendored)
+	assert.True(t, exec.ran("helm dependency update "+filepath.Join(source, "manifests", "redis")))
+	assert.True(t, exec.ran("helm template platform "+filepath.Join(source, "manifests", "platform")+" --skip-tests --namespace platform -f "+filepath.Join(source, "manifests", "platform", "values-offline.yaml")+" -f "))
+	assert.True(t, exec.ran("kubectl kustomize "+filepath.Join(source, "manifests", "ingress")))
+
+	require.Len(t, r.skipped, 2)
+	assert.Equal(t, "platform: https://charts.jetstack.io cert-manager is not in the bundled repository", r.skipped[0])
+	assert.Contains(t, r.skipped[1], "platform: failed to read")
+
+	var templates int
+	for _, command := range exec.commands {
+		if strings.HasPrefix(command, "helm template cache") {
+			templates++
+		}
+	}
+	assert.Equal(t, 1, templates, "a path is rendered once")
+}
+
+func TestRenderer_TemplateError(t *testing.T) {
+	exec := &fakeExecutor{handle: func(name string, args []string) (string, error) {
+		return "", errors.New("values don't meet the specifications of the schema")
+	}}
+	r := newRenderer(exec, t.TempDir(), testRepoURL)
+
+	err := r.renderChart(context.Background(), "app-of-apps", "chart", "argocd", noValuePath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to render chart")
+	assert.Contains(t, err.Error(), "Helm output: values don't meet")
+}
+
+func TestWriteValues(t *testing.T) {
+	file, err := writeValues("")
+	require.NoError(t, err)
+	assert.Empty(t, file)
+
+	file, err = writeValues(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, file)
+
+	file, err = writeValues(map[string]interface{}{"replicas": 1})
+	require.NoError(t, err)
+	defer os.Remove(file)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "replicas: 1\n", string(data))
+}
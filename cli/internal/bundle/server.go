@@ -0,0 +1,61 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// NewGitServer returns a handler serving the Git repositories below root read-only over
+// the smart HTTP protocol, through git http-backend
+func NewGitServer(root string) (http.Handler, error) {
+	git, err := exec.LookPath("git")
+	if err != nil {
+		return nil, fmt.Errorf("git is required to serve a bundle: %w", err)
+	}
+	return &cgi.Handler{
+		Path:       git,
+		Root:       "/",
+		Args:       []string{"http-backend"},
+		Env:        []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+		InheritEnv: []string{"PATH"},
+	}, nil
+}
+
+// Serve serves the Git repository of the bundle on port of every interface, so that the
+// cluster reaches it through host.k3d.internal, until ctx is done
+func (b *Bundle) Serve(ctx context.Context, port int) error {
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return fmt.Errorf("failed to serve the bundle on port %d: %w", port, err)
+	}
+	return b.ServeListener(ctx, listener)
+}
+
+// ServeListener serves the Git repository of the bundle on listener until ctx is done
+func (b *Bundle) ServeListener(ctx context.Context, listener net.Listener) error {
+	handler, err := NewGitServer(b.ServedRoot())
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the bundle: %w", err)
+	}
+	return nil
+}
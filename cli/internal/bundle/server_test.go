@@ -0,0 +1,64 @@
+package bundle
+
+import (
+	"context"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gitCommand(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(cmd.Environ(), "GIT_CONFIG_NOSYSTEM=1", "HOME="+t.TempDir())
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func TestBundle_Serve(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	work := t.TempDir()
+	writeFile(t, filepath.Join(work, "source", "manifests", "app-of-apps", "Chart.yaml"), "name: app-of-apps\n")
+	gitCommand(t, work, "init", "--quiet", "--initial-branch", "main", "source")
+	gitCommand(t, filepath.Join(work, "source"), "add", ".")
+	gitCommand(t, filepath.Join(work, "source"), "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "initial")
+
+	b := &Bundle{Dir: filepath.Join(work, "bundle")}
+	gitCommand(t, work, "clone", "--quiet", "--bare", "source", b.RepoPath())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- b.ServeListener(ctx, listener) }()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	url := strings.Replace(RepoURL(port), "host.k3d.internal", "127.0.0.1", 1)
+	gitCommand(t, work, "clone", "--quiet", "--depth", "1", "--branch", "main", url, "clone")
+	assert.FileExists(t, filepath.Join(work, "clone", "manifests", "app-of-apps", "Chart.yaml"))
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestBundle_Serve_PortInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	b := &Bundle{Dir: t.TempDir()}
+	err = b.Serve(context.Background(), listener.Addr().(*net.TCPAddr).Port)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to serve the bundle on port "+strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+}
@@ -18,6 +18,10 @@ type AppOfAppsConfig struct {
 	// Helm configuration
 	Namespace string // Target namespace (e.g., "argocd")
 	Timeout   string // Installation timeout (e.g., "60m")
+	// Tenant configuration
+	Tenant string // Tenant ID of a multi-tenant saas-shared install, empty for a single tenant
+	// Served repository configuration
+	RepositoryURL string // Git URL ArgoCD syncs from when GitHubRepo is a copy only the CLI can read, e.g. of an offline bundle
 }
 
 // NewAppOfAppsConfig creates a new AppOfAppsConfig with defaults
@@ -38,3 +42,8 @@ func (a *AppOfAppsConfig) GetGitURL() string {
 	baseURL := strings.TrimSuffix(a.GitHubRepo, ".git")
 	return fmt.Sprintf("git+%s@%s?ref=%s", baseURL, a.ChartPath, a.GitHubBranch)
 }
+
+// ReleaseName returns the Helm release name, which is unique per tenant
+func (a *AppOfAppsConfig) ReleaseName() string {
+	return TenantReleaseName(a.Tenant)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AppOfAppsRelease is the Helm release name of the app-of-apps chart of a single-tenant install
+const AppOfAppsRelease = "app-of-apps"
+
+// MaxTenantIDLength keeps the release, project and namespace names derived from a tenant ID
+// within the Kubernetes and Helm name limits
+const MaxTenantIDLength = 20
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// reservedTenantIDs would give a tenant names that belong to the cluster, ArgoCD or the
+// shared OpenFrame install, e.g. the tenant "kube" would deploy to kube-* namespaces
+var reservedTenantIDs = []string{
+	"kube", "kubernetes", "default", "argocd", "argo", "ingress", "cert", "local",
+	"openframe", "platform", "datasources", "microservices", "integrated-tools", "client-tools",
+}
+
+// ValidateTenantID checks that a tenant ID can be used in release, project and namespace names
+func ValidateTenantID(id string) error {
+	if len(id) > MaxTenantIDLength {
+		return fmt.Errorf("invalid tenant ID %q: at most %d characters", id, MaxTenantIDLength)
+	}
+	if !tenantIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid tenant ID %q: use lowercase letters, digits and '-', starting and ending with a letter or digit", id)
+	}
+	for _, reserved := range reservedTenantIDs {
+		if id == reserved {
+			return fmt.Errorf("invalid tenant ID %q: reserved, the namespaces %s* belong to the cluster or the shared install", id, TenantNamespacePrefix(id))
+		}
+	}
+	return nil
+}
+
+// TenantReleaseName returns the app-of-apps release of a tenant, the single-tenant release for ""
+func TenantReleaseName(id string) string {
+	if id == "" {
+		return AppOfAppsRelease
+	}
+	return AppOfAppsRelease + "-" + id
+}
+
+// TenantFromReleaseName returns the tenant of an app-of-apps release, "" for the single-tenant
+// release, and false for releases that are not app-of-apps
+func TenantFromReleaseName(release string) (string, bool) {
+	if release == AppOfAppsRelease {
+		return "", true
+	}
+	id, ok := strings.CutPrefix(release, AppOfAppsRelease+"-")
+	if !ok || ValidateTenantID(id) != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// TenantProject returns the ArgoCD AppProject that confines the applications of a tenant
+func TenantProject(id string) string {
+	return "tenant-" + id
+}
+
+// TenantNamespacePrefix returns the prefix of every namespace a tenant deploys to
+func TenantNamespacePrefix(id string) string {
+	return id + "-"
+}
+
+// TenantHost returns the ingress host of a tenant on a local cluster
+func TenantHost(id string) string {
+	return id + ".localhost"
+}
+
+// TenantValues returns the values that tell the app-of-apps chart which tenant it installs
+func TenantValues(id string) map[string]string {
+	return map[string]string{
+		"tenant.id":                            id,
+		"tenant.project":                       TenantProject(id),
+		"tenant.namespacePrefix":               TenantNamespacePrefix(id),
+		"tenant.host":                          TenantHost(id),
+		"deployment.saas.ingress.gcp.tenantID": id,
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTenantID(t *testing.T) {
+	for _, id := range []string{"acme", "a", "tenant-2", strings.Repeat("a", MaxTenantIDLength)} {
+		assert.NoError(t, ValidateTenantID(id), id)
+	}
+	for _, id := range []string{"", "Acme", "acme_corp", "-acme", "acme-", "acme.io", strings.Repeat("a", MaxTenantIDLength+1)} {
+		assert.Error(t, ValidateTenantID(id), id)
+	}
+	for _, id := range []string{"kube", "argocd", "default", "ingress", "cert", "platform"} {
+		assert.ErrorContains(t, ValidateTenantID(id), "reserved", id)
+	}
+	assert.NoError(t, ValidateTenantID("kubecon"), "only the exact reserved IDs are rejected")
+}
+
+func TestTenantReleaseName(t *testing.T) {
+	assert.Equal(t, "app-of-apps", TenantReleaseName(""))
+	assert.Equal(t, "app-of-apps-acme", TenantReleaseName("acme"))
+	assert.Equal(t, "app-of-apps-acme", (&AppOfAppsConfig{Tenant: "acme"}).ReleaseName())
+
+	for release, expected := range map[string]string{"app-of-apps": "", "app-of-apps-acme": "acme"} {
+		id, ok := TenantFromReleaseName(release)
+		assert.True(t, ok, release)
+		assert.Equal(t, expected, id, release)
+	}
+	for _, release := range []string{"argo-cd", "app-of-apps-", "app-of-apps-Bad"} {
+		_, ok := TenantFromReleaseName(release)
+		assert.False(t, ok, release)
+	}
+}
+
+func TestTenantValues(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"tenant.id":                            "acme",
+		"tenant.project":                       "tenant-acme",
+		"tenant.namespacePrefix":               "acme-",
+		"tenant.host":                          "acme.localhost",
+		"deployment.saas.ingress.gcp.tenantID": "acme",
+	}, TenantValues("acme"))
+}
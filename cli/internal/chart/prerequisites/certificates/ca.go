@@ -0,0 +1,484 @@
+package certificates
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	caCertFileName   = "rootCA.pem"
+	caKeyFileName    = "rootCA-key.pem"
+	leafCertFileName = "localhost.pem"
+	leafKeyFileName  = "localhost-key.pem"
+
+	caCommonName = "OpenFrame Local Development CA"
+
+	// caValidity keeps the root stable for years so it only has to be trusted once
+	caValidity = 10 * 365 * 24 * time.Hour
+	// leafValidity stays below the 825 day limit browsers enforce for TLS server certificates
+	leafValidity = 825 * 24 * time.Hour
+	// renewBefore is how close to expiry a certificate gets before it is replaced
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// DefaultHosts are the names every local certificate is issued for
+var DefaultHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// CertificateAuthority is a local root CA persisted on disk that issues the ingress certificate
+type CertificateAuthority struct {
+	dir string
+	now func() time.Time
+}
+
+// CertificateInfo describes one certificate on disk
+type CertificateInfo struct {
+	Path        string
+	Subject     string
+	Hosts       []string
+	NotBefore   time.Time
+	NotAfter    time.Time
+	Fingerprint string
+}
+
+// ExpiresWithin reports whether the certificate expires within d of now
+func (i *CertificateInfo) ExpiresWithin(now time.Time, d time.Duration) bool {
+	return now.Add(d).After(i.NotAfter)
+}
+
+// CertificateStatus reports the state of the root CA and the issued certificate
+type CertificateStatus struct {
+	CA   *CertificateInfo // nil when no CA has been generated yet
+	Leaf *CertificateInfo // nil when no certificate has been issued yet
+}
+
+// NewCertificateAuthority creates a CA rooted in dir
+func NewCertificateAuthority(dir string) *CertificateAuthority {
+	return &CertificateAuthority{dir: dir, now: time.Now}
+}
+
+// DefaultCertificateDirectory returns ~/.config/openframe/certs
+func DefaultCertificateDirectory() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "openframe", "certs"), nil
+}
+
+// Dir returns the directory holding the CA and certificate files
+func (ca *CertificateAuthority) Dir() string {
+	return ca.dir
+}
+
+// CACertPath returns the path of the root CA certificate
+func (ca *CertificateAuthority) CACertPath() string {
+	return filepath.Join(ca.dir, caCertFileName)
+}
+
+// CertificatePaths returns the paths of the issued certificate and its key
+func (ca *CertificateAuthority) CertificatePaths() (certFile, keyFile string) {
+	return filepath.Join(ca.dir, leafCertFileName), filepath.Join(ca.dir, leafKeyFileName)
+}
+
+// EnsureRoot loads the root CA, generating a new one when it is missing or about to expire.
+// created is true when a new root was written and therefore still has to be trusted.
+func (ca *CertificateAuthority) EnsureRoot() (cert *x509.Certificate, key *ecdsa.PrivateKey, created bool, err error) {
+	cert, key, err = ca.loadRoot()
+	if err == nil && !ca.now().Add(renewBefore).After(cert.NotAfter) {
+		return cert, key, false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, false, err
+	}
+	if err != nil && fileExists(ca.CACertPath()) {
+		// A new root would silently invalidate the trusted one, so only replace it when asked
+		return nil, nil, false, fmt.Errorf("the root CA key %s is missing, run 'openframe chart certificates renew --regenerate-ca' to replace the root CA",
+			filepath.Join(ca.dir, caKeyFileName))
+	}
+
+	cert, key, err = ca.generateRoot()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return cert, key, true, nil
+}
+
+// RegenerateRoot replaces the root CA with a new one, whether or not the current one is usable
+func (ca *CertificateAuthority) RegenerateRoot() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	return ca.generateRoot()
+}
+
+// NeedsRenewal reports why the issued certificate has to be replaced for hosts, or "" when it is still valid
+func (ca *CertificateAuthority) NeedsRenewal(hosts []string) (string, error) {
+	hosts, err := NormalizeHosts(hosts)
+	if err != nil {
+		return "", err
+	}
+
+	root, _, err := ca.loadRoot()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "no certificate authority", nil
+		}
+		return "", err
+	}
+	if ca.now().Add(renewBefore).After(root.NotAfter) {
+		return "certificate authority expires soon", nil
+	}
+
+	certFile, keyFile := ca.CertificatePaths()
+	leaf, err := readCertificate(certFile)
+	if err != nil {
+		return "no certificate issued", nil
+	}
+	if _, err := os.Stat(keyFile); err != nil {
+		return "certificate key is missing", nil
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(root)
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: pool, CurrentTime: ca.now(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}); err != nil {
+		return "certificate is not signed by the current certificate authority", nil
+	}
+	if ca.now().Add(renewBefore).After(leaf.NotAfter) {
+		return fmt.Sprintf("certificate expires on %s", leaf.NotAfter.Format("2006-01-02")), nil
+	}
+	if missing := missingHosts(certificateHosts(leaf), hosts); len(missing) > 0 {
+		return fmt.Sprintf("certificate does not cover %s", strings.Join(missing, ", ")), nil
+	}
+
+	return "", nil
+}
+
+// EnsureCertificate issues a certificate for hosts unless the current one is still valid and covers them.
+// It returns the reason the certificate was (re)issued, or "" when nothing changed.
+func (ca *CertificateAuthority) EnsureCertificate(hosts []string) (string, error) {
+	reason, err := ca.NeedsRenewal(hosts)
+	if err != nil || reason == "" {
+		return reason, err
+	}
+	if err := ca.IssueCertificate(hosts); err != nil {
+		return "", err
+	}
+	return reason, nil
+}
+
+// IssueCertificate always issues a new certificate for hosts, signed by the root CA
+func (ca *CertificateAuthority) IssueCertificate(hosts []string) error {
+	hosts, err := NormalizeHosts(hosts)
+	if err != nil {
+		return err
+	}
+
+	root, rootKey, _, err := ca.EnsureRoot()
+	if err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return err
+	}
+
+	now := ca.now()
+	notAfter := now.Add(leafValidity)
+	if notAfter.After(root.NotAfter) {
+		notAfter = root.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"OpenFrame development certificate"},
+			CommonName:   hosts[0],
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, root, &key.PublicKey, rootKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	certFile, keyFile := ca.CertificatePaths()
+	if err := writeKey(keyFile, key); err != nil {
+		return err
+	}
+	return writePEM(certFile, "CERTIFICATE", der, 0644)
+}
+
+// Status reports the CA and certificate currently on disk
+func (ca *CertificateAuthority) Status() (*CertificateStatus, error) {
+	status := &CertificateStatus{}
+
+	if root, err := readCertificate(ca.CACertPath()); err == nil {
+		status.CA = describeCertificate(ca.CACertPath(), root)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	certFile, _ := ca.CertificatePaths()
+	if leaf, err := readCertificate(certFile); err == nil {
+		status.Leaf = describeCertificate(certFile, leaf)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// ExportCABundle copies the root CA certificate to path so it can be trusted manually
+func (ca *CertificateAuthority) ExportCABundle(path string) error {
+	data, err := os.ReadFile(ca.CACertPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no certificate authority has been generated yet")
+		}
+		return fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write CA bundle: %w", err)
+	}
+	return nil
+}
+
+// NormalizeHosts validates hosts, removes duplicates and puts the default hosts first
+func NormalizeHosts(hosts []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+	for _, host := range append(append([]string{}, DefaultHosts...), hosts...) {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" || seen[host] {
+			continue
+		}
+		if err := validateHost(host); err != nil {
+			return nil, err
+		}
+		seen[host] = true
+		result = append(result, host)
+	}
+	return result, nil
+}
+
+// validateHost accepts IP addresses, DNS names and wildcards in the left-most label
+func validateHost(host string) error {
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+
+	name := host
+	if strings.HasPrefix(name, "*.") {
+		name = name[2:]
+	}
+	if name == "" || strings.Contains(name, "*") {
+		return fmt.Errorf("invalid certificate host %q: wildcards are only allowed as the first label", host)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("invalid certificate host %q", host)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return fmt.Errorf("invalid certificate host %q", host)
+			}
+		}
+	}
+	return nil
+}
+
+func (ca *CertificateAuthority) loadRoot() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	cert, err := readCertificate(ca.CACertPath())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(ca.dir, caKeyFileName))
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, fmt.Errorf("invalid CA key in %s", ca.dir)
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, nil, fmt.Errorf("CA key in %s does not match the CA certificate", ca.dir)
+	}
+	return cert, key, nil
+}
+
+func (ca *CertificateAuthority) generateRoot() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	if err := os.MkdirAll(ca.dir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hostname, _ := os.Hostname()
+	now := ca.now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"OpenFrame"},
+			OrganizationalUnit: []string{hostname},
+			CommonName:         caCommonName,
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	if err := writeKey(filepath.Join(ca.dir, caKeyFileName), key); err != nil {
+		return nil, nil, err
+	}
+	if err := writePEM(ca.CACertPath(), "CERTIFICATE", der, 0644); err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+func readCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found in %s", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cert, nil
+}
+
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key: %w", err)
+	}
+	return writePEM(path, "EC PRIVATE KEY", der, 0600)
+}
+
+func writePEM(path, blockType string, der []byte, perm os.FileMode) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	// Write to a temporary file first so an interrupted write never leaves a truncated file behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
+
+func describeCertificate(path string, cert *x509.Certificate) *CertificateInfo {
+	sum := sha256.Sum256(cert.Raw)
+	return &CertificateInfo{
+		Path:        path,
+		Subject:     cert.Subject.CommonName,
+		Hosts:       certificateHosts(cert),
+		NotBefore:   cert.NotBefore,
+		NotAfter:    cert.NotAfter,
+		Fingerprint: hex.EncodeToString(sum[:]),
+	}
+}
+
+func certificateHosts(cert *x509.Certificate) []string {
+	hosts := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+	return hosts
+}
+
+// missingHosts returns the wanted hosts that the certificate does not list
+func missingHosts(have, want []string) []string {
+	present := make(map[string]bool, len(have))
+	for _, host := range have {
+		present[strings.ToLower(host)] = true
+	}
+	var missing []string
+	for _, host := range want {
+		if ip := net.ParseIP(host); ip != nil {
+			host = ip.String()
+		}
+		if !present[host] {
+			missing = append(missing, host)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
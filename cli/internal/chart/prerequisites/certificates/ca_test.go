@@ -0,0 +1,213 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuthority(t *testing.T) *CertificateAuthority {
+	t.Helper()
+	return NewCertificateAuthority(filepath.Join(t.TempDir(), "certs"))
+}
+
+func TestCertificateAuthority_IssueCertificate(t *testing.T) {
+	ca := newTestAuthority(t)
+
+	require.NoError(t, ca.IssueCertificate([]string{"openframe.test", "*.openframe.test", "10.0.0.5"}))
+
+	root, err := readCertificate(ca.CACertPath())
+	require.NoError(t, err)
+	assert.True(t, root.IsCA)
+
+	certFile, keyFile := ca.CertificatePaths()
+	leaf, err := readCertificate(certFile)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"localhost", "openframe.test", "*.openframe.test"}, leaf.DNSNames)
+	var ips []string
+	for _, ip := range leaf.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	assert.ElementsMatch(t, []string{"127.0.0.1", "::1", "10.0.0.5"}, ips)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(root)
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: "api.openframe.test"})
+	assert.NoError(t, err, "wildcard host should verify against the root CA")
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(keyFile)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		info, err = os.Stat(filepath.Join(ca.Dir(), caKeyFileName))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+}
+
+func TestCertificateAuthority_EnsureRootIsPersisted(t *testing.T) {
+	ca := newTestAuthority(t)
+
+	first, _, created, err := ca.EnsureRoot()
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	second, _, created, err := ca.EnsureRoot()
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, first.SerialNumber, second.SerialNumber)
+}
+
+func TestCertificateAuthority_EnsureRootRegeneratesNearExpiry(t *testing.T) {
+	ca := newTestAuthority(t)
+
+	first, _, _, err := ca.EnsureRoot()
+	require.NoError(t, err)
+
+	ca.now = func() time.Time { return first.NotAfter.Add(-time.Hour) }
+	second, _, created, err := ca.EnsureRoot()
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotEqual(t, first.SerialNumber, second.SerialNumber)
+}
+
+func TestCertificateAuthority_EnsureRootKeepsRootWithoutKey(t *testing.T) {
+	ca := newTestAuthority(t)
+
+	first, _, _, err := ca.EnsureRoot()
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(ca.Dir(), caKeyFileName)))
+
+	_, _, _, err = ca.EnsureRoot()
+	assert.ErrorContains(t, err, "--regenerate-ca")
+
+	current, err := readCertificate(ca.CACertPath())
+	require.NoError(t, err)
+	assert.Equal(t, first.SerialNumber, current.SerialNumber, "the trusted root must not be replaced silently")
+
+	second, _, err := ca.RegenerateRoot()
+	require.NoError(t, err)
+	assert.NotEqual(t, first.SerialNumber, second.SerialNumber)
+
+	_, _, created, err := ca.EnsureRoot()
+	require.NoError(t, err)
+	assert.False(t, created)
+}
+
+func TestCertificateAuthority_EnsureCertificate(t *testing.T) {
+	ca := newTestAuthority(t)
+
+	reason, err := ca.EnsureCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "no certificate authority", reason)
+
+	certFile, _ := ca.CertificatePaths()
+	issued, err := readCertificate(certFile)
+	require.NoError(t, err)
+
+	t.Run("keeps a valid certificate", func(t *testing.T) {
+		reason, err := ca.EnsureCertificate([]string{"LOCALHOST"})
+		require.NoError(t, err)
+		assert.Empty(t, reason)
+
+		current, err := readCertificate(certFile)
+		require.NoError(t, err)
+		assert.Equal(t, issued.SerialNumber, current.SerialNumber)
+	})
+
+	t.Run("reissues for new hosts", func(t *testing.T) {
+		reason, err := ca.EnsureCertificate([]string{"openframe.test"})
+		require.NoError(t, err)
+		assert.Equal(t, "certificate does not cover openframe.test", reason)
+
+		current, err := readCertificate(certFile)
+		require.NoError(t, err)
+		assert.Contains(t, current.DNSNames, "openframe.test")
+	})
+
+	t.Run("reissues near expiry", func(t *testing.T) {
+		current, err := readCertificate(certFile)
+		require.NoError(t, err)
+
+		ca.now = func() time.Time { return current.NotAfter.Add(-24 * time.Hour) }
+		defer func() { ca.now = time.Now }()
+
+		reason, err := ca.NeedsRenewal(nil)
+		require.NoError(t, err)
+		assert.Contains(t, reason, "certificate expires on")
+	})
+}
+
+func TestCertificateAuthority_NeedsRenewalForForeignCertificate(t *testing.T) {
+	other := newTestAuthority(t)
+	require.NoError(t, other.IssueCertificate(nil))
+
+	ca := newTestAuthority(t)
+	_, _, _, err := ca.EnsureRoot()
+	require.NoError(t, err)
+
+	// A certificate from another CA, e.g. left behind by mkcert, must be replaced
+	otherCert, otherKey := other.CertificatePaths()
+	certFile, keyFile := ca.CertificatePaths()
+	for src, dst := range map[string]string{otherCert: certFile, otherKey: keyFile} {
+		data, err := os.ReadFile(src)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(dst, data, 0600))
+	}
+
+	reason, err := ca.NeedsRenewal(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "certificate is not signed by the current certificate authority", reason)
+}
+
+func TestCertificateAuthority_StatusAndExport(t *testing.T) {
+	ca := newTestAuthority(t)
+
+	status, err := ca.Status()
+	require.NoError(t, err)
+	assert.Nil(t, status.CA)
+	assert.Nil(t, status.Leaf)
+
+	err = ca.ExportCABundle(filepath.Join(t.TempDir(), "ca.pem"))
+	assert.ErrorContains(t, err, "no certificate authority")
+
+	require.NoError(t, ca.IssueCertificate(nil))
+
+	status, err = ca.Status()
+	require.NoError(t, err)
+	require.NotNil(t, status.CA)
+	require.NotNil(t, status.Leaf)
+	assert.Equal(t, caCommonName, status.CA.Subject)
+	assert.Len(t, status.CA.Fingerprint, 64)
+	assert.Equal(t, []string{"localhost", "127.0.0.1", "::1"}, status.Leaf.Hosts)
+	assert.False(t, status.Leaf.ExpiresWithin(time.Now(), renewBefore))
+	assert.True(t, status.Leaf.NotAfter.Before(status.CA.NotAfter))
+
+	exported := filepath.Join(t.TempDir(), "nested", "openframe-ca.pem")
+	require.NoError(t, ca.ExportCABundle(exported))
+
+	want, err := os.ReadFile(ca.CACertPath())
+	require.NoError(t, err)
+	got, err := os.ReadFile(exported)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestNormalizeHosts(t *testing.T) {
+	hosts, err := NormalizeHosts([]string{" Openframe.Test ", "localhost", "*.dev.local", "::1", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost", "127.0.0.1", "::1", "openframe.test", "*.dev.local"}, hosts)
+
+	for _, invalid := range []string{"*", "*.", "a.*.test", "bad host", "-leading.test", "double..dot"} {
+		_, err := NormalizeHosts([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
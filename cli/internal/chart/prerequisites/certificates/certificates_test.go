@@ -1,6 +1,8 @@
 package certificates
 
 import (
+	"errors"
+	"path/filepath"
 	"runtime"
 	"testing"
 )
@@ -13,6 +15,20 @@ func TestNewCertificateInstaller(t *testing.T) {
 	}
 }
 
+func TestNewCertificateInstaller_RemoteDockerHost(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "ssh://dev@buildbox")
+	installer := NewCertificateInstaller().WithHosts("openframe.test")
+
+	if len(installer.hosts) != 2 || installer.hosts[0] != "buildbox" || installer.hosts[1] != "openframe.test" {
+		t.Errorf("Expected the remote Docker host before the added hosts, got %v", installer.hosts)
+	}
+
+	t.Setenv("DOCKER_HOST", "unix:///var/run/docker.sock")
+	if hosts := NewCertificateInstaller().hosts; len(hosts) != 0 {
+		t.Errorf("Expected no extra hosts for a local Docker daemon, got %v", hosts)
+	}
+}
+
 func TestCertificateInstaller_GetInstallHelp(t *testing.T) {
 	installer := NewCertificateInstaller()
 	help := installer.GetInstallHelp()
@@ -21,23 +37,23 @@ func TestCertificateInstaller_GetInstallHelp(t *testing.T) {
 		t.Error("Install help should not be empty")
 	}
 
-	// Should mention mkcert
-	if !containsSubstring(help, "mkcert") {
-		t.Errorf("Help should mention mkcert: %s", help)
+	// Should mention the certificate authority
+	if !containsSubstring(help, "certificate authority") {
+		t.Errorf("Help should mention the certificate authority: %s", help)
 	}
 
 	switch runtime.GOOS {
 	case "darwin":
-		if !containsSubstring(help, "Homebrew") {
-			t.Errorf("macOS help should mention Homebrew: %s", help)
+		if !containsSubstring(help, "keychain") {
+			t.Errorf("macOS help should mention the keychain: %s", help)
 		}
 	case "linux":
-		if !containsSubstring(help, "downloaded") {
-			t.Errorf("Linux help should mention download: %s", help)
+		if !containsSubstring(help, "trust stores") {
+			t.Errorf("Linux help should mention the trust stores: %s", help)
 		}
 	case "windows":
-		if !containsSubstring(help, "manually") {
-			t.Errorf("Windows help should mention manual installation: %s", help)
+		if !containsSubstring(help, "certificate store") {
+			t.Errorf("Windows help should mention the certificate store: %s", help)
 		}
 	}
 }
@@ -72,45 +88,70 @@ func TestAreCertificatesGenerated(t *testing.T) {
 	_ = generated
 }
 
-func TestIsMkcertInstalled(t *testing.T) {
-	// Test mkcert detection
-	installed := isMkcertInstalled()
+func TestAreCertificatesGenerated_WithValidCertificate(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
 
-	// Should be equivalent to commandExists("mkcert")
-	expected := commandExists("mkcert")
-	if installed != expected {
-		t.Errorf("isMkcertInstalled() = %v, expected %v", installed, expected)
+	if areCertificatesGenerated() {
+		t.Fatal("Expected no certificates in an empty home directory")
 	}
-}
 
-func TestInstallMkcert(t *testing.T) {
-	installer := NewCertificateInstaller()
+	certDir, err := DefaultCertificateDirectory()
+	if err != nil {
+		t.Fatalf("DefaultCertificateDirectory() error = %v", err)
+	}
+	if err := NewCertificateAuthority(certDir).IssueCertificate(nil); err != nil {
+		t.Fatalf("IssueCertificate() error = %v", err)
+	}
 
-	// This will likely fail in test environment, but should handle errors gracefully
-	err := installer.installMkcert()
+	if !areCertificatesGenerated() {
+		t.Error("Expected certificates to be detected after issuing them")
+	}
+}
 
+func TestCertificateInstaller_TrustMarkerOnlyOnSuccess(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	calls := 0
+	trustErr := errors.New("sudo: a password is required")
+	defer func(original func(string) error) { trustRoot = original }(trustRoot)
+	trustRoot = func(string) error {
+		calls++
+		return trustErr
+	}
+
+	installer := NewCertificateInstaller()
+	if _, err := installer.EnsureCertificates(); !errors.Is(err, trustErr) {
+		t.Fatalf("Expected the trust error, got %v", err)
+	}
+	certDir, err := DefaultCertificateDirectory()
 	if err != nil {
-		// Should be a reasonable error message
-		validErrors := []string{
-			"Homebrew is required",
-			"failed to install mkcert",
-			"failed to download mkcert",
-			"automatic mkcert installation not supported",
-			"exit status",
-			"executable file not found",
-		}
+		t.Fatalf("DefaultCertificateDirectory() error = %v", err)
+	}
+	marker := filepath.Join(certDir, trustedMarkerFileName)
+	if fileExists(marker) {
+		t.Fatal("Expected no trust marker after trusting failed")
+	}
 
-		hasValidError := false
-		for _, validError := range validErrors {
-			if containsSubstring(err.Error(), validError) {
-				hasValidError = true
-				break
-			}
-		}
+	trustErr = nil
+	if _, err := installer.EnsureCertificates(); err != nil {
+		t.Fatalf("EnsureCertificates() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected trusting to be retried, got %d attempts", calls)
+	}
+	if !fileExists(marker) {
+		t.Error("Expected the trust marker once trusting succeeded")
+	}
 
-		if !hasValidError {
-			t.Errorf("Unexpected error type: %v", err)
-		}
+	if _, err := installer.EnsureCertificates(); err != nil {
+		t.Fatalf("EnsureCertificates() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected a trusted root not to be trusted again, got %d attempts", calls)
 	}
 }
 
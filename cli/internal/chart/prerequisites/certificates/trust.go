@@ -0,0 +1,141 @@
+package certificates
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// trustNickname identifies the CA in keychains and NSS databases so it can be replaced later
+const trustNickname = caCommonName
+
+// linuxSystemAnchor is where Debian-style distributions pick up extra CAs
+const linuxSystemAnchor = "/usr/local/share/ca-certificates/openframe-local-ca.crt"
+
+// linuxTrustAnchor is where Fedora-style distributions pick up extra CAs
+const linuxTrustAnchor = "/etc/pki/ca-trust/source/anchors/openframe-local-ca.crt"
+
+// trustRoot is replaced in tests, which must not touch the trust stores of the machine
+var trustRoot = trustRootCA
+
+// trustRootCA adds the CA to the platform trust stores and reports when it could not be
+// trusted, so that trusting is tried again on the next run
+func trustRootCA(caCertPath string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return trustRootCAMacOS(caCertPath)
+	case "linux":
+		return trustRootCALinux(caCertPath)
+	case "windows":
+		// Adds to the current user's store, Windows asks for confirmation itself
+		output, err := exec.Command("certutil", "-user", "-addstore", "Root", caCertPath).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to add the root CA to the Windows certificate store: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+func trustRootCAMacOS(caCertPath string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	// Resolve login keychain
+	kcOutput, _ := exec.Command("bash", "-c", `security default-keychain -d user | tr -d '"'`).Output()
+	keychain := strings.TrimSpace(string(kcOutput))
+	if keychain == "" || !fileExists(keychain) {
+		keychain = filepath.Join(homeDir, "Library/Keychains/login.keychain-db")
+		if !fileExists(keychain) {
+			return nil
+		}
+	}
+
+	// Remove CAs from earlier runs so only the current one stays trusted
+	findCmd := fmt.Sprintf(`security find-certificate -a -c "%s" -Z "%s" | awk '/SHA-1 hash:/ {print $3}'`, trustNickname, keychain)
+	shaOutput, _ := exec.Command("bash", "-c", findCmd).Output()
+	for _, sha := range strings.Fields(string(shaOutput)) {
+		exec.Command("security", "delete-certificate", "-Z", sha, keychain).Run() // Best effort
+	}
+
+	// First try silently
+	output, err := exec.Command("security", "add-trusted-cert", "-r", "trustRoot", "-p", "ssl", "-k", keychain, caCertPath).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	outputStr := string(output)
+	if strings.Contains(outputStr, "User interaction is not allowed") {
+		// Need user interaction - run interactively
+		trustCmd := exec.Command("security", "add-trusted-cert", "-r", "trustRoot", "-p", "ssl", "-k", keychain, caCertPath)
+		trustCmd.Stdin = os.Stdin
+		trustCmd.Stdout = os.Stdout
+		trustCmd.Stderr = os.Stderr
+		if err := trustCmd.Run(); err != nil {
+			return fmt.Errorf("certificate trust was not established (user cancelled or error occurred)")
+		}
+	} else if strings.Contains(outputStr, "authorization was canceled by the user") {
+		return fmt.Errorf("certificate trust was not established (user cancelled or error occurred)")
+	}
+	// Other errors are non-fatal
+	return nil
+}
+
+func trustRootCALinux(caCertPath string) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	// Browsers read NSS databases rather than the system store
+	if commandExists("certutil") {
+		nssDBPaths := []string{filepath.Join(homeDir, ".pki/nssdb")}
+
+		firefoxDir := filepath.Join(homeDir, ".mozilla/firefox")
+		if entries, err := os.ReadDir(firefoxDir); err == nil {
+			for _, entry := range entries {
+				if entry.IsDir() {
+					nssDBPaths = append(nssDBPaths, filepath.Join(firefoxDir, entry.Name()))
+				}
+			}
+		}
+
+		for _, dbPath := range nssDBPaths {
+			if !fileExists(filepath.Join(dbPath, "cert9.db")) {
+				continue
+			}
+			db := "sql:" + dbPath
+			exec.Command("certutil", "-D", "-d", db, "-n", trustNickname).Run() // Best effort
+			if output, err := exec.Command("certutil", "-A", "-d", db, "-t", "C,,", "-n", trustNickname, "-i", caCertPath).CombinedOutput(); err != nil {
+				return fmt.Errorf("failed to add the root CA to %s: %w: %s", dbPath, err, strings.TrimSpace(string(output)))
+			}
+		}
+	}
+
+	// System store, needs sudo and may prompt for a password
+	var anchor, refresh []string
+	switch {
+	case commandExists("update-ca-certificates"):
+		anchor = []string{"sudo", "cp", caCertPath, linuxSystemAnchor}
+		refresh = []string{"sudo", "update-ca-certificates"}
+	case commandExists("update-ca-trust"):
+		anchor = []string{"sudo", "cp", caCertPath, linuxTrustAnchor}
+		refresh = []string{"sudo", "update-ca-trust", "extract"}
+	default:
+		return fmt.Errorf("no system trust store found, neither update-ca-certificates nor update-ca-trust is installed")
+	}
+
+	for _, args := range [][]string{anchor, refresh} {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to run %s: %w", strings.Join(args, " "), err)
+		}
+	}
+	return nil
+}
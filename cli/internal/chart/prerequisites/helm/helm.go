@@ -1,9 +1,12 @@
 package helm
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"runtime"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 )
 
 type HelmInstaller struct{}
@@ -48,6 +51,10 @@ func (h *HelmInstaller) GetInstallHelp() string {
 }
 
 func (h *HelmInstaller) Install() error {
+	if installed, err := tools.InstallPinned(context.Background(), "helm"); installed || err != nil {
+		return err
+	}
+
 	switch runtime.GOOS {
 	case "darwin":
 		return h.installMacOS()
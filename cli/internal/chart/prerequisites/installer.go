@@ -228,20 +228,23 @@ func (i *Installer) CheckAndInstallNonInteractive(nonInteractive bool) error {
 	return nil
 }
 
-// RegenerateCertificatesOnly just regenerates certificates without checking other prerequisites
+// RegenerateCertificatesOnly renews certificates when they are close to expiry or invalid, without checking other prerequisites
 // This should be used for the install command only
 func (i *Installer) RegenerateCertificatesOnly() error {
 	certInstaller := certificates.NewCertificateInstaller()
-	spinner, _ := pterm.DefaultSpinner.Start("Refreshing certificates...")
-	if err := certInstaller.ForceRegenerate(); err != nil {
+	spinner, _ := pterm.DefaultSpinner.Start("Checking certificates...")
+	reason, err := certInstaller.EnsureCertificates()
+	if err != nil {
 		if strings.Contains(err.Error(), "user cancelled") {
 			spinner.Warning("Certificate trust skipped (deployment would be unsecure)")
 		} else {
 			spinner.Warning(fmt.Sprintf("Could not refresh certificates: %v", err))
 		}
 		// Non-fatal - continue anyway
+	} else if reason != "" {
+		spinner.Info(fmt.Sprintf("Certificates renewed (%s)", reason))
 	} else {
-		spinner.Info("Certificates refreshed")
+		spinner.Info("Certificates are up to date")
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
@@ -14,6 +15,12 @@ import (
 	"github.com/pterm/pterm"
 )
 
+// The argo-cd chart OpenFrame installs
+const (
+	ArgoCDChartRepository = "https://argoproj.github.io/argo-helm"
+	ArgoCDChartVersion    = "8.2.7"
+)
+
 // HelmManager handles Helm operations
 type HelmManager struct {
 	executor executor.CommandExecutor
@@ -59,16 +66,19 @@ func (h *HelmManager) IsChartInstalled(ctx context.Context, releaseName, namespa
 
 // InstallArgoCD installs ArgoCD using Helm with exact commands specified
 func (h *HelmManager) InstallArgoCD(ctx context.Context, config config.ChartInstallConfig) error {
-	// Add ArgoCD Helm repository
-	_, err := h.executor.Execute(ctx, "helm", "repo", "add", "argo", "https://argoproj.github.io/argo-helm")
-	if err != nil {
-		return fmt.Errorf("failed to add ArgoCD repository: %w", err)
-	}
+	// A local chart archive needs no repository
+	if config.ArgoCDChart == "" {
+		// Add ArgoCD Helm repository
+		_, err := h.executor.Execute(ctx, "helm", "repo", "add", "argo", ArgoCDChartRepository)
+		if err != nil {
+			return fmt.Errorf("failed to add ArgoCD repository: %w", err)
+		}
 
-	// Update repositories
-	_, err = h.executor.Execute(ctx, "helm", "repo", "update")
-	if err != nil {
-		return fmt.Errorf("failed to update Helm repositories: %w", err)
+		// Update repositories
+		_, err = h.executor.Execute(ctx, "helm", "repo", "update")
+		if err != nil {
+			return fmt.Errorf("failed to update Helm repositories: %w", err)
+		}
 	}
 
 	// Create a temporary file with ArgoCD values
@@ -85,15 +95,13 @@ func (h *HelmManager) InstallArgoCD(ctx context.Context, config config.ChartInst
 	tmpFile.Close()
 
 	// Install ArgoCD with upgrade --install
-	args := []string{
-		"upgrade", "--install", "argo-cd", "argo/argo-cd",
-		"--version=8.2.7",
+	args := append(argoCDChartArgs(config),
 		"--namespace", "argocd",
 		"--create-namespace",
 		"--wait",
 		"--timeout", "5m",
 		"-f", tmpFile.Name(),
-	}
+	)
 
 	if config.DryRun {
 		args = append(args, "--dry-run")
@@ -126,25 +134,28 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 		pterm.Info.Println("Installing ArgoCD...")
 	}
 
-	// Add ArgoCD repository silently
-	_, err := h.executor.Execute(ctx, "helm", "repo", "add", "argo", "https://argoproj.github.io/argo-helm")
-	if err != nil {
-		// Ignore if already exists
-		if !strings.Contains(err.Error(), "already exists") {
-			if spinner != nil {
-				spinner.Stop()
+	// A local chart archive needs no repository
+	if config.ArgoCDChart == "" {
+		// Add ArgoCD repository silently
+		_, err := h.executor.Execute(ctx, "helm", "repo", "add", "argo", ArgoCDChartRepository)
+		if err != nil {
+			// Ignore if already exists
+			if !strings.Contains(err.Error(), "already exists") {
+				if spinner != nil {
+					spinner.Stop()
+				}
+				return fmt.Errorf("failed to add ArgoCD repository: %w", err)
 			}
-			return fmt.Errorf("failed to add ArgoCD repository: %w", err)
 		}
-	}
 
-	// Update repositories silently
-	_, err = h.executor.Execute(ctx, "helm", "repo", "update")
-	if err != nil {
-		if spinner != nil {
-			spinner.Stop()
+		// Update repositories silently
+		_, err = h.executor.Execute(ctx, "helm", "repo", "update")
+		if err != nil {
+			if spinner != nil {
+				spinner.Stop()
+			}
+			return fmt.Errorf("failed to update Helm repositories: %w", err)
 		}
-		return fmt.Errorf("failed to update Helm repositories: %w", err)
 	}
 
 	// Create a temporary file with ArgoCD values
@@ -168,21 +179,19 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 
 	// Installation details are now silent - just show in verbose mode
 	if config.Verbose {
-		pterm.Info.Printf("   Version: 8.2.7\n")
+		pterm.Info.Printf("   Version: %s\n", ArgoCDChartVersion)
 		pterm.Info.Printf("   Namespace: argocd\n")
 		pterm.Info.Printf("   Values file: %s\n", tmpFile.Name())
 	}
 
 	// Install ArgoCD with upgrade --install
-	args := []string{
-		"upgrade", "--install", "argo-cd", "argo/argo-cd",
-		"--version=8.2.7",
+	args := append(argoCDChartArgs(config),
 		"--namespace", "argocd",
 		"--create-namespace",
 		"--wait",
 		"--timeout", "5m",
 		"-f", tmpFile.Name(),
-	}
+	)
 
 	if config.DryRun {
 		args = append(args, "--dry-run")
@@ -223,6 +232,15 @@ func (h *HelmManager) InstallArgoCDWithProgress(ctx context.Context, config conf
 	return nil
 }
 
+// argoCDChartArgs returns the helm upgrade arguments naming the argo-cd chart, the local
+// archive of the config when it has one
+func argoCDChartArgs(config config.ChartInstallConfig) []string {
+	if config.ArgoCDChart != "" {
+		return []string{"upgrade", "--install", "argo-cd", config.ArgoCDChart}
+	}
+	return []string{"upgrade", "--install", "argo-cd", "argo/argo-cd", "--version=" + ArgoCDChartVersion}
+}
+
 // InstallAppOfAppsFromLocal installs the app-of-apps chart from a local path
 func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config config.ChartInstallConfig, certFile, keyFile string) error {
 	// Validate configuration
@@ -235,9 +253,9 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 		return fmt.Errorf("chart path is required for app-of-apps installation")
 	}
 
-	// Install app-of-apps using the local chart path
+	// Install app-of-apps using the local chart path, one release per tenant
 	args := []string{
-		"upgrade", "--install", "app-of-apps", appConfig.ChartPath,
+		"upgrade", "--install", appConfig.ReleaseName(), appConfig.ChartPath,
 		"--namespace", appConfig.Namespace,
 		"--wait",
 		"--timeout", appConfig.Timeout,
@@ -261,6 +279,27 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 		}
 	}
 
+	// ArgoCD syncs from a repository served for it, not from the copy the chart was read from
+	if appConfig.RepositoryURL != "" {
+		args = append(args,
+			"--set-string", "deployment.oss.repository.URL="+appConfig.RepositoryURL,
+			"--set-string", "deployment.oss.repository.branch="+appConfig.GitHubBranch,
+		)
+	}
+
+	// Tenant values go last so that no values file can make tenants collide
+	if appConfig.Tenant != "" {
+		tenantValues := models.TenantValues(appConfig.Tenant)
+		keys := make([]string, 0, len(tenantValues))
+		for key := range tenantValues {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			args = append(args, "--set-string", fmt.Sprintf("%s=%s", key, tenantValues[key]))
+		}
+	}
+
 	if config.DryRun {
 		args = append(args, "--dry-run")
 	}
@@ -284,6 +323,39 @@ func (h *HelmManager) InstallAppOfAppsFromLocal(ctx context.Context, config conf
 	return nil
 }
 
+// ListReleases returns the names of the releases in namespace that match the regular expression filter
+func (h *HelmManager) ListReleases(ctx context.Context, namespace, filter string) ([]string, error) {
+	args := []string{"list", "-q", "-n", namespace}
+	if filter != "" {
+		args = append(args, "-f", filter)
+	}
+
+	result, err := h.executor.Execute(ctx, "helm", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	var releases []string
+	for _, release := range strings.Split(result.Stdout, "\n") {
+		if release = strings.TrimSpace(release); release != "" {
+			releases = append(releases, release)
+		}
+	}
+	return releases, nil
+}
+
+// UninstallChart removes a release and waits for its resources to be deleted
+func (h *HelmManager) UninstallChart(ctx context.Context, releaseName, namespace string) error {
+	result, err := h.executor.Execute(ctx, "helm", "uninstall", releaseName, "-n", namespace, "--wait")
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return fmt.Errorf("failed to uninstall %s: %w\nHelm output: %s", releaseName, err, result.Stderr)
+		}
+		return fmt.Errorf("failed to uninstall %s: %w", releaseName, err)
+	}
+	return nil
+}
+
 // GetChartStatus returns the status of a chart
 func (h *HelmManager) GetChartStatus(ctx context.Context, releaseName, namespace string) (models.ChartInfo, error) {
 	args := []string{"status", releaseName, "-n", namespace, "--output", "json"}
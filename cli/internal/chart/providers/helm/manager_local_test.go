@@ -111,3 +111,55 @@ func TestHelmManager_InstallAppOfAppsFromLocal(t *testing.T) {
 		})
 	}
 }
+
+func TestHelmManager_InstallAppOfAppsFromLocal_Tenant(t *testing.T) {
+	mockExec := executor.NewMockCommandExecutor()
+	manager := NewHelmManager(mockExec)
+
+	err := manager.InstallAppOfAppsFromLocal(context.Background(), config.ChartInstallConfig{
+		AppOfApps: &models.AppOfAppsConfig{
+			ChartPath:  "/tmp/chart/manifests/app-of-apps",
+			ValuesFile: "/path/to/values.yaml",
+			Namespace:  "argocd",
+			Timeout:    "60m",
+			Tenant:     "acme",
+		},
+	}, "", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "helm upgrade --install app-of-apps-acme /tmp/chart/manifests/app-of-apps --namespace argocd --wait --timeout 60m -f /path/to/values.yaml"+
+		" --set-string deployment.saas.ingress.gcp.tenantID=acme --set-string tenant.host=acme.localhost"+
+		" --set-string tenant.id=acme --set-string tenant.namespacePrefix=acme- --set-string tenant.project=tenant-acme",
+		mockExec.GetLastCommand())
+}
+
+func TestHelmManager_InstallAppOfAppsFromLocal_RepositoryURL(t *testing.T) {
+	mockExec := executor.NewMockCommandExecutor()
+	manager := NewHelmManager(mockExec)
+
+	err := manager.InstallAppOfAppsFromLocal(context.Background(), config.ChartInstallConfig{
+		AppOfApps: &models.AppOfAppsConfig{
+			GitHubBranch:  "main",
+			ChartPath:     "/tmp/chart/manifests/app-of-apps",
+			ValuesFile:    "/path/to/values.yaml",
+			Namespace:     "argocd",
+			Timeout:       "60m",
+			RepositoryURL: "http://host.k3d.internal:8780/repo.git",
+		},
+	}, "", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "helm upgrade --install app-of-apps /tmp/chart/manifests/app-of-apps --namespace argocd --wait --timeout 60m -f /path/to/values.yaml"+
+		" --set-string deployment.oss.repository.URL=http://host.k3d.internal:8780/repo.git --set-string deployment.oss.repository.branch=main",
+		mockExec.GetLastCommand())
+}
+
+func TestHelmManager_ListReleases(t *testing.T) {
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("helm list", &executor.CommandResult{Stdout: "app-of-apps\napp-of-apps-acme\n\n"})
+
+	releases, err := NewHelmManager(mockExec).ListReleases(context.Background(), "argocd", "^app-of-apps")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app-of-apps", "app-of-apps-acme"}, releases)
+	assert.Equal(t, "helm list -q -n argocd -f ^app-of-apps", mockExec.GetLastCommand())
+}
@@ -245,6 +245,22 @@ func TestHelmManager_InstallArgoCD(t *testing.T) {
 				assert.Contains(t, installCmd, "--dry-run")
 			},
 		},
+		{
+			name: "local chart archive",
+			config: config.ChartInstallConfig{
+				ArgoCDChart: "/tmp/bundle/charts/argo-cd-8.2.7.tgz",
+			},
+			setupMock: func(m *MockExecutor) {
+				m.SetError("helm repo", assert.AnError)
+			},
+			expectError: false,
+			checkCommands: func(t *testing.T, commands [][]string) {
+				// No repository is needed offline
+				require.Len(t, commands, 1)
+				assert.Equal(t, []string{"helm", "upgrade", "--install", "argo-cd", "/tmp/bundle/charts/argo-cd-8.2.7.tgz", "--namespace", "argocd"}, commands[0][:7])
+				assert.NotContains(t, commands[0], "--version=8.2.7")
+			},
+		},
 		{
 			name: "repo add fails",
 			config: config.ChartInstallConfig{
@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// managedByLabel marks resources the CLI created so they can be told apart from chart resources
+const managedByLabel = "app.kubernetes.io/managed-by"
+
+// SecretManager creates and inspects secrets with kubectl in the current context
+type SecretManager struct {
+	executor executor.CommandExecutor
+}
+
+// NewSecretManager creates a new secret manager
+func NewSecretManager(exec executor.CommandExecutor) *SecretManager {
+	return &SecretManager{
+		executor: exec,
+	}
+}
+
+// SecretExists reports whether the secret exists in namespace
+func (m *SecretManager) SecretExists(ctx context.Context, namespace, name string) (bool, error) {
+	result, err := m.executor.Execute(ctx, "kubectl", "-n", namespace, "get", "secret", name, "--ignore-not-found", "-o", "name")
+	if err != nil {
+		return false, fmt.Errorf("failed to look up secret %s/%s: %w", namespace, name, err)
+	}
+	return strings.TrimSpace(result.Stdout) != "", nil
+}
+
+// ApplyTLSSecret creates or updates a kubernetes.io/tls secret from PEM files, creating the namespace if needed
+func (m *SecretManager) ApplyTLSSecret(ctx context.Context, namespace, name, certFile, keyFile string) error {
+	cert, err := os.ReadFile(certFile)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate: %w", err)
+	}
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read key: %w", err)
+	}
+
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items": []interface{}{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": namespace},
+			},
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"type":       "kubernetes.io/tls",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": namespace,
+					"labels":    map[string]string{managedByLabel: "openframe-cli"},
+				},
+				// []byte values are base64 encoded by encoding/json, as the data field expects
+				"data": map[string][]byte{
+					"tls.crt": cert,
+					"tls.key": key,
+				},
+			},
+		},
+	}
+
+	return m.apply(ctx, manifest)
+}
+
+// apply writes the manifest to a private temporary file and applies it, so key material never shows up in arguments
+func (m *SecretManager) apply(ctx context.Context, manifest interface{}) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "openframe-secret-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary manifest: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write temporary manifest: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary manifest: %w", err)
+	}
+
+	result, err := m.executor.Execute(ctx, "kubectl", "apply", "-f", file.Name())
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return fmt.Errorf("failed to apply manifest: %w\nkubectl output: %s", err, strings.TrimSpace(result.Stderr))
+		}
+		return fmt.Errorf("failed to apply manifest: %w", err)
+	}
+	return nil
+}
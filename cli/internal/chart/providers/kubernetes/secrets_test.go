@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	testutil.InitializeTestMode()
+}
+
+// manifestCapture records the manifest passed to kubectl apply before it is deleted
+type manifestCapture struct {
+	args     []string
+	manifest []byte
+	err      error
+}
+
+func (m *manifestCapture) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	m.args = append([]string{name}, args...)
+	if len(args) == 3 && args[0] == "apply" {
+		m.manifest, _ = os.ReadFile(args[2])
+	}
+	if m.err != nil {
+		return &executor.CommandResult{ExitCode: 1, Stderr: "forbidden"}, m.err
+	}
+	return &executor.CommandResult{}, nil
+}
+
+func (m *manifestCapture) ExecuteWithOptions(ctx context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	return m.Execute(ctx, options.Command, options.Args...)
+}
+
+func TestSecretManager_ApplyTLSSecret(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, []byte("CERT"), 0644))
+	require.NoError(t, os.WriteFile(keyFile, []byte("KEY"), 0600))
+
+	capture := &manifestCapture{}
+	manager := NewSecretManager(capture)
+
+	require.NoError(t, manager.ApplyTLSSecret(context.Background(), "microservices", "example-tls", certFile, keyFile))
+
+	assert.Equal(t, "kubectl", capture.args[0])
+	assert.Equal(t, "apply", capture.args[1])
+	_, err := os.Stat(capture.args[3])
+	assert.True(t, os.IsNotExist(err), "temporary manifest should be removed")
+
+	var list struct {
+		Items []struct {
+			Kind     string            `json:"kind"`
+			Type     string            `json:"type"`
+			Metadata map[string]any    `json:"metadata"`
+			Data     map[string][]byte `json:"data"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(capture.manifest, &list))
+	require.Len(t, list.Items, 2)
+
+	assert.Equal(t, "Namespace", list.Items[0].Kind)
+	assert.Equal(t, "microservices", list.Items[0].Metadata["name"])
+
+	secret := list.Items[1]
+	assert.Equal(t, "Secret", secret.Kind)
+	assert.Equal(t, "kubernetes.io/tls", secret.Type)
+	assert.Equal(t, "example-tls", secret.Metadata["name"])
+	assert.Equal(t, "microservices", secret.Metadata["namespace"])
+	assert.Equal(t, []byte("CERT"), secret.Data["tls.crt"])
+	assert.Equal(t, []byte("KEY"), secret.Data["tls.key"])
+}
+
+func TestSecretManager_ApplyTLSSecretErrors(t *testing.T) {
+	manager := NewSecretManager(&manifestCapture{})
+	err := manager.ApplyTLSSecret(context.Background(), "microservices", "example-tls", "/missing/tls.crt", "/missing/tls.key")
+	assert.ErrorContains(t, err, "failed to read certificate")
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	require.NoError(t, os.WriteFile(certFile, []byte("CERT"), 0644))
+
+	manager = NewSecretManager(&manifestCapture{err: errors.New("exit status 1")})
+	err = manager.ApplyTLSSecret(context.Background(), "microservices", "example-tls", certFile, certFile)
+	assert.ErrorContains(t, err, "kubectl output: forbidden")
+}
+
+func TestSecretManager_SecretExists(t *testing.T) {
+	mock := testutil.NewTestMockExecutor()
+	mock.SetResponse("get secret present-tls", &executor.CommandResult{Stdout: "secret/present-tls\n"})
+	mock.SetResponse("get secret missing-tls", &executor.CommandResult{Stdout: ""})
+	manager := NewSecretManager(mock)
+
+	exists, err := manager.SecretExists(context.Background(), "microservices", "present-tls")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = manager.SecretExists(context.Background(), "microservices", "missing-tls")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
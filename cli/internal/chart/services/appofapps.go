@@ -10,6 +10,7 @@ import (
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/helm"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/errors"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	sharedErrors "github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
 	"github.com/pterm/pterm"
 )
@@ -70,6 +71,15 @@ func (a *AppOfApps) Install(ctx context.Context, config config.ChartInstallConfi
 		valuesFile = appConfig.ValuesFile
 	}
 
+	// Validate against the chart's values.schema.json, or the built-in schema when it has none
+	schema, _, err := helmvalues.SchemaForChart(cloneResult.ChartPath)
+	if err == nil {
+		err = NewConfigurationValidator().ValidateValuesFile(valuesFile, schema)
+	}
+	if err != nil {
+		return errors.WrapAsChartError("validation", "helm values", err).WithCluster(config.ClusterName)
+	}
+
 	certFile, keyFile := a.pathResolver.GetCertificateFiles()
 
 	// Create a modified config with the local chart path
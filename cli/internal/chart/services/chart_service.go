@@ -17,9 +17,11 @@ import (
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/errors"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/secrets"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	utilTypes "github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/external"
 	sharedErrors "github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/files"
@@ -37,6 +39,16 @@ type ChartService struct {
 	gitRepository  *git.Repository
 }
 
+// newClusterService returns a cluster service that knows the registered external clusters
+// and keeps the kubeconfigs of the clusters charts are installed on
+func newClusterService(exec executor.CommandExecutor) *cluster.ClusterService {
+	service := cluster.NewClusterService(exec).WithExternalRegistry(external.NewDefaultRegistry())
+	if dir, err := cluster.DefaultKubeconfigDir(); err == nil {
+		service.WithKubeconfigDir(dir)
+	}
+	return service
+}
+
 // NewChartService creates a new chart service
 func NewChartService(dryRun, verbose bool) *ChartService {
 	// Create executors
@@ -49,7 +61,7 @@ func NewChartService(dryRun, verbose bool) *ChartService {
 
 	return &ChartService{
 		executor:       chartExec,
-		clusterService: cluster.NewClusterService(clusterExec),
+		clusterService: newClusterService(clusterExec),
 		configService:  configService,
 		operationsUI:   chartUI.NewOperationsUI(),
 		displayService: chartUI.NewDisplayService(),
@@ -79,6 +91,8 @@ func (cs *ChartService) InstallWithContext(ctx context.Context, req utilTypes.In
 		chartService:   cs,
 		clusterService: cs.clusterService,
 		fileCleanup:    fileCleanup,
+		valuesFiles:    req.ValuesFiles,
+		setValues:      req.SetValues,
 	}
 
 	// Execute workflow with context
@@ -90,6 +104,8 @@ type InstallationWorkflow struct {
 	chartService   *ChartService
 	clusterService utilTypes.ClusterLister
 	fileCleanup    *files.FileCleanup
+	valuesFiles    []string // -f files, the wizard applies its choices on top of them
+	setValues      []string // --set overrides
 }
 
 // Execute runs the installation workflow
@@ -119,23 +135,46 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		// No delay - immediate cancellation
 	}()
 
+	// The temporary values can hold decrypted secrets, so they never outlive the install,
+	// not even when it stops before the installation step
+	defer w.fileCleanup.RestoreFiles(false)
+
 	// Step 1: Determine configuration mode and run appropriate workflow
 	var chartConfig *types.ChartConfiguration
 	if req.DryRun {
-		// Create minimal configuration for dry-run mode using base values from current directory
-		modifier := templates.NewHelmValuesModifier()
+		// Create minimal configuration for dry-run mode using the merged base values
+		modifier := w.newValuesModifier()
 		baseValues, err := modifier.LoadOrCreateBaseValues()
 		if err != nil {
 			return fmt.Errorf("failed to load base values for dry-run: %w", err)
 		}
+		tempFilePath, err := modifier.CreateTemporaryValuesFile(baseValues)
+		if err != nil {
+			return fmt.Errorf("failed to create temporary values file: %w", err)
+		}
+		if backupErr := w.fileCleanup.RegisterTempFile(tempFilePath); backupErr != nil {
+			pterm.Warning.Printf("Failed to register temp file for cleanup: %v\n", backupErr)
+		}
 
 		chartConfig = &types.ChartConfiguration{
 			BaseHelmValuesPath: "helm-values.yaml",
-			TempHelmValuesPath: "helm-values-tmp.yaml", // Use tmp file in current directory for dry-run
+			TempHelmValuesPath: tempFilePath, // Use tmp file in current directory for dry-run
 			ExistingValues:     baseValues,
 			ModifiedSections:   make([]string, 0),
 		}
 		pterm.Info.Println("Using existing configuration (dry-run mode)")
+	} else if req.NonInteractive && (req.AnswersFile != "" || configuration.HasAnswerEnv()) {
+		// Mode 1a: NON-INTERACTIVE WITH ANSWERS (CI/CD choosing every wizard option)
+		var err error
+		chartConfig, err = w.runAnswersConfiguration(req)
+		if err != nil {
+			return fmt.Errorf("non-interactive configuration failed: %w", err)
+		}
+
+		// Register temporary file for cleanup
+		if backupErr := w.fileCleanup.RegisterTempFile(chartConfig.TempHelmValuesPath); backupErr != nil {
+			pterm.Warning.Printf("Failed to register temp file for cleanup: %v\n", backupErr)
+		}
 	} else if req.NonInteractive {
 		// Mode 1: FULLY NON-INTERACTIVE (CI/CD)
 		if req.DeploymentMode == "" {
@@ -178,11 +217,34 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		}
 	}
 
+	// Tenants share the cluster, which only the saas-shared deployment is built for
+	if req.Tenant != "" && chartConfig.DeploymentMode != nil && *chartConfig.DeploymentMode != types.DeploymentModeSaaSShared {
+		return fmt.Errorf("--tenant requires the saas-shared deployment mode")
+	}
+
+	// Catch typos and wrong types before anything is installed
+	if err := NewConfigurationValidator().ValidateValues(chartConfig.ExistingValues); err != nil {
+		return fmt.Errorf("helm values do not match the values schema: %w", err)
+	}
+
+	// Bad credentials would otherwise only show up when ArgoCD fails to pull or clone
+	if !req.DryRun {
+		verifier := NewCredentialVerifier(w.chartService.executor).Skip(req.SkipVerify...)
+		if err := NewConfigurationValidator().VerifyCredentials(ctx, chartConfig.ExistingValues, verifier); err != nil {
+			return err
+		}
+	}
+
 	// Step 2: Select cluster
 	clusterName, err := w.selectCluster(req.Args, req.Verbose)
 	if err != nil || clusterName == "" {
 		return err
 	}
+	if targeter, ok := w.clusterService.(utilTypes.ClusterTargeter); ok {
+		if err := targeter.TargetCluster(clusterName); err != nil {
+			return errors.WrapAsChartError("cluster", "context", err).WithCluster(clusterName)
+		}
+	}
 
 	// Step 3: Confirm installation on the selected cluster (skip in non-interactive mode)
 	if !req.NonInteractive {
@@ -202,17 +264,23 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		pterm.Warning.Println("Skipping certificate regeneration (non-interactive mode)")
 	}
 
-	// Step 5: Build configuration
+	// Step 5: Create the TLS secret when a custom domain was configured
+	if err := w.ensureCustomDomainTLS(ctx, chartConfig); err != nil {
+		chartErr := errors.WrapAsChartError("configuration", "custom domain TLS", err).WithCluster(clusterName)
+		return sharedErrors.HandleGlobalError(chartErr, req.Verbose)
+	}
+
+	// Step 6: Build configuration
 	config, err := w.buildConfiguration(req, clusterName, chartConfig)
 	if err != nil {
 		chartErr := errors.WrapAsChartError("configuration", "build", err).WithCluster(clusterName)
 		return sharedErrors.HandleGlobalError(chartErr, req.Verbose)
 	}
 
-	// Step 6: Execute installation with retry support
+	// Step 7: Execute installation with retry support
 	err = w.performInstallationWithRetry(ctx, config)
 
-	// Step 7: Clean up generated files based on installation result
+	// Step 8: Clean up generated files based on installation result
 	if err != nil {
 		// Installation failed - clean up temporary files immediately
 		if cleanupErr := w.fileCleanup.RestoreFiles(req.Verbose); cleanupErr != nil {
@@ -228,10 +296,10 @@ func (w *InstallationWorkflow) ExecuteWithContext(parentCtx context.Context, req
 		return fmt.Errorf("installation cancelled by user")
 	}
 
-	// Step 8: ArgoCD sync is already handled by installer.InstallCharts
+	// Step 9: ArgoCD sync is already handled by installer.InstallCharts
 	// The installer waits for all ArgoCD applications after installing app-of-apps
 
-	// Step 9: Installation successful - clean up temporary files
+	// Step 10: Installation successful - clean up temporary files
 	if cleanupErr := w.fileCleanup.RestoreFilesOnSuccess(req.Verbose); cleanupErr != nil {
 		pterm.Warning.Printf("Failed to clean up files after successful installation: %v\n", cleanupErr)
 	}
@@ -263,7 +331,7 @@ func (w *InstallationWorkflow) regenerateCertificates() error {
 
 // runConfigurationWizard runs the configuration wizard to get user preferences
 func (w *InstallationWorkflow) runConfigurationWizard() (*types.ChartConfiguration, error) {
-	wizard := configuration.NewConfigurationWizard()
+	wizard := configuration.NewConfigurationWizard().WithValueLayers(w.valuesFiles, w.setValues).WithDecrypter(secrets.NewDefaultManager())
 
 	// Configure Helm values from current directory
 	config, err := wizard.ConfigureHelmValues()
@@ -274,27 +342,52 @@ func (w *InstallationWorkflow) runConfigurationWizard() (*types.ChartConfigurati
 	return config, nil
 }
 
+// runAnswersConfiguration configures the helm values from the answers file and the OPENFRAME_*
+// environment variables, with --deployment-mode taking precedence over both
+func (w *InstallationWorkflow) runAnswersConfiguration(req utilTypes.InstallationRequest) (*types.ChartConfiguration, error) {
+	decrypter := secrets.NewDefaultManager()
+
+	answers := &configuration.Answers{}
+	if req.AnswersFile != "" {
+		var err error
+		if answers, err = configuration.LoadAnswers(req.AnswersFile, decrypter); err != nil {
+			return nil, err
+		}
+	}
+	answers.ApplyEnv()
+	if req.DeploymentMode != "" {
+		answers.DeploymentMode = req.DeploymentMode
+	}
+
+	wizard := configuration.NewConfigurationWizard().WithValueLayers(w.valuesFiles, w.setValues).WithDecrypter(decrypter)
+	config, err := wizard.ConfigureFromAnswers(answers)
+	if err != nil {
+		return nil, err
+	}
+	wizard.ShowConfigurationSummary(config)
+
+	// Validate required configuration exists (after applying the answers)
+	if err := NewConfigurationValidator().ValidateConfiguration(config); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	return config, nil
+}
+
 // loadExistingConfiguration loads existing helm-values.yaml for non-interactive mode
 func (w *InstallationWorkflow) loadExistingConfiguration(deploymentModeStr string) (*types.ChartConfiguration, error) {
-	modifier := templates.NewHelmValuesModifier()
+	modifier := w.newValuesModifier()
 
-	// Load existing helm-values.yaml
+	// Load existing helm-values.yaml merged with the other value layers
 	values, err := modifier.LoadOrCreateBaseValues()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load helm-values.yaml: %w", err)
 	}
 
 	// Convert string to DeploymentMode
-	var deploymentMode types.DeploymentMode
-	switch deploymentModeStr {
-	case "oss-tenant":
-		deploymentMode = types.DeploymentModeOSS
-	case "saas-tenant":
-		deploymentMode = types.DeploymentModeSaaS
-	case "saas-shared":
-		deploymentMode = types.DeploymentModeSaaSShared
-	default:
-		return nil, fmt.Errorf("invalid deployment mode: %s", deploymentModeStr)
+	deploymentMode, err := types.ParseDeploymentMode(deploymentModeStr)
+	if err != nil {
+		return nil, err
 	}
 
 	// Auto-configure the specified deployment mode using existing HelmValuesModifier
@@ -311,6 +404,11 @@ func (w *InstallationWorkflow) loadExistingConfiguration(deploymentModeStr strin
 		return nil, fmt.Errorf("failed to create temporary values file: %w", err)
 	}
 
+	// Register temporary file for cleanup, before the checks below can fail
+	if backupErr := w.fileCleanup.RegisterTempFile(tempFilePath); backupErr != nil {
+		pterm.Warning.Printf("Failed to register temp file for cleanup: %v\n", backupErr)
+	}
+
 	// Extract SaaS repository password from helm values for SaaS Shared mode
 	var saasConfig *types.SaaSConfig
 	if deploymentMode == types.DeploymentModeSaaSShared {
@@ -344,22 +442,20 @@ func (w *InstallationWorkflow) loadExistingConfiguration(deploymentModeStr strin
 // runPartialConfigurationWizard runs wizard with pre-selected deployment mode
 func (w *InstallationWorkflow) runPartialConfigurationWizard(deploymentModeStr string) (*types.ChartConfiguration, error) {
 	// Convert string to DeploymentMode
-	var deploymentMode types.DeploymentMode
-	switch deploymentModeStr {
-	case "oss-tenant":
-		deploymentMode = types.DeploymentModeOSS
-	case "saas-tenant":
-		deploymentMode = types.DeploymentModeSaaS
-	case "saas-shared":
-		deploymentMode = types.DeploymentModeSaaSShared
-	default:
-		return nil, fmt.Errorf("invalid deployment mode: %s", deploymentModeStr)
+	deploymentMode, err := types.ParseDeploymentMode(deploymentModeStr)
+	if err != nil {
+		return nil, err
 	}
 
-	wizard := configuration.NewConfigurationWizard()
+	wizard := configuration.NewConfigurationWizard().WithValueLayers(w.valuesFiles, w.setValues).WithDecrypter(secrets.NewDefaultManager())
 	return wizard.ConfigureHelmValuesWithMode(deploymentMode)
 }
 
+// newValuesModifier creates a values modifier that starts from the requested value layers
+func (w *InstallationWorkflow) newValuesModifier() *templates.HelmValuesModifier {
+	return templates.NewHelmValuesModifier().WithValueLayers(w.valuesFiles, w.setValues).WithDecrypter(secrets.NewDefaultManager())
+}
+
 // waitForArgoCDSync waits for ArgoCD applications to be synced
 func (w *InstallationWorkflow) waitForArgoCDSync(ctx context.Context, config config.ChartInstallConfig) error {
 	if !config.HasAppOfApps() {
@@ -393,7 +489,7 @@ func (w *InstallationWorkflow) buildConfiguration(req utilTypes.InstallationRequ
 
 	// Determine repository URL based on deployment mode
 	githubRepo := req.GitHubRepo
-	if chartConfig.DeploymentMode != nil {
+	if chartConfig.DeploymentMode != nil && req.ServedRepo == "" {
 		// Always use deployment mode to determine repository URL if deployment mode is specified
 		// This ensures that SaaS Shared mode gets the correct repository
 		githubRepo = types.GetRepositoryURL(*chartConfig.DeploymentMode)
@@ -412,12 +508,21 @@ func (w *InstallationWorkflow) buildConfiguration(req utilTypes.InstallationRequ
 		deploymentModeStr = string(*chartConfig.DeploymentMode)
 	}
 
-	return configBuilder.BuildInstallConfigWithCustomHelmPath(
+	installConfig, err := configBuilder.BuildInstallConfigWithCustomHelmPath(
 		req.Force, req.DryRun, req.Verbose, req.NonInteractive, clusterName,
 		githubRepo, req.GitHubBranch, req.CertDir,
 		chartConfig.TempHelmValuesPath,
 		deploymentModeStr,
 	)
+	if err != nil {
+		return installConfig, err
+	}
+	installConfig.ArgoCDChart = req.ArgoCDChart
+	if installConfig.AppOfApps != nil {
+		installConfig.AppOfApps.Tenant = req.Tenant
+		installConfig.AppOfApps.RepositoryURL = req.ServedRepo
+	}
+	return installConfig, nil
 }
 
 // performInstallation executes the actual installation
@@ -430,6 +535,7 @@ func (w *InstallationWorkflow) performInstallation(ctx context.Context, config c
 	installer := &Installer{
 		argoCDService:    argoCDService,
 		appOfAppsService: appOfAppsService,
+		tenantManager:    NewTenantManager(w.chartService.executor),
 	}
 
 	err := installer.InstallChartsWithContext(ctx, config)
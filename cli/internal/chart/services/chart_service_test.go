@@ -1,12 +1,21 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"os"
 	"testing"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/git"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/helm"
+	chartUI "github.com/flamingo-stack/openframe/openframe/internal/chart/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/config"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	clusterDomain "github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/files"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockClusterLister implements ClusterLister interface for testing
@@ -170,3 +179,73 @@ func TestInstallationWorkflow_EmptyRequest(t *testing.T) {
 	assert.False(t, req.DryRun)
 	assert.False(t, req.Verbose)
 }
+
+// newNonInteractiveWorkflow returns a workflow with a mocked executor and the cluster "test",
+// run in a directory holding helm-values.yaml
+func newNonInteractiveWorkflow(t *testing.T) *InstallationWorkflow {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(originalDir) })
+	require.NoError(t, os.WriteFile("helm-values.yaml", []byte("registry:\n  docker:\n    username: openframe\n    password: secret\n"), 0o600))
+
+	mockExec := executor.NewMockCommandExecutor()
+	configService := config.NewService()
+	configService.Initialize()
+	lister := NewMockClusterLister()
+	lister.SetClusters([]clusterDomain.ClusterInfo{{Name: "test", Status: "running"}})
+
+	return &InstallationWorkflow{
+		chartService: &ChartService{
+			executor:       mockExec,
+			configService:  configService,
+			operationsUI:   chartUI.NewOperationsUI(),
+			displayService: chartUI.NewDisplayService(),
+			helmManager:    helm.NewHelmManager(mockExec),
+			gitRepository:  git.NewRepository(mockExec),
+		},
+		clusterService: lister,
+		fileCleanup:    files.NewFileCleanup(),
+	}
+}
+
+func TestInstallationWorkflow_NonInteractiveRemovesTempValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     types.InstallationRequest
+		wantErr bool
+	}{
+		{
+			// Without a Git repository only ArgoCD is installed
+			name: "success",
+			req:  types.InstallationRequest{Args: []string{"test"}, DeploymentMode: "oss-tenant", ServedRepo: "http://host.k3d.internal:8780/repo.git"},
+		},
+		{
+			name:    "unknown cluster",
+			req:     types.InstallationRequest{Args: []string{"missing"}, DeploymentMode: "oss-tenant"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid configuration",
+			req:     types.InstallationRequest{Args: []string{"test"}, DeploymentMode: "saas-shared"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow := newNonInteractiveWorkflow(t)
+			tt.req.NonInteractive = true
+			tt.req.SkipVerify = []string{"all"}
+
+			err := workflow.ExecuteWithContext(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoFileExists(t, "helm-values-tmp.yaml", "decrypted values must not stay in the working directory")
+			assert.FileExists(t, "helm-values.yaml")
+		})
+	}
+}
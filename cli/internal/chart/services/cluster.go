@@ -2,7 +2,9 @@ package services
 
 import (
 	chartUI "github.com/flamingo-stack/openframe/openframe/internal/chart/ui"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/errors"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
 	"github.com/pterm/pterm"
 )
 
@@ -48,3 +50,18 @@ func (c *ClusterSelector) SelectCluster(args []string, verbose bool) (string, er
 
 	return c.operationsUI.SelectClusterForInstall(clusters, args)
 }
+
+// SelectAndTargetCluster selects the cluster named in args, or asks for one, and points the
+// kubectl and helm commands of this process at it the way chart install does. It returns ""
+// when there is no cluster to select.
+func SelectAndTargetCluster(args []string, verbose bool) (string, error) {
+	clusterService := newClusterService(executor.NewRealCommandExecutor(false, verbose))
+	clusterName, err := NewClusterSelector(clusterService, chartUI.NewOperationsUI()).SelectCluster(args, verbose)
+	if err != nil || clusterName == "" {
+		return "", err
+	}
+	if err := clusterService.TargetCluster(clusterName); err != nil {
+		return "", errors.WrapAsChartError("cluster", "context", err).WithCluster(clusterName)
+	}
+	return clusterName, nil
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	sharedErrors "github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// Credential checks, each one can be skipped with --skip-verify
+const (
+	CredentialCheckRegistry = "registry" // Docker Hub and GHCR credentials
+	CredentialCheckNgrok    = "ngrok"    // ngrok API key and reserved domain
+	CredentialCheckGit      = "git"      // Repository access and branches
+	CredentialCheckAll      = "all"
+)
+
+// CredentialChecks lists the checks that can be skipped
+var CredentialChecks = []string{CredentialCheckRegistry, CredentialCheckNgrok, CredentialCheckGit}
+
+// CredentialEndpoints are the base URLs the credentials are checked against
+type CredentialEndpoints struct {
+	DockerHub string // Docker Hub registry API
+	GHCR      string // GitHub container registry API
+	NgrokAPI  string // ngrok REST API
+}
+
+// DefaultCredentialEndpoints returns the public service URLs, or the ones set in
+// OPENFRAME_DOCKER_REGISTRY_URL, OPENFRAME_GHCR_URL and OPENFRAME_NGROK_API_URL
+func DefaultCredentialEndpoints() CredentialEndpoints {
+	return CredentialEndpoints{
+		DockerHub: envOr("OPENFRAME_DOCKER_REGISTRY_URL", "https://registry-1.docker.io"),
+		GHCR:      envOr("OPENFRAME_GHCR_URL", "https://ghcr.io"),
+		NgrokAPI:  envOr("OPENFRAME_NGROK_API_URL", "https://api.ngrok.com"),
+	}
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// CredentialCheckResult is the outcome of checking one credential
+type CredentialCheckResult struct {
+	Check   string // registry, ngrok or git
+	Target  string // What was checked, e.g. "ghcr.io as octocat"
+	Skipped bool   // Skipped with --skip-verify, or nothing to check
+	Err     error  // nil when the check passed or was skipped
+}
+
+// CredentialVerifier checks registry, ngrok and repository credentials before anything is installed
+type CredentialVerifier struct {
+	executor  executor.CommandExecutor
+	client    *http.Client
+	endpoints CredentialEndpoints
+	skip      map[string]bool
+}
+
+// NewCredentialVerifier creates a verifier for the public services, running git through exec
+func NewCredentialVerifier(exec executor.CommandExecutor) *CredentialVerifier {
+	return &CredentialVerifier{
+		executor:  exec,
+		client:    &http.Client{Timeout: 15 * time.Second},
+		endpoints: DefaultCredentialEndpoints(),
+		skip:      make(map[string]bool),
+	}
+}
+
+// WithEndpoints checks the credentials against other base URLs, e.g. a local stand-in server
+func (v *CredentialVerifier) WithEndpoints(endpoints CredentialEndpoints) *CredentialVerifier {
+	v.endpoints = endpoints
+	return v
+}
+
+// Skip disables the given checks, "all" disables every check
+func (v *CredentialVerifier) Skip(checks ...string) *CredentialVerifier {
+	for _, check := range checks {
+		v.skip[check] = true
+	}
+	return v
+}
+
+// ValidateCredentialChecks rejects unknown --skip-verify names
+func ValidateCredentialChecks(checks []string) error {
+	for _, check := range checks {
+		known := check == CredentialCheckAll
+		for _, candidate := range CredentialChecks {
+			known = known || check == candidate
+		}
+		if !known {
+			return fmt.Errorf("unknown credential check: %s. Valid options: %s, %s", check, strings.Join(CredentialChecks, ", "), CredentialCheckAll)
+		}
+	}
+	return nil
+}
+
+// Verify runs every check that applies to the enabled deployments in values
+func (v *CredentialVerifier) Verify(ctx context.Context, values *helmvalues.Document) []CredentialCheckResult {
+	ossEnabled, _ := values.GetBool("deployment.oss.enabled")
+	saasEnabled, _ := values.GetBool("deployment.saas.enabled")
+
+	var results []CredentialCheckResult
+
+	// Registries: Docker Hub for OSS, GHCR for SaaS
+	if ossEnabled {
+		results = append(results, v.verifyRegistry(ctx, values, "registry.docker", "Docker Hub", v.endpoints.DockerHub))
+	}
+	if saasEnabled {
+		results = append(results, v.verifyRegistry(ctx, values, "registry.ghcr", "GHCR", v.endpoints.GHCR))
+	}
+
+	// ngrok, only when it is the selected ingress
+	if ngrokEnabled, _ := values.GetBool("deployment.oss.ingress.ngrok.enabled"); ossEnabled && ngrokEnabled {
+		results = append(results, v.verifyNgrok(ctx, values))
+	}
+
+	// Repositories and branches, the SaaS repository is private
+	if ossEnabled || saasEnabled {
+		results = append(results, v.verifyRepository(ctx, values, "deployment.oss.repository", ""))
+	}
+	if saasEnabled {
+		token, _ := values.GetString("deployment.saas.repository.password")
+		results = append(results, v.verifyRepository(ctx, values, "deployment.saas.repository", token))
+	}
+
+	return results
+}
+
+func (v *CredentialVerifier) skipped(check string) bool {
+	return v.skip[check] || v.skip[CredentialCheckAll]
+}
+
+// verifyRegistry logs in to the registry with the credentials under path
+func (v *CredentialVerifier) verifyRegistry(ctx context.Context, values *helmvalues.Document, path, name, baseURL string) CredentialCheckResult {
+	username, _ := values.GetString(path + ".username")
+	password, _ := values.GetString(path + ".password")
+	result := CredentialCheckResult{Check: CredentialCheckRegistry, Target: fmt.Sprintf("%s as %s", name, username)}
+
+	if v.skipped(CredentialCheckRegistry) {
+		result.Skipped = true
+		return result
+	}
+	if username == "" || username == "default" || password == "" {
+		result.Target = name + " (no credentials configured)"
+		result.Skipped = true
+		return result
+	}
+
+	result.Err = v.registryLogin(ctx, baseURL, username, password)
+	return result
+}
+
+// challengeParamPattern matches the key="value" pairs of a WWW-Authenticate header
+var challengeParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// registryLogin follows the registry v2 authentication flow: the /v2/ endpoint answers with
+// a challenge, and the credentials are exchanged for a token at the realm it names
+func (v *CredentialVerifier) registryLogin(ctx context.Context, baseURL, username, password string) error {
+	resp, err := v.get(ctx, strings.TrimSuffix(baseURL, "/")+"/v2/", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil // The registry does not require authentication
+	case http.StatusUnauthorized:
+	default:
+		return fmt.Errorf("unexpected response from %s: %s", baseURL, resp.Status)
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	scheme, _, _ := strings.Cut(challenge, " ")
+	params := make(map[string]string)
+	for _, match := range challengeParamPattern.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	loginURL := strings.TrimSuffix(baseURL, "/") + "/v2/"
+	if strings.EqualFold(scheme, "Bearer") {
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return fmt.Errorf("registry %s sent an invalid authentication challenge: %q", baseURL, challenge)
+		}
+		query := realm.Query()
+		for _, key := range []string{"service", "scope"} {
+			if params[key] != "" {
+				query.Set(key, params[key])
+			}
+		}
+		realm.RawQuery = query.Encode()
+		loginURL = realm.String()
+	} else if !strings.EqualFold(scheme, "Basic") {
+		return fmt.Errorf("registry %s uses unsupported authentication %q", baseURL, scheme)
+	}
+
+	resp, err = v.get(ctx, loginURL, func(req *http.Request) { req.SetBasicAuth(username, password) })
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("username or password was rejected")
+	default:
+		return fmt.Errorf("unexpected response from %s: %s", loginURL, resp.Status)
+	}
+}
+
+// verifyNgrok checks the API key and that the domain is reserved in the same account
+func (v *CredentialVerifier) verifyNgrok(ctx context.Context, values *helmvalues.Document) CredentialCheckResult {
+	domain, _ := values.GetString("deployment.oss.ingress.ngrok.url")
+	apiKey, _ := values.GetString("deployment.oss.ingress.ngrok.credentials.apiKey")
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(domain, "https://"), "http://"), "/"))
+	result := CredentialCheckResult{Check: CredentialCheckNgrok, Target: "ngrok domain " + domain}
+
+	if v.skipped(CredentialCheckNgrok) {
+		result.Skipped = true
+		return result
+	}
+	if apiKey == "" || domain == "" {
+		result.Err = fmt.Errorf("ngrok domain and API key are required")
+		return result
+	}
+
+	result.Err = v.findNgrokDomain(ctx, apiKey, domain)
+	return result
+}
+
+// ngrokReservedDomains is a page of the ngrok reserved domains list
+type ngrokReservedDomains struct {
+	ReservedDomains []struct {
+		Domain string `json:"domain"`
+	} `json:"reserved_domains"`
+	NextPageURI *string `json:"next_page_uri"`
+}
+
+func (v *CredentialVerifier) findNgrokDomain(ctx context.Context, apiKey, domain string) error {
+	next := strings.TrimSuffix(v.endpoints.NgrokAPI, "/") + "/reserved_domains"
+	for next != "" {
+		resp, err := v.get(ctx, next, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+			req.Header.Set("Ngrok-Version", "2")
+		})
+		if err != nil {
+			return err
+		}
+
+		var page ngrokReservedDomains
+		switch resp.StatusCode {
+		case http.StatusOK:
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			err = fmt.Errorf("API key was rejected, create one at %s", types.NgrokRegistrationURLs.APIKeyDocs)
+		default:
+			err = fmt.Errorf("unexpected response from the ngrok API: %s", resp.Status)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, reserved := range page.ReservedDomains {
+			if strings.EqualFold(reserved.Domain, domain) {
+				return nil
+			}
+		}
+		next = ""
+		if page.NextPageURI != nil {
+			next = *page.NextPageURI
+		}
+	}
+	return fmt.Errorf("domain %s is not reserved in this ngrok account, add it at %s", domain, types.NgrokRegistrationURLs.DomainDocs)
+}
+
+// verifyRepository checks with git ls-remote that the repository under path can be read and
+// has the configured branch. The token is passed in the environment, never on the command line.
+func (v *CredentialVerifier) verifyRepository(ctx context.Context, values *helmvalues.Document, path, token string) CredentialCheckResult {
+	repoURL, _ := values.GetString(path + ".URL")
+	branch, _ := values.GetString(path + ".branch")
+	if branch == "" {
+		branch = "main"
+	}
+	result := CredentialCheckResult{Check: CredentialCheckGit, Target: fmt.Sprintf("%s branch %s", repoURL, branch)}
+
+	if v.skipped(CredentialCheckGit) {
+		result.Skipped = true
+		return result
+	}
+	if repoURL == "" {
+		result.Target = path + " (no repository URL configured)"
+		result.Skipped = true
+		return result
+	}
+
+	env := map[string]string{"GIT_TERMINAL_PROMPT": "0"}
+	if token != "" {
+		env["GIT_CONFIG_COUNT"] = "1"
+		env["GIT_CONFIG_KEY_0"] = "http.extraHeader"
+		env["GIT_CONFIG_VALUE_0"] = "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("x-access-token:"+token))
+	}
+
+	output, err := v.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "git",
+		Args:    []string{"ls-remote", "--heads", repoURL, branch},
+		Env:     env,
+		Timeout: 30 * time.Second,
+	})
+	if err != nil {
+		message := err.Error()
+		if output != nil && strings.TrimSpace(output.Stderr) != "" {
+			message = strings.TrimSpace(output.Stderr)
+		}
+		result.Err = fmt.Errorf("cannot read the repository: %s", message)
+		return result
+	}
+
+	for _, line := range strings.Split(output.Stdout, "\n") {
+		if strings.HasSuffix(strings.TrimSpace(line), "refs/heads/"+branch) {
+			return result
+		}
+	}
+	result.Err = sharedErrors.NewBranchNotFoundError(branch)
+	return result
+}
+
+func (v *CredentialVerifier) get(ctx context.Context, rawURL string, prepare func(*http.Request)) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if prepare != nil {
+		prepare(req)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach %s: %w", req.URL.Host, err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	sharedErrors "github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRegistryServer stands in for a registry using the bearer token flow, accepting one user
+func newRegistryServer(t *testing.T, username, password string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry.test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/token":
+			assert.Equal(t, "registry.test", r.URL.Query().Get("service"))
+			if user, pass, ok := r.BasicAuth(); ok && user == username && pass == password {
+				_, _ = w.Write([]byte(`{"token":"t"}`))
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newNgrokServer stands in for the ngrok API, listing the domains over two pages
+func newNgrokServer(t *testing.T, apiKey string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+apiKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "2", r.Header.Get("Ngrok-Version"))
+		if r.URL.Query().Get("before_id") == "" {
+			fmt.Fprintf(w, `{"reserved_domains":[{"domain":"first.ngrok.app"}],"next_page_uri":"%s/reserved_domains?before_id=rd_1"}`, server.URL)
+			return
+		}
+		fmt.Fprint(w, `{"reserved_domains":[{"domain":"demo.ngrok.app"}],"next_page_uri":null}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func parseValues(t *testing.T, yaml string) *helmvalues.Document {
+	values, err := helmvalues.Parse([]byte(yaml))
+	require.NoError(t, err)
+	return values
+}
+
+func TestCredentialVerifier_RegistryLogin(t *testing.T) {
+	registry := newRegistryServer(t, "octocat", "secret")
+	verifier := NewCredentialVerifier(executor.NewMockCommandExecutor())
+
+	assert.NoError(t, verifier.registryLogin(context.Background(), registry.URL, "octocat", "secret"))
+	assert.EqualError(t, verifier.registryLogin(context.Background(), registry.URL, "octocat", "wrong"), "username or password was rejected")
+
+	basic := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); ok && user == "octocat" && pass == "secret" {
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer basic.Close()
+	assert.NoError(t, verifier.registryLogin(context.Background(), basic.URL, "octocat", "secret"))
+	assert.Error(t, verifier.registryLogin(context.Background(), basic.URL, "octocat", "wrong"))
+}
+
+func TestCredentialVerifier_FindNgrokDomain(t *testing.T) {
+	ngrok := newNgrokServer(t, "api-key")
+	verifier := NewCredentialVerifier(executor.NewMockCommandExecutor()).
+		WithEndpoints(CredentialEndpoints{NgrokAPI: ngrok.URL})
+
+	assert.NoError(t, verifier.findNgrokDomain(context.Background(), "api-key", "demo.ngrok.app"), "domains on later pages are found")
+
+	err := verifier.findNgrokDomain(context.Background(), "api-key", "other.ngrok.app")
+	assert.ErrorContains(t, err, "domain other.ngrok.app is not reserved in this ngrok account")
+
+	err = verifier.findNgrokDomain(context.Background(), "wrong", "demo.ngrok.app")
+	assert.ErrorContains(t, err, "API key was rejected")
+}
+
+func TestCredentialVerifier_VerifyRepository(t *testing.T) {
+	values := parseValues(t, `deployment:
+  saas:
+    repository:
+      URL: https://github.com/flamingo-stack/openframe-saas-tenant.git
+      branch: release
+      password: ghp_token
+`)
+
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("ls-remote", &executor.CommandResult{Stdout: "abc123\trefs/heads/release\n"})
+	result := NewCredentialVerifier(mockExec).verifyRepository(context.Background(), values, "deployment.saas.repository", "ghp_token")
+	assert.NoError(t, result.Err)
+	assert.True(t, mockExec.WasCommandExecuted("git ls-remote --heads https://github.com/flamingo-stack/openframe-saas-tenant.git release"))
+	for _, command := range mockExec.GetExecutedCommands() {
+		assert.NotContains(t, command, "ghp_token", "the token is not passed on the command line")
+	}
+
+	mockExec = executor.NewMockCommandExecutor()
+	mockExec.SetResponse("ls-remote", &executor.CommandResult{Stdout: "abc123\trefs/heads/feature/release\n"})
+	result = NewCredentialVerifier(mockExec).verifyRepository(context.Background(), values, "deployment.saas.repository", "ghp_token")
+	var branchErr *sharedErrors.BranchNotFoundError
+	require.ErrorAs(t, result.Err, &branchErr)
+	assert.Equal(t, "release", branchErr.Branch)
+
+	mockExec = executor.NewMockCommandExecutor()
+	mockExec.SetShouldFail(true, "remote: Repository not found.")
+	result = NewCredentialVerifier(mockExec).verifyRepository(context.Background(), values, "deployment.saas.repository", "ghp_token")
+	assert.EqualError(t, result.Err, "cannot read the repository: remote: Repository not found.")
+}
+
+func TestCredentialVerifier_Verify(t *testing.T) {
+	registry := newRegistryServer(t, "octocat", "secret")
+	ngrok := newNgrokServer(t, "api-key")
+	values := parseValues(t, `deployment:
+  oss:
+    enabled: true
+    repository:
+      URL: https://github.com/flamingo-stack/openframe-oss-tenant.git
+      branch: main
+    ingress:
+      ngrok:
+        enabled: true
+        url: https://demo.ngrok.app
+        credentials:
+          apiKey: api-key
+  saas:
+    enabled: false
+registry:
+  docker:
+    username: octocat
+    password: wrong
+`)
+
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("ls-remote", &executor.CommandResult{Stdout: "abc123\trefs/heads/main\n"})
+	verifier := NewCredentialVerifier(mockExec).WithEndpoints(CredentialEndpoints{DockerHub: registry.URL, NgrokAPI: ngrok.URL})
+
+	results := verifier.Verify(context.Background(), values)
+	require.Len(t, results, 3, "SaaS checks are left out while SaaS is disabled")
+	assert.Equal(t, CredentialCheckRegistry, results[0].Check)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, CredentialCheckNgrok, results[1].Check)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, CredentialCheckGit, results[2].Check)
+	assert.NoError(t, results[2].Err)
+
+	err := NewConfigurationValidator().VerifyCredentials(context.Background(), values, verifier)
+	assert.EqualError(t, err, "1 credential check(s) failed, fix the values or skip them with --skip-verify=registry")
+
+	mockExec = executor.NewMockCommandExecutor()
+	verifier = NewCredentialVerifier(mockExec).Skip(CredentialCheckAll)
+	for _, result := range verifier.Verify(context.Background(), values) {
+		assert.True(t, result.Skipped, result.Check)
+	}
+	assert.Zero(t, mockExec.GetCommandCount())
+	assert.NoError(t, NewConfigurationValidator().VerifyCredentials(context.Background(), values, verifier))
+}
+
+func TestValidateCredentialChecks(t *testing.T) {
+	assert.NoError(t, ValidateCredentialChecks([]string{"registry", "ngrok", "git", "all"}))
+	err := ValidateCredentialChecks([]string{"registry", "dns"})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "unknown credential check: dns"))
+}
+
+func TestDefaultCredentialEndpoints(t *testing.T) {
+	assert.Equal(t, "https://api.ngrok.com", DefaultCredentialEndpoints().NgrokAPI)
+
+	t.Setenv("OPENFRAME_NGROK_API_URL", "http://127.0.0.1:8080")
+	assert.Equal(t, "http://127.0.0.1:8080", DefaultCredentialEndpoints().NgrokAPI)
+}
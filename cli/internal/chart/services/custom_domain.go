@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/kubernetes"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/pterm/pterm"
+)
+
+// ensureCustomDomainTLS creates the TLS secret of a custom domain ingress in the selected cluster
+func (w *InstallationWorkflow) ensureCustomDomainTLS(ctx context.Context, chartConfig *types.ChartConfiguration) error {
+	if chartConfig == nil || chartConfig.IngressConfig == nil || chartConfig.IngressConfig.Type != types.IngressTypeCustom {
+		return nil
+	}
+	custom := chartConfig.IngressConfig.CustomDomain
+	if custom == nil {
+		return fmt.Errorf("custom domain ingress selected without domain settings")
+	}
+
+	secrets := kubernetes.NewSecretManager(w.chartService.executor)
+
+	if custom.TLSSource == types.TLSSourceSecret {
+		exists, err := secrets.SecretExists(ctx, types.CustomDomainNamespace, custom.TLSSecretName)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			pterm.Warning.Printf("TLS secret %s/%s does not exist yet, %s will not serve HTTPS until it is created\n",
+				types.CustomDomainNamespace, custom.TLSSecretName, custom.Host)
+		}
+		return nil
+	}
+
+	spinner, _ := pterm.DefaultSpinner.Start(fmt.Sprintf("Creating TLS secret %s/%s...", types.CustomDomainNamespace, custom.TLSSecretName))
+	if err := secrets.ApplyTLSSecret(ctx, types.CustomDomainNamespace, custom.TLSSecretName, custom.CertFile, custom.KeyFile); err != nil {
+		spinner.Fail("Failed to create TLS secret")
+		return fmt.Errorf("failed to create TLS secret for %s: %w", custom.Host, err)
+	}
+	spinner.Success(fmt.Sprintf("TLS secret %s/%s ready for %s", types.CustomDomainNamespace, custom.TLSSecretName, custom.Host))
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 type Installer struct {
 	argoCDService    types.ArgoCDService
 	appOfAppsService types.AppOfAppsService
+	tenantManager    *TenantManager // Creates the ArgoCD project of tenant installs
 }
 
 // InstallCharts handles the complete chart installation process
@@ -30,6 +31,13 @@ func (i *Installer) InstallChartsWithContext(ctx context.Context, config config.
 
 	// Install app-of-apps from GitHub repository if configured
 	if config.HasAppOfApps() {
+		// A tenant's applications are confined to its own project, which needs ArgoCD's CRDs
+		if config.AppOfApps.Tenant != "" && i.tenantManager != nil && !config.DryRun {
+			if err := i.tenantManager.EnsureProject(ctx, config.AppOfApps.Tenant); err != nil {
+				return errors.WrapAsChartError("installation", "tenant project", err).WithCluster(config.ClusterName)
+			}
+		}
+
 		if err := i.appOfAppsService.Install(ctx, config); err != nil {
 			// Check if this is a branch not found error
 			if _, ok := err.(*sharedErrors.BranchNotFoundError); ok {
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/providers/helm"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// argoCDNamespace is where ArgoCD, its projects and every app-of-apps release live
+const argoCDNamespace = "argocd"
+
+// tenantLabel marks the cluster resources the CLI creates for a tenant
+const tenantLabel = "openframe.io/tenant"
+
+// TenantApplication is an ArgoCD application of a tenant
+type TenantApplication struct {
+	Name   string
+	Health string
+	Sync   string
+}
+
+// TenantStatus describes one app-of-apps install on the cluster
+type TenantStatus struct {
+	ID            string // Empty for the single-tenant install
+	Release       string
+	ReleaseStatus string
+	Project       string
+	Namespaces    []string
+	Applications  []TenantApplication
+}
+
+// TenantManager manages the isolated saas-shared tenants of a cluster
+type TenantManager struct {
+	executor    executor.CommandExecutor
+	helmManager *helm.HelmManager
+}
+
+// NewTenantManager creates a tenant manager that runs kubectl and helm through exec
+func NewTenantManager(exec executor.CommandExecutor) *TenantManager {
+	return &TenantManager{
+		executor:    exec,
+		helmManager: helm.NewHelmManager(exec),
+	}
+}
+
+// tenantProjectManifest returns the AppProject that keeps the applications of a tenant in its
+// own namespaces. The argocd destination is for the Applications the root application of the
+// tenant creates, and Namespace is the only cluster resource, for the namespaces ArgoCD
+// creates for the tenant.
+func tenantProjectManifest(id string) string {
+	return fmt.Sprintf(`apiVersion: argoproj.io/v1alpha1
+kind: AppProject
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+  labels:
+    %[3]s: %[4]s
+spec:
+  description: OpenFrame tenant %[4]s
+  sourceRepos:
+    - "*"
+  destinations:
+    - server: https://kubernetes.default.svc
+      namespace: "%[5]s*"
+    - server: https://kubernetes.default.svc
+      namespace: %[2]s
+  clusterResourceWhitelist:
+    - group: ""
+      kind: Namespace
+`, models.TenantProject(id), argoCDNamespace, tenantLabel, id, models.TenantNamespacePrefix(id))
+}
+
+// EnsureProject creates or updates the ArgoCD AppProject of a tenant
+func (t *TenantManager) EnsureProject(ctx context.Context, id string) error {
+	result, err := t.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "kubectl",
+		Args:    []string{"apply", "-f", "-"},
+		Stdin:   tenantProjectManifest(id),
+	})
+	if err != nil {
+		if result != nil && result.Stderr != "" {
+			return fmt.Errorf("failed to create ArgoCD project %s: %s", models.TenantProject(id), strings.TrimSpace(result.Stderr))
+		}
+		return fmt.Errorf("failed to create ArgoCD project %s: %w", models.TenantProject(id), err)
+	}
+	return nil
+}
+
+// List returns the app-of-apps installs of the cluster, the single-tenant one first
+func (t *TenantManager) List(ctx context.Context) ([]TenantStatus, error) {
+	releases, err := t.helmManager.ListReleases(ctx, argoCDNamespace, "^"+models.AppOfAppsRelease)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenants []TenantStatus
+	for _, release := range releases {
+		id, ok := models.TenantFromReleaseName(release)
+		if !ok {
+			continue
+		}
+		tenants = append(tenants, TenantStatus{
+			ID:            id,
+			Release:       release,
+			ReleaseStatus: t.releaseStatus(ctx, release),
+		})
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].ID < tenants[j].ID })
+	return tenants, nil
+}
+
+// Status returns the release, applications and namespaces of a tenant
+func (t *TenantManager) Status(ctx context.Context, id string) (*TenantStatus, error) {
+	release := models.TenantReleaseName(id)
+	installed, err := t.helmManager.IsChartInstalled(ctx, release, argoCDNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+	if !installed {
+		return nil, fmt.Errorf("tenant %s is not installed (no %s release in the %s namespace)", id, release, argoCDNamespace)
+	}
+
+	status := &TenantStatus{
+		ID:            id,
+		Release:       release,
+		ReleaseStatus: t.releaseStatus(ctx, release),
+		Project:       models.TenantProject(id),
+	}
+	if status.Applications, err = t.applications(ctx, status.Project); err != nil {
+		return nil, err
+	}
+	if status.Namespaces, err = t.namespaces(ctx, id); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Uninstall removes a tenant: its release, the applications left in its project, the
+// project and the namespaces labelled with the tenant. Other tenants, ArgoCD and
+// namespaces that merely share the prefix of the tenant are left alone.
+func (t *TenantManager) Uninstall(ctx context.Context, id string) error {
+	release := models.TenantReleaseName(id)
+	installed, err := t.helmManager.IsChartInstalled(ctx, release, argoCDNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to list releases: %w", err)
+	}
+	if installed {
+		if err := t.helmManager.UninstallChart(ctx, release, argoCDNamespace); err != nil {
+			return err
+		}
+	}
+
+	project := models.TenantProject(id)
+	applications, err := t.applications(ctx, project)
+	if err != nil {
+		return err
+	}
+	if len(applications) > 0 {
+		args := []string{"-n", argoCDNamespace, "delete", "applications.argoproj.io", "--wait"}
+		for _, application := range applications {
+			args = append(args, application.Name)
+		}
+		if _, err := t.executor.Execute(ctx, "kubectl", args...); err != nil {
+			return fmt.Errorf("failed to delete the applications of tenant %s: %w", id, err)
+		}
+	}
+
+	if _, err := t.executor.Execute(ctx, "kubectl", "-n", argoCDNamespace, "delete", "appprojects.argoproj.io", project, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete ArgoCD project %s: %w", project, err)
+	}
+
+	if _, err := t.executor.Execute(ctx, "kubectl", "delete", "namespaces", "-l", tenantSelector(id), "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete the namespaces of tenant %s: %w", id, err)
+	}
+	return nil
+}
+
+// tenantSelector selects the cluster resources of a tenant, ArgoCD labels the tenant
+// namespaces when it creates them
+func tenantSelector(id string) string {
+	return tenantLabel + "=" + id
+}
+
+// releaseStatus returns the Helm status of a release, "unknown" when it cannot be read
+func (t *TenantManager) releaseStatus(ctx context.Context, release string) string {
+	result, err := t.executor.Execute(ctx, "helm", "status", release, "-n", argoCDNamespace, "-o", "json")
+	if err != nil {
+		return "unknown"
+	}
+	var status struct {
+		Info struct {
+			Status string `json:"status"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &status); err != nil || status.Info.Status == "" {
+		return "unknown"
+	}
+	return status.Info.Status
+}
+
+// applications returns the ArgoCD applications of a project
+func (t *TenantManager) applications(ctx context.Context, project string) ([]TenantApplication, error) {
+	result, err := t.executor.Execute(ctx, "kubectl", "-n", argoCDNamespace, "get", "applications.argoproj.io", "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ArgoCD applications: %w", err)
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				Project string `json:"project"`
+			} `json:"spec"`
+			Status struct {
+				Health struct {
+					Status string `json:"status"`
+				} `json:"health"`
+				Sync struct {
+					Status string `json:"status"`
+				} `json:"sync"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse ArgoCD applications: %w", err)
+	}
+
+	var applications []TenantApplication
+	for _, item := range list.Items {
+		if item.Spec.Project != project {
+			continue
+		}
+		applications = append(applications, TenantApplication{
+			Name:   item.Metadata.Name,
+			Health: valueOr(item.Status.Health.Status, "Unknown"),
+			Sync:   valueOr(item.Status.Sync.Status, "Unknown"),
+		})
+	}
+	sort.Slice(applications, func(i, j int) bool { return applications[i].Name < applications[j].Name })
+	return applications, nil
+}
+
+// namespaces returns the namespaces labelled with the tenant
+func (t *TenantManager) namespaces(ctx context.Context, id string) ([]string, error) {
+	result, err := t.executor.Execute(ctx, "kubectl", "get", "namespaces", "-l", tenantSelector(id), "-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := strings.Fields(result.Stdout)
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// valueOr returns value, or fallback when value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tenantApplicationsJSON = `{"items":[
+  {"metadata":{"name":"acme-api"},"spec":{"project":"tenant-acme"},"status":{"health":{"status":"Healthy"},"sync":{"status":"Synced"}}},
+  {"metadata":{"name":"globex-api"},"spec":{"project":"tenant-globex"},"status":{"health":{"status":"Healthy"},"sync":{"status":"Synced"}}},
+  {"metadata":{"name":"acme-ui"},"spec":{"project":"tenant-acme"},"status":{}}
+]}`
+
+// newTenantCluster stands in for a cluster with the single-tenant install and the tenants acme and globex
+func newTenantCluster() *executor.MockCommandExecutor {
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("helm list", &executor.CommandResult{Stdout: "app-of-apps-globex\napp-of-apps\napp-of-apps-acme\napp-of-apps-\n"})
+	mockExec.SetResponse("helm status", &executor.CommandResult{Stdout: `{"name":"app-of-apps-acme","info":{"status":"deployed"}}`})
+	mockExec.SetResponse("get applications.argoproj.io", &executor.CommandResult{Stdout: tenantApplicationsJSON})
+	mockExec.SetResponse("get namespaces -l openframe.io/tenant=acme", &executor.CommandResult{Stdout: "acme-ui acme-api"})
+	return mockExec
+}
+
+func TestTenantManager_List(t *testing.T) {
+	tenants, err := NewTenantManager(newTenantCluster()).List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []TenantStatus{
+		{ID: "", Release: "app-of-apps", ReleaseStatus: "deployed"},
+		{ID: "acme", Release: "app-of-apps-acme", ReleaseStatus: "deployed"},
+		{ID: "globex", Release: "app-of-apps-globex", ReleaseStatus: "deployed"},
+	}, tenants)
+}
+
+func TestTenantManager_Status(t *testing.T) {
+	status, err := NewTenantManager(newTenantCluster()).Status(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Equal(t, &TenantStatus{
+		ID:            "acme",
+		Release:       "app-of-apps-acme",
+		ReleaseStatus: "deployed",
+		Project:       "tenant-acme",
+		Namespaces:    []string{"acme-api", "acme-ui"},
+		Applications: []TenantApplication{
+			{Name: "acme-api", Health: "Healthy", Sync: "Synced"},
+			{Name: "acme-ui", Health: "Unknown", Sync: "Unknown"},
+		},
+	}, status, "other tenants' applications and namespaces are left out")
+
+	_, err = NewTenantManager(newTenantCluster()).Status(context.Background(), "initech")
+	assert.EqualError(t, err, "tenant initech is not installed (no app-of-apps-initech release in the argocd namespace)")
+}
+
+func TestTenantManager_Uninstall(t *testing.T) {
+	mockExec := newTenantCluster()
+	require.NoError(t, NewTenantManager(mockExec).Uninstall(context.Background(), "acme"))
+
+	for _, command := range []string{
+		"helm uninstall app-of-apps-acme -n argocd --wait",
+		"kubectl -n argocd delete applications.argoproj.io --wait acme-api acme-ui",
+		"kubectl -n argocd delete appprojects.argoproj.io tenant-acme --ignore-not-found",
+		"kubectl delete namespaces -l openframe.io/tenant=acme --ignore-not-found",
+	} {
+		assert.True(t, mockExec.WasCommandExecuted(command), command)
+	}
+	assert.False(t, mockExec.WasCommandExecuted("globex"), "other tenants are left alone")
+}
+
+func TestTenantProjectManifest(t *testing.T) {
+	manifest := tenantProjectManifest("acme")
+	assert.Contains(t, manifest, "name: tenant-acme\n  namespace: argocd")
+	assert.Contains(t, manifest, "openframe.io/tenant: acme")
+	assert.Contains(t, manifest, `namespace: "acme-*"`)
+	assert.Contains(t, manifest, "clusterResourceWhitelist:\n    - group: \"\"\n      kind: Namespace\n")
+	assert.NotContains(t, manifest, `kind: "*"`, "tenants must not create arbitrary cluster resources")
+
+	mockExec := executor.NewMockCommandExecutor()
+	require.NoError(t, NewTenantManager(mockExec).EnsureProject(context.Background(), "acme"))
+	assert.Equal(t, "kubectl apply -f -", mockExec.GetLastCommand())
+}
@@ -1,10 +1,13 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/pterm/pterm"
 )
 
 // ConfigurationValidator validates helm-values.yaml for non-interactive mode
@@ -37,7 +40,7 @@ func (v *ConfigurationValidator) ValidateConfiguration(config *types.ChartConfig
 }
 
 // validateOSSConfiguration validates OSS deployment configuration
-func (v *ConfigurationValidator) validateOSSConfiguration(values map[string]interface{}) error {
+func (v *ConfigurationValidator) validateOSSConfiguration(values *helmvalues.Document) error {
 	// Check OSS deployment is enabled
 	if !v.isDeploymentEnabled(values, "oss") {
 		return fmt.Errorf("OSS deployment must be enabled in helm-values.yaml")
@@ -47,7 +50,7 @@ func (v *ConfigurationValidator) validateOSSConfiguration(values map[string]inte
 }
 
 // validateSaaSConfiguration validates SaaS deployment configuration
-func (v *ConfigurationValidator) validateSaaSConfiguration(values map[string]interface{}) error {
+func (v *ConfigurationValidator) validateSaaSConfiguration(values *helmvalues.Document) error {
 	// Check SaaS deployment is enabled
 	if !v.isDeploymentEnabled(values, "saas") {
 		return fmt.Errorf("SaaS deployment must be enabled in helm-values.yaml")
@@ -67,7 +70,7 @@ func (v *ConfigurationValidator) validateSaaSConfiguration(values map[string]int
 }
 
 // validateSaaSSharedConfiguration validates SaaS Shared deployment configuration
-func (v *ConfigurationValidator) validateSaaSSharedConfiguration(values map[string]interface{}) error {
+func (v *ConfigurationValidator) validateSaaSSharedConfiguration(values *helmvalues.Document) error {
 	// Check SaaS deployment is enabled
 	if !v.isDeploymentEnabled(values, "saas") {
 		return fmt.Errorf("SaaS deployment must be enabled in helm-values.yaml")
@@ -86,58 +89,85 @@ func (v *ConfigurationValidator) validateSaaSSharedConfiguration(values map[stri
 	return nil
 }
 
-// Helper validation methods
+// ValidateValues checks helm values against the built-in app-of-apps schema
+func (v *ConfigurationValidator) ValidateValues(values *helmvalues.Document) error {
+	return helmvalues.DefaultSchema().Validate(values)
+}
 
-// isDeploymentEnabled checks if a deployment type is enabled
-func (v *ConfigurationValidator) isDeploymentEnabled(values map[string]interface{}, deploymentType string) bool {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if section, ok := deployment[deploymentType].(map[string]interface{}); ok {
-			if enabled, ok := section["enabled"].(bool); ok {
-				return enabled
-			}
-		}
+// ValidateValuesFile checks a values file against schema, reporting every violation with its position
+func (v *ConfigurationValidator) ValidateValuesFile(path string, schema *helmvalues.Schema) error {
+	values, err := helmvalues.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
 	}
-	return false
+	if err := schema.Validate(values); err != nil {
+		return fmt.Errorf("%s does not match the values schema: %w", path, err)
+	}
+	return nil
 }
 
-// hasBranch checks if a deployment type has a branch configured
-func (v *ConfigurationValidator) hasBranch(values map[string]interface{}, deploymentType string) bool {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if section, ok := deployment[deploymentType].(map[string]interface{}); ok {
-			if repository, ok := section["repository"].(map[string]interface{}); ok {
-				if branch, ok := repository["branch"].(string); ok {
-					return strings.TrimSpace(branch) != ""
-				}
-			}
+// VerifyCredentials checks the credentials in values against the registries, ngrok and git
+// before anything is installed, reporting each check
+func (v *ConfigurationValidator) VerifyCredentials(ctx context.Context, values *helmvalues.Document, verifier *CredentialVerifier) error {
+	pterm.Info.Println("Verifying credentials...")
+
+	var failed []string
+	for _, result := range verifier.Verify(ctx, values) {
+		switch {
+		case result.Skipped:
+			pterm.Printf("  - %s: %s skipped\n", result.Check, result.Target)
+		case result.Err != nil:
+			pterm.Printf("  %s %s: %s: %v\n", pterm.Red("✗"), result.Check, result.Target, result.Err)
+			failed = append(failed, result.Check)
+		default:
+			pterm.Printf("  %s %s: %s\n", pterm.Green("✓"), result.Check, result.Target)
 		}
 	}
-	return false
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d credential check(s) failed, fix the values or skip them with --skip-verify=%s", len(failed), strings.Join(uniqueStrings(failed), ","))
+	}
+	return nil
 }
 
-// hasPassword checks if a deployment type has a password configured
-func (v *ConfigurationValidator) hasPassword(values map[string]interface{}, deploymentType, passwordType string) bool {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if section, ok := deployment[deploymentType].(map[string]interface{}); ok {
-			if passwordSection, ok := section[passwordType].(map[string]interface{}); ok {
-				if password, ok := passwordSection["password"].(string); ok {
-					return strings.TrimSpace(password) != ""
-				}
-			}
+// uniqueStrings returns items without repetitions, keeping the first occurrence of each
+func uniqueStrings(items []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
 		}
 	}
-	return false
+	return unique
+}
+
+// Helper validation methods
+
+// isDeploymentEnabled checks if a deployment type is enabled
+func (v *ConfigurationValidator) isDeploymentEnabled(values *helmvalues.Document, deploymentType string) bool {
+	enabled, _ := values.GetBool("deployment." + deploymentType + ".enabled")
+	return enabled
+}
+
+// hasBranch checks if a deployment type has a branch configured
+func (v *ConfigurationValidator) hasBranch(values *helmvalues.Document, deploymentType string) bool {
+	return v.hasValue(values, "deployment."+deploymentType+".repository.branch")
+}
+
+// hasPassword checks if a deployment type has a password configured
+func (v *ConfigurationValidator) hasPassword(values *helmvalues.Document, deploymentType, passwordType string) bool {
+	return v.hasValue(values, "deployment."+deploymentType+"."+passwordType+".password")
 }
 
 // hasGHCRCredentials checks if GHCR registry credentials are configured
-func (v *ConfigurationValidator) hasGHCRCredentials(values map[string]interface{}) bool {
-	if registry, ok := values["registry"].(map[string]interface{}); ok {
-		if ghcr, ok := registry["ghcr"].(map[string]interface{}); ok {
-			username, hasUsername := ghcr["username"].(string)
-			password, hasPassword := ghcr["password"].(string)
-			return hasUsername && hasPassword &&
-				strings.TrimSpace(username) != "" &&
-				strings.TrimSpace(password) != ""
-		}
-	}
-	return false
+func (v *ConfigurationValidator) hasGHCRCredentials(values *helmvalues.Document) bool {
+	return v.hasValue(values, "registry.ghcr.username") && v.hasValue(values, "registry.ghcr.password")
+}
+
+// hasValue checks if path holds a non-blank string
+func (v *ConfigurationValidator) hasValue(values *helmvalues.Document, path string) bool {
+	value, ok := values.GetString(path)
+	return ok && strings.TrimSpace(value) != ""
 }
@@ -0,0 +1,594 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+)
+
+// Answers holds the replies to the configuration wizard questions, so that chart install
+// can run without prompts. Sections that are left out keep the values they already have.
+type Answers struct {
+	DeploymentMode string           `yaml:"deploymentMode,omitempty"` // oss-tenant, saas-tenant or saas-shared
+	Branch         string           `yaml:"branch,omitempty"`         // OSS repository branch, in every mode
+	Docker         *RegistryAnswers `yaml:"docker,omitempty"`         // Docker Hub credentials, OSS only
+	Ingress        *IngressAnswers  `yaml:"ingress,omitempty"`
+	SaaS           *SaaSAnswers     `yaml:"saas,omitempty"`
+}
+
+// RegistryAnswers holds container registry credentials
+type RegistryAnswers struct {
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	Email    string `yaml:"email,omitempty"`
+}
+
+// IngressAnswers selects the ingress and holds the settings of the selected type
+type IngressAnswers struct {
+	Type   string               `yaml:"type,omitempty"` // localhost, ngrok, custom or gcp
+	Ngrok  *NgrokAnswers        `yaml:"ngrok,omitempty"`
+	Custom *CustomDomainAnswers `yaml:"custom,omitempty"`
+	GCP    *GCPAnswers          `yaml:"gcp,omitempty"`
+}
+
+// NgrokAnswers holds the ngrok ingress settings
+type NgrokAnswers struct {
+	Domain     string   `yaml:"domain,omitempty"`
+	APIKey     string   `yaml:"apiKey,omitempty"`
+	AuthToken  string   `yaml:"authtoken,omitempty"`
+	AllowedIPs []string `yaml:"allowedIPs,omitempty"` // CIDRs, empty allows all IPs
+}
+
+// CustomDomainAnswers holds the custom domain ingress settings
+type CustomDomainAnswers struct {
+	Host          string `yaml:"host,omitempty"`
+	TLSSource     string `yaml:"tlsSource,omitempty"` // secret, files or local-ca
+	TLSSecretName string `yaml:"tlsSecretName,omitempty"`
+	CertFile      string `yaml:"certFile,omitempty"` // Required for the files source
+	KeyFile       string `yaml:"keyFile,omitempty"`
+}
+
+// GCPAnswers holds the gcp ingress settings
+type GCPAnswers struct {
+	TenantID string `yaml:"tenantID,omitempty"`
+}
+
+// SaaSAnswers holds the settings of the saas-tenant and saas-shared modes
+type SaaSAnswers struct {
+	RepositoryPassword string           `yaml:"repositoryPassword,omitempty"`
+	Branch             string           `yaml:"branch,omitempty"` // SaaS repository branch
+	GHCR               *RegistryAnswers `yaml:"ghcr,omitempty"`
+}
+
+// answerEnvVars maps the environment variables to the answers they set. They take
+// precedence over the answers file.
+var answerEnvVars = []struct {
+	name  string
+	field func(a *Answers) *string
+}{
+	{"OPENFRAME_DEPLOYMENT_MODE", func(a *Answers) *string { return &a.DeploymentMode }},
+	{"OPENFRAME_BRANCH", func(a *Answers) *string { return &a.Branch }},
+	{"OPENFRAME_DOCKER_USERNAME", func(a *Answers) *string { return &a.docker().Username }},
+	{"OPENFRAME_DOCKER_PASSWORD", func(a *Answers) *string { return &a.docker().Password }},
+	{"OPENFRAME_DOCKER_EMAIL", func(a *Answers) *string { return &a.docker().Email }},
+	{"OPENFRAME_INGRESS", func(a *Answers) *string { return &a.ingress().Type }},
+	{"OPENFRAME_NGROK_DOMAIN", func(a *Answers) *string { return &a.ngrok().Domain }},
+	{"OPENFRAME_NGROK_API_KEY", func(a *Answers) *string { return &a.ngrok().APIKey }},
+	{"OPENFRAME_NGROK_AUTHTOKEN", func(a *Answers) *string { return &a.ngrok().AuthToken }},
+	{"OPENFRAME_CUSTOM_HOST", func(a *Answers) *string { return &a.custom().Host }},
+	{"OPENFRAME_CUSTOM_TLS_SOURCE", func(a *Answers) *string { return &a.custom().TLSSource }},
+	{"OPENFRAME_CUSTOM_TLS_SECRET", func(a *Answers) *string { return &a.custom().TLSSecretName }},
+	{"OPENFRAME_CUSTOM_CERT_FILE", func(a *Answers) *string { return &a.custom().CertFile }},
+	{"OPENFRAME_CUSTOM_KEY_FILE", func(a *Answers) *string { return &a.custom().KeyFile }},
+	{"OPENFRAME_GCP_TENANT_ID", func(a *Answers) *string { return &a.gcp().TenantID }},
+	{"OPENFRAME_SAAS_REPOSITORY_PASSWORD", func(a *Answers) *string { return &a.saas().RepositoryPassword }},
+	{"OPENFRAME_SAAS_BRANCH", func(a *Answers) *string { return &a.saas().Branch }},
+	{"OPENFRAME_GHCR_USERNAME", func(a *Answers) *string { return &a.ghcr().Username }},
+	{"OPENFRAME_GHCR_PASSWORD", func(a *Answers) *string { return &a.ghcr().Password }},
+	{"OPENFRAME_GHCR_EMAIL", func(a *Answers) *string { return &a.ghcr().Email }},
+}
+
+// allowedIPsEnvVar holds the ngrok allowlist as comma-separated CIDRs
+const allowedIPsEnvVar = "OPENFRAME_NGROK_ALLOWED_IPS"
+
+// LoadAnswers reads an answers file, decrypting ENC[age:...] values and SOPS encrypted
+// files when decrypter is set. Unknown keys are rejected so that typos are not ignored.
+func LoadAnswers(path string, decrypter templates.ValuesDecrypter) (*Answers, error) {
+	doc, err := helmvalues.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answers file: %w", err)
+	}
+	if decrypter != nil {
+		layers := []helmvalues.Layer{{Name: path, Values: doc}}
+		if err := decrypter.DecryptLayers(context.Background(), layers); err != nil {
+			return nil, fmt.Errorf("failed to decrypt answers file: %w", err)
+		}
+		doc = layers[0].Values
+	}
+
+	data, err := doc.Bytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+	answers := &Answers{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(answers); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid answers file %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// ApplyEnv overrides the answers with the OPENFRAME_* environment variables that are set
+func (a *Answers) ApplyEnv() {
+	for _, envVar := range answerEnvVars {
+		if value := strings.TrimSpace(os.Getenv(envVar.name)); value != "" {
+			*envVar.field(a) = value
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv(allowedIPsEnvVar)); value != "" {
+		a.ngrok().AllowedIPs = nil
+		for _, cidr := range strings.Split(value, ",") {
+			if cidr = strings.TrimSpace(cidr); cidr != "" {
+				a.ngrok().AllowedIPs = append(a.ngrok().AllowedIPs, cidr)
+			}
+		}
+	}
+}
+
+// HasAnswerEnv reports whether any of the OPENFRAME_* answer environment variables is set
+func HasAnswerEnv() bool {
+	for _, envVar := range answerEnvVars {
+		if os.Getenv(envVar.name) != "" {
+			return true
+		}
+	}
+	return os.Getenv(allowedIPsEnvVar) != ""
+}
+
+// Write saves the answers to path, readable by the current user alone since they can hold credentials
+func (a *Answers) Write(path string) error {
+	data, err := yaml.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("failed to marshal answers: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write answers file: %w", err)
+	}
+	return nil
+}
+
+// OmitSecrets clears the passwords, tokens and API keys, which are then taken from the
+// environment or the values files when the answers are used
+func (a *Answers) OmitSecrets() {
+	if a.Docker != nil {
+		a.Docker.Password = ""
+	}
+	if a.Ingress != nil && a.Ingress.Ngrok != nil {
+		a.Ingress.Ngrok.APIKey = ""
+		a.Ingress.Ngrok.AuthToken = ""
+	}
+	if a.SaaS != nil {
+		a.SaaS.RepositoryPassword = ""
+		if a.SaaS.GHCR != nil {
+			a.SaaS.GHCR.Password = ""
+		}
+	}
+}
+
+// AnswersFromConfiguration returns the answers that reproduce a configuration made by the wizard
+func AnswersFromConfiguration(config *types.ChartConfiguration) *Answers {
+	answers := &Answers{}
+	if config.DeploymentMode == nil {
+		return answers
+	}
+	mode := *config.DeploymentMode
+	answers.DeploymentMode = mode.Name()
+
+	if config.Branch != nil {
+		answers.Branch = *config.Branch
+	}
+
+	if mode.IsSaaS() {
+		if config.SaaSConfig != nil {
+			answers.Branch = config.SaaSConfig.OSSBranch
+			answers.SaaS = &SaaSAnswers{
+				RepositoryPassword: config.SaaSConfig.RepositoryPassword,
+				Branch:             config.SaaSConfig.SaaSBranch,
+			}
+			if config.DockerRegistry != nil {
+				answers.SaaS.GHCR = registryAnswers(config.DockerRegistry)
+			}
+		}
+	} else if config.DockerRegistry != nil {
+		answers.Docker = registryAnswers(config.DockerRegistry)
+	}
+
+	if ingress := config.IngressConfig; ingress != nil {
+		answers.Ingress = &IngressAnswers{Type: string(ingress.Type)}
+		switch ingress.Type {
+		case types.IngressTypeNgrok:
+			if ingress.NgrokConfig != nil {
+				answers.Ingress.Ngrok = &NgrokAnswers{
+					Domain:    ingress.NgrokConfig.Domain,
+					APIKey:    ingress.NgrokConfig.APIKey,
+					AuthToken: ingress.NgrokConfig.AuthToken,
+				}
+				if ingress.NgrokConfig.UseAllowedIPs {
+					answers.Ingress.Ngrok.AllowedIPs = ingress.NgrokConfig.AllowedIPs
+				}
+			}
+		case types.IngressTypeCustom:
+			if custom := ingress.CustomDomain; custom != nil {
+				answers.Ingress.Custom = &CustomDomainAnswers{
+					Host:          custom.Host,
+					TLSSource:     string(custom.TLSSource),
+					TLSSecretName: custom.TLSSecretName,
+				}
+				// The local CA issues its certificate again on every install
+				if custom.TLSSource == types.TLSSourceFiles {
+					answers.Ingress.Custom.CertFile = custom.CertFile
+					answers.Ingress.Custom.KeyFile = custom.KeyFile
+				}
+			}
+		case types.IngressTypeGCP:
+			answers.Ingress.GCP = &GCPAnswers{TenantID: ingress.GCPTenantID}
+		}
+	}
+
+	return answers
+}
+
+func registryAnswers(registry *types.DockerRegistryConfig) *RegistryAnswers {
+	return &RegistryAnswers{Username: registry.Username, Password: registry.Password, Email: registry.Email}
+}
+
+// ConfigureFromAnswers configures the helm values like the interactive wizard would with
+// the given answers. All missing or invalid answers are reported at once, before anything
+// is applied.
+func (w *ConfigurationWizard) ConfigureFromAnswers(answers *Answers) (*types.ChartConfiguration, error) {
+	config, err := w.loadBaseValues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base values: %w", err)
+	}
+
+	resolved, err := w.resolveAnswers(answers, config.ExistingValues)
+	if err != nil {
+		return nil, err
+	}
+
+	pterm.Info.Printf("Configuring Helm values for %s deployment from answers\n", string(resolved.mode))
+
+	config.DeploymentMode = &resolved.mode
+	config.ModifiedSections = append(config.ModifiedSections, "deployment")
+
+	if resolved.mode.IsSaaS() {
+		w.applySaaSSettings(config, resolved.repoPassword, resolved.ghcr, resolved.saasBranch, resolved.branch)
+	} else {
+		w.branchConfig.applyBranch(config, resolved.branch)
+		if resolved.docker != nil {
+			w.dockerConfig.applyDockerSettings(config, resolved.docker)
+		}
+	}
+
+	if resolved.ingress != nil {
+		if custom := resolved.ingress.CustomDomain; custom != nil && custom.TLSSource == types.TLSSourceLocalCA {
+			if err := issueLocalCACertificate(custom); err != nil {
+				return nil, fmt.Errorf("ingress configuration failed: %w", err)
+			}
+		}
+		if err := w.ingressConfig.applyIngress(config, resolved.ingress); err != nil {
+			return nil, fmt.Errorf("ingress configuration failed: %w", err)
+		}
+	}
+
+	if err := w.createTemporaryValuesFile(config); err != nil {
+		return nil, fmt.Errorf("failed to create temporary values file: %w", err)
+	}
+
+	return config, nil
+}
+
+// resolvedAnswers are the answers checked and completed with the current values
+type resolvedAnswers struct {
+	mode         types.DeploymentMode
+	branch       string
+	docker       *types.DockerRegistryConfig
+	ingress      *types.IngressConfig
+	repoPassword string
+	saasBranch   string
+	ghcr         *types.DockerRegistryConfig
+}
+
+// answerProblems collects the missing and invalid answers of a run
+type answerProblems []string
+
+func (p *answerProblems) missing(key, envVar string) {
+	*p = append(*p, fmt.Sprintf("%s (%s) is required", key, envVar))
+}
+
+func (p *answerProblems) invalid(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+// resolveAnswers checks the answers for the deployment mode, falling back to the current
+// values for optional answers and for credentials that are already set
+func (w *ConfigurationWizard) resolveAnswers(answers *Answers, values *helmvalues.Document) (*resolvedAnswers, error) {
+	var problems answerProblems
+	resolved := &resolvedAnswers{}
+
+	if answers.DeploymentMode == "" {
+		problems.missing("deploymentMode", "OPENFRAME_DEPLOYMENT_MODE")
+	} else if mode, err := types.ParseDeploymentMode(answers.DeploymentMode); err != nil {
+		problems.invalid("deploymentMode (OPENFRAME_DEPLOYMENT_MODE): %s, expected oss-tenant, saas-tenant or saas-shared", answers.DeploymentMode)
+	} else {
+		resolved.mode = mode
+	}
+
+	resolved.branch = answerOr(answers.Branch, w.modifier.GetCurrentOSSBranch(values))
+
+	if resolved.mode.IsSaaS() {
+		if answers.Docker != nil {
+			problems.invalid("docker (OPENFRAME_DOCKER_*) is not used by %s, set the registry credentials in saas.ghcr (OPENFRAME_GHCR_*)", resolved.mode.Name())
+		}
+		w.resolveSaaSAnswers(answers.SaaS, values, resolved, &problems)
+	} else if resolved.mode != "" {
+		if answers.SaaS != nil {
+			problems.invalid("saas (OPENFRAME_SAAS_*, OPENFRAME_GHCR_*) is only used by saas-tenant and saas-shared")
+		}
+		if answers.Docker != nil {
+			resolved.docker = resolveRegistryAnswers(answers.Docker, w.modifier.GetCurrentDockerSettings(values), "docker", "OPENFRAME_DOCKER", &problems)
+		}
+	}
+
+	if answers.Ingress != nil && resolved.mode != "" {
+		resolved.ingress = w.resolveIngressAnswers(answers.Ingress, resolved.mode, values, &problems)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("missing or invalid answers:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return resolved, nil
+}
+
+// resolveSaaSAnswers checks the repository token and GHCR credentials the SaaS modes require
+func (w *ConfigurationWizard) resolveSaaSAnswers(saas *SaaSAnswers, values *helmvalues.Document, resolved *resolvedAnswers, problems *answerProblems) {
+	if saas == nil {
+		saas = &SaaSAnswers{}
+	}
+
+	resolved.repoPassword = answerOr(saas.RepositoryPassword, w.modifier.GetSaaSRepositoryPassword(values))
+	if resolved.repoPassword == "" {
+		problems.missing("saas.repositoryPassword", "OPENFRAME_SAAS_REPOSITORY_PASSWORD")
+	}
+	resolved.saasBranch = answerOr(saas.Branch, w.modifier.GetCurrentSaaSBranch(values))
+
+	current := &types.DockerRegistryConfig{Username: "default", Email: "default@example.com"}
+	if username, ok := values.GetString("registry.ghcr.username"); ok && username != "" {
+		current.Username = username
+	}
+	if password, ok := values.GetString("registry.ghcr.password"); ok {
+		current.Password = password
+	}
+	if email, ok := values.GetString("registry.ghcr.email"); ok && email != "" {
+		current.Email = email
+	}
+	ghcr := saas.GHCR
+	if ghcr == nil {
+		ghcr = &RegistryAnswers{}
+	}
+	resolved.ghcr = resolveRegistryAnswers(ghcr, current, "saas.ghcr", "OPENFRAME_GHCR", problems)
+}
+
+// resolveRegistryAnswers completes registry credentials with the current ones, a password is required
+func resolveRegistryAnswers(registry *RegistryAnswers, current *types.DockerRegistryConfig, key, envPrefix string, problems *answerProblems) *types.DockerRegistryConfig {
+	resolved := &types.DockerRegistryConfig{
+		Username: answerOr(registry.Username, current.Username),
+		Password: answerOr(registry.Password, current.Password),
+		Email:    answerOr(registry.Email, current.Email),
+	}
+	if resolved.Password == "" || resolved.Password == "****" {
+		problems.missing(key+".password", envPrefix+"_PASSWORD")
+	}
+	return resolved
+}
+
+// resolveIngressAnswers checks the ingress type against the deployment mode and the settings it needs
+func (w *ConfigurationWizard) resolveIngressAnswers(ingress *IngressAnswers, mode types.DeploymentMode, values *helmvalues.Document, problems *answerProblems) *types.IngressConfig {
+	allowed := []types.IngressType{types.IngressTypeLocalhost, types.IngressTypeNgrok, types.IngressTypeCustom}
+	if mode.IsSaaS() {
+		allowed = []types.IngressType{types.IngressTypeLocalhost, types.IngressTypeGCP}
+	}
+
+	if ingress.Type == "" {
+		problems.missing("ingress.type", "OPENFRAME_INGRESS")
+		return nil
+	}
+	ingressType := types.IngressType(ingress.Type)
+	supported := false
+	names := make([]string, len(allowed))
+	for idx, candidate := range allowed {
+		names[idx] = string(candidate)
+		supported = supported || candidate == ingressType
+	}
+	if !supported {
+		problems.invalid("ingress.type (OPENFRAME_INGRESS): %s is not available for %s, expected %s", ingress.Type, mode.Name(), strings.Join(names, ", "))
+		return nil
+	}
+
+	config := &types.IngressConfig{Type: ingressType}
+	switch ingressType {
+	case types.IngressTypeNgrok:
+		config.NgrokConfig = w.resolveNgrokAnswers(ingress.Ngrok, values, problems)
+	case types.IngressTypeCustom:
+		config.CustomDomain = w.resolveCustomDomainAnswers(ingress.Custom, values, problems)
+	case types.IngressTypeGCP:
+		config.GCPTenantID = defaultGCPTenantID
+		if ingress.GCP != nil {
+			config.GCPTenantID = answerOr(ingress.GCP.TenantID, defaultGCPTenantID)
+		}
+	}
+	return config
+}
+
+// resolveNgrokAnswers checks the ngrok domain, credentials and allowlist
+func (w *ConfigurationWizard) resolveNgrokAnswers(ngrok *NgrokAnswers, values *helmvalues.Document, problems *answerProblems) *types.NgrokConfig {
+	if ngrok == nil {
+		ngrok = &NgrokAnswers{}
+	}
+	current := w.ingressConfig.getCurrentNgrokSettings(values)
+
+	config := &types.NgrokConfig{
+		Domain:    answerOr(ngrok.Domain, current.Domain),
+		APIKey:    answerOr(ngrok.APIKey, current.APIKey),
+		AuthToken: answerOr(ngrok.AuthToken, current.AuthToken),
+	}
+	if config.Domain == "" {
+		problems.missing("ingress.ngrok.domain", "OPENFRAME_NGROK_DOMAIN")
+	}
+	if config.APIKey == "" {
+		problems.missing("ingress.ngrok.apiKey", "OPENFRAME_NGROK_API_KEY")
+	}
+	if config.AuthToken == "" {
+		problems.missing("ingress.ngrok.authtoken", "OPENFRAME_NGROK_AUTHTOKEN")
+	}
+
+	for _, cidr := range ngrok.AllowedIPs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			problems.invalid("ingress.ngrok.allowedIPs (%s): %s is not a CIDR such as 203.0.113.0/24", allowedIPsEnvVar, cidr)
+			continue
+		}
+		config.AllowedIPs = append(config.AllowedIPs, cidr)
+	}
+	config.UseAllowedIPs = len(config.AllowedIPs) > 0
+	return config
+}
+
+// resolveCustomDomainAnswers checks the host, TLS source and secret name, and the
+// certificate files for the files source
+func (w *ConfigurationWizard) resolveCustomDomainAnswers(custom *CustomDomainAnswers, values *helmvalues.Document, problems *answerProblems) *types.CustomDomainConfig {
+	if custom == nil {
+		custom = &CustomDomainAnswers{}
+	}
+	current := w.ingressConfig.getCurrentCustomDomainSettings(values)
+
+	config := &types.CustomDomainConfig{}
+	host := answerOr(custom.Host, current.Host)
+	if host == "" {
+		problems.missing("ingress.custom.host", "OPENFRAME_CUSTOM_HOST")
+		return config
+	}
+	host, err := normalizeCustomHost(host)
+	if err != nil {
+		problems.invalid("ingress.custom.host (OPENFRAME_CUSTOM_HOST): %v", err)
+		return config
+	}
+	config.Host = host
+
+	switch source := types.TLSSource(custom.TLSSource); source {
+	case "":
+		problems.missing("ingress.custom.tlsSource", "OPENFRAME_CUSTOM_TLS_SOURCE")
+	case types.TLSSourceSecret, types.TLSSourceFiles, types.TLSSourceLocalCA:
+		config.TLSSource = source
+	default:
+		problems.invalid("ingress.custom.tlsSource (OPENFRAME_CUSTOM_TLS_SOURCE): %s, expected secret, files or local-ca", custom.TLSSource)
+	}
+
+	defaultSecret := current.TLSSecretName
+	if defaultSecret == "" || current.Host != host {
+		defaultSecret = defaultTLSSecretName(host)
+	}
+	config.TLSSecretName = answerOr(custom.TLSSecretName, defaultSecret)
+	if !dnsSubdomainPattern.MatchString(config.TLSSecretName) {
+		problems.invalid("ingress.custom.tlsSecretName (OPENFRAME_CUSTOM_TLS_SECRET): invalid secret name %q, use lowercase letters, digits, '-' and '.'", config.TLSSecretName)
+	}
+
+	if config.TLSSource == types.TLSSourceFiles {
+		config.CertFile = custom.CertFile
+		config.KeyFile = custom.KeyFile
+		if config.CertFile == "" {
+			problems.missing("ingress.custom.certFile", "OPENFRAME_CUSTOM_CERT_FILE")
+		}
+		if config.KeyFile == "" {
+			problems.missing("ingress.custom.keyFile", "OPENFRAME_CUSTOM_KEY_FILE")
+		}
+		if config.CertFile != "" && config.KeyFile != "" {
+			if err := validateCertificateFiles(config.CertFile, config.KeyFile, host, time.Now()); err != nil {
+				problems.invalid("ingress.custom.certFile: %v", err)
+			}
+		}
+	}
+	return config
+}
+
+// answerOr returns the trimmed answer, or fallback when it is empty
+func answerOr(answer, fallback string) string {
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return fallback
+}
+
+func (a *Answers) docker() *RegistryAnswers {
+	if a.Docker == nil {
+		a.Docker = &RegistryAnswers{}
+	}
+	return a.Docker
+}
+
+func (a *Answers) ingress() *IngressAnswers {
+	if a.Ingress == nil {
+		a.Ingress = &IngressAnswers{}
+	}
+	return a.Ingress
+}
+
+func (a *Answers) ngrok() *NgrokAnswers {
+	if a.ingress().Ngrok == nil {
+		a.Ingress.Ngrok = &NgrokAnswers{}
+	}
+	return a.Ingress.Ngrok
+}
+
+func (a *Answers) custom() *CustomDomainAnswers {
+	if a.ingress().Custom == nil {
+		a.Ingress.Custom = &CustomDomainAnswers{}
+	}
+	return a.Ingress.Custom
+}
+
+func (a *Answers) gcp() *GCPAnswers {
+	if a.ingress().GCP == nil {
+		a.Ingress.GCP = &GCPAnswers{}
+	}
+	return a.Ingress.GCP
+}
+
+func (a *Answers) saas() *SaaSAnswers {
+	if a.SaaS == nil {
+		a.SaaS = &SaaSAnswers{}
+	}
+	return a.SaaS
+}
+
+func (a *Answers) ghcr() *RegistryAnswers {
+	if a.saas().GHCR == nil {
+		a.SaaS.GHCR = &RegistryAnswers{}
+	}
+	return a.SaaS.GHCR
+}
@@ -0,0 +1,256 @@
+package configuration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsealDecrypter strips the "sealed:" prefix of string values, standing in for age
+type unsealDecrypter struct{}
+
+func (unsealDecrypter) DecryptLayers(ctx context.Context, layers []helmvalues.Layer) error {
+	for _, layer := range layers {
+		for _, path := range layer.Values.StringPaths() {
+			value, _ := layer.Values.GetString(path)
+			if strings.HasPrefix(value, "sealed:") {
+				if err := layer.Values.Set(path, strings.TrimPrefix(value, "sealed:")); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// inTempDir runs the test in a temporary directory, where the temporary values file is written
+func inTempDir(t *testing.T) string {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return dir
+}
+
+func writeAnswersFile(t *testing.T, dir, content string) string {
+	path := filepath.Join(dir, "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadAnswers(t *testing.T) {
+	dir := t.TempDir()
+	path := writeAnswersFile(t, dir, `deploymentMode: oss-tenant
+branch: develop
+ingress:
+  type: ngrok
+  ngrok:
+    domain: demo.ngrok.app
+    apiKey: sealed:api-key
+    authtoken: token
+    allowedIPs: [203.0.113.0/24]
+`)
+
+	answers, err := LoadAnswers(path, unsealDecrypter{})
+	require.NoError(t, err)
+	assert.Equal(t, "oss-tenant", answers.DeploymentMode)
+	assert.Equal(t, "develop", answers.Branch)
+	require.NotNil(t, answers.Ingress)
+	assert.Equal(t, "api-key", answers.Ingress.Ngrok.APIKey, "encrypted answers are decrypted")
+	assert.Equal(t, []string{"203.0.113.0/24"}, answers.Ingress.Ngrok.AllowedIPs)
+
+	empty, err := LoadAnswers(writeAnswersFile(t, t.TempDir(), ""), nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Answers{}, empty)
+
+	_, err = LoadAnswers(writeAnswersFile(t, t.TempDir(), "deploymentmode: oss-tenant\n"), nil)
+	require.Error(t, err, "unknown keys are rejected")
+	assert.Contains(t, err.Error(), "deploymentmode")
+}
+
+func TestAnswers_ApplyEnv(t *testing.T) {
+	t.Setenv("OPENFRAME_DEPLOYMENT_MODE", "saas-tenant")
+	t.Setenv("OPENFRAME_GHCR_PASSWORD", "ghcr-token")
+	t.Setenv("OPENFRAME_NGROK_ALLOWED_IPS", "10.0.0.0/8, 192.168.0.0/16,")
+
+	answers := &Answers{DeploymentMode: "oss-tenant", Branch: "develop"}
+	answers.ApplyEnv()
+
+	assert.Equal(t, "saas-tenant", answers.DeploymentMode, "environment variables take precedence over the file")
+	assert.Equal(t, "develop", answers.Branch, "unset variables keep the file answers")
+	assert.Equal(t, "ghcr-token", answers.SaaS.GHCR.Password)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.0/16"}, answers.Ingress.Ngrok.AllowedIPs)
+	assert.Nil(t, answers.Docker, "sections without variables stay unanswered")
+	assert.True(t, HasAnswerEnv())
+}
+
+func TestConfigurationWizard_ConfigureFromAnswers_OSS(t *testing.T) {
+	dir := inTempDir(t)
+	base := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(`deployment:
+  oss:
+    enabled: false
+    repository:
+      branch: main
+    ingress:
+      localhost:
+        enabled: true
+`), 0644))
+
+	wizard := NewConfigurationWizard().WithValueLayers([]string{base}, nil)
+	config, err := wizard.ConfigureFromAnswers(&Answers{
+		DeploymentMode: "oss-tenant",
+		Branch:         "develop",
+		Docker:         &RegistryAnswers{Username: "octocat", Password: "docker-token"},
+		Ingress: &IngressAnswers{
+			Type:  "ngrok",
+			Ngrok: &NgrokAnswers{Domain: "demo.ngrok.app", APIKey: "api-key", AuthToken: "token", AllowedIPs: []string{"203.0.113.0/24"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "helm-values-tmp.yaml", config.TempHelmValuesPath)
+	assert.ElementsMatch(t, []string{"deployment", "branch", "docker", "ingress"}, config.ModifiedSections)
+
+	values, err := helmvalues.Load(filepath.Join(dir, config.TempHelmValuesPath))
+	require.NoError(t, err)
+	for path, expected := range map[string]interface{}{
+		"deployment.oss.enabled":                             true,
+		"deployment.oss.repository.branch":                   "develop",
+		"registry.docker.username":                           "octocat",
+		"registry.docker.password":                           "docker-token",
+		"deployment.oss.ingress.ngrok.enabled":               true,
+		"deployment.oss.ingress.localhost.enabled":           false,
+		"deployment.oss.ingress.ngrok.url":                   "demo.ngrok.app",
+		"deployment.oss.ingress.ngrok.credentials.apiKey":    "api-key",
+		"deployment.oss.ingress.ngrok.credentials.authtoken": "token",
+	} {
+		value, ok := values.Get(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, value, path)
+	}
+}
+
+func TestConfigurationWizard_ConfigureFromAnswers_SaaSFallsBackToValues(t *testing.T) {
+	dir := inTempDir(t)
+	base := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(`deployment:
+  saas:
+    repository:
+      password: repo-token
+      branch: release
+registry:
+  ghcr:
+    username: octocat
+    password: ghcr-token
+`), 0644))
+
+	wizard := NewConfigurationWizard().WithValueLayers([]string{base}, nil)
+	config, err := wizard.ConfigureFromAnswers(&Answers{
+		DeploymentMode: "saas-shared",
+		Ingress:        &IngressAnswers{Type: "gcp"},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, config.SaaSConfig)
+	assert.Equal(t, "repo-token", config.SaaSConfig.RepositoryPassword)
+	assert.Equal(t, "release", config.SaaSConfig.SaaSBranch)
+	assert.Equal(t, "main", config.SaaSConfig.OSSBranch)
+	assert.Equal(t, &types.DockerRegistryConfig{Username: "octocat", Password: "ghcr-token", Email: "default@example.com"}, config.DockerRegistry)
+	assert.Equal(t, defaultGCPTenantID, config.IngressConfig.GCPTenantID)
+}
+
+func TestConfigurationWizard_ConfigureFromAnswers_ReportsAllProblems(t *testing.T) {
+	inTempDir(t)
+	wizard := NewConfigurationWizard()
+
+	_, err := wizard.ConfigureFromAnswers(&Answers{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deploymentMode (OPENFRAME_DEPLOYMENT_MODE) is required")
+
+	_, err = wizard.ConfigureFromAnswers(&Answers{
+		DeploymentMode: "oss-tenant",
+		Ingress:        &IngressAnswers{Type: "ngrok", Ngrok: &NgrokAnswers{AllowedIPs: []string{"10.0.0.1"}}},
+	})
+	require.Error(t, err)
+	for _, problem := range []string{
+		"ingress.ngrok.domain (OPENFRAME_NGROK_DOMAIN) is required",
+		"ingress.ngrok.apiKey (OPENFRAME_NGROK_API_KEY) is required",
+		"ingress.ngrok.authtoken (OPENFRAME_NGROK_AUTHTOKEN) is required",
+		"10.0.0.1 is not a CIDR",
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+
+	_, err = wizard.ConfigureFromAnswers(&Answers{
+		DeploymentMode: "saas-tenant",
+		Docker:         &RegistryAnswers{Username: "octocat", Password: "token"},
+		Ingress:        &IngressAnswers{Type: "ngrok"},
+	})
+	require.Error(t, err)
+	for _, problem := range []string{
+		"docker (OPENFRAME_DOCKER_*) is not used by saas-tenant",
+		"saas.repositoryPassword (OPENFRAME_SAAS_REPOSITORY_PASSWORD) is required",
+		"saas.ghcr.password (OPENFRAME_GHCR_PASSWORD) is required",
+		"ngrok is not available for saas-tenant, expected localhost, gcp",
+	} {
+		assert.Contains(t, err.Error(), problem)
+	}
+
+	_, err = wizard.ConfigureFromAnswers(&Answers{
+		DeploymentMode: "oss-tenant",
+		Ingress:        &IngressAnswers{Type: "custom", Custom: &CustomDomainAnswers{Host: "openframe.example.com", TLSSource: "files"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ingress.custom.certFile (OPENFRAME_CUSTOM_CERT_FILE) is required")
+	assert.Contains(t, err.Error(), "ingress.custom.keyFile (OPENFRAME_CUSTOM_KEY_FILE) is required")
+
+	_, statErr := os.Stat("helm-values-tmp.yaml")
+	assert.True(t, os.IsNotExist(statErr), "nothing is written when answers are missing")
+}
+
+func TestAnswersFromConfiguration(t *testing.T) {
+	mode := types.DeploymentModeSaaS
+	config := &types.ChartConfiguration{
+		DeploymentMode: &mode,
+		SaaSConfig:     &types.SaaSConfig{RepositoryPassword: "repo-token", SaaSBranch: "release", OSSBranch: "develop"},
+		DockerRegistry: &types.DockerRegistryConfig{Username: "octocat", Password: "ghcr-token", Email: "octocat@example.com"},
+		IngressConfig:  &types.IngressConfig{Type: types.IngressTypeGCP, GCPTenantID: "acme"},
+	}
+
+	answers := AnswersFromConfiguration(config)
+	assert.Equal(t, &Answers{
+		DeploymentMode: "saas-tenant",
+		Branch:         "develop",
+		Ingress:        &IngressAnswers{Type: "gcp", GCP: &GCPAnswers{TenantID: "acme"}},
+		SaaS: &SaaSAnswers{
+			RepositoryPassword: "repo-token",
+			Branch:             "release",
+			GHCR:               &RegistryAnswers{Username: "octocat", Password: "ghcr-token", Email: "octocat@example.com"},
+		},
+	}, answers)
+
+	answers.OmitSecrets()
+	assert.Empty(t, answers.SaaS.RepositoryPassword)
+	assert.Empty(t, answers.SaaS.GHCR.Password)
+	assert.Equal(t, "octocat", answers.SaaS.GHCR.Username)
+
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, answers.Write(path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	loaded, err := LoadAnswers(path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, answers, loaded, "recorded answers load back unchanged")
+}
@@ -54,12 +54,17 @@ func (b *BranchConfigurator) Configure(config *types.ChartConfiguration) error {
 			return fmt.Errorf("branch input failed: %w", err)
 		}
 
-		branch = strings.TrimSpace(branch)
-		if branch != currentBranch {
-			config.Branch = &branch
-			config.ModifiedSections = append(config.ModifiedSections, "branch")
-		}
+		b.applyBranch(config, branch)
 	}
 
 	return nil
 }
+
+// applyBranch records the OSS repository branch when it differs from the current one
+func (b *BranchConfigurator) applyBranch(config *types.ChartConfiguration, branch string) {
+	branch = strings.TrimSpace(branch)
+	if branch != b.modifier.GetCurrentOSSBranch(config.ExistingValues) {
+		config.Branch = &branch
+		config.ModifiedSections = append(config.ModifiedSections, "branch")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +22,7 @@ func TestBranchConfigurator_Configure_KeepExisting(t *testing.T) {
 	_ = NewBranchConfigurator(modifier) // Test constructor
 
 	// Create configuration with existing values using new deployment structure
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"repository": map[string]interface{}{
@@ -29,7 +30,7 @@ func TestBranchConfigurator_Configure_KeepExisting(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	// This test would require user interaction, so we'll test the underlying logic
 	// by directly calling the modifier methods
@@ -42,7 +43,7 @@ func TestBranchConfigurator_Configure_CustomBranch(t *testing.T) {
 	_ = NewBranchConfigurator(modifier) // Test constructor
 
 	// Test the modifier can handle custom branch changes using new deployment structure
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"repository": map[string]interface{}{
@@ -50,7 +51,7 @@ func TestBranchConfigurator_Configure_CustomBranch(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	// Simulate custom branch selection for OSS deployment
 	newBranch := "develop"
@@ -67,7 +68,7 @@ func TestBranchConfigurator_Configure_CustomBranch(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify branch was updated in deployment structure
-	deployment := existingValues["deployment"].(map[string]interface{})
+	deployment := existingValues.Map()["deployment"].(map[string]interface{})
 	oss := deployment["oss"].(map[string]interface{})
 	repository := oss["repository"].(map[string]interface{})
 	assert.Equal(t, "develop", repository["branch"])
@@ -78,7 +79,7 @@ func TestBranchConfigurator_Configure_WithEmptyValues(t *testing.T) {
 	_ = NewBranchConfigurator(modifier) // Test constructor
 
 	// Test with empty values (no deployment section)
-	existingValues := map[string]interface{}{}
+	existingValues := helmvalues.New()
 
 	currentBranch := modifier.GetCurrentOSSBranch(existingValues)
 	assert.Equal(t, "main", currentBranch) // Should return default
@@ -97,7 +98,7 @@ func TestBranchConfigurator_Configure_WithEmptyValues(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify deployment structure was created
-	deployment, ok := existingValues["deployment"].(map[string]interface{})
+	deployment, ok := existingValues.Map()["deployment"].(map[string]interface{})
 	assert.True(t, ok)
 	oss, ok := deployment["oss"].(map[string]interface{})
 	assert.True(t, ok)
@@ -127,7 +128,7 @@ func TestBranchConfigurator_Configure_BranchValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			existingValues := map[string]interface{}{
+			existingValues := documentFromMap(t, map[string]interface{}{
 				"deployment": map[string]interface{}{
 					"oss": map[string]interface{}{
 						"repository": map[string]interface{}{
@@ -135,7 +136,7 @@ func TestBranchConfigurator_Configure_BranchValidation(t *testing.T) {
 						},
 					},
 				},
-			}
+			})
 
 			if tc.valid {
 				deploymentMode := types.DeploymentModeOSS
@@ -149,7 +150,7 @@ func TestBranchConfigurator_Configure_BranchValidation(t *testing.T) {
 				err := modifier.ApplyConfiguration(existingValues, config)
 				assert.NoError(t, err)
 
-				deployment := existingValues["deployment"].(map[string]interface{})
+				deployment := existingValues.Map()["deployment"].(map[string]interface{})
 				oss := deployment["oss"].(map[string]interface{})
 				repository := oss["repository"].(map[string]interface{})
 				assert.Equal(t, tc.branch, repository["branch"])
@@ -163,7 +164,7 @@ func TestBranchConfigurator_Configure_NoChanges(t *testing.T) {
 	_ = NewBranchConfigurator(modifier) // Test constructor
 
 	// Test when user keeps the same branch (no changes)
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"repository": map[string]interface{}{
@@ -171,37 +172,10 @@ func TestBranchConfigurator_Configure_NoChanges(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	// Create copy for comparison
-	originalValues := make(map[string]interface{})
-	for k, v := range existingValues {
-		if subMap, ok := v.(map[string]interface{}); ok {
-			originalSubMap := make(map[string]interface{})
-			for subK, subV := range subMap {
-				if subSubMap, ok := subV.(map[string]interface{}); ok {
-					originalSubSubMap := make(map[string]interface{})
-					for subSubK, subSubV := range subSubMap {
-						if subSubSubMap, ok := subSubV.(map[string]interface{}); ok {
-							originalSubSubSubMap := make(map[string]interface{})
-							for subSubSubK, subSubSubV := range subSubSubMap {
-								originalSubSubSubMap[subSubSubK] = subSubSubV
-							}
-							originalSubSubMap[subSubK] = originalSubSubSubMap
-						} else {
-							originalSubSubMap[subSubK] = subSubV
-						}
-					}
-					originalSubMap[subK] = originalSubSubMap
-				} else {
-					originalSubMap[subK] = subV
-				}
-			}
-			originalValues[k] = originalSubMap
-		} else {
-			originalValues[k] = v
-		}
-	}
+	originalValues := existingValues.Map()
 
 	config := &types.ChartConfiguration{
 		Branch:           nil, // No branch change
@@ -213,5 +187,5 @@ func TestBranchConfigurator_Configure_NoChanges(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Values should remain unchanged
-	assert.Equal(t, originalValues, existingValues)
+	assert.Equal(t, originalValues, existingValues.Map())
 }
@@ -0,0 +1,53 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/credentials"
+	"github.com/pterm/pterm"
+)
+
+// findRegistryCredential returns the credentials Docker already has for registry, nil when
+// there are none. A broken Docker config or helper only costs the offer, not the wizard.
+func findRegistryCredential(finder *credentials.Finder, registry string) *credentials.Credential {
+	if finder == nil {
+		return nil
+	}
+	credential, err := finder.Registry(context.Background(), registry)
+	if err != nil {
+		pterm.Warning.Printf("Could not read the Docker credentials for %s: %v\n", registry, err)
+		return nil
+	}
+	return credential
+}
+
+// findGHCRCredential returns the Docker credentials for GHCR, or else the GitHub token
+func findGHCRCredential(finder *credentials.Finder) *credentials.Credential {
+	if credential := findRegistryCredential(finder, credentials.GHCR); credential != nil {
+		return credential
+	}
+	return findGitHubToken(finder)
+}
+
+// findGitHubToken returns the GitHub token of the environment or the GitHub CLI, nil when there is none
+func findGitHubToken(finder *credentials.Finder) *credentials.Credential {
+	if finder == nil {
+		return nil
+	}
+	return finder.GitHubToken(context.Background())
+}
+
+// useFoundOption is the list option offering found credentials
+func useFoundOption(what string, credential *credentials.Credential) string {
+	if credential.Username == "" {
+		return fmt.Sprintf("Use %s from %s", what, credential.Source)
+	}
+	return fmt.Sprintf("Use %s of %s from %s", what, credential.Username, credential.Source)
+}
+
+// isUseFoundChoice tells whether the user picked the option made by useFoundOption
+func isUseFoundChoice(choice string) bool {
+	return strings.HasPrefix(choice, "Use ")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/credentials"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
 	"github.com/pterm/pterm"
@@ -13,6 +14,7 @@ import (
 // DockerConfigurator handles Docker registry configuration
 type DockerConfigurator struct {
 	modifier *templates.HelmValuesModifier
+	finder   *credentials.Finder
 }
 
 // NewDockerConfigurator creates a new Docker configurator
@@ -22,6 +24,12 @@ func NewDockerConfigurator(modifier *templates.HelmValuesModifier) *DockerConfig
 	}
 }
 
+// WithCredentialFinder makes the configurator offer Docker Hub credentials found on this machine
+func (d *DockerConfigurator) WithCredentialFinder(finder *credentials.Finder) *DockerConfigurator {
+	d.finder = finder
+	return d
+}
+
 // Configure asks user about Docker registry configuration
 func (d *DockerConfigurator) Configure(config *types.ChartConfiguration) error {
 	// Get current Docker settings from existing values
@@ -34,29 +42,49 @@ func (d *DockerConfigurator) Configure(config *types.ChartConfiguration) error {
 		"Input custom Docker credentials",
 	}
 
+	found := findRegistryCredential(d.finder, credentials.DockerHub)
+	if found != nil {
+		options = append([]string{useFoundOption("Docker Hub credentials", found)}, options...)
+	}
+
 	_, choice, err := sharedUI.SelectFromList("Docker credentials", options)
 	if err != nil {
 		return fmt.Errorf("docker choice failed: %w", err)
 	}
 
+	if found != nil && isUseFoundChoice(choice) {
+		d.applyDockerSettings(config, &types.DockerRegistryConfig{
+			Username: found.Username,
+			Password: found.Password,
+			Email:    currentDocker.Email,
+		})
+		return nil
+	}
+
 	if strings.Contains(choice, "custom") {
 		dockerConfig, err := d.promptForDockerSettings(currentDocker)
 		if err != nil {
 			return err
 		}
-
-		// Only set if values actually changed
-		if dockerConfig.Username != currentDocker.Username ||
-			dockerConfig.Password != currentDocker.Password ||
-			dockerConfig.Email != currentDocker.Email {
-			config.DockerRegistry = dockerConfig
-			config.ModifiedSections = append(config.ModifiedSections, "docker")
-		}
+		d.applyDockerSettings(config, dockerConfig)
 	}
 
 	return nil
 }
 
+// applyDockerSettings records the Docker registry credentials when they differ from the current ones
+func (d *DockerConfigurator) applyDockerSettings(config *types.ChartConfiguration, dockerConfig *types.DockerRegistryConfig) {
+	currentDocker := d.modifier.GetCurrentDockerSettings(config.ExistingValues)
+
+	// Only set if values actually changed
+	if dockerConfig.Username != currentDocker.Username ||
+		dockerConfig.Password != currentDocker.Password ||
+		dockerConfig.Email != currentDocker.Email {
+		config.DockerRegistry = dockerConfig
+		config.ModifiedSections = append(config.ModifiedSections, "docker")
+	}
+}
+
 // promptForDockerSettings prompts user for Docker registry settings
 func (d *DockerConfigurator) promptForDockerSettings(current *types.DockerRegistryConfig) (*types.DockerRegistryConfig, error) {
 	username, err := pterm.DefaultInteractiveTextInput.
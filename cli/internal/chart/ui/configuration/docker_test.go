@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +22,7 @@ func TestDockerConfigurator_Configure_DefaultCredentials(t *testing.T) {
 	_ = NewDockerConfigurator(modifier) // Test constructor
 
 	// Create configuration with existing Docker values
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"docker": map[string]interface{}{
 				"username": "default",
@@ -29,7 +30,7 @@ func TestDockerConfigurator_Configure_DefaultCredentials(t *testing.T) {
 				"email":    "default@example.com",
 			},
 		},
-	}
+	})
 
 	config := &types.ChartConfiguration{
 		ExistingValues:   existingValues,
@@ -52,7 +53,7 @@ func TestDockerConfigurator_Configure_CustomCredentials(t *testing.T) {
 	_ = NewDockerConfigurator(modifier) // Test constructor
 
 	// Test the modifier can handle custom Docker credentials
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"docker": map[string]interface{}{
 				"username": "default",
@@ -60,7 +61,7 @@ func TestDockerConfigurator_Configure_CustomCredentials(t *testing.T) {
 				"email":    "default@example.com",
 			},
 		},
-	}
+	})
 
 	// Simulate custom credentials selection
 	config := &types.ChartConfiguration{
@@ -78,7 +79,7 @@ func TestDockerConfigurator_Configure_CustomCredentials(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify Docker settings were updated
-	registry := existingValues["registry"].(map[string]interface{})
+	registry := existingValues.Map()["registry"].(map[string]interface{})
 	docker := registry["docker"].(map[string]interface{})
 	assert.Equal(t, "customuser", docker["username"])
 	assert.Equal(t, "custompass", docker["password"])
@@ -90,7 +91,7 @@ func TestDockerConfigurator_Configure_WithEmptyValues(t *testing.T) {
 	_ = NewDockerConfigurator(modifier) // Test constructor
 
 	// Test with empty values (no registry section)
-	existingValues := map[string]interface{}{}
+	existingValues := helmvalues.New()
 
 	currentDocker := modifier.GetCurrentDockerSettings(existingValues)
 	assert.Equal(t, "default", currentDocker.Username)
@@ -112,7 +113,7 @@ func TestDockerConfigurator_Configure_WithEmptyValues(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify registry and docker sections were created
-	registry, ok := existingValues["registry"].(map[string]interface{})
+	registry, ok := existingValues.Map()["registry"].(map[string]interface{})
 	assert.True(t, ok)
 	docker, ok := registry["docker"].(map[string]interface{})
 	assert.True(t, ok)
@@ -187,7 +188,7 @@ func TestDockerConfigurator_Configure_NoChangesWhenSameValues(t *testing.T) {
 	_ = NewDockerConfigurator(modifier) // Test constructor
 
 	// Test when user enters the same values as current
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"docker": map[string]interface{}{
 				"username": "sameuser",
@@ -195,29 +196,7 @@ func TestDockerConfigurator_Configure_NoChangesWhenSameValues(t *testing.T) {
 				"email":    "same@example.com",
 			},
 		},
-	}
-
-	// Create copy for comparison
-	originalValues := make(map[string]interface{})
-	for k, v := range existingValues {
-		if registry, ok := v.(map[string]interface{}); ok {
-			newRegistry := make(map[string]interface{})
-			for rk, rv := range registry {
-				if docker, ok := rv.(map[string]interface{}); ok {
-					newDocker := make(map[string]interface{})
-					for dk, dv := range docker {
-						newDocker[dk] = dv
-					}
-					newRegistry[rk] = newDocker
-				} else {
-					newRegistry[rk] = rv
-				}
-			}
-			originalValues[k] = newRegistry
-		} else {
-			originalValues[k] = v
-		}
-	}
+	})
 
 	// Simulate user entering the same values
 	config := &types.ChartConfiguration{
@@ -234,7 +213,7 @@ func TestDockerConfigurator_Configure_NoChangesWhenSameValues(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Values should be updated (even if they're the same)
-	registry := existingValues["registry"].(map[string]interface{})
+	registry := existingValues.Map()["registry"].(map[string]interface{})
 	docker := registry["docker"].(map[string]interface{})
 	assert.Equal(t, "sameuser", docker["username"])
 	assert.Equal(t, "samepass", docker["password"])
@@ -246,7 +225,7 @@ func TestDockerConfigurator_Configure_SpecialCharacters(t *testing.T) {
 	_ = NewDockerConfigurator(modifier) // Test constructor
 
 	// Test Docker credentials with special characters
-	existingValues := map[string]interface{}{}
+	existingValues := helmvalues.New()
 
 	config := &types.ChartConfiguration{
 		DockerRegistry: &types.DockerRegistryConfig{
@@ -262,7 +241,7 @@ func TestDockerConfigurator_Configure_SpecialCharacters(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify special characters are preserved
-	registry := existingValues["registry"].(map[string]interface{})
+	registry := existingValues.Map()["registry"].(map[string]interface{})
 	docker := registry["docker"].(map[string]interface{})
 	assert.Equal(t, "user@domain.com", docker["username"])
 	assert.Equal(t, "p@$$w0rd!@#$%^&*()", docker["password"])
@@ -301,7 +280,7 @@ func TestDockerConfigurator_Configure_EdgeCases(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			existingValues := map[string]interface{}{}
+			existingValues := helmvalues.New()
 
 			config := &types.ChartConfiguration{
 				DockerRegistry: &types.DockerRegistryConfig{
@@ -316,7 +295,7 @@ func TestDockerConfigurator_Configure_EdgeCases(t *testing.T) {
 			err := modifier.ApplyConfiguration(existingValues, config)
 			assert.NoError(t, err)
 
-			registry := existingValues["registry"].(map[string]interface{})
+			registry := existingValues.Map()["registry"].(map[string]interface{})
 			docker := registry["docker"].(map[string]interface{})
 			assert.Equal(t, tc.username, docker["username"])
 			assert.Equal(t, tc.password, docker["password"])
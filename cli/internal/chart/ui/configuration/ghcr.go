@@ -16,18 +16,12 @@ func (w *ConfigurationWizard) configureGHCRCredentials(config *types.ChartConfig
 	currentEmail := "default@example.com"
 	hasExistingCredentials := false
 
-	if config.ExistingValues != nil {
-		if registry, ok := config.ExistingValues["registry"].(map[string]interface{}); ok {
-			if ghcr, ok := registry["ghcr"].(map[string]interface{}); ok {
-				if username, ok := ghcr["username"].(string); ok && username != "" && username != "default" {
-					currentUsername = username
-					hasExistingCredentials = true
-				}
-				if email, ok := ghcr["email"].(string); ok && email != "" && email != "default@example.com" {
-					currentEmail = email
-				}
-			}
-		}
+	if username, ok := config.ExistingValues.GetString("registry.ghcr.username"); ok && username != "" && username != "default" {
+		currentUsername = username
+		hasExistingCredentials = true
+	}
+	if email, ok := config.ExistingValues.GetString("registry.ghcr.email"); ok && email != "" && email != "default@example.com" {
+		currentEmail = email
 	}
 
 	pterm.Info.Printf("GHCR Registry Credentials Configuration")
@@ -43,11 +37,32 @@ func (w *ConfigurationWizard) configureGHCRCredentials(config *types.ChartConfig
 		}
 	}
 
+	found := findGHCRCredential(w.finder)
+	if found != nil {
+		options = append([]string{useFoundOption("GHCR credentials", found)}, options...)
+	}
+
 	_, choice, err := sharedUI.SelectFromList("GHCR credentials", options)
 	if err != nil {
 		return "", "", "", fmt.Errorf("GHCR credentials choice failed: %w", err)
 	}
 
+	if found != nil && isUseFoundChoice(choice) {
+		username := found.Username
+		// A GitHub token without a known login still needs a username for the registry
+		if username == "" {
+			input, err := pterm.DefaultInteractiveTextInput.
+				WithDefaultValue(currentUsername).
+				WithMultiLine(false).
+				Show("GHCR Registry Username")
+			if err != nil {
+				return "", "", "", fmt.Errorf("GHCR username input failed: %w", err)
+			}
+			username = input
+		}
+		return strings.TrimSpace(username), found.Password, currentEmail, nil
+	}
+
 	// If user chooses to keep existing credentials and they exist
 	if hasExistingCredentials && strings.Contains(choice, "Keep existing") {
 		// Still need to collect password as it's not stored in plain text
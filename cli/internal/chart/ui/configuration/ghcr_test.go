@@ -3,6 +3,7 @@ package configuration
 import (
 	"testing"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -85,7 +86,7 @@ func TestConfigurationWizard_ExtractGHCRCredentials(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			config := &types.ChartConfiguration{
-				ExistingValues: tt.existingValues,
+				ExistingValues: documentFromMap(t, tt.existingValues),
 			}
 
 			// Test extraction logic (simulating internal logic of configureGHCRCredentials)
@@ -93,18 +94,12 @@ func TestConfigurationWizard_ExtractGHCRCredentials(t *testing.T) {
 			currentEmail := "default@example.com"
 			hasExistingCredentials := false
 
-			if config.ExistingValues != nil {
-				if registry, ok := config.ExistingValues["registry"].(map[string]interface{}); ok {
-					if ghcr, ok := registry["ghcr"].(map[string]interface{}); ok {
-						if username, ok := ghcr["username"].(string); ok && username != "" && username != "default" {
-							currentUsername = username
-							hasExistingCredentials = true
-						}
-						if email, ok := ghcr["email"].(string); ok && email != "" && email != "default@example.com" {
-							currentEmail = email
-						}
-					}
-				}
+			if username, ok := config.ExistingValues.GetString("registry.ghcr.username"); ok && username != "" && username != "default" {
+				currentUsername = username
+				hasExistingCredentials = true
+			}
+			if email, ok := config.ExistingValues.GetString("registry.ghcr.email"); ok && email != "" && email != "default@example.com" {
+				currentEmail = email
 			}
 
 			assert.Equal(t, tt.expectedUser, currentUsername)
@@ -126,7 +121,7 @@ func TestConfigurationWizard_GHCRCredentialsInConfig(t *testing.T) {
 			Email:    "ghcr@example.com",
 		},
 		ModifiedSections: []string{"docker"},
-		ExistingValues:   map[string]interface{}{},
+		ExistingValues:   helmvalues.New(),
 	}
 
 	assert.Equal(t, "ghcr-user", config.DockerRegistry.Username)
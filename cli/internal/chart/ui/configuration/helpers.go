@@ -75,6 +75,10 @@ func (w *ConfigurationWizard) ShowConfigurationSummary(config *types.ChartConfig
 				if config.IngressConfig.Type == types.IngressTypeNgrok && config.IngressConfig.NgrokConfig != nil {
 					pterm.Success.Printf("  - Ngrok domain: %s\n", config.IngressConfig.NgrokConfig.Domain)
 				}
+				if config.IngressConfig.Type == types.IngressTypeCustom && config.IngressConfig.CustomDomain != nil {
+					pterm.Success.Printf("  - Custom domain: %s (TLS secret %s, %s)\n", config.IngressConfig.CustomDomain.Host,
+						config.IngressConfig.CustomDomain.TLSSecretName, config.IngressConfig.CustomDomain.TLSSource)
+				}
 			}
 		}
 	}
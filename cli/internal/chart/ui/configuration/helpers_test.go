@@ -3,8 +3,10 @@ package configuration
 import (
 	"testing"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfigurationWizard_ShowConfigurationSummary_NoChanges(t *testing.T) {
@@ -13,7 +15,7 @@ func TestConfigurationWizard_ShowConfigurationSummary_NoChanges(t *testing.T) {
 	// Create configuration with no modified sections
 	config := &types.ChartConfiguration{
 		ModifiedSections: []string{},
-		ExistingValues:   map[string]interface{}{},
+		ExistingValues:   helmvalues.New(),
 	}
 
 	// Should not panic when called
@@ -40,7 +42,7 @@ func TestConfigurationWizard_ShowConfigurationSummary_WithChanges(t *testing.T)
 			Type: types.IngressTypeLocalhost,
 		},
 		ModifiedSections: []string{"deployment", "branch", "docker", "ingress"},
-		ExistingValues:   map[string]interface{}{},
+		ExistingValues:   helmvalues.New(),
 	}
 
 	// Should not panic when called
@@ -65,7 +67,7 @@ func TestConfigurationWizard_ShowConfigurationSummary_WithNgrokConfig(t *testing
 			},
 		},
 		ModifiedSections: []string{"ingress"},
-		ExistingValues:   map[string]interface{}{},
+		ExistingValues:   helmvalues.New(),
 	}
 
 	// Should not panic when called
@@ -87,7 +89,7 @@ func TestConfigurationWizard_ShowConfigurationSummary_WithSaaSConfig(t *testing.
 			OSSBranch:          "develop",
 		},
 		ModifiedSections: []string{"deployment", "saas"},
-		ExistingValues:   map[string]interface{}{},
+		ExistingValues:   helmvalues.New(),
 	}
 
 	// Should not panic when called
@@ -95,3 +97,11 @@ func TestConfigurationWizard_ShowConfigurationSummary_WithSaaSConfig(t *testing.
 		wizard.ShowConfigurationSummary(config)
 	})
 }
+
+// documentFromMap builds a values document from a map literal
+func documentFromMap(t *testing.T, values map[string]interface{}) *helmvalues.Document {
+	t.Helper()
+	doc, err := helmvalues.FromMap(values)
+	require.NoError(t, err)
+	return doc
+}
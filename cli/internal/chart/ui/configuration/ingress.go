@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
 	"github.com/pterm/pterm"
@@ -42,6 +43,7 @@ func (i *IngressConfigurator) Configure(config *types.ChartConfiguration) error
 		options = []string{
 			"Use localhost for Local only visibility",
 			"Use ngrok for External visibility",
+			"Use a custom domain with your own DNS and TLS certificate",
 		}
 	}
 
@@ -54,18 +56,22 @@ func (i *IngressConfigurator) Configure(config *types.ChartConfiguration) error
 
 	if strings.Contains(choice, "localhost") {
 		ingressConfig.Type = types.IngressTypeLocalhost
+	} else if strings.Contains(choice, "custom domain") {
+		ingressConfig.Type = types.IngressTypeCustom
 
-		// Apply localhost configuration to helm values
-		if err := i.applyLocalhostConfig(config.ExistingValues); err != nil {
-			return fmt.Errorf("failed to apply localhost configuration: %w", err)
+		customConfig, err := i.configureCustomDomain(config.ExistingValues)
+		if err != nil {
+			return fmt.Errorf("custom domain configuration failed: %w", err)
 		}
+		ingressConfig.CustomDomain = customConfig
 	} else if strings.Contains(choice, "gcp") {
 		ingressConfig.Type = types.IngressTypeGCP
 
-		// Apply GCP configuration to helm values
-		if err := i.applyGCPConfig(config.ExistingValues); err != nil {
-			return fmt.Errorf("failed to apply GCP configuration: %w", err)
+		tenantID, err := i.promptGCPTenantID()
+		if err != nil {
+			return err
 		}
+		ingressConfig.GCPTenantID = tenantID
 	} else {
 		// ngrok option (OSS deployment)
 		ingressConfig.Type = types.IngressTypeNgrok
@@ -76,11 +82,33 @@ func (i *IngressConfigurator) Configure(config *types.ChartConfiguration) error
 			return fmt.Errorf("ngrok configuration failed: %w", err)
 		}
 		ingressConfig.NgrokConfig = ngrokConfig
+	}
+
+	return i.applyIngress(config, ingressConfig)
+}
 
-		// Apply ngrok configuration to helm values
-		if err := i.applyNgrokConfig(config.ExistingValues, ngrokConfig); err != nil {
+// applyIngress applies the selected ingress to the helm values and records it in config
+func (i *IngressConfigurator) applyIngress(config *types.ChartConfiguration, ingressConfig *types.IngressConfig) error {
+	switch ingressConfig.Type {
+	case types.IngressTypeLocalhost:
+		if err := i.applyLocalhostConfig(config.ExistingValues); err != nil {
+			return fmt.Errorf("failed to apply localhost configuration: %w", err)
+		}
+	case types.IngressTypeCustom:
+		if err := i.applyCustomConfig(config.ExistingValues, ingressConfig.CustomDomain); err != nil {
+			return fmt.Errorf("failed to apply custom domain configuration: %w", err)
+		}
+	case types.IngressTypeGCP:
+		if err := applyGCPIngress(config.ExistingValues, ingressConfig.GCPTenantID); err != nil {
+			return fmt.Errorf("failed to apply GCP configuration: %w", err)
+		}
+		pterm.Success.Printf("✓ Configured GCP ingress with domain prefix: %s\n", ingressConfig.GCPTenantID)
+	case types.IngressTypeNgrok:
+		if err := i.applyNgrokConfig(config.ExistingValues, ingressConfig.NgrokConfig); err != nil {
 			return fmt.Errorf("failed to apply ngrok configuration: %w", err)
 		}
+	default:
+		return fmt.Errorf("unknown ingress type: %s", ingressConfig.Type)
 	}
 
 	config.IngressConfig = ingressConfig
@@ -90,7 +118,7 @@ func (i *IngressConfigurator) Configure(config *types.ChartConfiguration) error
 }
 
 // configureNgrok handles the complete Ngrok setup flow
-func (i *IngressConfigurator) configureNgrok(existingValues map[string]interface{}) (*types.NgrokConfig, error) {
+func (i *IngressConfigurator) configureNgrok(existingValues *helmvalues.Document) (*types.NgrokConfig, error) {
 	// Show registration info
 	pterm.Warning.Printf("You need to register for an Ngrok account, please visit: %s\n", types.NgrokRegistrationURLs.SignUp)
 
@@ -112,33 +140,20 @@ func (i *IngressConfigurator) configureNgrok(existingValues map[string]interface
 }
 
 // getCurrentNgrokSettings extracts current Ngrok settings from existing values
-func (i *IngressConfigurator) getCurrentNgrokSettings(values map[string]interface{}) *types.NgrokConfig {
+func (i *IngressConfigurator) getCurrentNgrokSettings(values *helmvalues.Document) *types.NgrokConfig {
 	current := &types.NgrokConfig{}
 
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if oss, ok := deployment["oss"].(map[string]interface{}); ok {
-			if ingress, ok := oss["ingress"].(map[string]interface{}); ok {
-				if ngrok, ok := ingress["ngrok"].(map[string]interface{}); ok {
-					// Extract URL/Domain
-					if url, ok := ngrok["url"].(string); ok {
-						current.Domain = url
-					}
-
-					// Extract credentials
-					if credentials, ok := ngrok["credentials"].(map[string]interface{}); ok {
-						if apiKey, ok := credentials["apiKey"].(string); ok {
-							current.APIKey = apiKey
-						}
-						// Check both possible field names for auth token
-						if authtoken, ok := credentials["authtoken"].(string); ok {
-							current.AuthToken = authtoken
-						} else if authtoken, ok := credentials["authtoken"].(string); ok {
-							current.AuthToken = authtoken
-						}
-					}
-				}
-			}
-		}
+	// Extract URL/Domain
+	if url, ok := values.GetString("deployment.oss.ingress.ngrok.url"); ok {
+		current.Domain = url
+	}
+
+	// Extract credentials
+	if apiKey, ok := values.GetString("deployment.oss.ingress.ngrok.credentials.apiKey"); ok {
+		current.APIKey = apiKey
+	}
+	if authtoken, ok := values.GetString("deployment.oss.ingress.ngrok.credentials.authtoken"); ok {
+		current.AuthToken = authtoken
 	}
 
 	return current
@@ -238,150 +253,105 @@ func (i *IngressConfigurator) configureNgrokIPAllowlist(config *types.NgrokConfi
 }
 
 // applyLocalhostConfig applies localhost ingress configuration to helm values
-func (i *IngressConfigurator) applyLocalhostConfig(values map[string]interface{}) error {
-	// Ensure values map is not nil
+func (i *IngressConfigurator) applyLocalhostConfig(values *helmvalues.Document) error {
+	// Ensure values are loaded
 	if values == nil {
-		return fmt.Errorf("values map is nil")
-	}
-
-	deployment, ok := values["deployment"].(map[string]interface{})
-	if !ok {
-		deployment = make(map[string]interface{})
-		values["deployment"] = deployment
-	}
-
-	oss, ok := deployment["oss"].(map[string]interface{})
-	if !ok {
-		oss = make(map[string]interface{})
-		deployment["oss"] = oss
-	}
-
-	ingress, ok := oss["ingress"].(map[string]interface{})
-	if !ok {
-		ingress = make(map[string]interface{})
-		oss["ingress"] = ingress
+		return fmt.Errorf("values document is nil")
 	}
 
 	// Configure localhost ingress
-	ingress["localhost"] = map[string]interface{}{
-		"enabled": true,
-	}
-
-	// Disable ngrok and gcp if they exist
-	if ngrokSection, ok := ingress["ngrok"].(map[string]interface{}); ok {
-		ngrokSection["enabled"] = false
-	}
-	if gcpSection, ok := ingress["gcp"].(map[string]interface{}); ok {
-		gcpSection["enabled"] = false
+	if err := values.Set("deployment.oss.ingress.localhost.enabled", true); err != nil {
+		return err
 	}
 
-	return nil
+	return enableOnlyIngress(values, "deployment.oss.ingress", "localhost")
 }
 
 // applyNgrokConfig applies ngrok ingress configuration to helm values
-func (i *IngressConfigurator) applyNgrokConfig(values map[string]interface{}, ngrokConfig *types.NgrokConfig) error {
-	// Ensure values map is not nil
+func (i *IngressConfigurator) applyNgrokConfig(values *helmvalues.Document, ngrokConfig *types.NgrokConfig) error {
+	// Ensure values are loaded
 	if values == nil {
-		return fmt.Errorf("values map is nil")
-	}
-
-	deployment, ok := values["deployment"].(map[string]interface{})
-	if !ok {
-		deployment = make(map[string]interface{})
-		values["deployment"] = deployment
-	}
-
-	oss, ok := deployment["oss"].(map[string]interface{})
-	if !ok {
-		oss = make(map[string]interface{})
-		deployment["oss"] = oss
-	}
-
-	ingress, ok := oss["ingress"].(map[string]interface{})
-	if !ok {
-		ingress = make(map[string]interface{})
-		oss["ingress"] = ingress
+		return fmt.Errorf("values document is nil")
 	}
 
 	// Configure ngrok ingress
-	ngrokSection := map[string]interface{}{
-		"enabled": true,
-		"url":     ngrokConfig.Domain,
-		"credentials": map[string]interface{}{
-			"apiKey":    ngrokConfig.APIKey,
-			"authtoken": ngrokConfig.AuthToken,
-		},
+	settings := []struct {
+		path  string
+		value interface{}
+	}{
+		{"deployment.oss.ingress.ngrok.enabled", true},
+		{"deployment.oss.ingress.ngrok.url", ngrokConfig.Domain},
+		{"deployment.oss.ingress.ngrok.credentials.apiKey", ngrokConfig.APIKey},
+		{"deployment.oss.ingress.ngrok.credentials.authtoken", ngrokConfig.AuthToken},
+	}
+	for _, setting := range settings {
+		if err := values.Set(setting.path, setting.value); err != nil {
+			return err
+		}
 	}
 
-	// Add IP allowlist configuration if specified
+	// Add IP allowlist configuration if specified, otherwise drop a previous allowlist
 	if ngrokConfig.UseAllowedIPs && len(ngrokConfig.AllowedIPs) > 0 {
-		ngrokSection["allowedIPs"] = ngrokConfig.AllowedIPs
+		if err := values.Set("deployment.oss.ingress.ngrok.allowedIPs", ngrokConfig.AllowedIPs); err != nil {
+			return err
+		}
+	} else {
+		values.Delete("deployment.oss.ingress.ngrok.allowedIPs")
 	}
 
-	ingress["ngrok"] = ngrokSection
-
-	// Disable localhost and gcp
-	ingress["localhost"] = map[string]interface{}{
-		"enabled": false,
-	}
-	if gcpSection, ok := ingress["gcp"].(map[string]interface{}); ok {
-		gcpSection["enabled"] = false
+	// Disable localhost explicitly, it is enabled by default in the chart
+	if err := values.Set("deployment.oss.ingress.localhost.enabled", false); err != nil {
+		return err
 	}
 
-	return nil
+	return enableOnlyIngress(values, "deployment.oss.ingress", "ngrok")
 }
 
-// applyGCPConfig applies GCP ingress configuration to helm values
-func (i *IngressConfigurator) applyGCPConfig(values map[string]interface{}) error {
-	// Ensure values map is not nil
-	if values == nil {
-		return fmt.Errorf("values map is nil")
-	}
+// defaultGCPTenantID is the domain prefix of the gcp ingress when none is given
+const defaultGCPTenantID = "openframe-tenant"
 
-	// Collect tenantID for GCP configuration
-	tenantIDInput := pterm.DefaultInteractiveTextInput.WithMultiLine(false).WithDefaultValue("openframe-tenant")
+// promptGCPTenantID asks for the domain prefix of the GCP deployment
+func (i *IngressConfigurator) promptGCPTenantID() (string, error) {
+	tenantIDInput := pterm.DefaultInteractiveTextInput.WithMultiLine(false).WithDefaultValue(defaultGCPTenantID)
 	tenantID, err := tenantIDInput.Show("Enter domain prefix for GCP deployment")
 	if err != nil {
-		return fmt.Errorf("domain prefix input failed: %w", err)
+		return "", fmt.Errorf("domain prefix input failed: %w", err)
 	}
 	tenantID = strings.TrimSpace(tenantID)
 	if tenantID == "" {
-		tenantID = "openframe-tenant"
+		tenantID = defaultGCPTenantID
 	}
+	return tenantID, nil
+}
 
-	deployment, ok := values["deployment"].(map[string]interface{})
-	if !ok {
-		deployment = make(map[string]interface{})
-		values["deployment"] = deployment
+// applyGCPIngress enables the GCP ingress of the SaaS deployment with the given tenant ID
+func applyGCPIngress(values *helmvalues.Document, tenantID string) error {
+	// Configure GCP ingress
+	if err := values.Set("deployment.saas.ingress.gcp.enabled", true); err != nil {
+		return err
 	}
-
-	saas, ok := deployment["saas"].(map[string]interface{})
-	if !ok {
-		saas = make(map[string]interface{})
-		deployment["saas"] = saas
+	if err := values.Set("deployment.saas.ingress.gcp.tenantID", tenantID); err != nil {
+		return err
 	}
 
-	ingress, ok := saas["ingress"].(map[string]interface{})
-	if !ok {
-		ingress = make(map[string]interface{})
-		saas["ingress"] = ingress
+	// Disable localhost explicitly, it is enabled by default in the chart
+	if err := values.Set("deployment.saas.ingress.localhost.enabled", false); err != nil {
+		return err
 	}
 
-	// Configure GCP ingress
-	ingress["gcp"] = map[string]interface{}{
-		"enabled":  true,
-		"tenantID": tenantID,
-	}
+	return enableOnlyIngress(values, "deployment.saas.ingress", "gcp")
+}
 
-	// Disable localhost and ngrok
-	ingress["localhost"] = map[string]interface{}{
-		"enabled": false,
-	}
-	if ngrokSection, ok := ingress["ngrok"].(map[string]interface{}); ok {
-		ngrokSection["enabled"] = false
+// enableOnlyIngress disables every ingress mode under ingressPath except the selected one,
+// since the chart fails unless exactly one mode is enabled
+func enableOnlyIngress(values *helmvalues.Document, ingressPath, selected string) error {
+	for _, name := range values.Keys(ingressPath) {
+		if name == selected || values.Keys(ingressPath+"."+name) == nil {
+			continue
+		}
+		if err := values.Set(ingressPath+"."+name+".enabled", false); err != nil {
+			return err
+		}
 	}
-
-	pterm.Success.Printf("✓ Configured GCP ingress with domain prefix: %s\n", tenantID)
-
 	return nil
 }
@@ -0,0 +1,202 @@
+package configuration
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/certificates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+)
+
+// dnsSubdomainPattern matches Kubernetes DNS-1123 subdomains, used for both hosts and secret names
+var dnsSubdomainPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// tlsSourceOptions maps the TLS source prompt options to their sources
+var tlsSourceOptions = []struct {
+	label  string
+	source types.TLSSource
+}{
+	{"Use a TLS secret that already exists in the cluster", types.TLSSourceSecret},
+	{"Create the TLS secret from PEM certificate and key files", types.TLSSourceFiles},
+	{"Issue a certificate from the OpenFrame local certificate authority", types.TLSSourceLocalCA},
+}
+
+// configureCustomDomain collects the hostname and TLS source for the custom domain ingress
+func (i *IngressConfigurator) configureCustomDomain(existingValues *helmvalues.Document) (*types.CustomDomainConfig, error) {
+	current := i.getCurrentCustomDomainSettings(existingValues)
+
+	hostInput := pterm.DefaultInteractiveTextInput.WithMultiLine(false)
+	if current.Host != "" {
+		hostInput = hostInput.WithDefaultValue(current.Host)
+	}
+	host, err := hostInput.Show("Hostname (DNS must point to the cluster ingress)")
+	if err != nil {
+		return nil, fmt.Errorf("hostname input failed: %w", err)
+	}
+	host, err = normalizeCustomHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &types.CustomDomainConfig{Host: host}
+
+	labels := make([]string, len(tlsSourceOptions))
+	for idx, option := range tlsSourceOptions {
+		labels[idx] = option.label
+	}
+	idx, _, err := sharedUI.SelectFromList("TLS certificate", labels)
+	if err != nil {
+		return nil, fmt.Errorf("TLS source choice failed: %w", err)
+	}
+	config.TLSSource = tlsSourceOptions[idx].source
+
+	defaultSecret := current.TLSSecretName
+	if defaultSecret == "" || current.Host != host {
+		defaultSecret = defaultTLSSecretName(host)
+	}
+	secretName, err := pterm.DefaultInteractiveTextInput.WithMultiLine(false).WithDefaultValue(defaultSecret).
+		Show(fmt.Sprintf("TLS secret name in namespace %s", types.CustomDomainNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("secret name input failed: %w", err)
+	}
+	config.TLSSecretName = strings.TrimSpace(secretName)
+	if config.TLSSecretName == "" {
+		config.TLSSecretName = defaultSecret
+	}
+	if !dnsSubdomainPattern.MatchString(config.TLSSecretName) {
+		return nil, fmt.Errorf("invalid secret name %q: use lowercase letters, digits, '-' and '.'", config.TLSSecretName)
+	}
+
+	switch config.TLSSource {
+	case types.TLSSourceFiles:
+		if err := i.collectCertificateFiles(config); err != nil {
+			return nil, err
+		}
+	case types.TLSSourceLocalCA:
+		if err := issueLocalCACertificate(config); err != nil {
+			return nil, err
+		}
+	}
+
+	pterm.Success.Printf("✓ Configured custom domain %s with TLS secret %s\n", config.Host, config.TLSSecretName)
+	return config, nil
+}
+
+// collectCertificateFiles prompts for the PEM files and checks they match the host
+func (i *IngressConfigurator) collectCertificateFiles(config *types.CustomDomainConfig) error {
+	certFile, err := pterm.DefaultInteractiveTextInput.WithMultiLine(false).Show("Path to the certificate (PEM, full chain)")
+	if err != nil {
+		return fmt.Errorf("certificate path input failed: %w", err)
+	}
+	keyFile, err := pterm.DefaultInteractiveTextInput.WithMultiLine(false).Show("Path to the private key (PEM)")
+	if err != nil {
+		return fmt.Errorf("key path input failed: %w", err)
+	}
+
+	config.CertFile = strings.TrimSpace(certFile)
+	config.KeyFile = strings.TrimSpace(keyFile)
+	return validateCertificateFiles(config.CertFile, config.KeyFile, config.Host, time.Now())
+}
+
+// issueLocalCACertificate adds the host to the certificate issued by the local CA
+func issueLocalCACertificate(config *types.CustomDomainConfig) error {
+	installer := certificates.NewCertificateInstaller().WithHosts(config.Host)
+	if _, err := installer.EnsureCertificates(); err != nil {
+		return fmt.Errorf("failed to issue certificate for %s: %w", config.Host, err)
+	}
+
+	certDir, err := certificates.DefaultCertificateDirectory()
+	if err != nil {
+		return err
+	}
+	config.CertFile, config.KeyFile = certificates.NewCertificateAuthority(certDir).CertificatePaths()
+	pterm.Info.Println("Browsers on other machines need the CA, export it with 'openframe chart certificates export'")
+	return nil
+}
+
+// getCurrentCustomDomainSettings extracts deployment.oss.ingress.custom from existing values
+func (i *IngressConfigurator) getCurrentCustomDomainSettings(values *helmvalues.Document) *types.CustomDomainConfig {
+	current := &types.CustomDomainConfig{}
+
+	if host, ok := values.GetString("deployment.oss.ingress.custom.host"); ok {
+		current.Host = host
+	}
+	if secretName, ok := values.GetString("deployment.oss.ingress.custom.tlsSecretName"); ok {
+		current.TLSSecretName = secretName
+	}
+
+	return current
+}
+
+// applyCustomConfig applies custom domain ingress configuration to helm values
+func (i *IngressConfigurator) applyCustomConfig(values *helmvalues.Document, customConfig *types.CustomDomainConfig) error {
+	// Ensure values are loaded
+	if values == nil {
+		return fmt.Errorf("values document is nil")
+	}
+
+	settings := []struct {
+		path  string
+		value interface{}
+	}{
+		{"deployment.oss.ingress.custom.enabled", true},
+		{"deployment.oss.ingress.custom.host", customConfig.Host},
+		{"deployment.oss.ingress.custom.tlsSecretName", customConfig.TLSSecretName},
+		// Disable localhost explicitly, it is enabled by default in the chart
+		{"deployment.oss.ingress.localhost.enabled", false},
+	}
+	for _, setting := range settings {
+		if err := values.Set(setting.path, setting.value); err != nil {
+			return err
+		}
+	}
+
+	return enableOnlyIngress(values, "deployment.oss.ingress", "custom")
+}
+
+// normalizeCustomHost validates a fully qualified hostname for the ingress rule
+func normalizeCustomHost(host string) (string, error) {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	if host == "" {
+		return "", fmt.Errorf("hostname is required for a custom domain")
+	}
+	if !strings.Contains(host, ".") || len(host) > 253 || !dnsSubdomainPattern.MatchString(host) {
+		return "", fmt.Errorf("invalid hostname %q: expected a fully qualified domain such as openframe.example.com", host)
+	}
+	return host, nil
+}
+
+// defaultTLSSecretName derives the secret name from the host, e.g. openframe.example.com -> openframe-example-com-tls
+func defaultTLSSecretName(host string) string {
+	return strings.ReplaceAll(host, ".", "-") + "-tls"
+}
+
+// validateCertificateFiles checks that the files form a key pair that is valid for host at now
+func validateCertificateFiles(certFile, keyFile, host string, now time.Time) error {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("invalid certificate or key: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	if err := leaf.VerifyHostname(host); err != nil {
+		return fmt.Errorf("certificate is not valid for %s: %w", host, err)
+	}
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("certificate expired on %s", leaf.NotAfter.Format("2006-01-02"))
+	}
+	if now.Before(leaf.NotBefore) {
+		return fmt.Errorf("certificate is not valid before %s", leaf.NotBefore.Format("2006-01-02"))
+	}
+	return nil
+}
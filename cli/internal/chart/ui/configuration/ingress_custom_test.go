@@ -0,0 +1,133 @@
+package configuration
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/certificates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enabledIngresses(values *helmvalues.Document) []string {
+	var enabled []string
+	for _, name := range values.Keys("deployment.oss.ingress") {
+		if on, _ := values.GetBool("deployment.oss.ingress." + name + ".enabled"); on {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+func TestIngressConfigurator_applyCustomConfig(t *testing.T) {
+	configurator := NewIngressConfigurator(templates.NewHelmValuesModifier())
+	values := documentFromMap(t, map[string]interface{}{
+		"deployment": map[string]interface{}{
+			"oss": map[string]interface{}{
+				"ingress": map[string]interface{}{
+					"localhost": map[string]interface{}{"enabled": true},
+					"ngrok":     map[string]interface{}{"enabled": true, "url": "example.ngrok-free.app"},
+				},
+			},
+		},
+	})
+
+	err := configurator.applyCustomConfig(values, &types.CustomDomainConfig{
+		Host:          "openframe.example.com",
+		TLSSecretName: "openframe-example-com-tls",
+		TLSSource:     types.TLSSourceFiles,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"custom"}, enabledIngresses(values))
+
+	host, _ := values.GetString("deployment.oss.ingress.custom.host")
+	assert.Equal(t, "openframe.example.com", host)
+	secretName, _ := values.GetString("deployment.oss.ingress.custom.tlsSecretName")
+	assert.Equal(t, "openframe-example-com-tls", secretName)
+	url, _ := values.GetString("deployment.oss.ingress.ngrok.url")
+	assert.Equal(t, "example.ngrok-free.app", url, "other settings are kept")
+
+	assert.Error(t, configurator.applyCustomConfig(nil, &types.CustomDomainConfig{}))
+}
+
+func TestIngressConfigurator_OtherModesDisableCustom(t *testing.T) {
+	configurator := NewIngressConfigurator(templates.NewHelmValuesModifier())
+	newValues := func() *helmvalues.Document {
+		return documentFromMap(t, map[string]interface{}{
+			"deployment": map[string]interface{}{
+				"oss": map[string]interface{}{
+					"ingress": map[string]interface{}{
+						"custom": map[string]interface{}{"enabled": true, "host": "openframe.example.com"},
+					},
+				},
+			},
+		})
+	}
+
+	values := newValues()
+	require.NoError(t, configurator.applyLocalhostConfig(values))
+	assert.Equal(t, []string{"localhost"}, enabledIngresses(values))
+
+	values = newValues()
+	require.NoError(t, configurator.applyNgrokConfig(values, &types.NgrokConfig{Domain: "example.ngrok-free.app"}))
+	assert.Equal(t, []string{"ngrok"}, enabledIngresses(values))
+}
+
+func TestIngressConfigurator_getCurrentCustomDomainSettings(t *testing.T) {
+	configurator := NewIngressConfigurator(templates.NewHelmValuesModifier())
+
+	current := configurator.getCurrentCustomDomainSettings(documentFromMap(t, map[string]interface{}{
+		"deployment": map[string]interface{}{
+			"oss": map[string]interface{}{
+				"ingress": map[string]interface{}{
+					"custom": map[string]interface{}{
+						"enabled":       true,
+						"host":          "openframe.techm.world",
+						"tlsSecretName": "openframe-techm-world-tls",
+					},
+				},
+			},
+		},
+	}))
+	assert.Equal(t, "openframe.techm.world", current.Host)
+	assert.Equal(t, "openframe-techm-world-tls", current.TLSSecretName)
+
+	assert.Empty(t, configurator.getCurrentCustomDomainSettings(helmvalues.New()).Host)
+}
+
+func TestNormalizeCustomHost(t *testing.T) {
+	host, err := normalizeCustomHost("  OpenFrame.Example.com. ")
+	require.NoError(t, err)
+	assert.Equal(t, "openframe.example.com", host)
+
+	for _, invalid := range []string{"", "localhost", "*.example.com", "bad_host.example.com", "-x.example.com", "https://openframe.example.com"} {
+		_, err := normalizeCustomHost(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestDefaultTLSSecretName(t *testing.T) {
+	assert.Equal(t, "openframe-techm-world-tls", defaultTLSSecretName("openframe.techm.world"))
+}
+
+func TestValidateCertificateFiles(t *testing.T) {
+	ca := certificates.NewCertificateAuthority(filepath.Join(t.TempDir(), "certs"))
+	require.NoError(t, ca.IssueCertificate([]string{"openframe.example.com"}))
+	certFile, keyFile := ca.CertificatePaths()
+
+	assert.NoError(t, validateCertificateFiles(certFile, keyFile, "openframe.example.com", time.Now()))
+
+	err := validateCertificateFiles(certFile, keyFile, "other.example.com", time.Now())
+	assert.ErrorContains(t, err, "not valid for other.example.com")
+
+	err = validateCertificateFiles(certFile, keyFile, "openframe.example.com", time.Now().AddDate(5, 0, 0))
+	assert.ErrorContains(t, err, "certificate expired")
+
+	err = validateCertificateFiles(certFile, ca.CACertPath(), "openframe.example.com", time.Now())
+	assert.ErrorContains(t, err, "invalid certificate or key")
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,14 +22,14 @@ func TestIngressConfigurator_Configure_LocalhostIngress(t *testing.T) {
 	configurator := NewIngressConfigurator(modifier)
 
 	// Test localhost ingress configuration
-	existingValues := map[string]interface{}{}
+	existingValues := helmvalues.New()
 
 	// Apply localhost configuration directly
 	err := configurator.applyLocalhostConfig(existingValues)
 	assert.NoError(t, err)
 
 	// Verify localhost ingress is configured
-	deployment := existingValues["deployment"].(map[string]interface{})
+	deployment := existingValues.Map()["deployment"].(map[string]interface{})
 	oss := deployment["oss"].(map[string]interface{})
 	ingress := oss["ingress"].(map[string]interface{})
 	localhost := ingress["localhost"].(map[string]interface{})
@@ -40,7 +41,7 @@ func TestIngressConfigurator_Configure_NgrokIngress(t *testing.T) {
 	configurator := NewIngressConfigurator(modifier)
 
 	// Test ngrok ingress configuration
-	existingValues := map[string]interface{}{}
+	existingValues := helmvalues.New()
 
 	// Create ngrok config
 	ngrokConfig := &types.NgrokConfig{
@@ -55,7 +56,7 @@ func TestIngressConfigurator_Configure_NgrokIngress(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify ngrok ingress is configured
-	deployment := existingValues["deployment"].(map[string]interface{})
+	deployment := existingValues.Map()["deployment"].(map[string]interface{})
 	oss := deployment["oss"].(map[string]interface{})
 	ingress := oss["ingress"].(map[string]interface{})
 	ngrok := ingress["ngrok"].(map[string]interface{})
@@ -72,7 +73,7 @@ func TestIngressConfigurator_Configure_NgrokWithAllowedIPs(t *testing.T) {
 	configurator := NewIngressConfigurator(modifier)
 
 	// Test ngrok with IP allowlist
-	existingValues := map[string]interface{}{}
+	existingValues := helmvalues.New()
 
 	// Create ngrok config with allowed IPs
 	ngrokConfig := &types.NgrokConfig{
@@ -88,7 +89,7 @@ func TestIngressConfigurator_Configure_NgrokWithAllowedIPs(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify ngrok ingress with allowed IPs
-	deployment := existingValues["deployment"].(map[string]interface{})
+	deployment := existingValues.Map()["deployment"].(map[string]interface{})
 	oss := deployment["oss"].(map[string]interface{})
 	ingress := oss["ingress"].(map[string]interface{})
 	ngrok := ingress["ngrok"].(map[string]interface{})
@@ -99,7 +100,7 @@ func TestIngressConfigurator_Configure_NgrokWithAllowedIPs(t *testing.T) {
 	assert.Equal(t, "auth_token_123", credentials["authtoken"])
 	assert.Equal(t, "api_key_456", credentials["apiKey"])
 
-	allowedIPs := ngrok["allowedIPs"].([]string)
+	allowedIPs := ngrok["allowedIPs"].([]interface{})
 	assert.Len(t, allowedIPs, 3)
 	assert.Contains(t, allowedIPs, "192.168.1.1")
 	assert.Contains(t, allowedIPs, "10.0.0.1")
@@ -145,6 +146,25 @@ func TestIngressConfigurator_Configure_GetCurrentIngressSettings(t *testing.T) {
 			},
 			expectedResult: "ngrok",
 		},
+		{
+			name: "custom domain enabled alongside localhost",
+			values: map[string]interface{}{
+				"deployment": map[string]interface{}{
+					"oss": map[string]interface{}{
+						"ingress": map[string]interface{}{
+							"custom": map[string]interface{}{
+								"enabled": true,
+								"host":    "openframe.example.com",
+							},
+							"localhost": map[string]interface{}{
+								"enabled": true,
+							},
+						},
+					},
+				},
+			},
+			expectedResult: "custom",
+		},
 		{
 			name: "both disabled",
 			values: map[string]interface{}{
@@ -183,7 +203,7 @@ func TestIngressConfigurator_Configure_GetCurrentIngressSettings(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := modifier.GetCurrentIngressSettings(tc.values)
+			result := modifier.GetCurrentIngressSettings(documentFromMap(t, tc.values))
 			assert.Equal(t, tc.expectedResult, result)
 		})
 	}
@@ -267,7 +287,7 @@ func TestIngressConfigurator_Configure_SwitchIngressTypes(t *testing.T) {
 	configurator := NewIngressConfigurator(modifier)
 
 	// Test switching from localhost to ngrok
-	existingValues := map[string]interface{}{
+	existingValues := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"ingress": map[string]interface{}{
@@ -277,7 +297,7 @@ func TestIngressConfigurator_Configure_SwitchIngressTypes(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	// Create ngrok config
 	ngrokConfig := &types.NgrokConfig{
@@ -292,7 +312,7 @@ func TestIngressConfigurator_Configure_SwitchIngressTypes(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify localhost is disabled and ngrok is enabled
-	deployment := existingValues["deployment"].(map[string]interface{})
+	deployment := existingValues.Map()["deployment"].(map[string]interface{})
 	oss := deployment["oss"].(map[string]interface{})
 	ingress := oss["ingress"].(map[string]interface{})
 
@@ -357,7 +377,7 @@ func TestIngressConfigurator_Configure_IPAllowlistScenarios(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			existingValues := map[string]interface{}{}
+			existingValues := helmvalues.New()
 
 			// Create ngrok config
 			ngrokConfig := &types.NgrokConfig{
@@ -373,15 +393,18 @@ func TestIngressConfigurator_Configure_IPAllowlistScenarios(t *testing.T) {
 			err := configurator.applyNgrokConfig(existingValues, ngrokConfig)
 			assert.NoError(t, err)
 
-			deployment := existingValues["deployment"].(map[string]interface{})
+			deployment := existingValues.Map()["deployment"].(map[string]interface{})
 			oss := deployment["oss"].(map[string]interface{})
 			ingress := oss["ingress"].(map[string]interface{})
 			ngrok := ingress["ngrok"].(map[string]interface{})
 
 			if tc.shouldHaveIPs {
-				allowedIPs, exists := ngrok["allowedIPs"]
+				allowedIPs, exists := ngrok["allowedIPs"].([]interface{})
 				assert.True(t, exists)
-				assert.Equal(t, tc.allowedIPs, allowedIPs)
+				assert.Len(t, allowedIPs, len(tc.allowedIPs))
+				for _, ip := range tc.allowedIPs {
+					assert.Contains(t, allowedIPs, ip)
+				}
 			} else {
 				_, exists := ngrok["allowedIPs"]
 				assert.False(t, exists)
@@ -389,3 +412,33 @@ func TestIngressConfigurator_Configure_IPAllowlistScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressConfigurator_applyGCPIngress_KeepsComments(t *testing.T) {
+	values, err := helmvalues.Parse([]byte(`deployment:
+  saas:
+    # Ingress configuration for SaaS deployment
+    ingress:
+      localhost:
+        enabled: true   # Enable localhost ingress with nginx for SaaS
+      gcp:
+        enabled: false
+        publicDomain: "internal.example.com"
+`))
+	assert.NoError(t, err)
+
+	assert.NoError(t, applyGCPIngress(values, "tenant-1"))
+
+	out, err := values.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, `deployment:
+  saas:
+    # Ingress configuration for SaaS deployment
+    ingress:
+      localhost:
+        enabled: false   # Enable localhost ingress with nginx for SaaS
+      gcp:
+        enabled: true
+        publicDomain: "internal.example.com"
+        tenantID: tenant-1
+`, string(out))
+}
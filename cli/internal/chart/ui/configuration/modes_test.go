@@ -60,9 +60,9 @@ deployment:
 	assert.NotNil(t, values)
 
 	// Verify existing values are loaded
-	global, ok := values["global"].(map[string]interface{})
+	branch, ok := values.GetString("global.repoBranch")
 	assert.True(t, ok)
-	assert.Equal(t, "develop", global["repoBranch"])
+	assert.Equal(t, "develop", branch)
 }
 
 func TestConfigurationWizard_Integration_LoadAndApply(t *testing.T) {
@@ -125,12 +125,12 @@ registry:
 	assert.NoError(t, err)
 
 	// Verify deployment structure changes
-	deployment := updatedValues["deployment"].(map[string]interface{})
+	deployment := updatedValues.Map()["deployment"].(map[string]interface{})
 	oss := deployment["oss"].(map[string]interface{})
 	repository := oss["repository"].(map[string]interface{})
 	assert.Equal(t, "develop", repository["branch"])
 
-	registry := updatedValues["registry"].(map[string]interface{})
+	registry := updatedValues.Map()["registry"].(map[string]interface{})
 	docker := registry["docker"].(map[string]interface{})
 	assert.Equal(t, "newuser", docker["username"])
 	assert.Equal(t, "newpass", docker["password"])
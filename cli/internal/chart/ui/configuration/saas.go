@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/credentials"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
 	"github.com/pterm/pterm"
@@ -15,12 +16,9 @@ func (w *ConfigurationWizard) configureSaaSDefaults(config *types.ChartConfigura
 	fmt.Println()
 
 	// Collect repository password
-	repoPassword, err := pterm.DefaultInteractiveTextInput.
-		WithMask("*").
-		WithMultiLine(false).
-		Show("Read Contents token for SaaS repository")
+	repoPassword, err := w.promptRepositoryPassword()
 	if err != nil {
-		return fmt.Errorf("repository password input failed: %w", err)
+		return err
 	}
 
 	// Configure GitHub container registry credentials (same UI as interactive mode)
@@ -30,23 +28,14 @@ func (w *ConfigurationWizard) configureSaaSDefaults(config *types.ChartConfigura
 	}
 
 	// Use existing branch values from helm-values.yaml without prompting (for default configuration mode)
-	saasBranch := w.getSaaSBranchFromValues(config.ExistingValues)
+	saasBranch := w.modifier.GetCurrentSaaSBranch(config.ExistingValues)
 	ossBranch := w.modifier.GetCurrentOSSBranch(config.ExistingValues)
 
-	// Set configurations
-	config.SaaSConfig = &types.SaaSConfig{
-		RepositoryPassword: strings.TrimSpace(repoPassword),
-		SaaSBranch:         strings.TrimSpace(saasBranch),
-		OSSBranch:          strings.TrimSpace(ossBranch),
-	}
-
-	config.DockerRegistry = &types.DockerRegistryConfig{
-		Username: strings.TrimSpace(ghcrUsername),
-		Password: strings.TrimSpace(ghcrPassword),
-		Email:    strings.TrimSpace(ghcrEmail),
-	}
-
-	config.ModifiedSections = append(config.ModifiedSections, "saas", "docker")
+	w.applySaaSSettings(config, repoPassword, &types.DockerRegistryConfig{
+		Username: ghcrUsername,
+		Password: ghcrPassword,
+		Email:    ghcrEmail,
+	}, saasBranch, ossBranch)
 
 	return nil
 }
@@ -57,12 +46,9 @@ func (w *ConfigurationWizard) configureSaaSInteractive(config *types.ChartConfig
 	fmt.Println()
 
 	// Collect repository password
-	repoPassword, err := pterm.DefaultInteractiveTextInput.
-		WithMask("*").
-		WithMultiLine(false).
-		Show("Read Contents token for SaaS repository")
+	repoPassword, err := w.promptRepositoryPassword()
 	if err != nil {
-		return fmt.Errorf("repository password input failed: %w", err)
+		return err
 	}
 
 	// Configure GitHub container registry credentials
@@ -83,7 +69,43 @@ func (w *ConfigurationWizard) configureSaaSInteractive(config *types.ChartConfig
 		return fmt.Errorf("OSS branch configuration failed: %w", err)
 	}
 
-	// Set configurations
+	w.applySaaSSettings(config, repoPassword, &types.DockerRegistryConfig{
+		Username: ghcrUsername,
+		Password: ghcrPassword,
+		Email:    ghcrEmail,
+	}, saasBranch, ossBranch)
+
+	return nil
+}
+
+// promptRepositoryPassword asks for the SaaS repository token, offering the GitHub token when there is one
+func (w *ConfigurationWizard) promptRepositoryPassword() (string, error) {
+	if found := findGitHubToken(w.finder); found != nil {
+		options := []string{
+			useFoundOption("the GitHub token", &credentials.Credential{Source: found.Source}),
+			"Input a different token",
+		}
+		_, choice, err := sharedUI.SelectFromList("SaaS repository token", options)
+		if err != nil {
+			return "", fmt.Errorf("repository token choice failed: %w", err)
+		}
+		if isUseFoundChoice(choice) {
+			return found.Password, nil
+		}
+	}
+
+	repoPassword, err := pterm.DefaultInteractiveTextInput.
+		WithMask("*").
+		WithMultiLine(false).
+		Show("Read Contents token for SaaS repository")
+	if err != nil {
+		return "", fmt.Errorf("repository password input failed: %w", err)
+	}
+	return repoPassword, nil
+}
+
+// applySaaSSettings records the SaaS repository access, branches and GHCR credentials
+func (w *ConfigurationWizard) applySaaSSettings(config *types.ChartConfiguration, repoPassword string, ghcr *types.DockerRegistryConfig, saasBranch, ossBranch string) {
 	config.SaaSConfig = &types.SaaSConfig{
 		RepositoryPassword: strings.TrimSpace(repoPassword),
 		SaaSBranch:         strings.TrimSpace(saasBranch),
@@ -91,31 +113,18 @@ func (w *ConfigurationWizard) configureSaaSInteractive(config *types.ChartConfig
 	}
 
 	config.DockerRegistry = &types.DockerRegistryConfig{
-		Username: strings.TrimSpace(ghcrUsername),
-		Password: strings.TrimSpace(ghcrPassword),
-		Email:    strings.TrimSpace(ghcrEmail),
+		Username: strings.TrimSpace(ghcr.Username),
+		Password: strings.TrimSpace(ghcr.Password),
+		Email:    strings.TrimSpace(ghcr.Email),
 	}
 
 	config.ModifiedSections = append(config.ModifiedSections, "saas", "docker")
-
-	return nil
 }
 
 // configureSaaSBranch configures the SaaS repository branch with OSS-style options
 func (w *ConfigurationWizard) configureSaaSBranch(config *types.ChartConfiguration) (string, error) {
 	// Get current SaaS branch from existing values if available
-	currentBranch := "main" // default
-	if config.ExistingValues != nil {
-		if deployment, ok := config.ExistingValues["deployment"].(map[string]interface{}); ok {
-			if saas, ok := deployment["saas"].(map[string]interface{}); ok {
-				if repository, ok := saas["repository"].(map[string]interface{}); ok {
-					if branch, ok := repository["branch"].(string); ok {
-						currentBranch = branch
-					}
-				}
-			}
-		}
-	}
+	currentBranch := w.modifier.GetCurrentSaaSBranch(config.ExistingValues)
 
 	pterm.Info.Printf("SaaS Repository Branch Configuration (current: %s)", currentBranch)
 
@@ -177,22 +186,3 @@ func (w *ConfigurationWizard) configureOSSBranchForSaaS(config *types.ChartConfi
 
 	return currentBranch, nil
 }
-
-// getSaaSBranchFromValues extracts the current SaaS repository branch from existing values
-func (w *ConfigurationWizard) getSaaSBranchFromValues(values map[string]interface{}) string {
-	if values == nil {
-		return "main" // default fallback
-	}
-
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if saas, ok := deployment["saas"].(map[string]interface{}); ok {
-			if repository, ok := saas["repository"].(map[string]interface{}); ok {
-				if branch, ok := repository["branch"].(string); ok {
-					return branch
-				}
-			}
-		}
-	}
-
-	return "main" // default fallback
-}
@@ -3,10 +3,11 @@ package configuration
 import (
 	"testing"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestConfigurationWizard_GetSaaSBranchFromValues(t *testing.T) {
+func TestConfigurationWizard_GetCurrentSaaSBranch(t *testing.T) {
 	wizard := NewConfigurationWizard()
 
 	tests := []struct {
@@ -76,7 +77,11 @@ func TestConfigurationWizard_GetSaaSBranchFromValues(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := wizard.getSaaSBranchFromValues(tt.values)
+			var values *helmvalues.Document
+			if tt.values != nil {
+				values = documentFromMap(t, tt.values)
+			}
+			result := wizard.modifier.GetCurrentSaaSBranch(values)
 			assert.Equal(t, tt.expectedBranch, result)
 		})
 	}
@@ -86,7 +91,7 @@ func TestConfigurationWizard_SaaSBranchExtraction(t *testing.T) {
 	wizard := NewConfigurationWizard()
 
 	// Test complex nested structure
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"global": map[string]interface{}{
 			"repoBranch": "global-main",
 		},
@@ -106,9 +111,9 @@ func TestConfigurationWizard_SaaSBranchExtraction(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
-	saasBranch := wizard.getSaaSBranchFromValues(values)
+	saasBranch := wizard.modifier.GetCurrentSaaSBranch(values)
 	assert.Equal(t, "saas-develop", saasBranch)
 
 	// Test OSS branch extraction via modifier
@@ -118,7 +123,7 @@ func TestConfigurationWizard_SaaSBranchExtraction(t *testing.T) {
 
 func TestConfigurationWizard_SaaSConfigStructure(t *testing.T) {
 	// Test that SaaS configuration maintains proper structure
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"saas": map[string]interface{}{
 				"repository": map[string]interface{}{
@@ -126,22 +131,22 @@ func TestConfigurationWizard_SaaSConfigStructure(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	// Verify branch extraction
 	wizard := NewConfigurationWizard()
-	branch := wizard.getSaaSBranchFromValues(values)
+	branch := wizard.modifier.GetCurrentSaaSBranch(values)
 	assert.Equal(t, "main", branch)
 
 	// Test with missing repository section
-	valuesNoRepo := map[string]interface{}{
+	valuesNoRepo := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"saas": map[string]interface{}{
 				"enabled": true,
 			},
 		},
-	}
+	})
 
-	branchNoRepo := wizard.getSaaSBranchFromValues(valuesNoRepo)
+	branchNoRepo := wizard.modifier.GetCurrentSaaSBranch(valuesNoRepo)
 	assert.Equal(t, "main", branchNoRepo) // Should return default
 }
\ No newline at end of file
@@ -2,7 +2,9 @@ package configuration
 
 import (
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/ui/templates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/credentials"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
 )
 
 // ConfigurationWizard handles the chart configuration workflow
@@ -11,19 +13,35 @@ type ConfigurationWizard struct {
 	branchConfig  *BranchConfigurator
 	dockerConfig  *DockerConfigurator
 	ingressConfig *IngressConfigurator
+	finder        *credentials.Finder
 }
 
 // NewConfigurationWizard creates a new configuration wizard
 func NewConfigurationWizard() *ConfigurationWizard {
 	modifier := templates.NewHelmValuesModifier()
+	// Credential helpers are only read, so they also run when the install is a dry run
+	finder := credentials.NewFinder(executor.NewRealCommandExecutor(false, false))
 	return &ConfigurationWizard{
 		modifier:      modifier,
 		branchConfig:  NewBranchConfigurator(modifier),
-		dockerConfig:  NewDockerConfigurator(modifier),
+		dockerConfig:  NewDockerConfigurator(modifier).WithCredentialFinder(finder),
 		ingressConfig: NewIngressConfigurator(modifier),
+		finder:        finder,
 	}
 }
 
+// WithValueLayers makes the wizard start from the merge of values files and --set overrides
+func (w *ConfigurationWizard) WithValueLayers(files, sets []string) *ConfigurationWizard {
+	w.modifier.WithValueLayers(files, sets)
+	return w
+}
+
+// WithDecrypter makes the wizard decrypt encrypted values files in memory
+func (w *ConfigurationWizard) WithDecrypter(decrypter templates.ValuesDecrypter) *ConfigurationWizard {
+	w.modifier.WithDecrypter(decrypter)
+	return w
+}
+
 // ConfigureHelmValues reads existing Helm values and prompts user for configuration changes
 func (w *ConfigurationWizard) ConfigureHelmValues() (*types.ChartConfiguration, error) {
 	// Step 1: Show deployment mode selection
@@ -1,23 +1,47 @@
 package templates
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"sort"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
-	"gopkg.in/yaml.v3"
 )
 
+// ValuesDecrypter decrypts encrypted value layers in memory
+type ValuesDecrypter interface {
+	DecryptLayers(ctx context.Context, layers []helmvalues.Layer) error
+}
+
 // HelmValuesModifier handles reading, modifying, and writing Helm values files
-type HelmValuesModifier struct{}
+type HelmValuesModifier struct {
+	valuesFiles []string        // values files merged in order, helm-values.yaml when empty
+	setValues   []string        // --set overrides applied on top of the files
+	decrypter   ValuesDecrypter // decrypts SOPS and age encrypted layers, nil keeps them as they are
+}
 
 // NewHelmValuesModifier creates a new Helm values modifier
 func NewHelmValuesModifier() *HelmValuesModifier {
 	return &HelmValuesModifier{}
 }
 
+// WithValueLayers makes the base values the merge of files and --set overrides, in that order
+func (h *HelmValuesModifier) WithValueLayers(files, sets []string) *HelmValuesModifier {
+	h.valuesFiles = files
+	h.setValues = sets
+	return h
+}
+
+// WithDecrypter decrypts encrypted value layers when they are loaded
+func (h *HelmValuesModifier) WithDecrypter(decrypter ValuesDecrypter) *HelmValuesModifier {
+	h.decrypter = decrypter
+	return h
+}
+
 // LoadExistingValues loads existing Helm values from file
-func (h *HelmValuesModifier) LoadExistingValues(helmValuesPath string) (map[string]interface{}, error) {
+func (h *HelmValuesModifier) LoadExistingValues(helmValuesPath string) (*helmvalues.Document, error) {
 	// Check if file exists
 	if _, err := os.Stat(helmValuesPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("helm values file not found at %s", helmValuesPath)
@@ -29,43 +53,58 @@ func (h *HelmValuesModifier) LoadExistingValues(helmValuesPath string) (map[stri
 		return nil, fmt.Errorf("failed to read helm values file: %w", err)
 	}
 
-	// Parse YAML
-	var values map[string]interface{}
-	if err := yaml.Unmarshal(data, &values); err != nil {
+	// Parse YAML, keeping comments and key order for writing it back
+	values, err := helmvalues.Parse(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse helm values YAML: %w", err)
 	}
 
-	// Handle empty file case - yaml.Unmarshal returns nil for empty content
-	if values == nil {
-		values = make(map[string]interface{})
-	}
-
 	return values, nil
 }
 
-// LoadOrCreateBaseValues loads helm values from current directory or creates default if missing
-func (h *HelmValuesModifier) LoadOrCreateBaseValues() (map[string]interface{}, error) {
-	baseHelmValuesPath := "helm-values.yaml"
-
-	// Try to load existing file from current directory
-	if _, err := os.Stat(baseHelmValuesPath); err == nil {
-		return h.LoadExistingValues(baseHelmValuesPath)
+// LoadOrCreateBaseValues merges the value layers, or creates empty values when there are none
+func (h *HelmValuesModifier) LoadOrCreateBaseValues() (*helmvalues.Document, error) {
+	layers, err := h.LoadValueLayers()
+	if err != nil {
+		return nil, err
 	}
 
-	// File doesn't exist, create empty values (only configured sections will be added)
-	emptyValues := make(map[string]interface{})
+	// Without layers the values start empty, only configured sections will be added
+	values, _ := helmvalues.Merge(layers)
+	return values, nil
+}
 
-	return emptyValues, nil
+// LoadValueLayers loads the values files and --set overrides, lowest priority first.
+// Without values files, helm-values.yaml from the current directory is used if it exists.
+func (h *HelmValuesModifier) LoadValueLayers() ([]helmvalues.Layer, error) {
+	files := h.valuesFiles
+	if len(files) == 0 {
+		baseHelmValuesPath := "helm-values.yaml"
+		if _, err := os.Stat(baseHelmValuesPath); err == nil {
+			files = []string{baseHelmValuesPath}
+		}
+	}
+	layers, err := helmvalues.LoadLayers(files, h.setValues)
+	if err != nil || h.decrypter == nil {
+		return layers, err
+	}
+	if err := h.decrypter.DecryptLayers(context.Background(), layers); err != nil {
+		return nil, fmt.Errorf("failed to decrypt values: %w", err)
+	}
+	return layers, nil
 }
 
 // CreateTemporaryValuesFile creates a temporary helm values file in current directory
-func (h *HelmValuesModifier) CreateTemporaryValuesFile(values map[string]interface{}) (string, error) {
+func (h *HelmValuesModifier) CreateTemporaryValuesFile(values *helmvalues.Document) (string, error) {
 	// Create temporary file in current directory
 	tempFile := "helm-values-tmp.yaml"
 
-	// Write values to temporary file
-	err := h.WriteValues(values, tempFile)
-	if err != nil {
+	// Only the current user may read it, since it can hold decrypted secrets. A left-over
+	// file is removed first because WriteFile keeps the mode of existing files.
+	if err := os.Remove(tempFile); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to replace temporary values file: %w", err)
+	}
+	if err := h.writeValues(values, tempFile, 0600); err != nil {
 		return "", fmt.Errorf("failed to create temporary values file: %w", err)
 	}
 
@@ -73,7 +112,11 @@ func (h *HelmValuesModifier) CreateTemporaryValuesFile(values map[string]interfa
 }
 
 // ApplyConfiguration applies configuration changes to Helm values
-func (h *HelmValuesModifier) ApplyConfiguration(values map[string]interface{}, config *types.ChartConfiguration) error {
+func (h *HelmValuesModifier) ApplyConfiguration(values *helmvalues.Document, config *types.ChartConfiguration) error {
+	if values == nil {
+		return fmt.Errorf("helm values are not loaded")
+	}
+
 	// Update deployment mode if it was modified
 	if config.DeploymentMode != nil {
 		if err := h.applyDeploymentMode(values, *config.DeploymentMode); err != nil {
@@ -94,35 +137,18 @@ func (h *HelmValuesModifier) ApplyConfiguration(values map[string]interface{}, c
 
 	// Update Docker registry if it was modified
 	if config.DockerRegistry != nil {
-		registry, ok := values["registry"].(map[string]interface{})
-		if !ok {
-			registry = make(map[string]interface{})
-			values["registry"] = registry
-		}
-
 		// For SaaS and SaaS Shared modes, update GHCR registry; for OSS, update docker registry
+		registry := "registry.docker"
 		if config.DeploymentMode != nil && (*config.DeploymentMode == types.DeploymentModeSaaS || *config.DeploymentMode == types.DeploymentModeSaaSShared) {
-			// Update GHCR registry section for SaaS and SaaS Shared
-			ghcr, ok := registry["ghcr"].(map[string]interface{})
-			if !ok {
-				ghcr = make(map[string]interface{})
-				registry["ghcr"] = ghcr
-			}
-
-			ghcr["username"] = config.DockerRegistry.Username
-			ghcr["password"] = config.DockerRegistry.Password
-			ghcr["email"] = config.DockerRegistry.Email
-		} else {
-			// Update docker registry section for OSS
-			docker, ok := registry["docker"].(map[string]interface{})
-			if !ok {
-				docker = make(map[string]interface{})
-				registry["docker"] = docker
-			}
+			registry = "registry.ghcr"
+		}
 
-			docker["username"] = config.DockerRegistry.Username
-			docker["password"] = config.DockerRegistry.Password
-			docker["email"] = config.DockerRegistry.Email
+		if err := setAll(values, map[string]interface{}{
+			registry + ".username": config.DockerRegistry.Username,
+			registry + ".password": config.DockerRegistry.Password,
+			registry + ".email":    config.DockerRegistry.Email,
+		}); err != nil {
+			return fmt.Errorf("failed to update registry credentials: %w", err)
 		}
 	}
 
@@ -137,128 +163,70 @@ func (h *HelmValuesModifier) ApplyConfiguration(values map[string]interface{}, c
 }
 
 // applyDeploymentMode applies deployment mode configuration to Helm values
-func (h *HelmValuesModifier) applyDeploymentMode(values map[string]interface{}, mode types.DeploymentMode) error {
-	// Ensure deployment section exists
-	deployment, ok := values["deployment"].(map[string]interface{})
-	if !ok {
-		deployment = make(map[string]interface{})
-		values["deployment"] = deployment
-	}
-
-	// Configure deployment mode
+func (h *HelmValuesModifier) applyDeploymentMode(values *helmvalues.Document, mode types.DeploymentMode) error {
 	switch mode {
 	case types.DeploymentModeOSS:
 		// Enable OSS, disable SaaS
-		h.ensureDeploymentSection(deployment, "oss", true)
-		h.ensureDeploymentSection(deployment, "saas", false)
+		return setAll(values, map[string]interface{}{
+			"deployment.oss.enabled":  true,
+			"deployment.saas.enabled": false,
+		})
 	case types.DeploymentModeSaaS, types.DeploymentModeSaaSShared:
 		// Enable SaaS, disable OSS
 		// SaaS Shared uses the same Helm configuration as SaaS but with different repository
-		h.ensureDeploymentSection(deployment, "oss", false)
-		h.ensureDeploymentSection(deployment, "saas", true)
+		return setAll(values, map[string]interface{}{
+			"deployment.oss.enabled":  false,
+			"deployment.saas.enabled": true,
+		})
 	default:
 		return fmt.Errorf("unknown deployment mode: %s", mode)
 	}
-
-	return nil
-}
-
-// ensureDeploymentSection ensures a deployment section exists with the specified enabled state
-func (h *HelmValuesModifier) ensureDeploymentSection(deployment map[string]interface{}, sectionName string, enabled bool) {
-	section, ok := deployment[sectionName].(map[string]interface{})
-	if !ok {
-		section = make(map[string]interface{})
-		deployment[sectionName] = section
-	}
-	section["enabled"] = enabled
 }
 
 // applySaaSConfig applies SaaS-specific configuration to Helm values
-func (h *HelmValuesModifier) applySaaSConfig(values map[string]interface{}, saasConfig types.SaaSConfig) error {
-	// Ensure deployment section exists
-	deployment, ok := values["deployment"].(map[string]interface{})
-	if !ok {
-		deployment = make(map[string]interface{})
-		values["deployment"] = deployment
-	}
-
-	// Configure SaaS repository settings
-	saas, ok := deployment["saas"].(map[string]interface{})
-	if !ok {
-		saas = make(map[string]interface{})
-		deployment["saas"] = saas
-	}
-
-	// Ensure SaaS repository section exists
-	saasRepository, ok := saas["repository"].(map[string]interface{})
-	if !ok {
-		saasRepository = make(map[string]interface{})
-		saas["repository"] = saasRepository
-	}
-
-	// Set SaaS repository password and branch
-	saasRepository["password"] = saasConfig.RepositoryPassword
-	saasRepository["branch"] = saasConfig.SaaSBranch
-
-	// Configure OSS repository settings
-	oss, ok := deployment["oss"].(map[string]interface{})
-	if !ok {
-		oss = make(map[string]interface{})
-		deployment["oss"] = oss
-	}
-
-	// Ensure OSS repository section exists
-	ossRepository, ok := oss["repository"].(map[string]interface{})
-	if !ok {
-		ossRepository = make(map[string]interface{})
-		oss["repository"] = ossRepository
-	}
-
-	// Set OSS repository branch
-	ossRepository["branch"] = saasConfig.OSSBranch
-
-	return nil
+func (h *HelmValuesModifier) applySaaSConfig(values *helmvalues.Document, saasConfig types.SaaSConfig) error {
+	return setAll(values, map[string]interface{}{
+		"deployment.saas.repository.password": saasConfig.RepositoryPassword,
+		"deployment.saas.repository.branch":   saasConfig.SaaSBranch,
+		"deployment.oss.repository.branch":    saasConfig.OSSBranch,
+	})
 }
 
 // updateOSSBranch updates the OSS repository branch
-func (h *HelmValuesModifier) updateOSSBranch(values map[string]interface{}, branch string) error {
-	// Ensure deployment section exists
-	deployment, ok := values["deployment"].(map[string]interface{})
-	if !ok {
-		deployment = make(map[string]interface{})
-		values["deployment"] = deployment
-	}
+func (h *HelmValuesModifier) updateOSSBranch(values *helmvalues.Document, branch string) error {
+	return values.Set("deployment.oss.repository.branch", branch)
+}
 
-	// Ensure OSS section exists
-	oss, ok := deployment["oss"].(map[string]interface{})
-	if !ok {
-		oss = make(map[string]interface{})
-		deployment["oss"] = oss
+// setAll sets several paths, in sorted order so that new keys are added deterministically
+func setAll(values *helmvalues.Document, updates map[string]interface{}) error {
+	paths := make([]string, 0, len(updates))
+	for path := range updates {
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
 
-	// Ensure repository section exists
-	repository, ok := oss["repository"].(map[string]interface{})
-	if !ok {
-		repository = make(map[string]interface{})
-		oss["repository"] = repository
+	for _, path := range paths {
+		if err := values.Set(path, updates[path]); err != nil {
+			return err
+		}
 	}
-
-	// Set the branch
-	repository["branch"] = branch
-
 	return nil
 }
 
 // WriteValues writes updated values back to the Helm values file
-func (h *HelmValuesModifier) WriteValues(values map[string]interface{}, helmValuesPath string) error {
-	// Marshal back to YAML
-	updatedData, err := yaml.Marshal(values)
+func (h *HelmValuesModifier) WriteValues(values *helmvalues.Document, helmValuesPath string) error {
+	return h.writeValues(values, helmValuesPath, 0644)
+}
+
+func (h *HelmValuesModifier) writeValues(values *helmvalues.Document, helmValuesPath string, perm os.FileMode) error {
+	// Render YAML, keeping the comments and layout of the original file
+	updatedData, err := values.Bytes()
 	if err != nil {
 		return fmt.Errorf("failed to marshal updated helm values: %w", err)
 	}
 
 	// Write updated values back to file
-	if err := os.WriteFile(helmValuesPath, updatedData, 0644); err != nil {
+	if err := os.WriteFile(helmValuesPath, updatedData, perm); err != nil {
 		return fmt.Errorf("failed to write updated helm values file: %w", err)
 	}
 
@@ -266,78 +234,61 @@ func (h *HelmValuesModifier) WriteValues(values map[string]interface{}, helmValu
 }
 
 // GetCurrentBranch extracts the current branch from Helm values (legacy method)
-func (h *HelmValuesModifier) GetCurrentBranch(values map[string]interface{}) string {
+func (h *HelmValuesModifier) GetCurrentBranch(values *helmvalues.Document) string {
 	// First check for deployment-specific branch
 	if branch := h.GetCurrentOSSBranch(values); branch != "main" {
 		return branch
 	}
 	// Fall back to legacy global setting
-	if global, ok := values["global"].(map[string]interface{}); ok {
-		if branch, ok := global["repoBranch"].(string); ok {
-			return branch
-		}
+	if branch, ok := values.GetString("global.repoBranch"); ok {
+		return branch
 	}
 	return "main" // default fallback
 }
 
 // GetCurrentOSSBranch extracts the current OSS repository branch from Helm values
-func (h *HelmValuesModifier) GetCurrentOSSBranch(values map[string]interface{}) string {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if oss, ok := deployment["oss"].(map[string]interface{}); ok {
-			if repository, ok := oss["repository"].(map[string]interface{}); ok {
-				if branch, ok := repository["branch"].(string); ok {
-					return branch
-				}
-			}
-		}
+func (h *HelmValuesModifier) GetCurrentOSSBranch(values *helmvalues.Document) string {
+	if branch, ok := values.GetString("deployment.oss.repository.branch"); ok {
+		return branch
+	}
+	return "main" // default fallback
+}
+
+// GetCurrentSaaSBranch extracts the current SaaS repository branch from Helm values
+func (h *HelmValuesModifier) GetCurrentSaaSBranch(values *helmvalues.Document) string {
+	if branch, ok := values.GetString("deployment.saas.repository.branch"); ok {
+		return branch
 	}
 	return "main" // default fallback
 }
 
 // GetCurrentDockerSettings extracts current Docker settings from Helm values
-func (h *HelmValuesModifier) GetCurrentDockerSettings(values map[string]interface{}) *types.DockerRegistryConfig {
+func (h *HelmValuesModifier) GetCurrentDockerSettings(values *helmvalues.Document) *types.DockerRegistryConfig {
 	config := &types.DockerRegistryConfig{
 		Username: "default",
 		Password: "****",
 		Email:    "default@example.com",
 	}
 
-	if registry, ok := values["registry"].(map[string]interface{}); ok {
-		if docker, ok := registry["docker"].(map[string]interface{}); ok {
-			if username, ok := docker["username"].(string); ok {
-				config.Username = username
-			}
-			if password, ok := docker["password"].(string); ok {
-				config.Password = password
-			}
-			if email, ok := docker["email"].(string); ok {
-				config.Email = email
-			}
-		}
+	if username, ok := values.GetString("registry.docker.username"); ok {
+		config.Username = username
+	}
+	if password, ok := values.GetString("registry.docker.password"); ok {
+		config.Password = password
+	}
+	if email, ok := values.GetString("registry.docker.email"); ok {
+		config.Email = email
 	}
 
 	return config
 }
 
 // GetCurrentIngressSettings extracts current ingress settings from Helm values
-func (h *HelmValuesModifier) GetCurrentIngressSettings(values map[string]interface{}) string {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if oss, ok := deployment["oss"].(map[string]interface{}); ok {
-			if ingress, ok := oss["ingress"].(map[string]interface{}); ok {
-				// Check if ngrok is enabled
-				if ngrok, ok := ingress["ngrok"].(map[string]interface{}); ok {
-					if enabled, ok := ngrok["enabled"].(bool); ok && enabled {
-						return "ngrok"
-					}
-				}
-
-				// Check if localhost is enabled
-				if localhost, ok := ingress["localhost"].(map[string]interface{}); ok {
-					if enabled, ok := localhost["enabled"].(bool); ok && enabled {
-						return "localhost"
-					}
-				}
-			}
+func (h *HelmValuesModifier) GetCurrentIngressSettings(values *helmvalues.Document) string {
+	// A custom domain takes precedence, then ngrok, then localhost
+	for _, ingress := range []string{"custom", "ngrok", "localhost"} {
+		if enabled, _ := values.GetBool("deployment.oss.ingress." + ingress + ".enabled"); enabled {
+			return ingress
 		}
 	}
 
@@ -345,36 +296,17 @@ func (h *HelmValuesModifier) GetCurrentIngressSettings(values map[string]interfa
 }
 
 // GetCurrentDeploymentMode extracts the current deployment mode from Helm values
-func (h *HelmValuesModifier) GetCurrentDeploymentMode(values map[string]interface{}) types.DeploymentMode {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		// Check if SaaS is enabled
-		if saas, ok := deployment["saas"].(map[string]interface{}); ok {
-			if enabled, ok := saas["enabled"].(bool); ok && enabled {
-				return types.DeploymentModeSaaS
-			}
-		}
-
-		// Check if OSS is enabled (or default to OSS)
-		if oss, ok := deployment["oss"].(map[string]interface{}); ok {
-			if enabled, ok := oss["enabled"].(bool); ok && enabled {
-				return types.DeploymentModeOSS
-			}
-		}
+func (h *HelmValuesModifier) GetCurrentDeploymentMode(values *helmvalues.Document) types.DeploymentMode {
+	// Check if SaaS is enabled
+	if enabled, _ := values.GetBool("deployment.saas.enabled"); enabled {
+		return types.DeploymentModeSaaS
 	}
 
 	return types.DeploymentModeOSS // default fallback
 }
 
 // GetSaaSRepositoryPassword extracts the SaaS repository password from Helm values
-func (h *HelmValuesModifier) GetSaaSRepositoryPassword(values map[string]interface{}) string {
-	if deployment, ok := values["deployment"].(map[string]interface{}); ok {
-		if saas, ok := deployment["saas"].(map[string]interface{}); ok {
-			if repository, ok := saas["repository"].(map[string]interface{}); ok {
-				if password, ok := repository["password"].(string); ok {
-					return password
-				}
-			}
-		}
-	}
-	return "" // return empty string if not found
+func (h *HelmValuesModifier) GetSaaSRepositoryPassword(values *helmvalues.Document) string {
+	password, _ := values.GetString("deployment.saas.repository.password")
+	return password // empty string if not found
 }
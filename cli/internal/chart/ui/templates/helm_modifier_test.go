@@ -1,10 +1,15 @@
 package templates
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,18 +52,11 @@ deployment:
 	assert.NotNil(t, values)
 
 	// Verify structure
-	global, ok := values["global"].(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "main", global["repoBranch"])
-	assert.Equal(t, "https://github.com/test/repo.git", global["repoURL"])
-
-	registry, ok := values["registry"].(map[string]interface{})
-	assert.True(t, ok)
-	docker, ok := registry["docker"].(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "testuser", docker["username"])
-	assert.Equal(t, "testpass", docker["password"])
-	assert.Equal(t, "test@example.com", docker["email"])
+	assertString(t, values, "global.repoBranch", "main")
+	assertString(t, values, "global.repoURL", "https://github.com/test/repo.git")
+	assertString(t, values, "registry.docker.username", "testuser")
+	assertString(t, values, "registry.docker.password", "testpass")
+	assertString(t, values, "registry.docker.email", "test@example.com")
 }
 
 func TestHelmValuesModifier_LoadExistingValues_FileNotFound(t *testing.T) {
@@ -102,18 +100,18 @@ func TestHelmValuesModifier_LoadExistingValues_EmptyFile(t *testing.T) {
 	err := os.WriteFile(testFile, []byte(""), 0644)
 	require.NoError(t, err)
 
-	// Test loading empty file - should return empty map, not nil
+	// Test loading empty file - should return empty values, not nil
 	values, err := modifier.LoadExistingValues(testFile)
 	assert.NoError(t, err)
 	assert.NotNil(t, values)
-	assert.Equal(t, 0, len(values))
+	assert.Empty(t, values.Map())
 }
 
 func TestHelmValuesModifier_GetCurrentOSSBranch(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Test with existing OSS branch in deployment structure
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"repository": map[string]interface{}{
@@ -121,24 +119,24 @@ func TestHelmValuesModifier_GetCurrentOSSBranch(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	branch := modifier.GetCurrentOSSBranch(values)
 	assert.Equal(t, "develop", branch)
 
 	// Test with no deployment section - should return default
-	emptyValues := make(map[string]interface{})
+	emptyValues := helmvalues.New()
 	defaultBranch := modifier.GetCurrentOSSBranch(emptyValues)
 	assert.Equal(t, "main", defaultBranch)
 
 	// Test with deployment section but no OSS branch - should return default
-	nobranchValues := map[string]interface{}{
+	nobranchValues := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"enabled": true,
 			},
 		},
-	}
+	})
 	noBranch := modifier.GetCurrentOSSBranch(nobranchValues)
 	assert.Equal(t, "main", noBranch)
 }
@@ -147,7 +145,7 @@ func TestHelmValuesModifier_GetCurrentDockerSettings(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Test with existing Docker settings
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"docker": map[string]interface{}{
 				"username": "myuser",
@@ -155,7 +153,7 @@ func TestHelmValuesModifier_GetCurrentDockerSettings(t *testing.T) {
 				"email":    "my@example.com",
 			},
 		},
-	}
+	})
 
 	docker := modifier.GetCurrentDockerSettings(values)
 	assert.Equal(t, "myuser", docker.Username)
@@ -163,20 +161,20 @@ func TestHelmValuesModifier_GetCurrentDockerSettings(t *testing.T) {
 	assert.Equal(t, "my@example.com", docker.Email)
 
 	// Test with no registry section - should return defaults
-	emptyValues := make(map[string]interface{})
+	emptyValues := helmvalues.New()
 	defaultDocker := modifier.GetCurrentDockerSettings(emptyValues)
 	assert.Equal(t, "default", defaultDocker.Username)
 	assert.Equal(t, "****", defaultDocker.Password)
 	assert.Equal(t, "default@example.com", defaultDocker.Email)
 
 	// Test with registry but no docker section - should return defaults
-	noDockerValues := map[string]interface{}{
+	noDockerValues := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"ghcr": map[string]interface{}{
 				"username": "ghcruser",
 			},
 		},
-	}
+	})
 	noDocker := modifier.GetCurrentDockerSettings(noDockerValues)
 	assert.Equal(t, "default", noDocker.Username)
 	assert.Equal(t, "****", noDocker.Password)
@@ -187,7 +185,7 @@ func TestHelmValuesModifier_ApplyConfiguration_Branch(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Prepare initial values with deployment structure
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"repository": map[string]interface{}{
@@ -195,7 +193,7 @@ func TestHelmValuesModifier_ApplyConfiguration_Branch(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	// Create configuration with new branch for OSS deployment
 	newBranch := "develop"
@@ -211,23 +209,20 @@ func TestHelmValuesModifier_ApplyConfiguration_Branch(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify changes in deployment structure
-	deployment := values["deployment"].(map[string]interface{})
-	oss := deployment["oss"].(map[string]interface{})
-	repository := oss["repository"].(map[string]interface{})
-	assert.Equal(t, "develop", repository["branch"])
+	assertString(t, values, "deployment.oss.repository.branch", "develop")
 }
 
 func TestHelmValuesModifier_ApplyConfiguration_Branch_NoDeployment(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Prepare values without deployment section
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"docker": map[string]interface{}{
 				"username": "test",
 			},
 		},
-	}
+	})
 
 	// Create configuration with new branch for OSS deployment
 	newBranch := "develop"
@@ -243,20 +238,14 @@ func TestHelmValuesModifier_ApplyConfiguration_Branch_NoDeployment(t *testing.T)
 	assert.NoError(t, err)
 
 	// Verify deployment structure was created
-	deployment, ok := values["deployment"].(map[string]interface{})
-	assert.True(t, ok)
-	oss, ok := deployment["oss"].(map[string]interface{})
-	assert.True(t, ok)
-	repository, ok := oss["repository"].(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "develop", repository["branch"])
+	assertString(t, values, "deployment.oss.repository.branch", "develop")
 }
 
 func TestHelmValuesModifier_ApplyConfiguration_Docker(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Prepare initial values
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"registry": map[string]interface{}{
 			"docker": map[string]interface{}{
 				"username": "olduser",
@@ -264,7 +253,7 @@ func TestHelmValuesModifier_ApplyConfiguration_Docker(t *testing.T) {
 				"email":    "old@example.com",
 			},
 		},
-	}
+	})
 
 	// Create configuration with new Docker settings
 	dockerConfig := &types.DockerRegistryConfig{
@@ -282,24 +271,22 @@ func TestHelmValuesModifier_ApplyConfiguration_Docker(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify changes
-	registry := values["registry"].(map[string]interface{})
-	docker := registry["docker"].(map[string]interface{})
-	assert.Equal(t, "newuser", docker["username"])
-	assert.Equal(t, "newpass", docker["password"])
-	assert.Equal(t, "new@example.com", docker["email"])
+	assertString(t, values, "registry.docker.username", "newuser")
+	assertString(t, values, "registry.docker.password", "newpass")
+	assertString(t, values, "registry.docker.email", "new@example.com")
 }
 
 func TestHelmValuesModifier_ApplyConfiguration_Docker_NoRegistry(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Prepare values without registry section
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"ingress": map[string]interface{}{
 				"enabled": true,
 			},
 		},
-	}
+	})
 
 	// Create configuration with new Docker settings
 	dockerConfig := &types.DockerRegistryConfig{
@@ -317,13 +304,9 @@ func TestHelmValuesModifier_ApplyConfiguration_Docker_NoRegistry(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify registry and docker sections were created
-	registry, ok := values["registry"].(map[string]interface{})
-	assert.True(t, ok)
-	docker, ok := registry["docker"].(map[string]interface{})
-	assert.True(t, ok)
-	assert.Equal(t, "newuser", docker["username"])
-	assert.Equal(t, "newpass", docker["password"])
-	assert.Equal(t, "new@example.com", docker["email"])
+	assertString(t, values, "registry.docker.username", "newuser")
+	assertString(t, values, "registry.docker.password", "newpass")
+	assertString(t, values, "registry.docker.email", "new@example.com")
 }
 
 func TestHelmValuesModifier_ApplyConfiguration_NoChanges(t *testing.T) {
@@ -336,10 +319,7 @@ func TestHelmValuesModifier_ApplyConfiguration_NoChanges(t *testing.T) {
 			"repoURL":    "https://github.com/test/repo.git",
 		},
 	}
-	values := make(map[string]interface{})
-	for k, v := range originalValues {
-		values[k] = v
-	}
+	values := documentFromMap(t, originalValues)
 
 	// Create configuration with no changes
 	config := &types.ChartConfiguration{
@@ -353,14 +333,14 @@ func TestHelmValuesModifier_ApplyConfiguration_NoChanges(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify no changes
-	assert.Equal(t, originalValues, values)
+	assert.Equal(t, originalValues, values.Map())
 }
 
 func TestHelmValuesModifier_WriteValues(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Prepare test values
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"global": map[string]interface{}{
 			"repoBranch": "develop",
 			"repoURL":    "https://github.com/test/repo.git",
@@ -372,7 +352,7 @@ func TestHelmValuesModifier_WriteValues(t *testing.T) {
 				"email":    "test@example.com",
 			},
 		},
-	}
+	})
 
 	// Create temporary file
 	tmpDir := t.TempDir()
@@ -392,20 +372,16 @@ func TestHelmValuesModifier_WriteValues(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Verify structure matches
-	global := loadedValues["global"].(map[string]interface{})
-	assert.Equal(t, "develop", global["repoBranch"])
-
-	registry := loadedValues["registry"].(map[string]interface{})
-	docker := registry["docker"].(map[string]interface{})
-	assert.Equal(t, "testuser", docker["username"])
+	assertString(t, loadedValues, "global.repoBranch", "develop")
+	assertString(t, loadedValues, "registry.docker.username", "testuser")
 }
 
 func TestHelmValuesModifier_WriteValues_InvalidPath(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
-	values := map[string]interface{}{
+	values := documentFromMap(t, map[string]interface{}{
 		"test": "value",
-	}
+	})
 
 	// Test writing to invalid path
 	err := modifier.WriteValues(values, "/invalid/path/that/does/not/exist/values.yaml")
@@ -417,7 +393,7 @@ func TestHelmValuesModifier_GetCurrentIngressSettings(t *testing.T) {
 	modifier := NewHelmValuesModifier()
 
 	// Test with ngrok enabled
-	valuesWithNgrok := map[string]interface{}{
+	valuesWithNgrok := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"ingress": map[string]interface{}{
@@ -430,13 +406,13 @@ func TestHelmValuesModifier_GetCurrentIngressSettings(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	ingress := modifier.GetCurrentIngressSettings(valuesWithNgrok)
 	assert.Equal(t, "ngrok", ingress)
 
 	// Test with localhost enabled
-	valuesWithLocalhost := map[string]interface{}{
+	valuesWithLocalhost := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"ingress": map[string]interface{}{
@@ -449,24 +425,158 @@ func TestHelmValuesModifier_GetCurrentIngressSettings(t *testing.T) {
 				},
 			},
 		},
-	}
+	})
 
 	ingress = modifier.GetCurrentIngressSettings(valuesWithLocalhost)
 	assert.Equal(t, "localhost", ingress)
 
 	// Test with no deployment section - should return default
-	emptyValues := make(map[string]interface{})
+	emptyValues := helmvalues.New()
 	defaultIngress := modifier.GetCurrentIngressSettings(emptyValues)
 	assert.Equal(t, "localhost", defaultIngress)
 
 	// Test with deployment but no ingress section - should return default
-	noIngressValues := map[string]interface{}{
+	noIngressValues := documentFromMap(t, map[string]interface{}{
 		"deployment": map[string]interface{}{
 			"oss": map[string]interface{}{
 				"enabled": true,
 			},
 		},
-	}
+	})
 	noIngress := modifier.GetCurrentIngressSettings(noIngressValues)
 	assert.Equal(t, "localhost", noIngress)
 }
+
+func TestHelmValuesModifier_WriteValues_PreservesComments(t *testing.T) {
+	modifier := NewHelmValuesModifier()
+
+	original := `# OpenFrame values
+deployment:
+  oss:
+    enabled: true
+    repository:
+      branch: main   # Branch deployed by ArgoCD
+
+  saas:
+    enabled: false
+`
+	testFile := filepath.Join(t.TempDir(), "helm-values.yaml")
+	require.NoError(t, os.WriteFile(testFile, []byte(original), 0644))
+
+	values, err := modifier.LoadExistingValues(testFile)
+	require.NoError(t, err)
+
+	branch := "develop"
+	deploymentMode := types.DeploymentModeOSS
+	require.NoError(t, modifier.ApplyConfiguration(values, &types.ChartConfiguration{
+		Branch:         &branch,
+		DeploymentMode: &deploymentMode,
+	}))
+	require.NoError(t, modifier.WriteValues(values, testFile))
+
+	data, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	assert.Equal(t, `# OpenFrame values
+deployment:
+  oss:
+    enabled: true
+    repository:
+      branch: develop   # Branch deployed by ArgoCD
+
+  saas:
+    enabled: false
+`, string(data))
+}
+
+// documentFromMap builds a values document from a map literal
+func documentFromMap(t *testing.T, values map[string]interface{}) *helmvalues.Document {
+	t.Helper()
+	doc, err := helmvalues.FromMap(values)
+	require.NoError(t, err)
+	return doc
+}
+
+// assertString checks the string stored at path
+func assertString(t *testing.T, values *helmvalues.Document, path, expected string) {
+	t.Helper()
+	actual, ok := values.GetString(path)
+	assert.True(t, ok, "missing %s", path)
+	assert.Equal(t, expected, actual, path)
+}
+
+func TestHelmValuesModifier_LoadOrCreateBaseValues_Layers(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	team := filepath.Join(dir, "team.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("deployment:\n  oss:\n    enabled: true\n    repository:\n      branch: main\n"), 0644))
+	require.NoError(t, os.WriteFile(team, []byte("deployment:\n  oss:\n    repository:\n      branch: team\n"), 0644))
+
+	modifier := NewHelmValuesModifier().WithValueLayers([]string{base, team}, []string{"environment=staging"})
+	values, err := modifier.LoadOrCreateBaseValues()
+	require.NoError(t, err)
+
+	assert.Equal(t, "team", modifier.GetCurrentOSSBranch(values))
+	assertString(t, values, "environment", "staging")
+
+	// The wizard's choices are the highest-priority layer
+	newBranch := "wizard"
+	deploymentMode := types.DeploymentModeOSS
+	require.NoError(t, modifier.ApplyConfiguration(values, &types.ChartConfiguration{
+		Branch:           &newBranch,
+		DeploymentMode:   &deploymentMode,
+		ModifiedSections: []string{"branch"},
+	}))
+	assertString(t, values, "deployment.oss.repository.branch", "wizard")
+
+	_, err = NewHelmValuesModifier().WithValueLayers([]string{filepath.Join(dir, "missing.yaml")}, nil).LoadOrCreateBaseValues()
+	assert.ErrorContains(t, err, "not found")
+}
+
+// upperDecrypter "decrypts" by upper-casing the docker password
+type upperDecrypter struct{ err error }
+
+func (d upperDecrypter) DecryptLayers(ctx context.Context, layers []helmvalues.Layer) error {
+	for _, layer := range layers {
+		if password, ok := layer.Values.GetString("registry.docker.password"); ok {
+			if err := layer.Values.Set("registry.docker.password", strings.ToUpper(password)); err != nil {
+				return err
+			}
+		}
+	}
+	return d.err
+}
+
+func TestHelmValuesModifier_LoadValueLayers_Decrypts(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "values.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("registry:\n  docker:\n    password: sealed\n"), 0644))
+
+	values, err := NewHelmValuesModifier().WithValueLayers([]string{file}, nil).WithDecrypter(upperDecrypter{}).LoadOrCreateBaseValues()
+	require.NoError(t, err)
+	assertString(t, values, "registry.docker.password", "SEALED")
+
+	_, err = NewHelmValuesModifier().WithValueLayers([]string{file}, nil).WithDecrypter(upperDecrypter{err: fmt.Errorf("no identity matched")}).LoadValueLayers()
+	assert.EqualError(t, err, "failed to decrypt values: no identity matched")
+}
+
+func TestHelmValuesModifier_CreateTemporaryValuesFile_IsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(originalDir)
+
+	// A left-over world-readable file is replaced, not reused
+	require.NoError(t, os.WriteFile("helm-values-tmp.yaml", []byte("old: true\n"), 0644))
+
+	values, err := helmvalues.Parse([]byte("registry:\n  docker:\n    password: secret\n"))
+	require.NoError(t, err)
+	path, err := NewHelmValuesModifier().CreateTemporaryValuesFile(values)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
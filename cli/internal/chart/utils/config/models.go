@@ -11,7 +11,8 @@ type ChartInstallConfig struct {
 	DryRun         bool
 	Verbose        bool
 	Silent         bool
-	NonInteractive bool // Suppresses interactive UI elements and spinners
+	NonInteractive bool   // Suppresses interactive UI elements and spinners
+	ArgoCDChart    string // Local argo-cd chart archive, installed instead of the one from the argo Helm repository
 	// App-of-apps specific configuration
 	AppOfApps *models.AppOfAppsConfig
 }
@@ -0,0 +1,180 @@
+package credentials
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// Registries the wizard asks credentials for
+const (
+	DockerHub = "index.docker.io"
+	GHCR      = "ghcr.io"
+)
+
+// registryAliases are the keys a registry can be stored under in the Docker config, the
+// first one is the server URL credential helpers know it by
+var registryAliases = map[string][]string{
+	DockerHub: {"https://index.docker.io/v1/", "index.docker.io", "docker.io", "registry-1.docker.io", "https://index.docker.io/v1"},
+	GHCR:      {"ghcr.io", "https://ghcr.io", "https://ghcr.io/"},
+}
+
+// Credential is a username and password found on this machine
+type Credential struct {
+	Username string
+	Password string
+	Source   string // Where it was found, e.g. "~/.docker/config.json" or "gh auth token"
+}
+
+// Finder looks up credentials the user already has in the Docker config, in Docker
+// credential helpers and in the GitHub CLI, so the wizard does not ask for them again
+type Finder struct {
+	executor   executor.CommandExecutor
+	configPath string
+}
+
+// NewFinder creates a finder for the default Docker config that runs helpers through exec
+func NewFinder(exec executor.CommandExecutor) *Finder {
+	// Without a home directory there is simply no Docker config to read
+	configPath, _ := DefaultDockerConfigPath()
+	return &Finder{executor: exec, configPath: configPath}
+}
+
+// WithConfigPath reads the Docker config from path instead
+func (f *Finder) WithConfigPath(path string) *Finder {
+	f.configPath = path
+	return f
+}
+
+// DefaultDockerConfigPath returns $DOCKER_CONFIG/config.json, or ~/.docker/config.json
+func DefaultDockerConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".docker", "config.json"), nil
+}
+
+// dockerConfig is the part of ~/.docker/config.json that holds credentials
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// Registry returns the credentials Docker uses for registry, nil when there are none. Like
+// Docker, a registry specific helper wins over the default store, which wins over auths.
+func (f *Finder) Registry(ctx context.Context, registry string) (*Credential, error) {
+	if f.configPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(f.configPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Docker config: %w", err)
+	}
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.configPath, err)
+	}
+
+	aliases := registryAliases[registry]
+	if aliases == nil {
+		aliases = []string{registry}
+	}
+
+	for _, alias := range aliases {
+		if helper := config.CredHelpers[alias]; helper != "" {
+			return f.fromHelper(ctx, helper, aliases[0])
+		}
+	}
+	if config.CredsStore != "" {
+		credential, err := f.fromHelper(ctx, config.CredsStore, aliases[0])
+		if credential != nil || err != nil {
+			return credential, err
+		}
+	}
+	for _, alias := range aliases {
+		if entry, ok := config.Auths[alias]; ok && entry.Auth != "" {
+			return decodeAuth(entry.Auth, f.configPath)
+		}
+	}
+	return nil, nil
+}
+
+// decodeAuth decodes the base64 user:password of an auths entry
+func decodeAuth(auth, source string) (*Credential, error) {
+	decoded, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth entry in %s: %w", source, err)
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok || username == "" {
+		return nil, fmt.Errorf("invalid auth entry in %s: expected user:password", source)
+	}
+	return &Credential{Username: username, Password: password, Source: source}, nil
+}
+
+// fromHelper asks docker-credential-<helper> for the credentials of serverURL
+func (f *Finder) fromHelper(ctx context.Context, helper, serverURL string) (*Credential, error) {
+	program := "docker-credential-" + helper
+	result, err := f.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: program,
+		Args:    []string{"get"},
+		Stdin:   serverURL,
+	})
+	if err != nil {
+		// Helpers report unknown servers on stdout or stderr and exit 1
+		if result != nil && strings.Contains(strings.ToLower(result.Stdout+result.Stderr), "credentials not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s failed: %w", program, err)
+	}
+
+	var found struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &found); err != nil {
+		return nil, fmt.Errorf("%s returned invalid output: %w", program, err)
+	}
+	// Identity tokens are stored with the user <token> and cannot be used as a password
+	if found.Secret == "" || found.Username == "<token>" {
+		return nil, nil
+	}
+	return &Credential{Username: found.Username, Password: found.Secret, Source: program}, nil
+}
+
+// GitHubToken returns $GITHUB_TOKEN, or the token of the GitHub CLI, nil when there is none.
+// The username is $GITHUB_USERNAME, or the login of the GitHub CLI account.
+func (f *Finder) GitHubToken(ctx context.Context) *Credential {
+	credential := &Credential{Username: os.Getenv("GITHUB_USERNAME")}
+
+	if token := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); token != "" {
+		credential.Password, credential.Source = token, "GITHUB_TOKEN"
+	} else if result, err := f.executor.Execute(ctx, "gh", "auth", "token"); err == nil && strings.TrimSpace(result.Stdout) != "" {
+		credential.Password, credential.Source = strings.TrimSpace(result.Stdout), "gh auth token"
+	} else {
+		return nil
+	}
+
+	if credential.Username == "" {
+		if result, err := f.executor.Execute(ctx, "gh", "api", "user", "--jq", ".login"); err == nil {
+			credential.Username = strings.TrimSpace(result.Stdout)
+		}
+	}
+	return credential
+}
@@ -0,0 +1,105 @@
+package credentials
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDockerConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestFinder_Registry_Auths(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("octocat:docker-token"))
+	path := writeDockerConfig(t, `{"auths":{"https://index.docker.io/v1/":{"auth":"`+auth+`"},"ghcr.io":{}}}`)
+	mockExec := executor.NewMockCommandExecutor()
+	finder := NewFinder(mockExec).WithConfigPath(path)
+
+	credential, err := finder.Registry(context.Background(), DockerHub)
+	require.NoError(t, err)
+	assert.Equal(t, &Credential{Username: "octocat", Password: "docker-token", Source: path}, credential)
+
+	credential, err = finder.Registry(context.Background(), GHCR)
+	require.NoError(t, err)
+	assert.Nil(t, credential, "entries without auth are stored in a helper that is not configured")
+	assert.Zero(t, mockExec.GetCommandCount())
+
+	path = writeDockerConfig(t, `{"auths":{"docker.io":{"auth":"bm90LWEtcGFpcg=="}}}`)
+	_, err = NewFinder(mockExec).WithConfigPath(path).Registry(context.Background(), DockerHub)
+	assert.ErrorContains(t, err, "expected user:password")
+}
+
+func TestFinder_Registry_Helpers(t *testing.T) {
+	path := writeDockerConfig(t, `{
+  "auths": {"ghcr.io": {"auth": "`+base64.StdEncoding.EncodeToString([]byte("stale:token"))+`"}},
+  "credsStore": "desktop",
+  "credHelpers": {"ghcr.io": "gh"}
+}`)
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("docker-credential-gh get", &executor.CommandResult{Stdout: `{"ServerURL":"ghcr.io","Username":"octocat","Secret":"ghcr-token"}`})
+	mockExec.SetResponse("docker-credential-desktop get", &executor.CommandResult{Stdout: `{"ServerURL":"https://index.docker.io/v1/","Username":"<token>","Secret":"identity"}`})
+	finder := NewFinder(mockExec).WithConfigPath(path)
+
+	credential, err := finder.Registry(context.Background(), GHCR)
+	require.NoError(t, err)
+	assert.Equal(t, &Credential{Username: "octocat", Password: "ghcr-token", Source: "docker-credential-gh"}, credential, "registry helpers win over auths")
+
+	credential, err = finder.Registry(context.Background(), DockerHub)
+	require.NoError(t, err)
+	assert.Nil(t, credential, "identity tokens are not offered")
+
+	mockExec = executor.NewMockCommandExecutor()
+	mockExec.SetShouldFail(true, "credentials not found in native keychain")
+	credential, err = NewFinder(mockExec).WithConfigPath(path).Registry(context.Background(), DockerHub)
+	require.NoError(t, err)
+	assert.Nil(t, credential)
+
+	mockExec = executor.NewMockCommandExecutor()
+	mockExec.SetShouldFail(true, "exec: not found")
+	_, err = NewFinder(mockExec).WithConfigPath(path).Registry(context.Background(), DockerHub)
+	assert.ErrorContains(t, err, "docker-credential-desktop failed")
+}
+
+func TestFinder_Registry_NoConfig(t *testing.T) {
+	finder := NewFinder(executor.NewMockCommandExecutor()).WithConfigPath(filepath.Join(t.TempDir(), "config.json"))
+	credential, err := finder.Registry(context.Background(), DockerHub)
+	assert.NoError(t, err)
+	assert.Nil(t, credential)
+}
+
+func TestFinder_GitHubToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_USERNAME", "")
+
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetShouldFail(true, "You are not logged into any GitHub hosts")
+	assert.Nil(t, NewFinder(mockExec).GitHubToken(context.Background()), "no token without the variable or a gh login")
+
+	mockExec = executor.NewMockCommandExecutor()
+	mockExec.SetResponse("gh auth token", &executor.CommandResult{Stdout: "gho_token\n"})
+	mockExec.SetResponse("gh api user", &executor.CommandResult{Stdout: "octocat\n"})
+	assert.Equal(t, &Credential{Username: "octocat", Password: "gho_token", Source: "gh auth token"}, NewFinder(mockExec).GitHubToken(context.Background()))
+
+	t.Setenv("GITHUB_TOKEN", "ghp_token")
+	t.Setenv("GITHUB_USERNAME", "hubot")
+	mockExec = executor.NewMockCommandExecutor()
+	assert.Equal(t, &Credential{Username: "hubot", Password: "ghp_token", Source: "GITHUB_TOKEN"}, NewFinder(mockExec).GitHubToken(context.Background()))
+	assert.Zero(t, mockExec.GetCommandCount())
+}
+
+func TestDefaultDockerConfigPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	path, err := DefaultDockerConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "config.json"), path)
+}
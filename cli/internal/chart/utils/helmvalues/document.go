@@ -0,0 +1,446 @@
+package helmvalues
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultIndent is used for documents that were not parsed from a file
+const defaultIndent = 2
+
+// Document is a Helm values file held as a YAML node tree, so that edits keep the
+// comments, key order and formatting of the original file.
+//
+// Paths are dot-separated mapping keys such as "deployment.oss.ingress.ngrok.enabled".
+// A literal dot inside a key is written as "\.", e.g. "annotations.kubernetes\.io/ingress\.class".
+type Document struct {
+	root     *yaml.Node // top-level mapping node
+	comments [2]string  // head and foot comments of the YAML document
+	source   []byte     // original file content, nil for documents built in memory
+	baseline []byte     // source as re-encoded before any edit, used to compute the edits
+	indent   int
+}
+
+// New creates an empty values document
+func New() *Document {
+	return &Document{
+		root:   &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		indent: defaultIndent,
+	}
+}
+
+// Parse parses Helm values from YAML. Empty and comment-only content yields an empty document.
+func Parse(data []byte) (*Document, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		source: append([]byte(nil), data...),
+		indent: detectIndent(data),
+	}
+
+	// Empty and comment-only files decode to a zero node, "---" alone to a null scalar
+	if node.Kind == 0 || len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+		doc.root = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		return doc, nil
+	}
+
+	if node.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("helm values must be a mapping at the top level")
+	}
+	doc.root = node.Content[0]
+	doc.comments = [2]string{node.HeadComment, node.FootComment}
+
+	baseline, err := doc.encode()
+	if err != nil {
+		return nil, err
+	}
+	doc.baseline = baseline
+
+	return doc, nil
+}
+
+// Load reads and parses a Helm values file
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// FromMap builds a document from decoded values, keys are written in sorted order
+func FromMap(values map[string]interface{}) (*Document, error) {
+	doc := New()
+	if values == nil {
+		return doc, nil
+	}
+
+	var node yaml.Node
+	if err := node.Encode(values); err != nil {
+		return nil, fmt.Errorf("failed to encode values: %w", err)
+	}
+	doc.root = &node
+	return doc, nil
+}
+
+// Get returns the decoded value at path
+func (d *Document) Get(path string) (interface{}, bool) {
+	node := d.lookup(path)
+	if node == nil {
+		return nil, false
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// GetString returns the string at path, it reports false for missing and non-string values
+func (d *Document) GetString(path string) (string, bool) {
+	value, ok := d.Get(path)
+	if !ok {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// GetBool returns the boolean at path, it reports false for missing and non-boolean values
+func (d *Document) GetBool(path string) (bool, bool) {
+	value, ok := d.Get(path)
+	if !ok {
+		return false, false
+	}
+	b, ok := value.(bool)
+	return b, ok
+}
+
+// Has reports whether path exists
+func (d *Document) Has(path string) bool {
+	return d.lookup(path) != nil
+}
+
+// Keys returns the keys of the mapping at path in file order, or nil if path is not a mapping
+func (d *Document) Keys(path string) []string {
+	if d == nil {
+		return nil
+	}
+	node := d.root
+	if path != "" {
+		node = d.lookup(path)
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	keys := make([]string, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	return keys
+}
+
+// StringPaths returns the path of every string value below mappings, in file order.
+// Strings inside lists are not included since paths cannot address them.
+func (d *Document) StringPaths() []string {
+	paths := make([]string, 0)
+	if d == nil {
+		return paths
+	}
+
+	var walk func(node *yaml.Node, keys []string)
+	walk = func(node *yaml.Node, keys []string) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childKeys := append(append([]string(nil), keys...), node.Content[i].Value)
+			value := resolve(node.Content[i+1])
+			switch {
+			case value.Kind == yaml.MappingNode:
+				walk(value, childKeys)
+			case value.Kind == yaml.ScalarNode && value.Tag == "!!str":
+				paths = append(paths, joinPath(childKeys))
+			}
+		}
+	}
+	walk(d.root, nil)
+	return paths
+}
+
+// Set stores value at path, creating missing mappings on the way. Existing entries keep
+// their position and comments, and are left untouched when the value does not change.
+func (d *Document) Set(path string, value interface{}) error {
+	keys, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode value for %s: %w", path, err)
+	}
+
+	parent := d.root
+	for _, key := range keys[:len(keys)-1] {
+		idx := findKey(parent, key)
+		if idx < 0 {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			parent.Content = append(parent.Content, keyNode(key), child)
+			parent = child
+			continue
+		}
+
+		child := resolve(parent.Content[idx+1])
+		if child.Kind != yaml.MappingNode {
+			// Replace scalars such as an empty "ingress:" with a mapping
+			replacement := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			moveComments(parent.Content[idx+1], replacement)
+			parent.Content[idx+1] = replacement
+			child = replacement
+		}
+		parent = child
+	}
+
+	last := keys[len(keys)-1]
+	idx := findKey(parent, last)
+	if idx < 0 {
+		parent.Content = append(parent.Content, keyNode(last), &node)
+		return nil
+	}
+
+	current := parent.Content[idx+1]
+	if sameScalar(current, &node) {
+		return nil
+	}
+	if current.Kind == yaml.ScalarNode && node.Kind == yaml.ScalarNode && node.Tag == "!!str" &&
+		current.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		node.Style = current.Style
+	}
+	moveComments(current, &node)
+	parent.Content[idx+1] = &node
+	return nil
+}
+
+// Delete removes the entry at path and reports whether it existed
+func (d *Document) Delete(path string) bool {
+	if d == nil {
+		return false
+	}
+	keys, err := splitPath(path)
+	if err != nil {
+		return false
+	}
+
+	parent := d.root
+	if len(keys) > 1 {
+		parent = d.lookupKeys(keys[:len(keys)-1])
+	}
+	if parent == nil || parent.Kind != yaml.MappingNode {
+		return false
+	}
+
+	idx := findKey(parent, keys[len(keys)-1])
+	if idx < 0 {
+		return false
+	}
+	parent.Content = append(parent.Content[:idx], parent.Content[idx+2:]...)
+	return true
+}
+
+// Map decodes the whole document
+func (d *Document) Map() map[string]interface{} {
+	values := make(map[string]interface{})
+	if d == nil {
+		return values
+	}
+	if err := d.root.Decode(&values); err != nil {
+		return make(map[string]interface{})
+	}
+	return values
+}
+
+// Bytes renders the document. For parsed documents only the edited lines change, while
+// blank lines, comment alignment and untouched values are copied from the original file.
+func (d *Document) Bytes() ([]byte, error) {
+	encoded, err := d.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.source == nil {
+		return encoded, nil
+	}
+
+	if d.baseline == nil {
+		// Empty or comment-only file: keep its comments and append the new values
+		if len(d.root.Content) == 0 {
+			return d.source, nil
+		}
+		out := append([]byte(nil), d.source...)
+		if len(out) > 0 && out[len(out)-1] != '\n' {
+			out = append(out, '\n')
+		}
+		return append(out, encoded...), nil
+	}
+
+	if bytes.Equal(encoded, d.baseline) {
+		return d.source, nil
+	}
+	if patched, ok := patchSource(d.source, d.baseline, encoded); ok {
+		return patched, nil
+	}
+	return encoded, nil
+}
+
+// Write renders the document to path
+func (d *Document) Write(path string) error {
+	data, err := d.Bytes()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// encode renders the node tree with the indentation of the original file
+func (d *Document) encode() ([]byte, error) {
+	if len(d.root.Content) == 0 {
+		return []byte{}, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(d.indent)
+	document := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: d.comments[0],
+		FootComment: d.comments[1],
+		Content:     []*yaml.Node{d.root},
+	}
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("failed to encode helm values: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode helm values: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// lookup returns the value node at path, following aliases. A nil document has no values.
+func (d *Document) lookup(path string) *yaml.Node {
+	if d == nil {
+		return nil
+	}
+	keys, err := splitPath(path)
+	if err != nil {
+		return nil
+	}
+	return d.lookupKeys(keys)
+}
+
+func (d *Document) lookupKeys(keys []string) *yaml.Node {
+	node := d.root
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		idx := findKey(node, key)
+		if idx < 0 {
+			return nil
+		}
+		node = resolve(node.Content[idx+1])
+	}
+	return node
+}
+
+// splitPath splits a dotted path into keys, honouring "\." escapes
+func splitPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty values path")
+	}
+
+	var keys []string
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	keys = append(keys, current.String())
+
+	for _, key := range keys {
+		if key == "" {
+			return nil, fmt.Errorf("invalid values path %q: empty key", path)
+		}
+	}
+	return keys, nil
+}
+
+// findKey returns the index of key within a mapping's content, or -1
+func findKey(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func keyNode(key string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+}
+
+func resolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// sameScalar reports whether two scalar nodes hold the same value
+func sameScalar(a, b *yaml.Node) bool {
+	if a.Kind != yaml.ScalarNode || b.Kind != yaml.ScalarNode {
+		return false
+	}
+	return a.ShortTag() == b.ShortTag() && a.Value == b.Value
+}
+
+// moveComments carries the comments of a replaced value node over to its replacement
+func moveComments(from, to *yaml.Node) {
+	to.HeadComment = from.HeadComment
+	to.FootComment = from.FootComment
+	if to.Kind == yaml.ScalarNode {
+		to.LineComment = from.LineComment
+	}
+}
+
+// detectIndent returns the smallest indentation used by the file
+func detectIndent(data []byte) int {
+	indent := 0
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n > 0 && (indent == 0 || n < indent) {
+			indent = n
+		}
+	}
+	if indent < 2 || indent > 9 {
+		return defaultIndent
+	}
+	return indent
+}
@@ -0,0 +1,258 @@
+package helmvalues
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleValues = `environment: dev
+
+deployment:
+  oss:
+    enabled: true
+    # Ingress configuration - enable only ONE of the following
+    ingress:
+      localhost:
+        enabled: true   # Enable localhost ingress with nginx
+      ngrok:
+        enabled: false  # Enable ngrok ingress for external access
+        credentials:
+          apiKey: ""
+          authtoken: ""
+    repository:
+      URL: https://github.com/flamingo-stack/openframe-oss-tenant.git
+      branch: main
+      autoSync: true
+
+  saas:
+    enabled: false
+    repository:
+      password: ""  # GitHub PAT for private repo access
+
+# Registry credentials
+registry:
+  docker:
+    username: "default"
+    email: 'default@example.com'
+`
+
+func TestDocument_GetAndKeys(t *testing.T) {
+	doc, err := Parse([]byte(sampleValues))
+	require.NoError(t, err)
+
+	branch, ok := doc.GetString("deployment.oss.repository.branch")
+	assert.True(t, ok)
+	assert.Equal(t, "main", branch)
+
+	enabled, ok := doc.GetBool("deployment.oss.ingress.ngrok.enabled")
+	assert.True(t, ok)
+	assert.False(t, enabled)
+
+	_, ok = doc.GetString("deployment.oss.enabled")
+	assert.False(t, ok, "booleans are not strings")
+	_, ok = doc.Get("deployment.missing.key")
+	assert.False(t, ok)
+	assert.True(t, doc.Has("registry.docker"))
+
+	assert.Equal(t, []string{"environment", "deployment", "registry"}, doc.Keys(""))
+	assert.Equal(t, []string{"localhost", "ngrok"}, doc.Keys("deployment.oss.ingress"))
+	assert.Nil(t, doc.Keys("deployment.oss.enabled"))
+}
+
+func TestDocument_StringPaths(t *testing.T) {
+	doc, err := Parse([]byte(sampleValues + "annotations:\n  kubernetes.io/ingress.class: nginx\nhosts: [a, b]\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"environment",
+		"deployment.oss.ingress.ngrok.credentials.apiKey",
+		"deployment.oss.ingress.ngrok.credentials.authtoken",
+		"deployment.oss.repository.URL",
+		"deployment.oss.repository.branch",
+		"deployment.saas.repository.password",
+		"registry.docker.username",
+		"registry.docker.email",
+		`annotations.kubernetes\.io/ingress\.class`,
+	}, doc.StringPaths())
+	assert.Empty(t, New().StringPaths())
+}
+
+func TestDocument_UnchangedDocumentIsByteIdentical(t *testing.T) {
+	doc, err := Parse([]byte(sampleValues))
+	require.NoError(t, err)
+
+	// Setting a value to what it already is must not touch the file
+	require.NoError(t, doc.Set("deployment.oss.repository.branch", "main"))
+	require.NoError(t, doc.Set("deployment.oss.ingress.localhost.enabled", true))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, sampleValues, string(out))
+}
+
+func TestDocument_SetPreservesFormatting(t *testing.T) {
+	doc, err := Parse([]byte(sampleValues))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("deployment.oss.ingress.localhost.enabled", false))
+	require.NoError(t, doc.Set("deployment.oss.ingress.ngrok.enabled", true))
+	require.NoError(t, doc.Set("deployment.oss.ingress.ngrok.url", "example.ngrok.app"))
+	require.NoError(t, doc.Set("deployment.saas.repository.password", "secret"))
+	require.NoError(t, doc.Set("registry.docker.username", "octocat"))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	expected := `environment: dev
+
+deployment:
+  oss:
+    enabled: true
+    # Ingress configuration - enable only ONE of the following
+    ingress:
+      localhost:
+        enabled: false   # Enable localhost ingress with nginx
+      ngrok:
+        enabled: true  # Enable ngrok ingress for external access
+        credentials:
+          apiKey: ""
+          authtoken: ""
+        url: example.ngrok.app
+    repository:
+      URL: https://github.com/flamingo-stack/openframe-oss-tenant.git
+      branch: main
+      autoSync: true
+
+  saas:
+    enabled: false
+    repository:
+      password: "secret"  # GitHub PAT for private repo access
+
+# Registry credentials
+registry:
+  docker:
+    username: "octocat"
+    email: 'default@example.com'
+`
+	assert.Equal(t, expected, string(out))
+}
+
+func TestDocument_SetCreatesSections(t *testing.T) {
+	doc, err := Parse([]byte(sampleValues))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("deployment.oss.ingress.custom.host", "openframe.example.com"))
+	require.NoError(t, doc.Set("registry.ghcr.username", "octocat"))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	reparsed, err := Parse(out)
+	require.NoError(t, err)
+	host, _ := reparsed.GetString("deployment.oss.ingress.custom.host")
+	assert.Equal(t, "openframe.example.com", host)
+	assert.Equal(t, []string{"localhost", "ngrok", "custom"}, reparsed.Keys("deployment.oss.ingress"))
+	assert.Contains(t, string(out), "        enabled: true   # Enable localhost ingress with nginx\n      ngrok:")
+	assert.Contains(t, string(out), "  ghcr:\n    username: octocat\n")
+}
+
+func TestDocument_SetReplacesScalarWithMapping(t *testing.T) {
+	doc, err := Parse([]byte("deployment:\n  oss:\n    ingress:\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("deployment.oss.ingress.localhost.enabled", true))
+
+	enabled, ok := doc.GetBool("deployment.oss.ingress.localhost.enabled")
+	assert.True(t, ok)
+	assert.True(t, enabled)
+}
+
+func TestDocument_Delete(t *testing.T) {
+	doc, err := Parse([]byte(sampleValues))
+	require.NoError(t, err)
+
+	assert.True(t, doc.Delete("deployment.oss.ingress.ngrok"))
+	assert.False(t, doc.Delete("deployment.oss.ingress.ngrok"))
+	assert.False(t, doc.Delete("deployment.missing.key"))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "ngrok")
+	assert.Contains(t, string(out), "        enabled: true   # Enable localhost ingress with nginx\n    repository:")
+}
+
+func TestDocument_EscapedDots(t *testing.T) {
+	doc := New()
+	require.NoError(t, doc.Set(`ingress.annotations.kubernetes\.io/ingress\.class`, "nginx"))
+
+	assert.Equal(t, []string{"kubernetes.io/ingress.class"}, doc.Keys("ingress.annotations"))
+	class, ok := doc.GetString(`ingress.annotations.kubernetes\.io/ingress\.class`)
+	assert.True(t, ok)
+	assert.Equal(t, "nginx", class)
+
+	assert.Error(t, doc.Set("deployment..oss", true))
+	assert.Error(t, doc.Set("", true))
+}
+
+func TestDocument_EmptyAndCommentOnlyFiles(t *testing.T) {
+	for _, content := range []string{"", "---\n", "# Local overrides\n"} {
+		doc, err := Parse([]byte(content))
+		require.NoError(t, err)
+		assert.Empty(t, doc.Map())
+
+		out, err := doc.Bytes()
+		require.NoError(t, err)
+		assert.Equal(t, content, string(out), "unchanged empty file must not become null")
+
+		require.NoError(t, doc.Set("deployment.oss.enabled", true))
+		out, err = doc.Bytes()
+		require.NoError(t, err)
+		assert.Equal(t, content+"deployment:\n  oss:\n    enabled: true\n", string(out))
+	}
+}
+
+func TestDocument_RejectsNonMapping(t *testing.T) {
+	_, err := Parse([]byte("- a\n- b\n"))
+	assert.Error(t, err)
+
+	_, err = Parse([]byte("deployment: [unclosed\n"))
+	assert.Error(t, err)
+}
+
+func TestDocument_KeepsFileIndentation(t *testing.T) {
+	doc, err := Parse([]byte("deployment:\n    oss:\n        enabled: true\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.Set("deployment.oss.repository.branch", "develop"))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "deployment:\n    oss:\n        enabled: true\n        repository:\n            branch: develop\n", string(out))
+}
+
+func TestDocument_FromMapAndWrite(t *testing.T) {
+	doc, err := FromMap(map[string]interface{}{
+		"registry": map[string]interface{}{
+			"docker": map[string]interface{}{"username": "user"},
+		},
+		"deployment": map[string]interface{}{
+			"oss": map[string]interface{}{"enabled": true},
+		},
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, doc.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "deployment:\n  oss:\n    enabled: true\nregistry:\n  docker:\n    username: user\n", string(data))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, doc.Map(), loaded.Map())
+}
@@ -0,0 +1,276 @@
+package helmvalues
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetLayerName names the layer built from --set arguments
+const SetLayerName = "--set"
+
+// Layer is one source of values, such as a -f file or the --set arguments
+type Layer struct {
+	Name   string
+	Values *Document
+}
+
+// Origins maps the path of every leaf value to the name of the layer it came from
+type Origins map[string]string
+
+// Source returns the layer that provided the value at path. Mappings have no origin of
+// their own, so for them the first layer that provided a value below path is returned.
+func (o Origins) Source(path string) string {
+	if name, ok := o[path]; ok {
+		return name
+	}
+	leaves := make([]string, 0)
+	for leaf := range o {
+		if strings.HasPrefix(leaf, path+".") {
+			leaves = append(leaves, leaf)
+		}
+	}
+	if len(leaves) == 0 {
+		return ""
+	}
+	sort.Strings(leaves)
+	return o[leaves[0]]
+}
+
+// LoadLayers loads the values files in order, followed by a layer for the --set arguments
+func LoadLayers(files, sets []string) ([]Layer, error) {
+	layers := make([]Layer, 0, len(files)+1)
+	for _, file := range files {
+		values, err := Load(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("values file %s not found", file)
+			}
+			return nil, fmt.Errorf("failed to load values file %s: %w", file, err)
+		}
+		layers = append(layers, Layer{Name: file, Values: values})
+	}
+
+	if len(sets) > 0 {
+		values, err := ParseSet(sets)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, Layer{Name: SetLayerName, Values: values})
+	}
+	return layers, nil
+}
+
+// Merge deep-merges layers in order with Helm semantics: mappings are merged key by key,
+// while scalars, lists and nulls of a later layer replace the earlier value. The result
+// is the first layer's document, edited in place, so it keeps that file's formatting and
+// can be written back with few changes.
+func Merge(layers []Layer) (*Document, Origins) {
+	var merged *Document
+	origins := make(Origins)
+	for _, layer := range layers {
+		if layer.Values == nil {
+			continue
+		}
+		if merged == nil {
+			merged = layer.Values
+			recordOrigins(origins, nil, merged.root, layer.Name)
+			continue
+		}
+		mergeMapping(merged.root, layer.Values.root, nil, layer.Name, origins)
+	}
+	if merged == nil {
+		merged = New()
+	}
+	return merged, origins
+}
+
+// mergeMapping merges src into dst, recording the origin of every replaced leaf
+func mergeMapping(dst, src *yaml.Node, path []string, name string, origins Origins) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i].Value, resolve(src.Content[i+1])
+		childPath := append(append([]string(nil), path...), key)
+
+		idx := findKey(dst, key)
+		if idx >= 0 {
+			current := resolve(dst.Content[idx+1])
+			if current.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode {
+				mergeMapping(current, value, childPath, name, origins)
+				if len(current.Content) > 0 {
+					delete(origins, joinPath(childPath))
+				}
+				continue
+			}
+		}
+
+		replacement := copyNode(value)
+		forgetOrigins(origins, joinPath(childPath))
+		recordOrigins(origins, childPath, replacement, name)
+
+		if idx < 0 {
+			dst.Content = append(dst.Content, copyNode(src.Content[i]), replacement)
+			continue
+		}
+		if sameScalar(dst.Content[idx+1], replacement) {
+			continue
+		}
+		// The key moves with the value, so positions point into the layer that set it
+		replacementKey := copyNode(src.Content[i])
+		moveComments(dst.Content[idx], replacementKey)
+		moveComments(dst.Content[idx+1], replacement)
+		dst.Content[idx], dst.Content[idx+1] = replacementKey, replacement
+	}
+}
+
+// recordOrigins attributes every leaf under node to the named layer. Scalars, lists
+// and empty mappings are leaves.
+func recordOrigins(origins Origins, path []string, node *yaml.Node, name string) {
+	node = resolve(node)
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		if len(path) > 0 {
+			origins[joinPath(path)] = name
+		}
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		childPath := append(append([]string(nil), path...), node.Content[i].Value)
+		recordOrigins(origins, childPath, node.Content[i+1], name)
+	}
+}
+
+// forgetOrigins removes the origins of path and everything below it
+func forgetOrigins(origins Origins, path string) {
+	for leaf := range origins {
+		if leaf == path || strings.HasPrefix(leaf, path+".") {
+			delete(origins, leaf)
+		}
+	}
+}
+
+// copyNode deep-copies a node, expanding aliases so that the copy does not depend on
+// anchors of the document it came from
+func copyNode(node *yaml.Node) *yaml.Node {
+	node = resolve(node)
+	clone := *node
+	clone.Anchor = ""
+	clone.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		clone.Content[i] = copyNode(child)
+	}
+	return &clone
+}
+
+// RenderWithOrigins renders the document with the source layer of each leaf as a line
+// comment. Comments of the original files are left out.
+func RenderWithOrigins(doc *Document, origins Origins) ([]byte, error) {
+	annotated := &Document{root: copyNode(doc.root), indent: doc.indent}
+	annotateOrigins(annotated.root, nil, origins)
+	return annotated.encode()
+}
+
+func annotateOrigins(node *yaml.Node, path []string, origins Origins) {
+	node.HeadComment, node.LineComment, node.FootComment = "", "", ""
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := append(append([]string(nil), path...), key.Value)
+			key.HeadComment, key.LineComment, key.FootComment = "", "", ""
+			annotateOrigins(value, childPath, origins)
+			if origin, ok := origins[joinPath(childPath)]; ok {
+				if value.Kind == yaml.ScalarNode || len(value.Content) == 0 {
+					value.LineComment = origin
+				} else {
+					key.LineComment = origin
+				}
+			}
+		}
+		return
+	}
+	for _, child := range node.Content {
+		annotateOrigins(child, path, origins)
+	}
+}
+
+// ParseSet parses Helm style --set arguments such as "a.b=1,c.d={x,y}" into a document.
+// Values are typed like Helm does: true, false, null and integers are converted, anything
+// else is a string. "\," escapes a comma and "\." a dot inside a key.
+func ParseSet(args []string) (*Document, error) {
+	doc := New()
+	for _, arg := range args {
+		for _, pair := range splitUnescaped(arg, ',') {
+			if pair == "" {
+				continue
+			}
+			idx := strings.Index(pair, "=")
+			if idx <= 0 {
+				return nil, fmt.Errorf("invalid --set value %q: expected key=value", pair)
+			}
+			key, raw := pair[:idx], pair[idx+1:]
+			if strings.ContainsAny(key, "[]") {
+				return nil, fmt.Errorf("invalid --set key %q: list indexes are not supported, set the whole list with {a,b}", key)
+			}
+			if err := doc.Set(key, parseSetValue(raw)); err != nil {
+				return nil, fmt.Errorf("invalid --set key %q: %w", key, err)
+			}
+		}
+	}
+	return doc, nil
+}
+
+func parseSetValue(raw string) interface{} {
+	if strings.HasPrefix(raw, "{") && strings.HasSuffix(raw, "}") {
+		items := make([]interface{}, 0)
+		for _, item := range splitUnescaped(raw[1:len(raw)-1], ',') {
+			if item != "" {
+				items = append(items, parseSetValue(item))
+			}
+		}
+		return items
+	}
+
+	raw = strings.ReplaceAll(raw, `\,`, ",")
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	// Like Helm, numbers with a leading zero stay strings
+	if raw == "0" || (raw != "" && raw[0] != '0' && raw[0] != '+') {
+		if number, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return number
+		}
+	}
+	return raw
+}
+
+// splitUnescaped splits s on sep, ignoring separators escaped with a backslash or
+// inside {...} lists. Escapes are kept for the caller to interpret.
+func splitUnescaped(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
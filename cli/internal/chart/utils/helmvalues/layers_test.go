@@ -0,0 +1,155 @@
+package helmvalues
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseLayer(t *testing.T, name, content string) Layer {
+	t.Helper()
+	values, err := Parse([]byte(content))
+	require.NoError(t, err)
+	return Layer{Name: name, Values: values}
+}
+
+func TestMerge_HelmSemantics(t *testing.T) {
+	base := parseLayer(t, "base.yaml", `deployment:
+  oss:
+    enabled: true   # OSS is the default
+    repository:
+      branch: main
+      autoSync: true
+    ingress:
+      ngrok:
+        allowedIPs: [10.0.0.1, 10.0.0.2]
+registry:
+  docker:
+    username: base
+`)
+	team := parseLayer(t, "team.yaml", `deployment:
+  oss:
+    repository:
+      branch: develop
+    ingress:
+      ngrok:
+        allowedIPs: [192.168.0.1]
+registry: null
+`)
+	sets, err := ParseSet([]string{"deployment.oss.repository.branch=feature-x,environment=staging"})
+	require.NoError(t, err)
+
+	merged, origins := Merge([]Layer{base, team, {Name: SetLayerName, Values: sets}})
+
+	branch, _ := merged.GetString("deployment.oss.repository.branch")
+	assert.Equal(t, "feature-x", branch)
+	autoSync, _ := merged.GetBool("deployment.oss.repository.autoSync")
+	assert.True(t, autoSync, "mappings are merged key by key")
+	ips, _ := merged.Get("deployment.oss.ingress.ngrok.allowedIPs")
+	assert.Equal(t, []interface{}{"192.168.0.1"}, ips, "lists are replaced, not appended")
+	registry, ok := merged.Get("registry")
+	assert.True(t, ok)
+	assert.Nil(t, registry, "null replaces the earlier value")
+
+	assert.Equal(t, Origins{
+		"deployment.oss.enabled":                  "base.yaml",
+		"deployment.oss.repository.branch":        SetLayerName,
+		"deployment.oss.repository.autoSync":      "base.yaml",
+		"deployment.oss.ingress.ngrok.allowedIPs": "team.yaml",
+		"registry":    "team.yaml",
+		"environment": SetLayerName,
+	}, origins)
+
+	// The merged document keeps the formatting of the first file
+	out, err := merged.Bytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "    enabled: true   # OSS is the default\n")
+}
+
+func TestMerge_NoLayers(t *testing.T) {
+	merged, origins := Merge(nil)
+	assert.Empty(t, merged.Map())
+	assert.Empty(t, origins)
+}
+
+func TestMerge_ExpandsAliases(t *testing.T) {
+	base := parseLayer(t, "base.yaml", "registry:\n  docker:\n    username: base\n")
+	extra := parseLayer(t, "extra.yaml", "defaults: &creds\n  username: shared\nregistry:\n  ghcr: *creds\n")
+
+	merged, _ := Merge([]Layer{base, extra})
+
+	out, err := merged.Bytes()
+	require.NoError(t, err)
+	reparsed, err := Parse(out)
+	require.NoError(t, err)
+	username, _ := reparsed.GetString("registry.ghcr.username")
+	assert.Equal(t, "shared", username)
+}
+
+func TestParseSet(t *testing.T) {
+	doc, err := ParseSet([]string{
+		`a.b=1,a.c=true,a.d=null`,
+		`e=007,f=hello\, world,g={x,y,3}`,
+		`annotations.kubernetes\.io/class=nginx`,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"a":           map[string]interface{}{"b": 1, "c": true, "d": nil},
+		"e":           "007",
+		"f":           "hello, world",
+		"g":           []interface{}{"x", "y", 3},
+		"annotations": map[string]interface{}{"kubernetes.io/class": "nginx"},
+	}, doc.Map())
+
+	_, err = ParseSet([]string{"novalue"})
+	assert.ErrorContains(t, err, "expected key=value")
+	_, err = ParseSet([]string{"list[0]=x"})
+	assert.ErrorContains(t, err, "list indexes are not supported")
+}
+
+func TestLoadLayers(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	require.NoError(t, os.WriteFile(base, []byte("environment: dev\n"), 0644))
+
+	layers, err := LoadLayers([]string{base}, []string{"environment=prod"})
+	require.NoError(t, err)
+	require.Len(t, layers, 2)
+	assert.Equal(t, base, layers[0].Name)
+	assert.Equal(t, SetLayerName, layers[1].Name)
+
+	_, err = LoadLayers([]string{filepath.Join(dir, "missing.yaml")}, nil)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestRenderWithOrigins(t *testing.T) {
+	base := parseLayer(t, "base.yaml", "# heading\ndeployment:\n  oss:\n    enabled: true # comment\n    repository:\n      branch: main\n")
+	override := parseLayer(t, "local.yaml", "deployment:\n  oss:\n    repository:\n      branch: dev\n")
+
+	merged, origins := Merge([]Layer{base, override})
+	out, err := RenderWithOrigins(merged, origins)
+	require.NoError(t, err)
+
+	assert.Equal(t, `deployment:
+  oss:
+    enabled: true # base.yaml
+    repository:
+      branch: dev # local.yaml
+`, string(out))
+}
+
+func TestOrigins_Source(t *testing.T) {
+	origins := Origins{
+		"deployment.oss.enabled":           "base.yaml",
+		"deployment.oss.repository.branch": SetLayerName,
+	}
+
+	assert.Equal(t, SetLayerName, origins.Source("deployment.oss.repository.branch"))
+	assert.Equal(t, SetLayerName, origins.Source("deployment.oss.repository"))
+	assert.Equal(t, "base.yaml", origins.Source("deployment.oss"))
+	assert.Equal(t, "", origins.Source("registry"))
+}
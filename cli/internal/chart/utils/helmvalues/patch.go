@@ -0,0 +1,209 @@
+package helmvalues
+
+import (
+	"strings"
+)
+
+// maxDiffCells bounds the line diff table, larger edits fall back to re-encoding
+const maxDiffCells = 16 << 20
+
+// patchSource applies the line changes between baseline and encoded to source.
+//
+// The YAML encoder drops blank lines, trailing spaces and comment alignment, so the
+// baseline (the untouched tree, re-encoded) differs from the source only in that
+// whitespace. Diffing baseline against the edited encoding gives exactly the edited
+// lines, which are then replayed on the original file. It reports false when the
+// baseline cannot be lined up with the source.
+func patchSource(source, baseline, encoded []byte) ([]byte, bool) {
+	src := splitLines(string(source))
+	base := splitLines(string(baseline))
+	next := splitLines(string(encoded))
+
+	origin, ok := alignLines(src, base)
+	if !ok {
+		return nil, false
+	}
+
+	ops, ok := diffLines(base, next)
+	if !ok {
+		return nil, false
+	}
+
+	var out []string
+	copied := 0 // next source line to copy
+	copyUntil := func(end int) {
+		for ; copied < end; copied++ {
+			out = append(out, src[copied])
+		}
+	}
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			copyUntil(origin[ops[i].base] + 1)
+			i++
+			continue
+		}
+
+		// Collect one hunk of deletions and insertions
+		var deleted, inserted []int
+		for ; i < len(ops) && ops[i].kind != opEqual; i++ {
+			if ops[i].kind == opDelete {
+				deleted = append(deleted, ops[i].base)
+			} else {
+				inserted = append(inserted, ops[i].next)
+			}
+		}
+
+		for _, b := range deleted {
+			copyUntil(origin[b])
+			copied++ // drop the source line
+		}
+		for n, k := range inserted {
+			line := next[k]
+			if len(deleted) == len(inserted) {
+				line = keepCommentAlignment(src[origin[deleted[n]]], base[deleted[n]], line)
+			}
+			out = append(out, line)
+		}
+	}
+	copyUntil(len(src))
+
+	result := strings.Join(out, "\n")
+	if strings.HasSuffix(string(source), "\n") || len(source) == 0 {
+		result += "\n"
+	}
+	return []byte(result), true
+}
+
+// alignLines maps every baseline line to its source line. Only blank lines and
+// document markers may be skipped in the source.
+func alignLines(src, base []string) ([]int, bool) {
+	origin := make([]int, len(base))
+	i := 0
+	for j, line := range base {
+		want := normalizeLine(line)
+		for i < len(src) && normalizeLine(src[i]) != want {
+			if skipped := normalizeLine(src[i]); skipped != "" && skipped != "---" {
+				return nil, false
+			}
+			i++
+		}
+		if i == len(src) {
+			return nil, false
+		}
+		origin[j] = i
+		i++
+	}
+	return origin, true
+}
+
+// keepCommentAlignment reuses the spacing before a trailing comment when an edited
+// line keeps the comment of the line it replaces
+func keepCommentAlignment(src, base, line string) string {
+	comment := trailingComment(base)
+	if comment == "" || trailingComment(line) != comment {
+		return line
+	}
+
+	trimmed := strings.TrimRight(src, " \t")
+	if !strings.HasSuffix(trimmed, comment) {
+		return line
+	}
+	code := strings.TrimRight(strings.TrimSuffix(trimmed, comment), " \t")
+	gap := trimmed[len(code) : len(trimmed)-len(comment)]
+
+	return strings.TrimSuffix(strings.TrimRight(line, " \t"), " "+comment) + gap + comment
+}
+
+// trailingComment returns the "# ..." suffix of an encoded line, if any
+func trailingComment(line string) string {
+	idx := strings.Index(line, " #")
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimRight(line[idx+1:], " \t")
+}
+
+func normalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type diffOp struct {
+	kind opKind
+	base int // index into the baseline lines, for equal and delete
+	next int // index into the new lines, for equal and insert
+}
+
+// diffLines computes a longest-common-subsequence line diff
+func diffLines(a, b []string) ([]diffOp, bool) {
+	// Common prefix and suffix keep the table small for local edits
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	if (len(midA)+1)*(len(midB)+1) > maxDiffCells {
+		return nil, false
+	}
+
+	// lcs[i][j] is the LCS length of midA[i:] and midB[j:]
+	width := len(midB) + 1
+	lcs := make([]int32, (len(midA)+1)*width)
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{kind: opEqual, base: i, next: i})
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, diffOp{kind: opEqual, base: prefix + i, next: prefix + j})
+			i++
+			j++
+		case j < len(midB) && (i == len(midA) || lcs[i*width+j+1] >= lcs[(i+1)*width+j]):
+			ops = append(ops, diffOp{kind: opInsert, next: prefix + j})
+			j++
+		default:
+			ops = append(ops, diffOp{kind: opDelete, base: prefix + i})
+			i++
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		ops = append(ops, diffOp{kind: opEqual, base: len(a) - suffix + k, next: len(b) - suffix + k})
+	}
+	return ops, true
+}
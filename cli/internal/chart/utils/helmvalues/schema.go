@@ -0,0 +1,477 @@
+package helmvalues
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaFileName is the schema file Helm looks for next to Chart.yaml
+const SchemaFileName = "values.schema.json"
+
+//go:embed values.schema.json
+var defaultSchema []byte
+
+// Schema is the subset of JSON Schema used to validate Helm values: type, properties,
+// additionalProperties, required, enum, items, pattern, minLength, minimum, maximum
+// and local $ref. Other keywords are accepted and ignored.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 schemaTypes        `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *additional        `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes holds "type", which is either a single type name or a list of them
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = list
+	return nil
+}
+
+// additional holds "additionalProperties", which is either a boolean or a schema
+type additional struct {
+	allowed bool
+	schema  *Schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	a.schema = &Schema{}
+	return json.Unmarshal(data, a.schema)
+}
+
+// DefaultSchema returns the schema for the app-of-apps chart values built into the CLI
+func DefaultSchema() *Schema {
+	schema, err := ParseSchema(defaultSchema)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in values schema: %v", err))
+	}
+	return schema
+}
+
+// ParseSchema parses a JSON schema and compiles its patterns
+func ParseSchema(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid values schema: %w", err)
+	}
+	if err := schema.compile(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// LoadSchema reads and parses a JSON schema file
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := ParseSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// SchemaForChart returns the values.schema.json shipped with the chart in chartPath,
+// or the built-in schema when the chart has none. The second result names the source.
+func SchemaForChart(chartPath string) (*Schema, string, error) {
+	path := filepath.Join(chartPath, SchemaFileName)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return DefaultSchema(), "built-in schema", nil
+	}
+	schema, err := LoadSchema(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return schema, path, nil
+}
+
+// compile prepares the patterns of the schema and all its subschemas
+func (s *Schema) compile() error {
+	if s == nil {
+		return nil
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q in values schema: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+
+	children := []*Schema{s.Items}
+	if s.AdditionalProperties != nil {
+		children = append(children, s.AdditionalProperties.schema)
+	}
+	for _, group := range []map[string]*Schema{s.Properties, s.Definitions, s.Defs} {
+		for _, child := range group {
+			children = append(children, child)
+		}
+	}
+	for _, child := range children {
+		if err := child.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidationError is a single schema violation in a values file
+type ValidationError struct {
+	Path       string // dotted path of the offending key, "" for the top level
+	Line       int    // 1-based position in the file, 0 when unknown
+	Column     int
+	Message    string
+	Suggestion string // closest known key for unknown keys
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if e.Path == "" {
+		b.WriteString("(top level)")
+	} else {
+		b.WriteString(e.Path)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&b, " (line %d, column %d)", e.Line, e.Column)
+	}
+	b.WriteString(": ")
+	b.WriteString(e.Message)
+	if e.Suggestion != "" {
+		fmt.Fprintf(&b, ", did you mean %q?", e.Suggestion)
+	}
+	return b.String()
+}
+
+// ValidationErrors lists every violation found in a values file, in file order
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d schema violation(s):\n  %s", len(e), strings.Join(messages, "\n  "))
+}
+
+// Validate checks the document against schema and returns nil when it is valid.
+//
+// Besides the JSON Schema rules, unknown keys that closely resemble a declared property
+// are reported even where additional properties are allowed, since they are almost
+// always typos that Helm would silently ignore.
+func (s *Schema) Validate(doc *Document) error {
+	if s == nil || doc == nil {
+		return nil
+	}
+
+	v := &validator{root: s}
+	v.validate(s, doc.root, nil, doc.root)
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		a, b := v.errors[i], v.errors[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.errors
+}
+
+type validator struct {
+	root   *Schema
+	errors ValidationErrors
+}
+
+func (v *validator) report(path []string, at *yaml.Node, format string, args ...interface{}) *ValidationError {
+	err := &ValidationError{
+		Path:    joinPath(path),
+		Line:    at.Line,
+		Column:  at.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+	v.errors = append(v.errors, err)
+	return err
+}
+
+// validate checks node against schema. at is the node errors point to, which is the
+// key for mapping entries so that the position matches the line the key is on.
+func (v *validator) validate(schema *Schema, node *yaml.Node, path []string, at *yaml.Node) {
+	schema = v.deref(schema, path, at)
+	if schema == nil {
+		return
+	}
+	node = resolve(node)
+
+	actual := nodeType(node)
+	if actual == "null" {
+		// Helm drops null values before validating, they only remove chart defaults
+		return
+	}
+	if len(schema.Type) > 0 && !typeAllowed(schema.Type, actual) {
+		v.report(path, at, "expected %s, got %s", strings.Join(schema.Type, " or "), actual)
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(node, schema.Enum) {
+		allowed := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			allowed[i] = fmt.Sprintf("%v", value)
+		}
+		err := v.report(path, at, "value %q is not one of: %s", node.Value, strings.Join(allowed, ", "))
+		if actual == "string" {
+			err.Suggestion = closest(node.Value, allowed)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(schema, node, path, at)
+	case yaml.SequenceNode:
+		if schema.Items != nil {
+			for i, item := range node.Content {
+				itemPath := append(append([]string(nil), path...), fmt.Sprintf("[%d]", i))
+				v.validate(schema.Items, item, itemPath, item)
+			}
+		}
+	case yaml.ScalarNode:
+		v.validateScalar(schema, node, actual, path, at)
+	}
+}
+
+func (v *validator) validateMapping(schema *Schema, node *yaml.Node, path []string, at *yaml.Node) {
+	known := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+
+	strict := schema.AdditionalProperties != nil && !schema.AdditionalProperties.allowed
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		childPath := append(append([]string(nil), path...), key.Value)
+
+		if nodeType(resolve(value)) == "null" {
+			// Helm removes keys set to null, so they cannot be unknown
+			continue
+		}
+		if property, ok := schema.Properties[key.Value]; ok {
+			v.validate(property, value, childPath, key)
+			continue
+		}
+
+		suggestion := closest(key.Value, known)
+		switch {
+		case strict || suggestion != "":
+			v.report(childPath, key, "unknown key").Suggestion = suggestion
+		case schema.AdditionalProperties != nil && schema.AdditionalProperties.schema != nil:
+			v.validate(schema.AdditionalProperties.schema, value, childPath, key)
+		}
+	}
+
+	for _, name := range schema.Required {
+		if findKey(node, name) < 0 {
+			v.report(path, at, "missing required key %q", name)
+		}
+	}
+}
+
+func (v *validator) validateScalar(schema *Schema, node *yaml.Node, actual string, path []string, at *yaml.Node) {
+	if actual == "string" {
+		if schema.MinLength != nil && len([]rune(node.Value)) < *schema.MinLength {
+			if *schema.MinLength == 1 {
+				v.report(path, at, "must not be empty")
+			} else {
+				v.report(path, at, "must be at least %d characters", *schema.MinLength)
+			}
+		}
+		if schema.pattern != nil && node.Value != "" && !schema.pattern.MatchString(node.Value) {
+			v.report(path, at, "value %q does not match %s", node.Value, schema.Pattern)
+		}
+	}
+
+	if actual == "integer" || actual == "number" {
+		var number float64
+		if err := node.Decode(&number); err != nil {
+			return
+		}
+		if schema.Minimum != nil && number < *schema.Minimum {
+			v.report(path, at, "must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			v.report(path, at, "must be at most %v", *schema.Maximum)
+		}
+	}
+}
+
+// deref follows local references of the form #/definitions/name or #/$defs/name
+func (v *validator) deref(schema *Schema, path []string, at *yaml.Node) *Schema {
+	for depth := 0; schema != nil && schema.Ref != ""; depth++ {
+		ref := schema.Ref
+		var target *Schema
+		switch {
+		case strings.HasPrefix(ref, "#/definitions/"):
+			target = v.root.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		case strings.HasPrefix(ref, "#/$defs/"):
+			target = v.root.Defs[strings.TrimPrefix(ref, "#/$defs/")]
+		case ref == "#":
+			target = v.root
+		}
+		if target == nil || depth > 32 {
+			v.report(path, at, "schema reference %q cannot be resolved", ref)
+			return nil
+		}
+		schema = target
+	}
+	return schema
+}
+
+// nodeType returns the JSON type of a node
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func typeAllowed(allowed []string, actual string) bool {
+	for _, t := range allowed {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func inEnum(node *yaml.Node, enum []interface{}) bool {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return false
+	}
+	// Compare through JSON so that YAML integers match JSON numbers
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	for _, candidate := range enum {
+		if expected, err := json.Marshal(candidate); err == nil && string(expected) == string(encoded) {
+			return true
+		}
+		if a, ok := candidate.(float64); ok {
+			if b, ok := value.(int); ok && a == float64(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// joinPath renders keys as a values path, escaping dots inside keys
+func joinPath(keys []string) string {
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 && !strings.HasPrefix(key, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(strings.ReplaceAll(key, ".", `\.`))
+	}
+	return b.String()
+}
+
+// closest returns the candidate nearest to name, or "" when none is close enough to be
+// a likely typo. Case-only differences always match.
+func closest(name string, candidates []string) string {
+	best, bestDistance := "", 0
+	for _, candidate := range candidates {
+		if strings.EqualFold(name, candidate) {
+			return candidate
+		}
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if best == "" || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	limit := 2
+	if len(name) <= 4 {
+		limit = 1
+	}
+	if best == "" || bestDistance > limit {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
@@ -0,0 +1,161 @@
+package helmvalues
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validationErrors(t *testing.T, schema *Schema, content string) ValidationErrors {
+	t.Helper()
+	doc, err := Parse([]byte(content))
+	require.NoError(t, err)
+
+	err = schema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	return errs
+}
+
+func TestDefaultSchema_AcceptsChartValues(t *testing.T) {
+	for _, path := range []string{
+		"../../../../../manifests/app-of-apps/values.yaml",
+		"../../../../helm-values-example.yaml",
+	} {
+		doc, err := Load(path)
+		require.NoError(t, err)
+		assert.NoError(t, DefaultSchema().Validate(doc), path)
+	}
+
+	assert.Empty(t, validationErrors(t, DefaultSchema(), sampleValues))
+}
+
+func TestDefaultSchema_UnknownKeyWithSuggestion(t *testing.T) {
+	errs := validationErrors(t, DefaultSchema(), `deployment:
+  oss:
+    enabled: true
+    ingres:
+      localhost:
+        enabled: true
+`)
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, "deployment.oss.ingres", errs[0].Path)
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Equal(t, 5, errs[0].Column)
+	assert.Equal(t, "ingress", errs[0].Suggestion)
+	assert.Equal(t, `deployment.oss.ingres (line 4, column 5): unknown key, did you mean "ingress"?`, errs[0].Error())
+}
+
+func TestDefaultSchema_TypoAtPermissiveLevel(t *testing.T) {
+	// Unknown top-level keys are passed to the apps, but near misses are typos
+	errs := validationErrors(t, DefaultSchema(), "deploymnet:\n  oss:\n    enabled: true\nglobal:\n  domain: example.com\n")
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, "deploymnet", errs[0].Path)
+	assert.Equal(t, "deployment", errs[0].Suggestion)
+}
+
+func TestDefaultSchema_TypesAndPatterns(t *testing.T) {
+	errs := validationErrors(t, DefaultSchema(), `deployment:
+  oss:
+    enabled: "yes"
+    repository:
+      URL: github.com/flamingo-stack/openframe-oss-tenant
+      branch: ""
+    ingress:
+      custom:
+        host: Not_A_Host
+      ngrok:
+        allowedIPs: [10.0.0.1, ""]
+registry:
+  ghcr:
+    username: 42
+`)
+
+	messages := make(map[string]string)
+	for _, err := range errs {
+		messages[err.Path] = err.Message
+	}
+	assert.Equal(t, map[string]string{
+		"deployment.oss.enabled":                     "expected boolean, got string",
+		"deployment.oss.repository.URL":              `value "github.com/flamingo-stack/openframe-oss-tenant" does not match ^(https?://|ssh://|git@)`,
+		"deployment.oss.repository.branch":           "must not be empty",
+		"deployment.oss.ingress.custom.host":         `value "Not_A_Host" does not match ^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)+$`,
+		"deployment.oss.ingress.ngrok.allowedIPs[1]": "must not be empty",
+		"registry.ghcr.username":                     "expected string, got integer",
+	}, messages)
+
+	// Errors are reported in file order
+	assert.Equal(t, 3, errs[0].Line)
+	assert.Equal(t, 14, errs[len(errs)-1].Line)
+}
+
+func TestSchema_Keywords(t *testing.T) {
+	schema, err := ParseSchema([]byte(`{
+		"type": "object",
+		"required": ["mode"],
+		"properties": {
+			"mode": {"enum": ["oss", "saas"]},
+			"replicas": {"type": "integer", "minimum": 1, "maximum": 5},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"ratio": {"type": "number"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	errs := validationErrors(t, schema, "mode: sass\nreplicas: 7\nlabels:\n  team: 3\nratio: 1\n")
+	require.Len(t, errs, 3)
+	assert.Equal(t, `value "sass" is not one of: oss, saas`, errs[0].Message)
+	assert.Equal(t, "saas", errs[0].Suggestion)
+	assert.Equal(t, "must be at most 5", errs[1].Message)
+	assert.Equal(t, "labels.team", errs[2].Path)
+
+	errs = validationErrors(t, schema, "replicas: 2\n")
+	require.Len(t, errs, 1)
+	assert.Equal(t, `(top level) (line 1, column 1): missing required key "mode"`, errs[0].Error())
+}
+
+func TestSchema_InvalidSchema(t *testing.T) {
+	_, err := ParseSchema([]byte(`{"type": 5}`))
+	assert.Error(t, err)
+
+	_, err = ParseSchema([]byte(`{"properties": {"a": {"pattern": "("}}}`))
+	assert.Error(t, err)
+
+	schema, err := ParseSchema([]byte(`{"properties": {"a": {"$ref": "#/definitions/missing"}}}`))
+	require.NoError(t, err)
+	errs := validationErrors(t, schema, "a: 1\n")
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "cannot be resolved")
+}
+
+func TestSchemaForChart(t *testing.T) {
+	chartDir := t.TempDir()
+
+	schema, source, err := SchemaForChart(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, "built-in schema", source)
+	assert.NotNil(t, schema.Properties["deployment"])
+
+	path := filepath.Join(chartDir, SchemaFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"additionalProperties": false}`), 0644))
+	schema, source, err = SchemaForChart(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, path, source)
+	assert.Len(t, validationErrors(t, schema, "deployment: {}\n"), 1)
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"localhost", "ngrok", "custom"}
+	assert.Equal(t, "localhost", closest("locahost", candidates))
+	assert.Equal(t, "ngrok", closest("NGROK", candidates))
+	assert.Equal(t, "", closest("gcp", candidates))
+	assert.Equal(t, "", closest("anything", nil))
+}
@@ -0,0 +1,354 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+const (
+	// KeyFileEnv overrides the location of the age key file, it is the variable SOPS reads too
+	KeyFileEnv = "SOPS_AGE_KEY_FILE"
+
+	encryptedPrefix = "ENC[age:"
+	encryptedSuffix = "]"
+)
+
+// SecretPaths are the values that hold credentials and are encrypted by default
+var SecretPaths = []string{
+	"registry.docker.password",
+	"registry.ghcr.username",
+	"registry.ghcr.password",
+	"deployment.oss.ingress.ngrok.credentials.apiKey",
+	"deployment.oss.ingress.ngrok.credentials.authtoken",
+	"deployment.saas.repository.password",
+}
+
+// Manager encrypts and decrypts helm values with a local age key. Plaintext is only
+// passed to the sops and age binaries through stdin and stdout, never through files.
+type Manager struct {
+	executor executor.CommandExecutor
+	keyFile  string
+}
+
+// NewManager creates a manager that uses the age identity in keyFile
+func NewManager(exec executor.CommandExecutor, keyFile string) *Manager {
+	return &Manager{executor: exec, keyFile: keyFile}
+}
+
+// NewDefaultManager creates a manager for the default key file that runs the installed
+// sops and age binaries. Decryption only reads, so dry-runs do not apply to it.
+func NewDefaultManager() *Manager {
+	// An unknown key location is only reported once a value actually needs the key
+	keyFile, _ := DefaultKeyFile()
+	return NewManager(executor.NewRealCommandExecutor(false, false), keyFile)
+}
+
+// DefaultKeyFile returns $SOPS_AGE_KEY_FILE, or ~/.config/openframe/age/keys.txt when it is unset
+func DefaultKeyFile() (string, error) {
+	if keyFile := os.Getenv(KeyFileEnv); keyFile != "" {
+		return keyFile, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "openframe", "age", "keys.txt"), nil
+}
+
+// KeyFile returns the path of the age key file
+func (m *Manager) KeyFile() string {
+	return m.keyFile
+}
+
+// IsEncrypted reports whether value is an ENC[age:...] leaf
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// IsSOPS reports whether the values were encrypted as a whole file by SOPS
+func IsSOPS(values *helmvalues.Document) bool {
+	return len(values.Keys("sops")) > 0
+}
+
+// EncryptedPaths returns the paths of the ENC[age:...] leaves in values
+func EncryptedPaths(values *helmvalues.Document) []string {
+	paths := make([]string, 0)
+	for _, path := range values.StringPaths() {
+		if value, _ := values.GetString(path); IsEncrypted(value) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// DecryptLayers replaces encrypted layers with their plaintext in memory. SOPS files
+// are decrypted as a whole, ENC[age:...] leaves one by one.
+func (m *Manager) DecryptLayers(ctx context.Context, layers []helmvalues.Layer) error {
+	for i, layer := range layers {
+		if layer.Values == nil {
+			continue
+		}
+		values, err := m.Decrypt(ctx, layer.Name, layer.Values)
+		if err != nil {
+			return fmt.Errorf("%s: %w", layer.Name, err)
+		}
+		layers[i].Values = values
+	}
+	return nil
+}
+
+// Decrypt returns the plaintext of values loaded from path. Values without encrypted
+// content are returned as they are, ENC[age:...] leaves are decrypted in place.
+func (m *Manager) Decrypt(ctx context.Context, path string, values *helmvalues.Document) (*helmvalues.Document, error) {
+	if IsSOPS(values) && path != helmvalues.SetLayerName {
+		return m.decryptSOPS(ctx, path)
+	}
+
+	for _, valuePath := range EncryptedPaths(values) {
+		ciphertext, _ := values.GetString(valuePath)
+		plaintext, err := m.decryptValue(ctx, ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s: %w", valuePath, err)
+		}
+		if err := values.Set(valuePath, plaintext); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func (m *Manager) decryptSOPS(ctx context.Context, path string) (*helmvalues.Document, error) {
+	if err := m.requireKeyFile(); err != nil {
+		return nil, err
+	}
+	result, err := m.run(ctx, executor.ExecuteOptions{
+		Command: "sops",
+		Args:    []string{"--decrypt", "--input-type", "yaml", "--output-type", "yaml", path},
+		Env:     map[string]string{KeyFileEnv: m.keyFile},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt SOPS file: %w", err)
+	}
+	values, err := helmvalues.Parse([]byte(result.Stdout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted values: %w", err)
+	}
+	return values, nil
+}
+
+func (m *Manager) decryptValue(ctx context.Context, value string) (string, error) {
+	encoded := strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix)
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	if err := m.requireKeyFile(); err != nil {
+		return "", err
+	}
+	result, err := m.run(ctx, executor.ExecuteOptions{
+		Command: "age",
+		Args:    []string{"--decrypt", "--identity", m.keyFile},
+		Stdin:   string(ciphertext),
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.Stdout, nil
+}
+
+// Encrypt replaces the non-empty plaintext strings at paths with ENC[age:...] leaves for
+// the public key of the key file. Missing and already encrypted values are skipped. It
+// returns the paths that were encrypted.
+func (m *Manager) Encrypt(ctx context.Context, values *helmvalues.Document, paths []string) ([]string, error) {
+	encrypted := make([]string, 0)
+	var recipient string
+	for _, path := range paths {
+		value, ok := values.GetString(path)
+		if !ok || value == "" || IsEncrypted(value) {
+			continue
+		}
+
+		if recipient == "" {
+			var err error
+			if recipient, err = m.Recipient(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		result, err := m.run(ctx, executor.ExecuteOptions{
+			Command: "age",
+			Args:    []string{"--encrypt", "--recipient", recipient},
+			Stdin:   value,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt %s: %w", path, err)
+		}
+		leaf := encryptedPrefix + base64.StdEncoding.EncodeToString([]byte(result.Stdout)) + encryptedSuffix
+		if err := values.Set(path, leaf); err != nil {
+			return nil, err
+		}
+		encrypted = append(encrypted, path)
+	}
+	return encrypted, nil
+}
+
+// Recipient returns the age public key of the key file
+func (m *Manager) Recipient(ctx context.Context) (string, error) {
+	if err := m.requireKeyFile(); err != nil {
+		return "", err
+	}
+	result, err := m.run(ctx, executor.ExecuteOptions{
+		Command: "age-keygen",
+		Args:    []string{"-y", m.keyFile},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read the public key of %s: %w", m.keyFile, err)
+	}
+	recipient := strings.TrimSpace(result.Stdout)
+	if recipient == "" {
+		return "", fmt.Errorf("no public key found in %s", m.keyFile)
+	}
+	return recipient, nil
+}
+
+// EnsureKey generates the age key file when it does not exist yet. created is true when
+// a new key was written.
+func (m *Manager) EnsureKey(ctx context.Context) (created bool, err error) {
+	if m.keyFile == "" {
+		return false, fmt.Errorf("age key file location is unknown, set %s", KeyFileEnv)
+	}
+	if _, err := os.Stat(m.keyFile); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to check age key file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.keyFile), 0700); err != nil {
+		return false, fmt.Errorf("failed to create age key directory: %w", err)
+	}
+	if _, err := m.run(ctx, executor.ExecuteOptions{
+		Command: "age-keygen",
+		Args:    []string{"-o", m.keyFile},
+	}); err != nil {
+		return false, fmt.Errorf("failed to generate age key: %w", err)
+	}
+	return true, nil
+}
+
+// Edit decrypts the values file at path into a private temporary file, lets edit change
+// it, and writes the result back with the secrets encrypted again. Values that were
+// encrypted before stay encrypted, as do the default SecretPaths. The temporary file is
+// removed before Edit returns. changed is false when the plaintext was not modified.
+func (m *Manager) Edit(ctx context.Context, path string, edit func(file string) error) (changed bool, err error) {
+	values, err := helmvalues.Load(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	if IsSOPS(values) {
+		return false, fmt.Errorf("%s is encrypted with SOPS, edit it with 'sops %s'", path, path)
+	}
+	paths := append(EncryptedPaths(values), SecretPaths...)
+
+	if _, err := m.Decrypt(ctx, path, values); err != nil {
+		return false, err
+	}
+	plaintext, err := values.Bytes()
+	if err != nil {
+		return false, err
+	}
+
+	dir, err := os.MkdirTemp("", "openframe-secrets-")
+	if err != nil {
+		return false, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, filepath.Base(path))
+	if err := os.WriteFile(file, plaintext, 0600); err != nil {
+		return false, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := edit(file); err != nil {
+		return false, err
+	}
+
+	edited, err := os.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read edited values: %w", err)
+	}
+	if string(edited) == string(plaintext) {
+		return false, nil
+	}
+	editedValues, err := helmvalues.Parse(edited)
+	if err != nil {
+		return false, fmt.Errorf("edited values are not valid YAML, nothing was saved: %w", err)
+	}
+
+	if _, err := m.Encrypt(ctx, editedValues, paths); err != nil {
+		return false, err
+	}
+	if err := editedValues.Write(path); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func (m *Manager) requireKeyFile() error {
+	if m.keyFile == "" {
+		return fmt.Errorf("age key file location is unknown, set %s", KeyFileEnv)
+	}
+	if _, err := os.Stat(m.keyFile); err != nil {
+		return fmt.Errorf("age key file %s not found, set %s or run 'openframe secrets encrypt' to create one", m.keyFile, KeyFileEnv)
+	}
+	return nil
+}
+
+// run executes a sops or age command, explaining how to install the binary when it is missing
+func (m *Manager) run(ctx context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	result, err := m.executor.ExecuteWithOptions(ctx, options)
+	if err == nil {
+		return result, nil
+	}
+	if result != nil && strings.TrimSpace(result.Stderr) != "" {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(result.Stderr))
+	}
+	if !commandExists(options.Command) {
+		return nil, fmt.Errorf("%s is not installed. %s", options.Command, InstallHelp(options.Command))
+	}
+	return nil, err
+}
+
+func commandExists(cmd string) bool {
+	_, err := exec.LookPath(cmd)
+	return err == nil
+}
+
+// InstallHelp explains how to install sops or age (which provides age-keygen)
+func InstallHelp(tool string) string {
+	name, url := "age", "https://github.com/FiloSottile/age#installation"
+	if tool == "sops" {
+		name, url = "sops", "https://github.com/getsops/sops/releases"
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return fmt.Sprintf("Install it via Homebrew 'brew install %s' or from %s", name, url)
+	case "linux":
+		if tool == "sops" {
+			return fmt.Sprintf("Download the sops binary from %s", url)
+		}
+		return fmt.Sprintf("Install it with your package manager, e.g. 'sudo apt-get install age', or from %s", url)
+	case "windows":
+		return fmt.Sprintf("Install it via Chocolatey 'choco install %s' or from %s", name, url)
+	default:
+		return fmt.Sprintf("Please install it from %s", url)
+	}
+}
@@ -0,0 +1,220 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAge stands in for the sops and age binaries, "encrypting" by adding a prefix
+type fakeAge struct {
+	commands []executor.ExecuteOptions
+	sopsOut  string
+}
+
+func (f *fakeAge) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	return f.ExecuteWithOptions(ctx, executor.ExecuteOptions{Command: name, Args: args})
+}
+
+func (f *fakeAge) ExecuteWithOptions(ctx context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	f.commands = append(f.commands, options)
+	switch {
+	case options.Command == "age-keygen" && options.Args[0] == "-y":
+		return &executor.CommandResult{Stdout: "age1recipient\n"}, nil
+	case options.Command == "age-keygen" && options.Args[0] == "-o":
+		return &executor.CommandResult{}, os.WriteFile(options.Args[1], []byte("AGE-SECRET-KEY-1\n"), 0600)
+	case options.Command == "age" && options.Args[0] == "--encrypt":
+		return &executor.CommandResult{Stdout: "sealed:" + options.Stdin}, nil
+	case options.Command == "age" && options.Args[0] == "--decrypt":
+		if !strings.HasPrefix(options.Stdin, "sealed:") {
+			return &executor.CommandResult{ExitCode: 1, Stderr: "age: error: no identity matched any of the recipients"}, fmt.Errorf("exit status 1")
+		}
+		return &executor.CommandResult{Stdout: strings.TrimPrefix(options.Stdin, "sealed:")}, nil
+	case options.Command == "sops":
+		return &executor.CommandResult{Stdout: f.sopsOut}, nil
+	}
+	return nil, fmt.Errorf("unexpected command %s", options.Command)
+}
+
+func sealed(plaintext string) string {
+	return "ENC[age:" + base64.StdEncoding.EncodeToString([]byte("sealed:"+plaintext)) + "]"
+}
+
+func newTestManager(t *testing.T) (*Manager, *fakeAge) {
+	t.Helper()
+	keyFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(keyFile, []byte("AGE-SECRET-KEY-1\n"), 0600))
+	fake := &fakeAge{}
+	return NewManager(fake, keyFile), fake
+}
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	manager, fake := newTestManager(t)
+	values, err := helmvalues.Parse([]byte(`registry:
+  docker:
+    username: default
+    password: hunter2 # docker hub token
+  ghcr:
+    username: ""
+deployment:
+  saas:
+    repository:
+      password: ghp_token
+`))
+	require.NoError(t, err)
+
+	encrypted, err := manager.Encrypt(context.Background(), values, SecretPaths)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"registry.docker.password", "deployment.saas.repository.password"}, encrypted)
+	assert.Equal(t, encrypted, EncryptedPaths(values))
+
+	out, err := values.Bytes()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "hunter2")
+	assert.Contains(t, string(out), "password: "+sealed("hunter2")+" # docker hub token")
+	assert.Contains(t, string(out), "username: default")
+
+	// Plaintext only ever travels through stdin
+	for _, command := range fake.commands {
+		assert.NotContains(t, strings.Join(command.Args, " "), "hunter2")
+	}
+	assert.Equal(t, []string{"--encrypt", "--recipient", "age1recipient"}, fake.commands[1].Args)
+
+	// Encrypting again leaves encrypted values alone
+	encrypted, err = manager.Encrypt(context.Background(), values, SecretPaths)
+	require.NoError(t, err)
+	assert.Empty(t, encrypted)
+
+	decrypted, err := manager.Decrypt(context.Background(), "helm-values.yaml", values)
+	require.NoError(t, err)
+	password, _ := decrypted.GetString("registry.docker.password")
+	assert.Equal(t, "hunter2", password)
+	token, _ := decrypted.GetString("deployment.saas.repository.password")
+	assert.Equal(t, "ghp_token", token)
+}
+
+func TestDecrypt_Errors(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	values, err := helmvalues.Parse([]byte("registry:\n  docker:\n    password: ENC[age:bm90LXNlYWxlZA==]\n"))
+	require.NoError(t, err)
+	_, err = manager.Decrypt(context.Background(), "helm-values.yaml", values)
+	assert.EqualError(t, err, "failed to decrypt registry.docker.password: age: error: no identity matched any of the recipients")
+
+	values, err = helmvalues.Parse([]byte("registry:\n  docker:\n    password: ENC[age:***]\n"))
+	require.NoError(t, err)
+	_, err = manager.Decrypt(context.Background(), "helm-values.yaml", values)
+	assert.ErrorContains(t, err, "invalid ciphertext")
+
+	missingKey := NewManager(&fakeAge{}, filepath.Join(t.TempDir(), "missing.txt"))
+	values, err = helmvalues.Parse([]byte("registry:\n  docker:\n    password: " + sealed("x") + "\n"))
+	require.NoError(t, err)
+	_, err = missingKey.Decrypt(context.Background(), "helm-values.yaml", values)
+	assert.ErrorContains(t, err, "not found, set SOPS_AGE_KEY_FILE")
+
+	// Plaintext values need no key at all
+	values, err = helmvalues.Parse([]byte("registry:\n  docker:\n    password: plain\n"))
+	require.NoError(t, err)
+	_, err = missingKey.Decrypt(context.Background(), "helm-values.yaml", values)
+	assert.NoError(t, err)
+}
+
+func TestDecryptLayers_SOPS(t *testing.T) {
+	manager, fake := newTestManager(t)
+	fake.sopsOut = "registry:\n  docker:\n    password: hunter2\n"
+
+	encrypted, err := helmvalues.Parse([]byte(`registry:
+  docker:
+    password: ENC[AES256_GCM,data:abc,iv:def,tag:ghi,type:str]
+sops:
+  age:
+    - recipient: age1recipient
+  mac: ENC[AES256_GCM,data:xyz]
+`))
+	require.NoError(t, err)
+	sets, err := helmvalues.ParseSet([]string{"registry.ghcr.password=" + sealed("ghcr")})
+	require.NoError(t, err)
+
+	layers := []helmvalues.Layer{{Name: "secrets.enc.yaml", Values: encrypted}, {Name: helmvalues.SetLayerName, Values: sets}}
+	require.NoError(t, manager.DecryptLayers(context.Background(), layers))
+
+	assert.False(t, IsSOPS(layers[0].Values))
+	password, _ := layers[0].Values.GetString("registry.docker.password")
+	assert.Equal(t, "hunter2", password)
+	ghcr, _ := layers[1].Values.GetString("registry.ghcr.password")
+	assert.Equal(t, "ghcr", ghcr)
+
+	assert.Equal(t, "sops", fake.commands[0].Command)
+	assert.Equal(t, "secrets.enc.yaml", fake.commands[0].Args[len(fake.commands[0].Args)-1])
+	assert.Equal(t, manager.KeyFile(), fake.commands[0].Env[KeyFileEnv])
+}
+
+func TestEnsureKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "age", "keys.txt")
+	manager := NewManager(&fakeAge{}, keyFile)
+
+	created, err := manager.EnsureKey(context.Background())
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.FileExists(t, keyFile)
+
+	created, err = manager.EnsureKey(context.Background())
+	require.NoError(t, err)
+	assert.False(t, created)
+}
+
+func TestEdit(t *testing.T) {
+	manager, _ := newTestManager(t)
+	path := filepath.Join(t.TempDir(), "helm-values.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("registry:\n  docker:\n    password: "+sealed("old")+"\n"), 0644))
+
+	var tempFile string
+	changed, err := manager.Edit(context.Background(), path, func(file string) error {
+		tempFile = file
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "registry:\n  docker:\n    password: old\n", string(data))
+		return os.WriteFile(file, []byte("registry:\n  docker:\n    password: new\n  ghcr:\n    password: token\n"), 0600)
+	})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.NoFileExists(t, tempFile, "plaintext is removed after editing")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "registry:\n  docker:\n    password: "+sealed("new")+"\n  ghcr:\n    password: "+sealed("token")+"\n", string(data))
+
+	changed, err = manager.Edit(context.Background(), path, func(string) error { return nil })
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestEdit_RejectsSOPSFiles(t *testing.T) {
+	manager, _ := newTestManager(t)
+	path := filepath.Join(t.TempDir(), "helm-values.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("a: ENC[AES256_GCM,data:abc]\nsops:\n  mac: x\n"), 0644))
+
+	_, err := manager.Edit(context.Background(), path, func(string) error { return nil })
+	assert.ErrorContains(t, err, "is encrypted with SOPS")
+}
+
+func TestDefaultKeyFile(t *testing.T) {
+	t.Setenv(KeyFileEnv, "/keys/age.txt")
+	keyFile, err := DefaultKeyFile()
+	require.NoError(t, err)
+	assert.Equal(t, "/keys/age.txt", keyFile)
+
+	t.Setenv(KeyFileEnv, "")
+	keyFile, err = DefaultKeyFile()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(".config", "openframe", "age", "keys.txt"), keyFile[len(keyFile)-len(filepath.Join(".config", "openframe", "age", "keys.txt")):])
+}
@@ -1,6 +1,11 @@
 package types
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
+)
 
 // DockerRegistryConfig holds Docker registry settings
 type DockerRegistryConfig struct {
@@ -18,6 +23,41 @@ const (
 	DeploymentModeSaaSShared DeploymentMode = "saas-shared"
 )
 
+// deploymentModeNames maps the --deployment-mode names to deployment modes
+var deploymentModeNames = []struct {
+	name string
+	mode DeploymentMode
+}{
+	{"oss-tenant", DeploymentModeOSS},
+	{"saas-tenant", DeploymentModeSaaS},
+	{"saas-shared", DeploymentModeSaaSShared},
+}
+
+// ParseDeploymentMode converts a --deployment-mode name such as "oss-tenant" to a deployment mode
+func ParseDeploymentMode(name string) (DeploymentMode, error) {
+	for _, entry := range deploymentModeNames {
+		if entry.name == name {
+			return entry.mode, nil
+		}
+	}
+	return "", fmt.Errorf("invalid deployment mode: %s", name)
+}
+
+// Name returns the --deployment-mode name of the mode, e.g. "oss-tenant"
+func (m DeploymentMode) Name() string {
+	for _, entry := range deploymentModeNames {
+		if entry.mode == m {
+			return entry.name
+		}
+	}
+	return string(m)
+}
+
+// IsSaaS reports whether the mode deploys the SaaS tenant, shared or not
+func (m DeploymentMode) IsSaaS() bool {
+	return m == DeploymentModeSaaS || m == DeploymentModeSaaSShared
+}
+
 // IngressType represents the type of ingress to use
 type IngressType string
 
@@ -25,8 +65,30 @@ const (
 	IngressTypeLocalhost IngressType = "localhost"
 	IngressTypeNgrok     IngressType = "ngrok"
 	IngressTypeGCP       IngressType = "gcp"
+	IngressTypeCustom    IngressType = "custom"
+)
+
+// TLSSource is where the certificate of a custom domain comes from
+type TLSSource string
+
+const (
+	TLSSourceSecret  TLSSource = "secret"   // TLS secret that already exists in the cluster
+	TLSSourceFiles   TLSSource = "files"    // PEM certificate and key files
+	TLSSourceLocalCA TLSSource = "local-ca" // Certificate issued by the CLI's local certificate authority
 )
 
+// CustomDomainNamespace is where the gateway ingress, and therefore its TLS secret, lives
+const CustomDomainNamespace = "microservices"
+
+// CustomDomainConfig holds the custom domain ingress settings
+type CustomDomainConfig struct {
+	Host          string    `json:"host"`
+	TLSSecretName string    `json:"tlsSecretName"`
+	TLSSource     TLSSource `json:"tlsSource"`
+	CertFile      string    `json:"certFile,omitempty"` // Set for the files and local-ca sources
+	KeyFile       string    `json:"keyFile,omitempty"`
+}
+
 // NgrokConfig holds Ngrok-specific configuration
 type NgrokConfig struct {
 	// Ngrok credentials
@@ -52,8 +114,10 @@ type SaaSConfig struct {
 
 // IngressConfig holds ingress configuration options
 type IngressConfig struct {
-	Type        IngressType  `json:"type"`
-	NgrokConfig *NgrokConfig `json:"ngrok,omitempty"`
+	Type         IngressType         `json:"type"`
+	NgrokConfig  *NgrokConfig        `json:"ngrok,omitempty"`
+	CustomDomain *CustomDomainConfig `json:"custom,omitempty"`
+	GCPTenantID  string              `json:"gcpTenantID,omitempty"` // Domain prefix of the gcp ingress
 }
 
 // NgrokRegistrationURLs contains the URLs for Ngrok registration and documentation
@@ -73,15 +137,15 @@ var NgrokRegistrationURLs = struct {
 
 // ChartConfiguration holds all configurable options for chart installation
 type ChartConfiguration struct {
-	BaseHelmValuesPath string                 // Path to the original helm-values.yaml (read-only)
-	TempHelmValuesPath string                 // Path to the temporary helm values file for installation
-	ExistingValues     map[string]interface{} // Current values from the file
-	ModifiedSections   []string               // Track which sections were modified
-	DeploymentMode     *DeploymentMode        // nil means use existing, otherwise use this value
-	Branch             *string                // nil means use existing, otherwise use this value
-	DockerRegistry     *DockerRegistryConfig  // nil means use existing, otherwise use this value
-	IngressConfig      *IngressConfig         // nil means use existing, otherwise use this value
-	SaaSConfig         *SaaSConfig            // nil means use existing, otherwise use this value
+	BaseHelmValuesPath string                // Path to the original helm-values.yaml (read-only)
+	TempHelmValuesPath string                // Path to the temporary helm values file for installation
+	ExistingValues     *helmvalues.Document  // Current values from the file
+	ModifiedSections   []string              // Track which sections were modified
+	DeploymentMode     *DeploymentMode       // nil means use existing, otherwise use this value
+	Branch             *string               // nil means use existing, otherwise use this value
+	DockerRegistry     *DockerRegistryConfig // nil means use existing, otherwise use this value
+	IngressConfig      *IngressConfig        // nil means use existing, otherwise use this value
+	SaaSConfig         *SaaSConfig           // nil means use existing, otherwise use this value
 }
 
 // GetRepositoryURL returns the appropriate repository URL based on deployment mode
@@ -4,12 +4,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/utils/helmvalues"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestIngressType_Constants(t *testing.T) {
 	assert.Equal(t, IngressType("localhost"), IngressTypeLocalhost)
 	assert.Equal(t, IngressType("ngrok"), IngressTypeNgrok)
+	assert.Equal(t, IngressType("custom"), IngressTypeCustom)
 }
 
 func TestDockerRegistryConfig_Creation(t *testing.T) {
@@ -111,7 +113,7 @@ func TestChartConfiguration_MinimalConfig(t *testing.T) {
 	config := &ChartConfiguration{
 		BaseHelmValuesPath: "/path/to/base/values.yaml",
 		TempHelmValuesPath: "/path/to/temp/values.yaml",
-		ExistingValues:     make(map[string]interface{}),
+		ExistingValues:     helmvalues.New(),
 		ModifiedSections:   []string{},
 	}
 
@@ -144,7 +146,7 @@ func TestChartConfiguration_FullConfig(t *testing.T) {
 	config := &ChartConfiguration{
 		BaseHelmValuesPath: "/path/to/base/values.yaml",
 		TempHelmValuesPath: "/path/to/temp/values.yaml",
-		ExistingValues:     map[string]interface{}{"test": "value"},
+		ExistingValues:     helmvalues.New(),
 		ModifiedSections:   []string{"branch", "docker", "ingress"},
 		Branch:             &branch,
 		DockerRegistry:     dockerConfig,
@@ -259,24 +261,19 @@ func TestNgrokConfig_IPAllowlistScenarios(t *testing.T) {
 
 func TestChartConfiguration_DeepCopyBehavior(t *testing.T) {
 	// Test that modifying nested structures doesn't affect original
-	originalValues := map[string]interface{}{
-		"global": map[string]interface{}{
-			"repoBranch": "main",
-		},
-	}
+	originalValues, err := helmvalues.Parse([]byte("global:\n  repoBranch: main\n"))
+	assert.NoError(t, err)
 
 	config := &ChartConfiguration{
 		ExistingValues: originalValues,
 	}
 
 	// Modify the values through config
-	if global, ok := config.ExistingValues["global"].(map[string]interface{}); ok {
-		global["repoBranch"] = "develop"
-	}
+	assert.NoError(t, config.ExistingValues.Set("global.repoBranch", "develop"))
 
 	// Original should be modified too (since it's the same reference)
-	global := originalValues["global"].(map[string]interface{})
-	assert.Equal(t, "develop", global["repoBranch"])
+	branch, _ := originalValues.GetString("global.repoBranch")
+	assert.Equal(t, "develop", branch)
 }
 
 func TestIngressType_StringComparison(t *testing.T) {
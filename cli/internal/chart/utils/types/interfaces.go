@@ -24,6 +24,12 @@ type ClusterLister interface {
 	ListClusters() ([]clusterDomain.ClusterInfo, error)
 }
 
+// ClusterTargeter points the kubectl and helm commands of this process at a selected
+// cluster without changing the user's current context
+type ClusterTargeter interface {
+	TargetCluster(name string) error
+}
+
 // HelmProvider manages Helm chart operations
 type HelmProvider interface {
 	InstallArgoCD(ctx context.Context, config config.ChartInstallConfig) error
@@ -142,6 +148,13 @@ type InstallationRequest struct {
 	GitHubRepo     string
 	GitHubBranch   string
 	CertDir        string
-	DeploymentMode string // Deployment mode: "oss-tenant", "saas-tenant", "saas-shared", or empty for interactive
-	NonInteractive bool   // Skip all prompts, use existing helm-values.yaml
+	DeploymentMode string   // Deployment mode: "oss-tenant", "saas-tenant", "saas-shared", or empty for interactive
+	NonInteractive bool     // Skip all prompts, use existing helm-values.yaml
+	ValuesFiles    []string // Values files merged in order, helm-values.yaml when empty
+	SetValues      []string // --set overrides applied on top of the values files
+	AnswersFile    string   // Answers to the configuration wizard, implies NonInteractive
+	SkipVerify     []string // Credential checks to skip: registry, ngrok, git or all
+	Tenant         string   // Tenant ID of a multi-tenant saas-shared install, empty for a single tenant
+	ArgoCDChart    string   // Local argo-cd chart archive, installed instead of the one from the argo Helm repository
+	ServedRepo     string   // Git URL ArgoCD syncs from when GitHubRepo is a local copy, e.g. of an offline bundle
 }
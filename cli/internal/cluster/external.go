@@ -0,0 +1,255 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/external"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+)
+
+// WithExternalRegistry lets the service manage the clusters registered in registry
+func (s *ClusterService) WithExternalRegistry(registry *external.Registry) *ClusterService {
+	if registry != nil {
+		s.external = external.NewManager(s.executor, registry)
+	}
+	return s
+}
+
+// isExternal reports whether name is a registered external cluster
+func (s *ClusterService) isExternal(name string) bool {
+	if s.external == nil {
+		return false
+	}
+	_, ok, err := s.external.Get(name)
+	return err == nil && ok
+}
+
+// RegisterCluster records an existing cluster reachable through a kubeconfig context and
+// returns the results of its capability checks
+func (s *ClusterService) RegisterCluster(registration external.Registration) ([]external.Capability, error) {
+	if s.external == nil {
+		return nil, fmt.Errorf("external clusters are not available")
+	}
+	ctx := context.Background()
+
+	if _, err := s.manager.DetectClusterType(ctx, registration.Name); err == nil {
+		return nil, models.NewClusterAlreadyExistsError(registration.Name)
+	}
+	if err := s.external.Register(ctx, registration); err != nil {
+		return nil, err
+	}
+	return s.external.CheckCapabilities(ctx, registration.Name)
+}
+
+// unregisterExternalCluster forgets a registered cluster without touching the cluster itself
+func (s *ClusterService) unregisterExternalCluster(name string) error {
+	if s.external == nil {
+		return models.NewClusterNotFoundError(name)
+	}
+	if err := s.external.Unregister(name); err != nil {
+		return err
+	}
+	pterm.Info.Printf("Unregistered external cluster '%s', the cluster itself was left running\n", name)
+	return nil
+}
+
+// openFrameReleaseNamespace is where chart install puts the argo-cd and app-of-apps releases
+const openFrameReleaseNamespace = "argocd"
+
+// openFrameNamespaces are the namespaces OpenFrame deploys to. Other namespaces of an
+// external cluster, kube-system above all, belong to whoever runs the cluster.
+var openFrameNamespaces = []string{"argocd", "openframe", "platform", "datasources", "microservices", "integrated-tools", "client-tools"}
+
+// isOpenFrameRelease reports whether a release in the argocd namespace was installed by
+// chart install: argo-cd, app-of-apps, or the app-of-apps-<id> release of a tenant
+func isOpenFrameRelease(release string) bool {
+	return release == "argo-cd" || release == "app-of-apps" || strings.HasPrefix(release, "app-of-apps-")
+}
+
+// cleanupExternalCluster removes what chart install put on a registered cluster: the OpenFrame
+// Helm releases and namespaces, after confirmation. Everything else on the cluster, and its
+// nodes, are not managed by the CLI and are left alone.
+func (s *ClusterService) cleanupExternalCluster(name string, verbose bool, force bool) error {
+	ctx := context.Background()
+
+	if err := s.TargetCluster(name); err != nil {
+		return err
+	}
+
+	releases, namespaces, err := s.externalCleanupPlan(ctx)
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 && len(namespaces) == 0 {
+		pterm.Info.Printf("No OpenFrame releases or namespaces found on cluster %s\n", name)
+		return nil
+	}
+
+	registration, _, _ := s.external.Get(name)
+	pterm.Info.Printf("Cleanup of external cluster %s (context %s) removes:\n", name, registration.Context)
+	for _, release := range releases {
+		pterm.Printf("  - Helm release %s/%s\n", openFrameReleaseNamespace, release)
+	}
+	for _, namespace := range namespaces {
+		pterm.Printf("  - Namespace %s\n", namespace)
+	}
+
+	// Always confirm, the cluster is shared with workloads the CLI knows nothing about
+	confirm := s.confirm
+	if confirm == nil {
+		confirm = func(message string) (bool, error) { return ui.ConfirmActionInteractive(message, false) }
+	}
+	confirmed, err := confirm(fmt.Sprintf("Remove these OpenFrame resources from external cluster '%s'?", name))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		pterm.Info.Println("Cleanup cancelled.")
+		return nil
+	}
+
+	// The app-of-apps releases go first, so that ArgoCD is still there to remove their applications
+	sort.SliceStable(releases, func(i, j int) bool { return releases[j] == "argo-cd" && releases[i] != "argo-cd" })
+	for _, release := range releases {
+		if verbose {
+			pterm.Info.Printf("Uninstalling Helm release: %s\n", release)
+		}
+		args := []string{"uninstall", release, "--namespace", openFrameReleaseNamespace, "--wait"}
+		if force {
+			args = append(args, "--no-hooks")
+		}
+		if _, err := s.executor.Execute(ctx, "helm", args...); err != nil {
+			return fmt.Errorf("failed to uninstall Helm release %s: %w", release, err)
+		}
+	}
+
+	if len(namespaces) > 0 {
+		args := append([]string{"delete", "namespace", "--ignore-not-found=true"}, namespaces...)
+		if _, err := s.executor.Execute(ctx, "kubectl", args...); err != nil {
+			return fmt.Errorf("failed to delete namespaces %s: %w", strings.Join(namespaces, ", "), err)
+		}
+	}
+
+	if verbose {
+		pterm.Success.Printf("Cleanup completed for cluster: %s\n", name)
+	}
+	return nil
+}
+
+// externalCleanupPlan returns the OpenFrame releases and namespaces present on the targeted cluster
+func (s *ClusterService) externalCleanupPlan(ctx context.Context) ([]string, []string, error) {
+	result, err := s.executor.Execute(ctx, "helm", "list", "--namespace", openFrameReleaseNamespace, "--all", "--short")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list Helm releases: %w", err)
+	}
+	var releases []string
+	for _, release := range strings.Fields(result.Stdout) {
+		if isOpenFrameRelease(release) {
+			releases = append(releases, release)
+		}
+	}
+
+	result, err = s.executor.Execute(ctx, "kubectl", "get", "namespaces", "-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	existing := map[string]bool{}
+	for _, namespace := range strings.Fields(result.Stdout) {
+		existing[namespace] = true
+	}
+	var namespaces []string
+	for _, namespace := range openFrameNamespaces {
+		if existing[namespace] {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return releases, namespaces, nil
+}
+
+// showExternalClusterStatus shows the nodes and capability checks of a registered cluster
+func (s *ClusterService) showExternalClusterStatus(ctx context.Context, name string) error {
+	registration, _, err := s.external.Get(name)
+	if err != nil {
+		return err
+	}
+	status, err := s.external.GetClusterStatus(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster status: %w", err)
+	}
+
+	kubeconfig := registration.Kubeconfig
+	if kubeconfig == "" {
+		kubeconfig = "default"
+	}
+	version := status.K8sVersion
+	if version == "" {
+		version = "unknown"
+	}
+
+	fmt.Println()
+	boxContent := fmt.Sprintf(
+		"NAME:       %s\n"+
+			"TYPE:       %s\n"+
+			"STATUS:     %s\n"+
+			"NODES:      %d\n"+
+			"VERSION:    %s\n"+
+			"CONTEXT:    %s\n"+
+			"KUBECONFIG: %s",
+		pterm.Bold.Sprint(status.Name),
+		strings.ToUpper(string(status.Type)),
+		status.Status,
+		status.NodeCount,
+		version,
+		registration.Context,
+		kubeconfig,
+	)
+	pterm.DefaultBox.
+		WithTitle(" 📊 Cluster Status ").
+		WithTitleTopCenter().
+		Println(boxContent)
+
+	if status.Status == external.StatusUnreachable {
+		fmt.Println()
+		pterm.Warning.Printf("The API server of context %s does not answer\n", registration.Context)
+		return nil
+	}
+
+	if len(status.Nodes) > 0 {
+		fmt.Println()
+		pterm.Info.Printf("🖥️ Nodes:\n")
+		for _, node := range status.Nodes {
+			pterm.Printf("  %-40s %-14s %s\n", node.Name, node.Role, node.Status)
+		}
+	}
+
+	capabilities, err := s.external.CheckCapabilities(ctx, name)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	pterm.Info.Printf("✅ Capabilities:\n")
+	ShowCapabilities(capabilities)
+
+	fmt.Println()
+	pterm.Info.Printf("⚙️ Management Commands:\n")
+	pterm.Printf("  Unregister cluster:  openframe cluster delete %s\n", status.Name)
+	pterm.Printf("  Install OpenFrame:   openframe chart install %s\n", status.Name)
+	pterm.Printf("  Access with kubectl: kubectl --context %s get nodes\n", registration.Context)
+	return nil
+}
+
+// ShowCapabilities prints one line per capability check
+func ShowCapabilities(capabilities []external.Capability) {
+	for _, capability := range capabilities {
+		mark := pterm.Green("✓")
+		if !capability.OK {
+			mark = pterm.Yellow("!")
+		}
+		pterm.Printf("  %s %-22s %s\n", mark, capability.Name, capability.Detail)
+	}
+}
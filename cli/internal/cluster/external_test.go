@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/external"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExternalTestService returns a service with the k3d cluster test-cluster and the
+// external cluster vm registered with the context shared
+func newExternalTestService(t *testing.T) (*ClusterService, *executor.MockCommandExecutor) {
+	t.Helper()
+	mockExec := createTestExecutor().(*executor.MockCommandExecutor)
+	registry := external.NewRegistry(filepath.Join(t.TempDir(), "external.json"))
+	require.NoError(t, registry.Add(external.Registration{Name: "vm", Context: "shared"}))
+	return NewClusterService(mockExec).WithExternalRegistry(registry), mockExec
+}
+
+func TestClusterService_ExternalClusters(t *testing.T) {
+	service, _ := newExternalTestService(t)
+
+	clusters, err := service.ListClusters()
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, "test-cluster", clusters[0].Name)
+	assert.Equal(t, "vm", clusters[1].Name)
+	assert.Equal(t, models.ClusterTypeExternal, clusters[1].Type)
+
+	clusterType, err := service.DetectClusterType("vm")
+	require.NoError(t, err)
+	assert.Equal(t, models.ClusterTypeExternal, clusterType)
+
+	err = service.CreateCluster(models.ClusterConfig{Name: "vm", Type: models.ClusterTypeK3d, NodeCount: 1})
+	assert.ErrorContains(t, err, "registered as an external cluster")
+}
+
+func TestClusterService_DeleteExternalClusterUnregisters(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+
+	require.NoError(t, service.DeleteCluster("vm", models.ClusterTypeExternal, true))
+	assert.False(t, service.isExternal("vm"))
+	assert.False(t, mockExec.WasCommandExecuted("k3d cluster delete"), "the cluster itself is left alone")
+}
+
+func TestClusterService_ScaleExternalCluster(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+
+	_, err := service.ScaleCluster("vm", 4)
+	assert.ErrorContains(t, err, "external cluster")
+	assert.False(t, mockExec.WasCommandExecuted("k3d node"), "the nodes of external clusters are left alone")
+}
+
+func TestClusterService_UpgradeExternalCluster(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+
+	_, err := service.UpgradeCluster("vm", "v1.32.2-k3s1", false)
+	assert.ErrorContains(t, err, "external cluster")
+	assert.False(t, mockExec.WasCommandExecuted("docker"), "the nodes of external clusters are left alone")
+}
+
+func TestClusterService_CleanupExternalCluster(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+	mockExec.SetResponse("helm list --namespace argocd", &executor.CommandResult{Stdout: "argo-cd\napp-of-apps-acme\nteam-tooling\napp-of-apps\n"})
+	mockExec.SetResponse("kubectl get namespaces", &executor.CommandResult{Stdout: "default kube-system argocd platform team-tooling datasources"})
+
+	var prompt string
+	service.confirm = func(message string) (bool, error) {
+		prompt = message
+		return true, nil
+	}
+	require.NoError(t, service.CleanupCluster("vm", models.ClusterTypeExternal, false, true))
+	assert.Contains(t, prompt, "'vm'")
+
+	var uninstalled []string
+	for _, command := range mockExec.GetExecutedCommands() {
+		if strings.HasPrefix(command, "helm uninstall") {
+			uninstalled = append(uninstalled, strings.Fields(command)[2])
+		}
+	}
+	assert.Equal(t, []string{"app-of-apps-acme", "app-of-apps", "argo-cd"}, uninstalled, "argo-cd goes last and other releases stay")
+	assert.True(t, mockExec.WasCommandExecuted("kubectl delete namespace --ignore-not-found=true argocd platform datasources"))
+	assert.False(t, mockExec.WasCommandExecuted("kube-system"), "kube-system is never touched")
+	assert.False(t, mockExec.WasCommandExecuted("uninstall team-tooling"))
+	assert.False(t, mockExec.WasCommandExecuted("docker"), "the nodes of external clusters are left alone")
+}
+
+func TestClusterService_CleanupExternalClusterCancelled(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+	mockExec.SetResponse("helm list --namespace argocd", &executor.CommandResult{Stdout: "app-of-apps\n"})
+	mockExec.SetResponse("kubectl get namespaces", &executor.CommandResult{Stdout: "argocd"})
+	service.confirm = func(string) (bool, error) { return false, nil }
+
+	require.NoError(t, service.CleanupCluster("vm", models.ClusterTypeExternal, false, true))
+	assert.False(t, mockExec.WasCommandExecuted("helm uninstall"))
+	assert.False(t, mockExec.WasCommandExecuted("kubectl delete"))
+}
@@ -0,0 +1,246 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/pterm/pterm"
+)
+
+// telepresenceStatus is the part of 'telepresence status --output json' naming the connection
+type telepresenceStatus struct {
+	UserDaemon struct {
+		Status            string `json:"status"`
+		KubernetesContext string `json:"kubernetes_context"`
+	} `json:"user_daemon"`
+}
+
+// DefaultKubeconfigDir returns ~/.config/openframe/kubeconfig
+func DefaultKubeconfigDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "openframe", "kubeconfig"), nil
+}
+
+// DefaultKubeconfigPath returns the kubeconfig kubectl writes to: the first file of
+// $KUBECONFIG, or ~/.kube/config
+func DefaultKubeconfigPath() (string, error) {
+	for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if path != "" {
+			return path, nil
+		}
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".kube", "config"), nil
+}
+
+// WithKubeconfigDir makes TargetCluster keep the kubeconfig of each cluster in dir
+func (s *ClusterService) WithKubeconfigDir(dir string) *ClusterService {
+	s.kubeconfigDir = dir
+	return s
+}
+
+// KubeContext returns the kubeconfig context of a cluster and the kubeconfig file holding
+// it, empty for the default kubeconfig
+func (s *ClusterService) KubeContext(name string) (string, string, error) {
+	if s.isExternal(name) {
+		registration, _, err := s.external.Get(name)
+		if err != nil {
+			return "", "", err
+		}
+		return registration.Context, registration.Kubeconfig, nil
+	}
+
+	if _, err := s.manager.DetectClusterType(context.Background(), name); err != nil {
+		return "", "", err
+	}
+	return "k3d-" + name, "", nil
+}
+
+// GetKubeconfig returns a self-contained kubeconfig for a cluster whose current context
+// is the cluster's
+func (s *ClusterService) GetKubeconfig(name string) (string, error) {
+	ctx := context.Background()
+	if s.isExternal(name) {
+		return s.external.Kubeconfig(ctx, name)
+	}
+
+	clusterType, err := s.manager.DetectClusterType(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return s.manager.GetKubeconfig(ctx, name, clusterType)
+}
+
+// WriteKubeconfig writes the kubeconfig of a cluster to path, readable by the owner only
+func (s *ClusterService) WriteKubeconfig(name, path string) error {
+	content, err := s.GetKubeconfig(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write kubeconfig to %s: %w", path, err)
+	}
+	return nil
+}
+
+// MergeKubeconfig merges the context of a cluster into the default kubeconfig, keeping its
+// current context, and returns the path of the default kubeconfig
+func (s *ClusterService) MergeKubeconfig(name string) (string, error) {
+	ctx := context.Background()
+
+	target, err := DefaultKubeconfigPath()
+	if err != nil {
+		return "", err
+	}
+	content, err := s.GetKubeconfig(name)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(content) == "" {
+		// Dry runs print the commands instead of returning a kubeconfig
+		return target, nil
+	}
+
+	incoming, err := os.CreateTemp("", "openframe-kubeconfig-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary kubeconfig: %w", err)
+	}
+	defer os.Remove(incoming.Name())
+	_, err = incoming.WriteString(content)
+	incoming.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to write temporary kubeconfig: %w", err)
+	}
+
+	currentContext := ""
+	if result, err := s.executor.Execute(ctx, "kubectl", "config", "current-context", "--kubeconfig", target); err == nil {
+		currentContext = strings.TrimSpace(result.Stdout)
+	}
+
+	// The incoming kubeconfig comes first so that its entries replace stale ones, such as
+	// those of an earlier cluster with the same name
+	merged, err := s.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "kubectl",
+		Args:    []string{"config", "view", "--raw"},
+		Env:     map[string]string{"KUBECONFIG": incoming.Name() + string(os.PathListSeparator) + target},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to merge kubeconfig of cluster %s: %w", name, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+	}
+	if err := os.WriteFile(target, []byte(merged.Stdout), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig to %s: %w", target, err)
+	}
+
+	// The incoming kubeconfig names the cluster as the current context, restore the old one
+	args := []string{"config", "unset", "current-context", "--kubeconfig", target}
+	if currentContext != "" {
+		args = []string{"config", "use-context", currentContext, "--kubeconfig", target}
+	}
+	if _, err := s.executor.Execute(ctx, "kubectl", args...); err != nil {
+		return "", fmt.Errorf("failed to restore the current context of %s: %w", target, err)
+	}
+	return target, nil
+}
+
+// TargetCluster points the kubectl, helm and telepresence commands this process runs at a
+// cluster without touching the user's kubeconfig: the cluster's kubeconfig is written to
+// the kubeconfig directory and KUBECONFIG set to it. Without a kubeconfig directory the
+// commands keep using the current context.
+func (s *ClusterService) TargetCluster(name string) error {
+	if s.kubeconfigDir == "" {
+		return nil
+	}
+
+	content, err := s.GetKubeconfig(name)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		// Dry runs print the commands instead of returning a kubeconfig
+		return nil
+	}
+
+	path := filepath.Join(s.kubeconfigDir, name+".yaml")
+	if err := os.MkdirAll(s.kubeconfigDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.kubeconfigDir, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return fmt.Errorf("failed to write kubeconfig to %s: %w", path, err)
+	}
+	if err := os.Setenv("KUBECONFIG", path); err != nil {
+		return fmt.Errorf("failed to set KUBECONFIG: %w", err)
+	}
+	return nil
+}
+
+// UseClusterContext makes a cluster the current context of the default kubeconfig, merging
+// its context in first, and moves a running telepresence connection over to it
+func (s *ClusterService) UseClusterContext(name string) error {
+	ctx := context.Background()
+
+	contextName, kubeconfig, err := s.KubeContext(name)
+	if err != nil {
+		return err
+	}
+
+	// Registered clusters of the default kubeconfig are already in it; k3d contexts are
+	// merged again so that the API port of a recreated cluster is picked up
+	if !s.isExternal(name) || kubeconfig != "" {
+		if _, err := s.MergeKubeconfig(name); err != nil {
+			return err
+		}
+	}
+
+	target, err := DefaultKubeconfigPath()
+	if err != nil {
+		return err
+	}
+	if _, err := s.executor.Execute(ctx, "kubectl", "config", "use-context", contextName, "--kubeconfig", target); err != nil {
+		return fmt.Errorf("failed to switch kubectl context to %s: %w", contextName, err)
+	}
+
+	return s.reconnectTelepresence(ctx, contextName)
+}
+
+// reconnectTelepresence moves a connected telepresence daemon to contextName, leaving it
+// alone when telepresence is not installed or not connected
+func (s *ClusterService) reconnectTelepresence(ctx context.Context, contextName string) error {
+	result, err := s.executor.Execute(ctx, "telepresence", "status", "--output", "json")
+	if err != nil {
+		return nil
+	}
+
+	var status telepresenceStatus
+	if err := json.Unmarshal([]byte(result.Stdout), &status); err != nil {
+		return nil
+	}
+	if status.UserDaemon.Status != "Connected" || status.UserDaemon.KubernetesContext == contextName {
+		return nil
+	}
+
+	if _, err := s.executor.Execute(ctx, "telepresence", "quit"); err != nil {
+		return fmt.Errorf("failed to disconnect telepresence from %s: %w", status.UserDaemon.KubernetesContext, err)
+	}
+	if _, err := s.executor.Execute(ctx, "telepresence", "connect", "--context", contextName); err != nil {
+		return fmt.Errorf("failed to connect telepresence to %s: %w", contextName, err)
+	}
+	pterm.Info.Printf("Telepresence moved from %s to %s\n", status.UserDaemon.KubernetesContext, contextName)
+	return nil
+}
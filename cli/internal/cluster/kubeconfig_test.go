@@ -0,0 +1,87 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = "apiVersion: v1\ncurrent-context: k3d-test-cluster\n"
+
+func TestClusterService_KubeContext(t *testing.T) {
+	service, _ := newExternalTestService(t)
+
+	contextName, kubeconfig, err := service.KubeContext("vm")
+	require.NoError(t, err)
+	assert.Equal(t, "shared", contextName)
+	assert.Empty(t, kubeconfig)
+
+	contextName, _, err = service.KubeContext("test-cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "k3d-test-cluster", contextName)
+}
+
+func TestClusterService_TargetCluster(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+	mockExec.SetResponse("k3d kubeconfig get test-cluster", &executor.CommandResult{Stdout: testKubeconfig})
+	t.Setenv("KUBECONFIG", "")
+
+	// Without a kubeconfig directory the current context is used
+	require.NoError(t, service.TargetCluster("test-cluster"))
+	assert.Empty(t, os.Getenv("KUBECONFIG"))
+
+	dir := t.TempDir()
+	service.WithKubeconfigDir(dir)
+	require.NoError(t, service.TargetCluster("test-cluster"))
+
+	path := filepath.Join(dir, "test-cluster.yaml")
+	assert.Equal(t, path, os.Getenv("KUBECONFIG"))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testKubeconfig, string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.False(t, mockExec.WasCommandExecuted("use-context"), "the user's current context is left alone")
+}
+
+func TestClusterService_MergeKubeconfig(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+	mockExec.SetResponse("k3d kubeconfig get test-cluster", &executor.CommandResult{Stdout: testKubeconfig})
+	mockExec.SetResponse("config current-context", &executor.CommandResult{Stdout: "docker-desktop\n"})
+	mockExec.SetResponse("config view --raw", &executor.CommandResult{Stdout: "merged\n"})
+
+	target := filepath.Join(t.TempDir(), "config")
+	t.Setenv("KUBECONFIG", target)
+
+	path, err := service.MergeKubeconfig("test-cluster")
+	require.NoError(t, err)
+	assert.Equal(t, target, path)
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "merged\n", string(content))
+	assert.Equal(t, "kubectl config use-context docker-desktop --kubeconfig "+target, mockExec.GetLastCommand(),
+		"the current context of the default kubeconfig is kept")
+}
+
+func TestClusterService_UseClusterContext(t *testing.T) {
+	service, mockExec := newExternalTestService(t)
+	target := filepath.Join(t.TempDir(), "config")
+	t.Setenv("KUBECONFIG", target)
+
+	require.NoError(t, service.UseClusterContext("vm"))
+	assert.False(t, mockExec.WasCommandExecuted("config view"), "the context is already in the default kubeconfig")
+	assert.True(t, mockExec.WasCommandExecuted("kubectl config use-context shared --kubeconfig "+target))
+
+	mockExec.SetResponse("telepresence status", &executor.CommandResult{
+		Stdout: `{"user_daemon":{"status":"Connected","kubernetes_context":"shared"}}`,
+	})
+	require.NoError(t, service.UseClusterContext("test-cluster"))
+	assert.True(t, mockExec.WasCommandExecuted("k3d kubeconfig get test-cluster"))
+	assert.True(t, mockExec.WasCommandExecuted("kubectl config use-context k3d-test-cluster --kubeconfig "+target))
+	assert.Equal(t, "telepresence connect --context k3d-test-cluster", mockExec.GetLastCommand())
+}
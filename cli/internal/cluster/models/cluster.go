@@ -6,8 +6,9 @@ import "time"
 type ClusterType string
 
 const (
-	ClusterTypeK3d ClusterType = "k3d"
-	ClusterTypeGKE ClusterType = "gke"
+	ClusterTypeK3d      ClusterType = "k3d"
+	ClusterTypeGKE      ClusterType = "gke"
+	ClusterTypeExternal ClusterType = "external" // Created elsewhere, registered by kubeconfig context
 )
 
 // ClusterConfig holds cluster configuration
@@ -16,6 +17,14 @@ type ClusterConfig struct {
 	Type       ClusterType `json:"type"`
 	NodeCount  int         `json:"node_count"`
 	K8sVersion string      `json:"k8s_version"`
+	// Ports requests specific host ports, 0 reuses the saved assignment or picks a free port
+	Ports PortAssignment `json:"ports,omitempty"`
+	// NoSwitchContext keeps the current kubectl context instead of switching to the new cluster
+	NoSwitchContext bool `json:"no_switch_context,omitempty"`
+	// TTL makes the cluster expire, so that 'cluster reap' deletes it; 0 never expires
+	TTL time.Duration `json:"ttl,omitempty"`
+	// Labels are kept on the cluster's containers to find it again, such as ci-run=123
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // ClusterInfo represents information about a cluster
@@ -27,6 +36,9 @@ type ClusterInfo struct {
 	K8sVersion string      `json:"k8s_version,omitempty"`
 	CreatedAt  time.Time   `json:"created_at,omitempty"`
 	Nodes      []NodeInfo  `json:"nodes,omitempty"`
+	// ExpiresAt is when a cluster created with a TTL is reaped, zero if it never expires
+	ExpiresAt time.Time         `json:"expires_at,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
 }
 
 // NodeInfo represents information about a node in the cluster
@@ -36,6 +48,44 @@ type NodeInfo struct {
 	Role   string `json:"role"`
 }
 
+// ScaleResult describes the nodes a scale operation changed and where the pods ended up
+type ScaleResult struct {
+	Added       []string       `json:"added,omitempty"`
+	Removed     []string       `json:"removed,omitempty"`
+	Evicted     []string       `json:"evicted,omitempty"`     // Pods drained off removed nodes, namespace/name
+	PodsPerNode map[string]int `json:"podsPerNode,omitempty"` // Pods per node after scaling
+	Pending     []string       `json:"pending,omitempty"`     // Pods left without a node, namespace/name
+}
+
+// UpgradeResult describes the nodes an upgrade replaced and the applications it watched
+type UpgradeResult struct {
+	From         string   `json:"from"`
+	To           string   `json:"to"`
+	Upgraded     []string `json:"upgraded,omitempty"`
+	Skipped      []string `json:"skipped,omitempty"`      // Nodes already running the target version
+	Applications []string `json:"applications,omitempty"` // Argo CD applications kept Healthy, namespace/name
+}
+
+// ReapPlan lists what 'cluster reap' removes: expired or broken clusters and the Docker
+// resources and temporary files left behind by clusters and commands that are gone
+type ReapPlan struct {
+	Clusters  []ReapCluster `json:"clusters,omitempty"`
+	Networks  []string      `json:"networks,omitempty"`
+	Volumes   []string      `json:"volumes,omitempty"`
+	TempFiles []string      `json:"tempFiles,omitempty"`
+}
+
+// ReapCluster is a cluster to reap and why
+type ReapCluster struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Empty reports whether there is nothing to reap
+func (p ReapPlan) Empty() bool {
+	return len(p.Clusters) == 0 && len(p.Networks) == 0 && len(p.Volumes) == 0 && len(p.TempFiles) == 0
+}
+
 // ProviderOptions contains provider-specific options
 type ProviderOptions struct {
 	K3d     *K3dOptions `json:"k3d,omitempty"`
@@ -53,4 +103,3 @@ type GKEOptions struct {
 	Zone    string `json:"zone"`
 	Project string `json:"project"`
 }
-
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/flags"
 	"github.com/spf13/cobra"
@@ -19,12 +20,18 @@ type CreateFlags struct {
 	NodeCount   int
 	K8sVersion  string
 	SkipWizard  bool
+	Ports       PortAssignment // Host ports to reserve, 0 reuses the saved port or picks one
+	// NoSwitchContext leaves the current kubectl context alone
+	NoSwitchContext bool
+	TTL             time.Duration // Lifetime after which 'cluster reap' deletes the cluster, 0 for none
+	Labels          []string      // key=value labels to find the cluster by
 }
 
 // ListFlags contains flags specific to list command
 type ListFlags struct {
 	GlobalFlags
-	Quiet bool
+	Quiet  bool
+	Labels []string // key=value labels a cluster must carry to be listed
 }
 
 // StatusFlags contains flags specific to status command
@@ -46,6 +53,33 @@ type CleanupFlags struct {
 	Force bool // Cleanup-specific force flag
 }
 
+// RegisterFlags contains flags specific to register command
+type RegisterFlags struct {
+	GlobalFlags
+	Context    string
+	Kubeconfig string
+}
+
+// ScaleFlags contains flags specific to scale command
+type ScaleFlags struct {
+	GlobalFlags
+	Agents int
+}
+
+// UpgradeFlags contains flags specific to upgrade command
+type UpgradeFlags struct {
+	GlobalFlags
+	Version       string // k3s release to move to, such as v1.32.2-k3s1
+	AllowUntested bool   // Allow Kubernetes minors OpenFrame is not tested against
+}
+
+// KubeconfigFlags contains flags specific to kubeconfig command
+type KubeconfigFlags struct {
+	GlobalFlags
+	Output string // File to write the kubeconfig to, stdout when empty
+	Merge  bool   // Merge into the default kubeconfig
+}
+
 // Flag setup functions
 
 // AddGlobalFlags adds global flags to a cluster command
@@ -60,11 +94,41 @@ func AddCreateFlags(cmd *cobra.Command, flags *CreateFlags) {
 	cmd.Flags().IntVarP(&flags.NodeCount, "nodes", "n", 3, "Number of worker nodes (default 3)")
 	cmd.Flags().StringVar(&flags.K8sVersion, "version", "", "Kubernetes version")
 	cmd.Flags().BoolVar(&flags.SkipWizard, "skip-wizard", false, "Skip interactive wizard")
+	cmd.Flags().IntVar(&flags.Ports.API, "api-port", 0, "Host port of the Kubernetes API (default: saved port, then 6550)")
+	cmd.Flags().IntVar(&flags.Ports.HTTP, "http-port", 0, "Host port of the HTTP ingress (default: saved port, then 80)")
+	cmd.Flags().IntVar(&flags.Ports.HTTPS, "https-port", 0, "Host port of the HTTPS ingress (default: saved port, then 443)")
+	cmd.Flags().BoolVar(&flags.NoSwitchContext, "no-switch-context", false, "Keep the current kubectl context instead of switching to the new cluster")
+	cmd.Flags().DurationVar(&flags.TTL, "ttl", 0, "Delete the cluster with 'cluster reap' once it is this old, such as 2h")
+	cmd.Flags().StringArrayVar(&flags.Labels, "label", nil, "Label the cluster with key=value, can be repeated")
+}
+
+// AddRegisterFlags adds register-specific flags to a command
+func AddRegisterFlags(cmd *cobra.Command, flags *RegisterFlags) {
+	cmd.Flags().StringVar(&flags.Context, "context", "", "Kubeconfig context of the cluster (required)")
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", "", "Kubeconfig file holding the context (default: $KUBECONFIG or ~/.kube/config)")
+}
+
+// AddScaleFlags adds scale-specific flags to a command
+func AddScaleFlags(cmd *cobra.Command, flags *ScaleFlags) {
+	cmd.Flags().IntVar(&flags.Agents, "agents", -1, "Number of agent nodes the cluster should run (required)")
+}
+
+// AddUpgradeFlags adds upgrade-specific flags to a command
+func AddUpgradeFlags(cmd *cobra.Command, flags *UpgradeFlags) {
+	cmd.Flags().StringVar(&flags.Version, "version", "", "k3s release to upgrade to, such as v1.32.2-k3s1 (required)")
+	cmd.Flags().BoolVar(&flags.AllowUntested, "allow-untested", false, "Allow Kubernetes versions OpenFrame is not tested against")
+}
+
+// AddKubeconfigFlags adds kubeconfig-specific flags to a command
+func AddKubeconfigFlags(cmd *cobra.Command, flags *KubeconfigFlags) {
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Write the kubeconfig to this file instead of stdout")
+	cmd.Flags().BoolVar(&flags.Merge, "merge", false, "Merge the kubeconfig into the default kubeconfig without switching context")
 }
 
 // AddListFlags adds list-specific flags to a command
 func AddListFlags(cmd *cobra.Command, flags *ListFlags) {
 	cmd.Flags().BoolVarP(&flags.Quiet, "quiet", "q", false, "Only show cluster names")
+	cmd.Flags().StringArrayVarP(&flags.Labels, "label", "l", nil, "Only list clusters labelled key=value, can be repeated")
 }
 
 // AddStatusFlags adds status-specific flags to a command
@@ -137,12 +201,70 @@ func ValidateCreateFlags(flags *CreateFlags) error {
 		return fmt.Errorf("node count must be at least 1: %d", flags.NodeCount)
 	}
 
+	if flags.TTL < 0 {
+		return fmt.Errorf("--ttl cannot be negative: %s", flags.TTL)
+	}
+	if _, err := ParseLabels(flags.Labels); err != nil {
+		return err
+	}
+
+	return ValidatePortAssignment(flags.Ports)
+}
+
+// ValidateRegisterFlags validates register flag combinations
+func ValidateRegisterFlags(flags *RegisterFlags) error {
+	if err := ValidateGlobalFlags(&flags.GlobalFlags); err != nil {
+		return err
+	}
+	if strings.TrimSpace(flags.Context) == "" {
+		return fmt.Errorf("--context is required: the kubeconfig context of the cluster to register")
+	}
+	return nil
+}
+
+// ValidateScaleFlags validates scale flag combinations
+func ValidateScaleFlags(flags *ScaleFlags) error {
+	if err := ValidateGlobalFlags(&flags.GlobalFlags); err != nil {
+		return err
+	}
+	if flags.Agents < 0 {
+		return fmt.Errorf("--agents is required: the number of agent nodes, 0 or more")
+	}
+	return nil
+}
+
+// ValidateUpgradeFlags validates upgrade flag combinations
+func ValidateUpgradeFlags(flags *UpgradeFlags) error {
+	if err := ValidateGlobalFlags(&flags.GlobalFlags); err != nil {
+		return err
+	}
+	if flags.Version == "" {
+		return fmt.Errorf("--version is required: the k3s release to upgrade to, such as v1.32.2-k3s1")
+	}
+	if !strings.HasPrefix(flags.Version, "v") || !strings.Contains(flags.Version, "-k3s") {
+		return fmt.Errorf("--version %s is no k3s release, use a tag such as v1.32.2-k3s1", flags.Version)
+	}
+	return nil
+}
+
+// ValidateKubeconfigFlags validates kubeconfig flag combinations
+func ValidateKubeconfigFlags(flags *KubeconfigFlags) error {
+	if err := ValidateGlobalFlags(&flags.GlobalFlags); err != nil {
+		return err
+	}
+	if flags.Merge && strings.TrimSpace(flags.Output) != "" {
+		return fmt.Errorf("--output and --merge cannot be used together")
+	}
 	return nil
 }
 
 // ValidateListFlags validates list flag combinations
 func ValidateListFlags(flags *ListFlags) error {
-	return ValidateGlobalFlags(&flags.GlobalFlags)
+	if err := ValidateGlobalFlags(&flags.GlobalFlags); err != nil {
+		return err
+	}
+	_, err := ParseLabels(flags.Labels)
+	return err
 }
 
 // ValidateStatusFlags validates status flag combinations
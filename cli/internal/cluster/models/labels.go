@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^[A-Za-z0-9._-]*$`)
+)
+
+// ParseLabels parses key=value pairs as given to --label
+func ParseLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("--label %s must be key=value", pair)
+		}
+		if !labelKeyPattern.MatchString(key) || len(key) > 63 {
+			return nil, fmt.Errorf("--label key %q must be 1-63 letters, digits, '.', '_', '-' or '/', starting and ending with a letter or digit", key)
+		}
+		if !labelValuePattern.MatchString(value) || len(value) > 63 {
+			return nil, fmt.Errorf("--label value %q may only hold up to 63 letters, digits, '.', '_' or '-'", value)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// MatchesLabels reports whether a cluster carries every label of selector
+func (c ClusterInfo) MatchesLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		if actual, ok := c.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterClustersByLabels returns the clusters carrying every label of selector
+func FilterClustersByLabels(clusters []ClusterInfo, selector map[string]string) []ClusterInfo {
+	if len(selector) == 0 {
+		return clusters
+	}
+	var matching []ClusterInfo
+	for _, cluster := range clusters {
+		if cluster.MatchesLabels(selector) {
+			matching = append(matching, cluster)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabels(t *testing.T) {
+	labels, err := ParseLabels([]string{"ci-run=123", "team=core", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ci-run": "123", "team": "core", "empty": ""}, labels)
+
+	_, err = ParseLabels([]string{"ci-run"})
+	assert.ErrorContains(t, err, "must be key=value")
+	_, err = ParseLabels([]string{"-run=1"})
+	assert.ErrorContains(t, err, "key")
+	_, err = ParseLabels([]string{"run=a b"})
+	assert.ErrorContains(t, err, "value")
+}
+
+func TestFilterClustersByLabels(t *testing.T) {
+	clusters := []ClusterInfo{
+		{Name: "ci-1", Labels: map[string]string{"ci-run": "123", "team": "core"}},
+		{Name: "ci-2", Labels: map[string]string{"ci-run": "124"}},
+		{Name: "dev"},
+	}
+
+	assert.Equal(t, clusters, FilterClustersByLabels(clusters, nil))
+	assert.Equal(t, clusters[:1], FilterClustersByLabels(clusters, map[string]string{"ci-run": "123"}))
+	assert.Empty(t, FilterClustersByLabels(clusters, map[string]string{"ci-run": "124", "team": "core"}))
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Default host ports of the first cluster
+const (
+	DefaultAPIPort   = 6550
+	DefaultHTTPPort  = 80
+	DefaultHTTPSPort = 443
+)
+
+// PortAssignment holds the host ports a cluster publishes, 0 meaning "pick one"
+type PortAssignment struct {
+	API   int    `json:"api,omitempty"`
+	HTTP  int    `json:"http,omitempty"`
+	HTTPS int    `json:"https,omitempty"`
+	Host  string `json:"host,omitempty"` // Remote Docker host publishing the ports, empty for this machine
+}
+
+// IsComplete reports whether every port is assigned
+func (p PortAssignment) IsComplete() bool {
+	return p.API != 0 && p.HTTP != 0 && p.HTTPS != 0
+}
+
+// Ports returns the assigned ports, 0 for unassigned ones
+func (p PortAssignment) Ports() []int {
+	return []int{p.API, p.HTTP, p.HTTPS}
+}
+
+// String formats the assignment like "api 6550, http 80, https auto"
+func (p PortAssignment) String() string {
+	format := func(port int) string {
+		if port == 0 {
+			return "auto"
+		}
+		return strconv.Itoa(port)
+	}
+	return fmt.Sprintf("api %s, http %s, https %s", format(p.API), format(p.HTTP), format(p.HTTPS))
+}
+
+// HTTPURL returns the URL of the ingress over HTTP
+func (p PortAssignment) HTTPURL() string {
+	return p.url("http", "localhost", p.HTTP, DefaultHTTPPort)
+}
+
+// HTTPSURL returns the URL of the ingress over HTTPS
+func (p PortAssignment) HTTPSURL() string {
+	return p.url("https", "localhost", p.HTTPS, DefaultHTTPSPort)
+}
+
+// APIServerURL returns the Kubernetes API server address written to the kubeconfig
+func (p PortAssignment) APIServerURL() string {
+	return p.url("https", "127.0.0.1", p.API, 0)
+}
+
+// url builds the URL of a port on the Docker host, local when no remote host is set,
+// leaving out the default port of the scheme
+func (p PortAssignment) url(scheme, local string, port, defaultPort int) string {
+	host := p.Host
+	if host == "" {
+		host = local
+	}
+	if port != defaultPort {
+		return scheme + "://" + net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return scheme + "://" + host
+}
+
+// ValidatePort checks that a --*-port flag value is a usable TCP port, 0 meaning "pick one"
+func ValidatePort(flag string, port int) error {
+	if port < 0 || port > 65535 {
+		return fmt.Errorf("invalid --%s %d: must be between 1 and 65535", flag, port)
+	}
+	return nil
+}
+
+// ValidatePortAssignment checks the ports of an assignment and that they differ
+func ValidatePortAssignment(p PortAssignment) error {
+	for _, entry := range []struct {
+		flag string
+		port int
+	}{{"api-port", p.API}, {"http-port", p.HTTP}, {"https-port", p.HTTPS}} {
+		if err := ValidatePort(entry.flag, entry.port); err != nil {
+			return err
+		}
+	}
+
+	seen := map[int]bool{}
+	for _, port := range p.Ports() {
+		if port != 0 && seen[port] {
+			return fmt.Errorf("port %d is assigned twice, the api, http and https ports must differ", port)
+		}
+		seen[port] = true
+	}
+	return nil
+}
+
+// PortBinding is one host port published by a node of a cluster
+type PortBinding struct {
+	Node          string `json:"node"`
+	ContainerPort string `json:"containerPort"` // e.g. "443/tcp"
+	HostIP        string `json:"hostIP"`
+	HostPort      int    `json:"hostPort"`
+}
+
+// Purpose describes what a binding is for, based on its container port
+func (b PortBinding) Purpose() string {
+	switch strings.SplitN(b.ContainerPort, "/", 2)[0] {
+	case "6443":
+		return "Kubernetes API"
+	case "80":
+		return "Ingress HTTP"
+	case "443":
+		return "Ingress HTTPS"
+	default:
+		return ""
+	}
+}
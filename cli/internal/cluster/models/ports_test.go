@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePortAssignment(t *testing.T) {
+	assert.NoError(t, ValidatePortAssignment(PortAssignment{}))
+	assert.NoError(t, ValidatePortAssignment(PortAssignment{API: 6551, HTTP: 8080, HTTPS: 8443}))
+	assert.EqualError(t, ValidatePortAssignment(PortAssignment{HTTP: 70000}), "invalid --http-port 70000: must be between 1 and 65535")
+	assert.EqualError(t, ValidatePortAssignment(PortAssignment{API: -1}), "invalid --api-port -1: must be between 1 and 65535")
+	assert.EqualError(t, ValidatePortAssignment(PortAssignment{API: 8443, HTTPS: 8443}), "port 8443 is assigned twice, the api, http and https ports must differ")
+}
+
+func TestPortAssignment_URLs(t *testing.T) {
+	defaults := PortAssignment{API: DefaultAPIPort, HTTP: DefaultHTTPPort, HTTPS: DefaultHTTPSPort}
+	assert.Equal(t, "http://localhost", defaults.HTTPURL())
+	assert.Equal(t, "https://localhost", defaults.HTTPSURL())
+	assert.Equal(t, "https://127.0.0.1:6550", defaults.APIServerURL())
+
+	custom := PortAssignment{API: 6551, HTTP: 8080, HTTPS: 8443}
+	assert.Equal(t, "http://localhost:8080", custom.HTTPURL())
+	assert.Equal(t, "https://localhost:8443", custom.HTTPSURL())
+	assert.Equal(t, "api 6551, http 8080, https auto", PortAssignment{API: 6551, HTTP: 8080}.String())
+
+	remote := PortAssignment{API: 6550, HTTP: 80, HTTPS: 8443, Host: "buildbox"}
+	assert.Equal(t, "http://buildbox", remote.HTTPURL())
+	assert.Equal(t, "https://buildbox:8443", remote.HTTPSURL())
+	assert.Equal(t, "https://buildbox:6550", remote.APIServerURL())
+
+	ipv6 := PortAssignment{API: 6550, HTTP: 8080, HTTPS: 443, Host: "fd00::5"}
+	assert.Equal(t, "http://[fd00::5]:8080", ipv6.HTTPURL())
+	assert.Equal(t, "https://[fd00::5]", ipv6.HTTPSURL())
+}
+
+func TestPortBinding_Purpose(t *testing.T) {
+	assert.Equal(t, "Kubernetes API", PortBinding{ContainerPort: "6443/tcp"}.Purpose())
+	assert.Equal(t, "Ingress HTTP", PortBinding{ContainerPort: "80/tcp"}.Purpose())
+	assert.Equal(t, "Ingress HTTPS", PortBinding{ContainerPort: "443"}.Purpose())
+	assert.Empty(t, PortBinding{ContainerPort: "5432/tcp"}.Purpose())
+}
@@ -1,9 +1,12 @@
 package k3d
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"runtime"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 )
 
 type K3dInstaller struct{}
@@ -48,6 +51,10 @@ func (k *K3dInstaller) GetInstallHelp() string {
 }
 
 func (k *K3dInstaller) Install() error {
+	if installed, err := tools.InstallPinned(context.Background(), "k3d"); installed || err != nil {
+		return err
+	}
+
 	switch runtime.GOOS {
 	case "darwin":
 		return k.installMacOS()
@@ -1,10 +1,13 @@
 package kubectl
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 )
 
 type KubectlInstaller struct{}
@@ -49,6 +52,10 @@ func (k *KubectlInstaller) GetInstallHelp() string {
 }
 
 func (k *KubectlInstaller) Install() error {
+	if installed, err := tools.InstallPinned(context.Background(), "kubectl"); installed || err != nil {
+		return err
+	}
+
 	switch runtime.GOOS {
 	case "darwin":
 		return k.installMacOS()
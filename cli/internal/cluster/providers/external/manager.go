@@ -0,0 +1,372 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// MinKubernetesVersion is the oldest Kubernetes minor version OpenFrame is tested against
+const MinKubernetesVersion = "1.27"
+
+// StatusUnreachable is the status of a registered cluster whose API server does not answer
+const StatusUnreachable = "unreachable"
+
+// requestTimeout bounds every call to the API server of a registered cluster
+const requestTimeout = "10s"
+
+// Capability is the outcome of one check of what OpenFrame needs from a cluster
+type Capability struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+// Manager manages clusters that were created outside the CLI and registered by kubeconfig context
+type Manager struct {
+	executor executor.CommandExecutor
+	registry *Registry
+}
+
+// NewManager creates an external cluster manager keeping its registrations in registry
+func NewManager(exec executor.CommandExecutor, registry *Registry) *Manager {
+	return &Manager{
+		executor: exec,
+		registry: registry,
+	}
+}
+
+// Register validates the context of a registration and records it
+func (m *Manager) Register(ctx context.Context, registration Registration) error {
+	if err := models.ValidateClusterName(registration.Name); err != nil {
+		return err
+	}
+	if registration.Context == "" {
+		return models.NewInvalidConfigError("context", registration.Context, "kubeconfig context cannot be empty")
+	}
+	if registration.Kubeconfig != "" {
+		if _, err := os.Stat(registration.Kubeconfig); err != nil {
+			return fmt.Errorf("kubeconfig %s: %w", registration.Kubeconfig, err)
+		}
+	}
+
+	args := []string{"config", "get-contexts", "-o", "name"}
+	if registration.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", registration.Kubeconfig)
+	}
+	result, err := m.executor.Execute(ctx, "kubectl", args...)
+	if err != nil {
+		return fmt.Errorf("failed to read kubeconfig contexts: %w", err)
+	}
+	found := false
+	for _, name := range strings.Fields(result.Stdout) {
+		if name == registration.Context {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("context %s not found in %s", registration.Context, kubeconfigLabel(registration.Kubeconfig))
+	}
+
+	if registration.RegisteredAt.IsZero() {
+		registration.RegisteredAt = time.Now().UTC()
+	}
+	return m.registry.Add(registration)
+}
+
+// Unregister forgets a registered cluster; the cluster itself is left untouched
+func (m *Manager) Unregister(name string) error {
+	removed, err := m.registry.Remove(name)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return models.NewClusterNotFoundError(name)
+	}
+	return nil
+}
+
+// Get returns the registration of a cluster, false when it is not registered
+func (m *Manager) Get(name string) (Registration, bool, error) {
+	return m.registry.Get(name)
+}
+
+// ListClusters returns the registered clusters with the readiness of their nodes
+func (m *Manager) ListClusters(ctx context.Context) ([]models.ClusterInfo, error) {
+	registrations, err := m.registry.List()
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]models.ClusterInfo, 0, len(registrations))
+	for _, registration := range registrations {
+		clusters = append(clusters, m.clusterInfo(ctx, registration))
+	}
+	return clusters, nil
+}
+
+// GetClusterStatus returns the nodes, readiness and Kubernetes version of a registered cluster
+func (m *Manager) GetClusterStatus(ctx context.Context, name string) (models.ClusterInfo, error) {
+	registration, ok, err := m.registry.Get(name)
+	if err != nil {
+		return models.ClusterInfo{}, err
+	}
+	if !ok {
+		return models.ClusterInfo{}, models.NewClusterNotFoundError(name)
+	}
+
+	info := m.clusterInfo(ctx, registration)
+	if info.Status != StatusUnreachable {
+		if version, err := m.serverVersion(ctx, registration); err == nil {
+			info.K8sVersion = version
+		}
+	}
+	return info, nil
+}
+
+// CheckCapabilities checks the Kubernetes version, default StorageClass and ingress
+// controller of a registered cluster
+func (m *Manager) CheckCapabilities(ctx context.Context, name string) ([]Capability, error) {
+	registration, ok, err := m.registry.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, models.NewClusterNotFoundError(name)
+	}
+
+	return []Capability{
+		m.checkVersion(ctx, registration),
+		m.checkStorageClass(ctx, registration),
+		m.checkIngressController(ctx, registration),
+	}, nil
+}
+
+// Kubeconfig returns a self-contained kubeconfig holding only the context of a registered
+// cluster, with that context as the current one
+func (m *Manager) Kubeconfig(ctx context.Context, name string) (string, error) {
+	registration, ok, err := m.registry.Get(name)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", models.NewClusterNotFoundError(name)
+	}
+
+	args := []string{"config", "view", "--minify", "--flatten", "--context", registration.Context}
+	if registration.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", registration.Kubeconfig)
+	}
+	result, err := m.executor.Execute(ctx, "kubectl", args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get kubeconfig of context %s: %w", registration.Context, err)
+	}
+	return result.Stdout, nil
+}
+
+// kubectl runs kubectl against the context of a registration
+func (m *Manager) kubectl(ctx context.Context, registration Registration, args ...string) (*executor.CommandResult, error) {
+	fullArgs := []string{"--context", registration.Context, "--request-timeout", requestTimeout}
+	if registration.Kubeconfig != "" {
+		fullArgs = append(fullArgs, "--kubeconfig", registration.Kubeconfig)
+	}
+	return m.executor.Execute(ctx, "kubectl", append(fullArgs, args...)...)
+}
+
+// clusterInfo describes a registration, its status being "<ready>/<total>" nodes
+func (m *Manager) clusterInfo(ctx context.Context, registration Registration) models.ClusterInfo {
+	info := models.ClusterInfo{
+		Name:      registration.Name,
+		Type:      models.ClusterTypeExternal,
+		Status:    StatusUnreachable,
+		CreatedAt: registration.RegisteredAt,
+		Nodes:     []models.NodeInfo{},
+	}
+
+	result, err := m.kubectl(ctx, registration, "get", "nodes", "-o", "json")
+	if err != nil {
+		return info
+	}
+
+	var nodes struct {
+		Items []struct {
+			Metadata struct {
+				Name   string            `json:"name"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Status struct {
+				Conditions []struct {
+					Type   string `json:"type"`
+					Status string `json:"status"`
+				} `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &nodes); err != nil {
+		return info
+	}
+
+	ready := 0
+	for _, node := range nodes.Items {
+		status := "NotReady"
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == "Ready" && condition.Status == "True" {
+				status = "Ready"
+				ready++
+			}
+		}
+		role := "worker"
+		if _, ok := node.Metadata.Labels["node-role.kubernetes.io/control-plane"]; ok {
+			role = "control-plane"
+		}
+		info.Nodes = append(info.Nodes, models.NodeInfo{Name: node.Metadata.Name, Status: status, Role: role})
+	}
+	info.NodeCount = len(nodes.Items)
+	info.Status = fmt.Sprintf("%d/%d", ready, len(nodes.Items))
+	return info
+}
+
+// serverVersion returns the git version of the API server, e.g. "v1.31.5+k3s1"
+func (m *Manager) serverVersion(ctx context.Context, registration Registration) (string, error) {
+	result, err := m.kubectl(ctx, registration, "version", "-o", "json")
+	if err != nil {
+		return "", fmt.Errorf("failed to read the server version: %w", err)
+	}
+	var version struct {
+		ServerVersion struct {
+			GitVersion string `json:"gitVersion"`
+		} `json:"serverVersion"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &version); err != nil || version.ServerVersion.GitVersion == "" {
+		return "", fmt.Errorf("failed to parse the server version")
+	}
+	return version.ServerVersion.GitVersion, nil
+}
+
+func (m *Manager) checkVersion(ctx context.Context, registration Registration) Capability {
+	capability := Capability{Name: "Kubernetes version"}
+	version, err := m.serverVersion(ctx, registration)
+	if err != nil {
+		capability.Detail = err.Error()
+		return capability
+	}
+	capability.OK = versionAtLeast(version, MinKubernetesVersion)
+	if capability.OK {
+		capability.Detail = version
+	} else {
+		capability.Detail = fmt.Sprintf("%s is older than the supported v%s", version, MinKubernetesVersion)
+	}
+	return capability
+}
+
+func (m *Manager) checkStorageClass(ctx context.Context, registration Registration) Capability {
+	capability := Capability{Name: "Default StorageClass"}
+	names, defaults, err := m.classes(ctx, registration, "storageclasses", "storageclass.kubernetes.io/is-default-class")
+	if err != nil {
+		capability.Detail = err.Error()
+		return capability
+	}
+	switch {
+	case len(defaults) > 0:
+		capability.OK = true
+		capability.Detail = strings.Join(defaults, ", ")
+	case len(names) > 0:
+		capability.Detail = fmt.Sprintf("none of %s is marked default, persistent volume claims without a class stay pending", strings.Join(names, ", "))
+	default:
+		capability.Detail = "no StorageClass, persistent volume claims stay pending"
+	}
+	return capability
+}
+
+func (m *Manager) checkIngressController(ctx context.Context, registration Registration) Capability {
+	capability := Capability{Name: "Ingress controller"}
+	names, defaults, err := m.classes(ctx, registration, "ingressclasses", "ingressclass.kubernetes.io/is-default-class")
+	if err != nil {
+		capability.Detail = err.Error()
+		return capability
+	}
+	if len(names) == 0 {
+		capability.Detail = "no IngressClass, install an ingress controller to reach OpenFrame"
+		return capability
+	}
+	capability.OK = true
+	capability.Detail = strings.Join(names, ", ")
+	if len(defaults) > 0 {
+		capability.Detail += " (default " + strings.Join(defaults, ", ") + ")"
+	}
+	return capability
+}
+
+// classes lists the StorageClasses or IngressClasses of a cluster and those marked default
+func (m *Manager) classes(ctx context.Context, registration Registration, resource, defaultAnnotation string) ([]string, []string, error) {
+	result, err := m.kubectl(ctx, registration, "get", resource, "-o", "json")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list %s: %w", resource, err)
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name        string            `json:"name"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &list); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", resource, err)
+	}
+
+	var names, defaults []string
+	for _, item := range list.Items {
+		names = append(names, item.Metadata.Name)
+		if item.Metadata.Annotations[defaultAnnotation] == "true" {
+			defaults = append(defaults, item.Metadata.Name)
+		}
+	}
+	sort.Strings(names)
+	sort.Strings(defaults)
+	return names, defaults, nil
+}
+
+// versionAtLeast reports whether a version such as "v1.31.5+k3s1" is at least the minor version minimum
+func versionAtLeast(version, minimum string) bool {
+	parse := func(value string) (int, int, bool) {
+		parts := strings.SplitN(strings.TrimPrefix(value, "v"), ".", 3)
+		if len(parts) < 2 {
+			return 0, 0, false
+		}
+		major, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return 0, 0, false
+		}
+		minor, err := strconv.Atoi(strings.TrimRight(parts[1], "+"))
+		if err != nil {
+			return 0, 0, false
+		}
+		return major, minor, true
+	}
+
+	major, minor, ok := parse(version)
+	minMajor, minMinor, minOK := parse(minimum)
+	if !ok || !minOK {
+		return false
+	}
+	return major > minMajor || (major == minMajor && minor >= minMinor)
+}
+
+// kubeconfigLabel names a kubeconfig in messages
+func kubeconfigLabel(path string) string {
+	if path == "" {
+		return "the default kubeconfig"
+	}
+	return path
+}
@@ -0,0 +1,147 @@
+package external
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nodesJSON = `{"items":[
+  {"metadata":{"name":"vm-1","labels":{"node-role.kubernetes.io/control-plane":"true"}},"status":{"conditions":[{"type":"Ready","status":"True"}]}},
+  {"metadata":{"name":"vm-2","labels":{}},"status":{"conditions":[{"type":"Ready","status":"False"}]}}
+]}`
+
+// newSharedVM stands in for a k3s VM reachable through the context "shared"
+func newSharedVM(t *testing.T) (*Manager, *executor.MockCommandExecutor) {
+	t.Helper()
+	mockExec := executor.NewMockCommandExecutor()
+	mockExec.SetResponse("config get-contexts", &executor.CommandResult{Stdout: "docker-desktop\nshared\n"})
+	mockExec.SetResponse("get nodes", &executor.CommandResult{Stdout: nodesJSON})
+	mockExec.SetResponse("version -o json", &executor.CommandResult{Stdout: `{"serverVersion":{"gitVersion":"v1.31.5+k3s1"}}`})
+	mockExec.SetResponse("get storageclasses", &executor.CommandResult{Stdout: `{"items":[{"metadata":{"name":"local-path","annotations":{"storageclass.kubernetes.io/is-default-class":"true"}}}]}`})
+	mockExec.SetResponse("get ingressclasses", &executor.CommandResult{Stdout: `{"items":[]}`})
+
+	registry := NewRegistry(filepath.Join(t.TempDir(), "clusters", "external.json"))
+	return NewManager(mockExec, registry), mockExec
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(filepath.Join(t.TempDir(), "clusters", "external.json"))
+
+	list, err := registry.List()
+	require.NoError(t, err)
+	assert.Empty(t, list, "a missing file holds no registrations")
+
+	require.NoError(t, registry.Add(Registration{Name: "vm", Context: "shared"}))
+	require.NoError(t, registry.Add(Registration{Name: "desktop", Context: "docker-desktop"}))
+
+	list, err = registry.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Registration{{Name: "desktop", Context: "docker-desktop"}, {Name: "vm", Context: "shared"}}, list)
+
+	removed, err := registry.Remove("vm")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = registry.Remove("vm")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestManager_Register(t *testing.T) {
+	manager, _ := newSharedVM(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Register(ctx, Registration{Name: "vm", Context: "shared"}))
+	registration, ok, err := manager.Get("vm")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "shared", registration.Context)
+	assert.False(t, registration.RegisteredAt.IsZero())
+
+	err = manager.Register(ctx, Registration{Name: "other", Context: "missing"})
+	assert.EqualError(t, err, "context missing not found in the default kubeconfig")
+
+	err = manager.Register(ctx, Registration{Name: "other", Context: "shared", Kubeconfig: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.ErrorContains(t, err, "missing.yaml")
+
+	err = manager.Register(ctx, Registration{Name: "vm", Context: ""})
+	assert.ErrorContains(t, err, "kubeconfig context cannot be empty")
+}
+
+func TestManager_ClusterStatus(t *testing.T) {
+	manager, mockExec := newSharedVM(t)
+	ctx := context.Background()
+	require.NoError(t, manager.Register(ctx, Registration{Name: "vm", Context: "shared"}))
+
+	info, err := manager.GetClusterStatus(ctx, "vm")
+	require.NoError(t, err)
+	assert.Equal(t, models.ClusterTypeExternal, info.Type)
+	assert.Equal(t, "1/2", info.Status, "one of two nodes is ready")
+	assert.Equal(t, "v1.31.5+k3s1", info.K8sVersion)
+	assert.Equal(t, []models.NodeInfo{
+		{Name: "vm-1", Status: "Ready", Role: "control-plane"},
+		{Name: "vm-2", Status: "NotReady", Role: "worker"},
+	}, info.Nodes)
+	assert.True(t, mockExec.WasCommandExecuted("kubectl --context shared --request-timeout 10s get nodes -o json"))
+
+	_, err = manager.GetClusterStatus(ctx, "missing")
+	assert.Error(t, err)
+}
+
+func TestManager_ClusterStatus_Unreachable(t *testing.T) {
+	manager, mockExec := newSharedVM(t)
+	ctx := context.Background()
+	require.NoError(t, manager.Register(ctx, Registration{Name: "vm", Context: "shared"}))
+
+	mockExec.SetShouldFail(true, "Unable to connect to the server")
+	clusters, err := manager.ListClusters(ctx)
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, StatusUnreachable, clusters[0].Status)
+}
+
+func TestManager_CheckCapabilities(t *testing.T) {
+	manager, _ := newSharedVM(t)
+	ctx := context.Background()
+	require.NoError(t, manager.Register(ctx, Registration{Name: "vm", Context: "shared"}))
+
+	capabilities, err := manager.CheckCapabilities(ctx, "vm")
+	require.NoError(t, err)
+	assert.Equal(t, []Capability{
+		{Name: "Kubernetes version", OK: true, Detail: "v1.31.5+k3s1"},
+		{Name: "Default StorageClass", OK: true, Detail: "local-path"},
+		{Name: "Ingress controller", OK: false, Detail: "no IngressClass, install an ingress controller to reach OpenFrame"},
+	}, capabilities)
+}
+
+func TestManager_Kubeconfig(t *testing.T) {
+	manager, mockExec := newSharedVM(t)
+	ctx := context.Background()
+
+	kubeconfig := filepath.Join(t.TempDir(), "shared.yaml")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte("apiVersion: v1\n"), 0o600))
+	require.NoError(t, manager.Register(ctx, Registration{Name: "vm", Context: "shared", Kubeconfig: kubeconfig}))
+
+	mockExec.SetResponse("config view", &executor.CommandResult{Stdout: "current-context: shared\n"})
+	content, err := manager.Kubeconfig(ctx, "vm")
+	require.NoError(t, err)
+	assert.Equal(t, "current-context: shared\n", content)
+	assert.Equal(t, "kubectl config view --minify --flatten --context shared --kubeconfig "+kubeconfig, mockExec.GetLastCommand())
+
+	_, err = manager.Kubeconfig(ctx, "missing")
+	assert.Error(t, err)
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	assert.True(t, versionAtLeast("v1.31.5+k3s1", "1.27"))
+	assert.True(t, versionAtLeast("v1.27.0", "1.27"))
+	assert.True(t, versionAtLeast("v1.28+", "1.27"), "managed clusters report minors such as 28+")
+	assert.False(t, versionAtLeast("v1.26.9", "1.27"))
+	assert.False(t, versionAtLeast("unknown", "1.27"))
+}
@@ -0,0 +1,138 @@
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Registration records an existing cluster the CLI manages through a kubeconfig context
+type Registration struct {
+	Name         string    `json:"name"`
+	Context      string    `json:"context"`
+	Kubeconfig   string    `json:"kubeconfig,omitempty"` // Empty for the default kubeconfig
+	RegisteredAt time.Time `json:"registeredAt"`
+}
+
+// Registry persists the registered external clusters
+type Registry struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewRegistry creates a registry backed by the JSON file at path
+func NewRegistry(path string) *Registry {
+	return &Registry{path: path}
+}
+
+// DefaultRegistryPath returns ~/.config/openframe/clusters/external.json
+func DefaultRegistryPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "openframe", "clusters", "external.json"), nil
+}
+
+// NewDefaultRegistry returns the registry in the user's config directory, nil when there
+// is no home directory to keep it in
+func NewDefaultRegistry() *Registry {
+	path, err := DefaultRegistryPath()
+	if err != nil {
+		return nil
+	}
+	return NewRegistry(path)
+}
+
+// List returns the registered clusters sorted by name
+func (r *Registry) List() ([]Registration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registrations, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Registration, 0, len(registrations))
+	for _, registration := range registrations {
+		list = append(list, registration)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Get returns the registration of a cluster
+func (r *Registry) Get(name string) (Registration, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registrations, err := r.load()
+	if err != nil {
+		return Registration{}, false, err
+	}
+	registration, ok := registrations[name]
+	return registration, ok, nil
+}
+
+// Add records a registration, replacing an earlier one with the same name
+func (r *Registry) Add(registration Registration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registrations, err := r.load()
+	if err != nil {
+		return err
+	}
+	registrations[registration.Name] = registration
+	return r.save(registrations)
+}
+
+// Remove forgets a registration, false when there was none
+func (r *Registry) Remove(name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registrations, err := r.load()
+	if err != nil {
+		return false, err
+	}
+	if _, ok := registrations[name]; !ok {
+		return false, nil
+	}
+	delete(registrations, name)
+	return true, r.save(registrations)
+}
+
+func (r *Registry) load() (map[string]Registration, error) {
+	registrations := map[string]Registration{}
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return registrations, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external clusters from %s: %w", r.path, err)
+	}
+	if err := json.Unmarshal(data, &registrations); err != nil {
+		return nil, fmt.Errorf("failed to parse external clusters in %s: %w", r.path, err)
+	}
+	return registrations, nil
+}
+
+func (r *Registry) save(registrations map[string]Registration) error {
+	data, err := json.MarshalIndent(registrations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode external clusters: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(r.path), err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save external clusters to %s: %w", r.path, err)
+	}
+	return nil
+}
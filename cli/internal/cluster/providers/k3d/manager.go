@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/docker"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
 )
 
@@ -37,29 +38,45 @@ type ClusterManager interface {
 
 // K3dManager manages K3D cluster operations
 type K3dManager struct {
-	executor executor.CommandExecutor
-	verbose  bool
-	timeout  string
+	executor   executor.CommandExecutor
+	verbose    bool
+	timeout    string
+	portStore  *PortStore // Optional, keeps host ports stable across recreates
+	dockerHost string     // Remote Docker host publishing the cluster ports, empty for this machine
 }
 
 // NewK3dManager creates a new K3D cluster manager with default timeout
 func NewK3dManager(exec executor.CommandExecutor, verbose bool) *K3dManager {
 	return &K3dManager{
-		executor: exec,
-		verbose:  verbose,
-		timeout:  defaultTimeout,
+		executor:   exec,
+		verbose:    verbose,
+		timeout:    defaultTimeout,
+		dockerHost: docker.RemoteHost(),
 	}
 }
 
 // NewK3dManagerWithTimeout creates a new K3D cluster manager with custom timeout
 func NewK3dManagerWithTimeout(exec executor.CommandExecutor, verbose bool, timeout string) *K3dManager {
 	return &K3dManager{
-		executor: exec,
-		verbose:  verbose,
-		timeout:  timeout,
+		executor:   exec,
+		verbose:    verbose,
+		timeout:    timeout,
+		dockerHost: docker.RemoteHost(),
 	}
 }
 
+// WithDockerHost sets the remote Docker host the cluster ports are published on,
+// empty for this machine. It defaults to the host DOCKER_HOST points at.
+func (m *K3dManager) WithDockerHost(host string) *K3dManager {
+	m.dockerHost = host
+	return m
+}
+
+// DockerHost returns the remote Docker host, empty when Docker runs on this machine
+func (m *K3dManager) DockerHost() string {
+	return m.dockerHost
+}
+
 // CreateCluster creates a new K3D cluster using config file approach
 func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterConfig) error {
 	if err := m.validateClusterConfig(config); err != nil {
@@ -70,7 +87,12 @@ func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterCon
 		return models.NewProviderNotFoundError(config.Type)
 	}
 
-	configFile, err := m.createK3dConfigFile(config)
+	ports, err := m.allocatePorts(config)
+	if err != nil {
+		return models.NewClusterOperationError("create", config.Name, fmt.Errorf("failed to allocate host ports: %w", err))
+	}
+
+	configFile, err := m.createK3dConfigFile(config, ports)
 	if err != nil {
 		return models.NewClusterOperationError("create", config.Name, fmt.Errorf("failed to create config file: %w", err))
 	}
@@ -90,6 +112,11 @@ func (m *K3dManager) CreateCluster(ctx context.Context, config models.ClusterCon
 	if _, err := m.executor.Execute(ctx, "k3d", args...); err != nil {
 		return models.NewClusterOperationError("create", config.Name, fmt.Errorf("failed to create cluster %s: %w", config.Name, err))
 	}
+	m.savePorts(config.Name, ports)
+
+	if config.NoSwitchContext {
+		return nil
+	}
 
 	// Set kubectl context to the newly created cluster
 	contextName := fmt.Sprintf("k3d-%s", config.Name)
@@ -171,12 +198,15 @@ func (m *K3dManager) ListClusters(ctx context.Context) ([]models.ClusterInfo, er
 		}
 
 		clusters = append(clusters, models.ClusterInfo{
-			Name:      k3dCluster.Name,
-			Type:      models.ClusterTypeK3d,
-			Status:    fmt.Sprintf("%d/%d", k3dCluster.ServersRunning, k3dCluster.ServersCount),
-			NodeCount: k3dCluster.AgentsCount + k3dCluster.ServersCount,
-			CreatedAt: createdAt,
-			Nodes:     []models.NodeInfo{},
+			Name:       k3dCluster.Name,
+			Type:       models.ClusterTypeK3d,
+			Status:     fmt.Sprintf("%d/%d", k3dCluster.ServersRunning, k3dCluster.ServersCount),
+			NodeCount:  k3dCluster.AgentsCount + k3dCluster.ServersCount,
+			K8sVersion: oldestVersion(k3dCluster.Nodes),
+			CreatedAt:  createdAt,
+			Nodes:      clusterNodes(k3dCluster),
+			ExpiresAt:  clusterExpiry(k3dCluster),
+			Labels:     clusterLabels(k3dCluster),
 		})
 	}
 
@@ -237,6 +267,24 @@ func (m *K3dManager) GetKubeconfig(ctx context.Context, name string, clusterType
 	return result.Stdout, nil
 }
 
+// HostImages returns the images Docker needs to create a cluster with the default node
+// image: the node image and the load balancer and tools images of the installed k3d
+func (m *K3dManager) HostImages(ctx context.Context) ([]string, error) {
+	result, err := m.executor.Execute(ctx, "k3d", "version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the k3d version: %w", err)
+	}
+	match := k3dVersionPattern.FindStringSubmatch(result.Stdout)
+	if match == nil {
+		return nil, fmt.Errorf("failed to parse the k3d version from %q", strings.TrimSpace(result.Stdout))
+	}
+	return []string{
+		defaultK3sImage,
+		"ghcr.io/k3d-io/k3d-proxy:" + match[1],
+		"ghcr.io/k3d-io/k3d-tools:" + match[1],
+	}, nil
+}
+
 // validateClusterConfig validates the cluster configuration
 func (m *K3dManager) validateClusterConfig(config models.ClusterConfig) error {
 	if config.Name == "" {
@@ -251,8 +299,8 @@ func (m *K3dManager) validateClusterConfig(config models.ClusterConfig) error {
 	return nil
 }
 
-// createK3dConfigFile creates a k3d config file
-func (m *K3dManager) createK3dConfigFile(config models.ClusterConfig) (string, error) {
+// createK3dConfigFile creates a k3d config file publishing the given host ports
+func (m *K3dManager) createK3dConfigFile(config models.ClusterConfig, ports models.PortAssignment) (string, error) {
 	image := defaultK3sImage
 	if runtime.GOARCH == "arm64" {
 		image = defaultK3sImage
@@ -275,20 +323,20 @@ servers: %d
 agents: %d
 image: %s`, config.Name, servers, agents, image)
 
-	// Always use dynamic ports to avoid conflicts, regardless of cluster name
-	ports, err := m.findAvailablePorts(3)
-	if err != nil || len(ports) < 3 {
-		return "", fmt.Errorf("failed to allocate available ports: %w", err)
+	apiPort := strconv.Itoa(ports.API)
+	// On a remote Docker host the API port must be reachable from this machine, under
+	// the name k3d also adds to the API server certificate
+	apiHost, apiHostIP := "127.0.0.1", "127.0.0.1"
+	if m.dockerHost != "" {
+		apiHost, apiHostIP = m.dockerHost, "0.0.0.0"
 	}
-
-	apiPort := strconv.Itoa(ports[0])
-	httpPort := strconv.Itoa(ports[1])
-	httpsPort := strconv.Itoa(ports[2])
+	httpPort := strconv.Itoa(ports.HTTP)
+	httpsPort := strconv.Itoa(ports.HTTPS)
 
 	configContent += fmt.Sprintf(`
 kubeAPI:
-  host: "127.0.0.1"
-  hostIP: "127.0.0.1"
+  host: "%s"
+  hostIP: "%s"
   hostPort: "%s"
 options:
   k3s:
@@ -302,13 +350,16 @@ options:
       - arg: --kubelet-arg=eviction-soft=
         nodeFilters:
           - all
+  kubeconfig:
+    updateDefaultKubeconfig: true
+    switchCurrentContext: %t%s
 ports:
   - port: %s:80
     nodeFilters:
       - loadbalancer
   - port: %s:443
     nodeFilters:
-      - loadbalancer`, apiPort, httpPort, httpsPort)
+      - loadbalancer`, apiHost, apiHostIP, apiPort, !config.NoSwitchContext, runtimeLabelsConfig(config, time.Now()), httpPort, httpsPort)
 
 	tmpFile, err := os.CreateTemp("", "k3d-config-*.yaml")
 	if err != nil {
@@ -342,46 +393,6 @@ func (m *K3dManager) isTestCluster(name string) bool {
 		strings.ContainsAny(name[len(name)-timestampSuffixLen:], "0123456789")
 }
 
-// findAvailablePorts finds the specified number of available TCP ports using intelligent approach
-func (m *K3dManager) findAvailablePorts(count int) ([]int, error) {
-	// Get ports used by existing k3d clusters
-	usedPorts := m.getUsedPortsByExistingClusters()
-
-	// Start with default ports and increment if busy (matching script behavior)
-	defaultPorts := []int{6550, 80, 443} // API, HTTP, HTTPS
-    alternatePorts := []int{6551, 81, 444}
-
-	var ports []int
-
-	for i := 0; i < count && i < len(defaultPorts); i++ {
-		// Check if default port is available and not used by existing clusters
-		if m.isPortAvailable(defaultPorts[i]) && !m.isPortInUse(defaultPorts[i], usedPorts) {
-			ports = append(ports, defaultPorts[i])
-		} else if m.isPortAvailable(alternatePorts[i]) && !m.isPortInUse(alternatePorts[i], usedPorts) {
-			ports = append(ports, alternatePorts[i])
-		} else {
-			// Find next available port that's not used by k3d clusters
-			found := false
-			for port := alternatePorts[i] + 1; port < alternatePorts[i]+1000; port++ {
-				if m.isPortAvailable(port) && !m.isPortInUse(port, usedPorts) {
-					ports = append(ports, port)
-					found = true
-					break
-				}
-			}
-			if !found {
-				return nil, fmt.Errorf("could not find available port for index %d", i)
-			}
-		}
-	}
-
-	if len(ports) < count {
-		return nil, fmt.Errorf("could not find %d available ports", count)
-	}
-
-	return ports, nil
-}
-
 // getUsedPortsByExistingClusters returns a map of ports used by existing k3d clusters
 func (m *K3dManager) getUsedPortsByExistingClusters() map[int]bool {
 	usedPorts := make(map[int]bool)
@@ -430,8 +441,11 @@ func (m *K3dManager) isPortInUse(port int, usedPorts map[int]bool) bool {
 	return usedPorts[port]
 }
 
-// isPortAvailable checks if a TCP port is available
+// isPortAvailable checks if a TCP port is available on the Docker host
 func (m *K3dManager) isPortAvailable(port int) bool {
+	if m.dockerHost != "" {
+		return docker.IsPortAvailable(m.dockerHost, port)
+	}
 	address := fmt.Sprintf(":%d", port)
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
@@ -456,11 +470,19 @@ type k3dClusterInfo struct {
 type k3dNode struct {
 	Name          string                   `json:"name"`
 	Role          string                   `json:"role"`
+	Image         string                   `json:"image,omitempty"`
+	State         k3dNodeState             `json:"State"`
 	Created       time.Time                `json:"created"`
 	RuntimeLabels map[string]string        `json:"runtimeLabels,omitempty"`
 	PortMappings  map[string][]PortMapping `json:"portMappings,omitempty"`
 }
 
+// k3dNodeState is the container state of a k3d node
+type k3dNodeState struct {
+	Running bool   `json:"Running"`
+	Status  string `json:"Status"`
+}
+
 // PortMapping represents a port mapping for k3d nodes
 type PortMapping struct {
 	HostIP   string `json:"HostIp"`
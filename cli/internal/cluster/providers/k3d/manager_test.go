@@ -110,6 +110,19 @@ func TestK3dManager_CreateCluster(t *testing.T) {
 				m.On("Execute", mock.Anything, "kubectl", mock.Anything).Return(&execPkg.CommandResult{Stdout: "Switched to context \"k3d-test-cluster\"."}, nil)
 			},
 		},
+		{
+			name: "cluster creation without context switch",
+			config: models.ClusterConfig{
+				Name:            "test-cluster",
+				Type:            models.ClusterTypeK3d,
+				NodeCount:       1,
+				NoSwitchContext: true,
+			},
+			setupMock: func(m *MockExecutor) {
+				// No kubectl expectation: switching the context would fail the test
+				m.On("Execute", mock.Anything, "k3d", mock.Anything).Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+			},
+		},
 		{
 			name: "empty cluster name",
 			config: models.ClusterConfig{
@@ -535,6 +548,31 @@ func TestK3dManager_GetKubeconfig(t *testing.T) {
 	})
 }
 
+func TestK3dManager_HostImages(t *testing.T) {
+	t.Run("images of the installed k3d", func(t *testing.T) {
+		executor := &MockExecutor{}
+		executor.On("Execute", mock.Anything, "k3d", []string{"version"}).Return(&execPkg.CommandResult{Stdout: "k3d version v5.8.3\nk3s version v1.31.5-k3s1 (default)\n"}, nil)
+
+		manager := NewK3dManager(executor, false)
+		images, err := manager.HostImages(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{DefaultImage(), "ghcr.io/k3d-io/k3d-proxy:5.8.3", "ghcr.io/k3d-io/k3d-tools:5.8.3"}, images)
+		executor.AssertExpectations(t)
+	})
+
+	t.Run("unparseable version", func(t *testing.T) {
+		executor := &MockExecutor{}
+		executor.On("Execute", mock.Anything, "k3d", []string{"version"}).Return(&execPkg.CommandResult{Stdout: "k3d version unknown"}, nil)
+
+		manager := NewK3dManager(executor, false)
+		_, err := manager.HostImages(context.Background())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to parse the k3d version from "k3d version unknown"`)
+	})
+}
+
 func TestK3dManager_validateClusterConfig(t *testing.T) {
 	manager := &K3dManager{}
 
@@ -0,0 +1,264 @@
+package k3d
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// k3dPodList is the part of 'kubectl get pods -o json' needed to follow pods across nodes
+type k3dPodList struct {
+	Items []struct {
+		Metadata struct {
+			Name            string `json:"name"`
+			Namespace       string `json:"namespace"`
+			OwnerReferences []struct {
+				Kind string `json:"kind"`
+			} `json:"ownerReferences"`
+		} `json:"metadata"`
+		Spec struct {
+			NodeName string `json:"nodeName"`
+		} `json:"spec"`
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// clusterNodes returns the server and agent nodes of a cluster, leaving out the load
+// balancer and tools containers, which are no Kubernetes nodes
+func clusterNodes(cluster k3dClusterInfo) []models.NodeInfo {
+	nodes := []models.NodeInfo{}
+	for _, node := range cluster.Nodes {
+		if node.Role != "server" && node.Role != "agent" {
+			continue
+		}
+		status := node.State.Status
+		if status == "" {
+			status = "unknown"
+		}
+		nodes = append(nodes, models.NodeInfo{Name: node.Name, Status: status, Role: node.Role})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// ScaleAgents adds or removes agent nodes until the cluster runs the given number of
+// agents. New nodes are waited for until they are Ready; removed nodes are cordoned and
+// drained before their containers are deleted, the newest first.
+func (m *K3dManager) ScaleAgents(ctx context.Context, name string, agents int) (models.ScaleResult, error) {
+	var result models.ScaleResult
+	if name == "" {
+		return result, models.NewInvalidConfigError("name", name, "cluster name cannot be empty")
+	}
+	if agents < 0 {
+		return result, models.NewInvalidConfigError("agents", agents, "agent count cannot be negative")
+	}
+
+	cluster, err := m.getK3dCluster(ctx, name)
+	if err != nil {
+		return result, models.NewClusterOperationError("scale", name, err)
+	}
+
+	var current []k3dNode
+	image := ""
+	for _, node := range cluster.Nodes {
+		switch node.Role {
+		case "agent":
+			current = append(current, node)
+		case "server":
+			image = node.Image
+		}
+	}
+
+	switch {
+	case agents > len(current):
+		result.Added, err = m.addAgents(ctx, cluster, image, agents-len(current))
+	case agents < len(current):
+		result.Removed, result.Evicted, err = m.removeAgents(ctx, name, current, len(current)-agents)
+	}
+	if err != nil {
+		return result, models.NewClusterOperationError("scale", name, err)
+	}
+
+	result.PodsPerNode, result.Pending, err = m.podPlacement(ctx, name)
+	if err != nil {
+		return result, models.NewClusterOperationError("scale", name, err)
+	}
+	return result, nil
+}
+
+// addAgents creates count agent nodes with the image of the servers and waits until
+// Kubernetes reports them Ready
+func (m *K3dManager) addAgents(ctx context.Context, cluster k3dClusterInfo, image string, count int) ([]string, error) {
+	next := nextAgentIndex(cluster)
+	var added []string
+	for i := 0; i < count; i++ {
+		nodeName := fmt.Sprintf("%s-agent-%d", cluster.Name, next+i)
+		args := []string{"node", "create", nodeName, "--cluster", cluster.Name, "--role", "agent", "--wait", "--timeout", m.timeout}
+		if image != "" {
+			args = append(args, "--image", image)
+		}
+		if m.verbose {
+			args = append(args, "--verbose")
+		}
+		if _, err := m.executor.Execute(ctx, "k3d", args...); err != nil {
+			return added, fmt.Errorf("failed to create agent node %s: %w", nodeName, err)
+		}
+		// k3d numbers the replicas of a node, the container is the first one
+		added = append(added, fmt.Sprintf("k3d-%s-0", nodeName))
+	}
+
+	for _, node := range added {
+		if _, err := m.kubectl(ctx, cluster.Name, "wait", "--for=condition=Ready", "node/"+node, "--timeout", m.timeout); err != nil {
+			return added, fmt.Errorf("node %s did not become Ready: %w", node, err)
+		}
+	}
+	return added, nil
+}
+
+// removeAgents cordons, drains and deletes the count newest agents, returning the removed
+// nodes and the pods that were evicted from them
+func (m *K3dManager) removeAgents(ctx context.Context, clusterName string, agents []k3dNode, count int) ([]string, []string, error) {
+	sort.Slice(agents, func(i, j int) bool {
+		if !agents[i].Created.Equal(agents[j].Created) {
+			return agents[i].Created.After(agents[j].Created)
+		}
+		return agents[i].Name > agents[j].Name
+	})
+
+	var removed, evicted []string
+	for _, node := range agents[:count] {
+		pods, err := m.evictablePods(ctx, clusterName, node.Name)
+		if err != nil {
+			return removed, evicted, err
+		}
+
+		if _, err := m.kubectl(ctx, clusterName, "cordon", node.Name); err != nil {
+			return removed, evicted, fmt.Errorf("failed to cordon node %s: %w", node.Name, err)
+		}
+		if _, err := m.kubectl(ctx, clusterName, "drain", node.Name, "--ignore-daemonsets", "--delete-emptydir-data", "--timeout", m.timeout); err != nil {
+			return removed, evicted, fmt.Errorf("failed to drain node %s, it stays cordoned until 'kubectl uncordon %s': %w", node.Name, node.Name, err)
+		}
+		evicted = append(evicted, pods...)
+
+		if _, err := m.executor.Execute(ctx, "k3d", "node", "delete", node.Name); err != nil {
+			return removed, evicted, fmt.Errorf("failed to delete node %s: %w", node.Name, err)
+		}
+		// k3d removes the container, the Node object is left behind NotReady
+		if _, err := m.kubectl(ctx, clusterName, "delete", "node", node.Name, "--ignore-not-found"); err != nil {
+			return removed, evicted, fmt.Errorf("failed to delete node object %s: %w", node.Name, err)
+		}
+		removed = append(removed, node.Name)
+	}
+	return removed, evicted, nil
+}
+
+// evictablePods lists the pods a drain moves off a node: DaemonSet pods stay in place and
+// finished pods are not started again
+func (m *K3dManager) evictablePods(ctx context.Context, clusterName, node string) ([]string, error) {
+	pods, err := m.listPods(ctx, clusterName, "--field-selector", "spec.nodeName="+node)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		daemon := false
+		for _, owner := range pod.Metadata.OwnerReferences {
+			if owner.Kind == "DaemonSet" {
+				daemon = true
+			}
+		}
+		if !daemon {
+			names = append(names, pod.Metadata.Namespace+"/"+pod.Metadata.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// podPlacement counts the active pods of every node and lists the pods waiting for one
+func (m *K3dManager) podPlacement(ctx context.Context, clusterName string) (map[string]int, []string, error) {
+	pods, err := m.listPods(ctx, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	perNode := map[string]int{}
+	var pending []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == "Succeeded" || pod.Status.Phase == "Failed" {
+			continue
+		}
+		if pod.Spec.NodeName == "" {
+			pending = append(pending, pod.Metadata.Namespace+"/"+pod.Metadata.Name)
+			continue
+		}
+		perNode[pod.Spec.NodeName]++
+	}
+	sort.Strings(pending)
+	return perNode, pending, nil
+}
+
+func (m *K3dManager) listPods(ctx context.Context, clusterName string, args ...string) (k3dPodList, error) {
+	var pods k3dPodList
+	result, err := m.kubectl(ctx, clusterName, append([]string{"get", "pods", "--all-namespaces", "--output", "json"}, args...)...)
+	if err != nil {
+		return pods, fmt.Errorf("failed to list pods: %w", err)
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &pods); err != nil {
+		return pods, fmt.Errorf("failed to parse pod list: %w", err)
+	}
+	return pods, nil
+}
+
+// getK3dCluster returns a single cluster of 'k3d cluster list'
+func (m *K3dManager) getK3dCluster(ctx context.Context, name string) (k3dClusterInfo, error) {
+	result, err := m.executor.Execute(ctx, "k3d", "cluster", "list", "--output", "json")
+	if err != nil {
+		return k3dClusterInfo{}, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var k3dClusters []k3dClusterInfo
+	if err := json.Unmarshal([]byte(result.Stdout), &k3dClusters); err != nil {
+		return k3dClusterInfo{}, fmt.Errorf("failed to parse cluster list JSON: %w", err)
+	}
+	for _, cluster := range k3dClusters {
+		if cluster.Name == name {
+			return cluster, nil
+		}
+	}
+	return k3dClusterInfo{}, models.NewClusterNotFoundError(name)
+}
+
+// kubectl runs kubectl against the context of a k3d cluster, whatever the current one is
+func (m *K3dManager) kubectl(ctx context.Context, clusterName string, args ...string) (*executor.CommandResult, error) {
+	return m.executor.Execute(ctx, "kubectl", append([]string{"--context", "k3d-" + clusterName}, args...)...)
+}
+
+// nextAgentIndex returns the number after the highest agent number of a cluster, so that
+// added agents k3d-<cluster>-agent-<n>-0 never clash with existing ones
+func nextAgentIndex(cluster k3dClusterInfo) int {
+	prefix := fmt.Sprintf("k3d-%s-agent-", cluster.Name)
+	next := 0
+	for _, node := range cluster.Nodes {
+		if node.Role != "agent" || !strings.HasPrefix(node.Name, prefix) {
+			continue
+		}
+		number, _, _ := strings.Cut(strings.TrimPrefix(node.Name, prefix), "-")
+		if index, err := strconv.Atoi(number); err == nil && index >= next {
+			next = index + 1
+		}
+	}
+	return next
+}
@@ -0,0 +1,108 @@
+package k3d
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	execPkg "github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// scaleClusterJSON is a cluster "dev" with one server and the agents 0 and 1, agent 1 the newest
+const scaleClusterJSON = `[{"name":"dev","serversCount":1,"serversRunning":1,"agentsCount":2,"nodes":[
+  {"name":"k3d-dev-server-0","role":"server","image":"rancher/k3s:v1.31.5-k3s1","State":{"Running":true,"Status":"running"},"created":"2024-01-01T00:00:00Z"},
+  {"name":"k3d-dev-agent-0","role":"agent","State":{"Running":true,"Status":"running"},"created":"2024-01-01T00:00:00Z"},
+  {"name":"k3d-dev-agent-1","role":"agent","State":{"Running":true,"Status":"running"},"created":"2024-01-02T00:00:00Z"},
+  {"name":"k3d-dev-serverlb","role":"loadbalancer","State":{"Running":true,"Status":"running"},"created":"2024-01-01T00:00:00Z"}
+]}]`
+
+const scalePodsJSON = `{"items":[
+  {"metadata":{"name":"api-1","namespace":"openframe"},"spec":{"nodeName":"k3d-dev-agent-0"},"status":{"phase":"Running"}},
+  {"metadata":{"name":"svclb-1","namespace":"kube-system","ownerReferences":[{"kind":"DaemonSet"}]},"spec":{"nodeName":"k3d-dev-agent-0"},"status":{"phase":"Running"}},
+  {"metadata":{"name":"pinot-0","namespace":"datasources"},"spec":{},"status":{"phase":"Pending"}},
+  {"metadata":{"name":"job-1","namespace":"openframe"},"spec":{"nodeName":"k3d-dev-server-0"},"status":{"phase":"Succeeded"}}
+]}`
+
+// scaleAgentPodsJSON are the pods of k3d-dev-agent-1
+const scaleAgentPodsJSON = `{"items":[
+  {"metadata":{"name":"api-2","namespace":"openframe"},"spec":{"nodeName":"k3d-dev-agent-1"},"status":{"phase":"Running"}},
+  {"metadata":{"name":"svclb-2","namespace":"kube-system","ownerReferences":[{"kind":"DaemonSet"}]},"spec":{"nodeName":"k3d-dev-agent-1"},"status":{"phase":"Running"}},
+  {"metadata":{"name":"job-2","namespace":"openframe"},"spec":{"nodeName":"k3d-dev-agent-1"},"status":{"phase":"Succeeded"}}
+]}`
+
+func newScaleTestManager() *MockExecutor {
+	executor := &MockExecutor{}
+	executor.On("Execute", mock.Anything, "k3d", []string{"cluster", "list", "--output", "json"}).
+		Return(&execPkg.CommandResult{Stdout: scaleClusterJSON}, nil)
+	executor.On("Execute", mock.Anything, "kubectl", mock.MatchedBy(func(args []string) bool {
+		return args[len(args)-1] == "spec.nodeName=k3d-dev-agent-1"
+	})).Return(&execPkg.CommandResult{Stdout: scaleAgentPodsJSON}, nil)
+	executor.On("Execute", mock.Anything, "kubectl", mock.MatchedBy(func(args []string) bool {
+		return len(args) > 3 && args[2] == "get" && args[3] == "pods"
+	})).Return(&execPkg.CommandResult{Stdout: scalePodsJSON}, nil)
+	executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+	return executor
+}
+
+func TestK3dManager_ListClusters_Nodes(t *testing.T) {
+	manager := NewK3dManager(newScaleTestManager(), false)
+
+	info, err := manager.GetClusterStatus(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []models.NodeInfo{
+		{Name: "k3d-dev-agent-0", Status: "running", Role: "agent"},
+		{Name: "k3d-dev-agent-1", Status: "running", Role: "agent"},
+		{Name: "k3d-dev-server-0", Status: "running", Role: "server"},
+	}, info.Nodes, "the load balancer is no Kubernetes node")
+}
+
+func TestK3dManager_ScaleAgents_Up(t *testing.T) {
+	executor := newScaleTestManager()
+	manager := NewK3dManager(executor, false)
+
+	result, err := manager.ScaleAgents(context.Background(), "dev", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"k3d-dev-agent-2-0"}, result.Added)
+	assert.Empty(t, result.Removed)
+	assert.Equal(t, map[string]int{"k3d-dev-agent-0": 2}, result.PodsPerNode)
+	assert.Equal(t, []string{"datasources/pinot-0"}, result.Pending)
+
+	executor.AssertCalled(t, "Execute", mock.Anything, "k3d", []string{
+		"node", "create", "dev-agent-2", "--cluster", "dev", "--role", "agent", "--wait", "--timeout", defaultTimeout,
+		"--image", "rancher/k3s:v1.31.5-k3s1",
+	})
+	executor.AssertCalled(t, "Execute", mock.Anything, "kubectl", []string{
+		"--context", "k3d-dev", "wait", "--for=condition=Ready", "node/k3d-dev-agent-2-0", "--timeout", defaultTimeout,
+	})
+}
+
+func TestK3dManager_ScaleAgents_Down(t *testing.T) {
+	executor := newScaleTestManager()
+	manager := NewK3dManager(executor, false)
+
+	result, err := manager.ScaleAgents(context.Background(), "dev", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"k3d-dev-agent-1"}, result.Removed, "the newest agent goes first")
+	assert.Equal(t, []string{"openframe/api-2"}, result.Evicted, "DaemonSet and finished pods are not evicted")
+
+	executor.AssertCalled(t, "Execute", mock.Anything, "kubectl", []string{"--context", "k3d-dev", "cordon", "k3d-dev-agent-1"})
+	executor.AssertCalled(t, "Execute", mock.Anything, "kubectl", []string{
+		"--context", "k3d-dev", "drain", "k3d-dev-agent-1", "--ignore-daemonsets", "--delete-emptydir-data", "--timeout", defaultTimeout,
+	})
+	executor.AssertCalled(t, "Execute", mock.Anything, "k3d", []string{"node", "delete", "k3d-dev-agent-1"})
+	executor.AssertNotCalled(t, "Execute", mock.Anything, "k3d", []string{"node", "delete", "k3d-dev-agent-0"})
+}
+
+func TestK3dManager_ScaleAgents_Invalid(t *testing.T) {
+	manager := NewK3dManager(newScaleTestManager(), false)
+
+	_, err := manager.ScaleAgents(context.Background(), "dev", -1)
+	assert.ErrorContains(t, err, "agent count cannot be negative")
+
+	_, err = manager.ScaleAgents(context.Background(), "missing", 2)
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,307 @@
+package k3d
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+)
+
+// PortStore persists the host ports of every cluster the CLI created, so that a
+// recreated cluster keeps its kubeconfig server and ingress URLs. Assignments are
+// kept when a cluster is deleted: they act as reservations for its next create.
+type PortStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewPortStore creates a port store backed by the JSON file at path
+func NewPortStore(path string) *PortStore {
+	return &PortStore{path: path}
+}
+
+// DefaultPortStorePath returns ~/.config/openframe/clusters/ports.json
+func DefaultPortStorePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "openframe", "clusters", "ports.json"), nil
+}
+
+// NewDefaultPortStore returns the port store in the user's config directory, nil when
+// there is no home directory to keep it in
+func NewDefaultPortStore() *PortStore {
+	path, err := DefaultPortStorePath()
+	if err != nil {
+		return nil
+	}
+	return NewPortStore(path)
+}
+
+// Path returns the file the assignments are stored in
+func (s *PortStore) Path() string {
+	return s.path
+}
+
+// All returns the saved assignments by cluster name, empty when nothing was saved yet
+func (s *PortStore) All() (map[string]models.PortAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Get returns the saved assignment of a cluster
+func (s *PortStore) Get(name string) (models.PortAssignment, bool, error) {
+	assignments, err := s.All()
+	if err != nil {
+		return models.PortAssignment{}, false, err
+	}
+	ports, ok := assignments[name]
+	return ports, ok, nil
+}
+
+// Save records the assignment of a cluster
+func (s *PortStore) Save(name string, ports models.PortAssignment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assignments, err := s.load()
+	if err != nil {
+		return err
+	}
+	assignments[name] = ports
+
+	data, err := json.MarshalIndent(assignments, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode port assignments: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	if err := os.WriteFile(s.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save port assignments to %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func (s *PortStore) load() (map[string]models.PortAssignment, error) {
+	assignments := map[string]models.PortAssignment{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return assignments, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read port assignments from %s: %w", s.path, err)
+	}
+	if err := json.Unmarshal(data, &assignments); err != nil {
+		return nil, fmt.Errorf("failed to parse port assignments in %s: %w", s.path, err)
+	}
+	return assignments, nil
+}
+
+// WithPortStore makes the manager reuse and save the host ports of the clusters it creates
+func (m *K3dManager) WithPortStore(store *PortStore) *K3dManager {
+	m.portStore = store
+	return m
+}
+
+// SavedPorts returns the saved host ports of a cluster, false when there are none
+func (m *K3dManager) SavedPorts(name string) (models.PortAssignment, bool) {
+	if m.portStore == nil {
+		return models.PortAssignment{}, false
+	}
+	ports, ok, err := m.portStore.Get(name)
+	if err != nil {
+		return models.PortAssignment{}, false
+	}
+	return ports, ok
+}
+
+// allocatePorts chooses the API, HTTP and HTTPS host ports of a new cluster on the
+// Docker host. Ports requested in the config must be free; otherwise the saved
+// assignment of the cluster is reused when its ports are still free, and the remaining
+// ports are picked from the defaults, skipping ports published or reserved by other
+// clusters.
+func (m *K3dManager) allocatePorts(config models.ClusterConfig) (models.PortAssignment, error) {
+	if err := models.ValidatePortAssignment(config.Ports); err != nil {
+		return models.PortAssignment{}, err
+	}
+
+	usedPorts := m.getUsedPortsByExistingClusters()
+	reserved := map[int]string{}
+	var saved models.PortAssignment
+	if m.portStore != nil {
+		assignments, err := m.portStore.All()
+		if err != nil {
+			return models.PortAssignment{}, err
+		}
+		saved = assignments[config.Name]
+
+		names := make([]string, 0, len(assignments))
+		for name := range assignments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if name == config.Name {
+				continue
+			}
+			for _, port := range assignments[name].Ports() {
+				if _, taken := reserved[port]; port != 0 && !taken {
+					reserved[port] = name
+				}
+			}
+		}
+	}
+
+	requested := config.Ports.Ports()
+	previous := saved.Ports()
+	taken := map[int]bool{}
+	for _, port := range requested {
+		if port != 0 {
+			taken[port] = true
+		}
+	}
+
+	free := func(port int) bool {
+		_, isReserved := reserved[port]
+		return !isReserved && !taken[port] && !m.isPortInUse(port, usedPorts) && m.isPortAvailable(port)
+	}
+
+	defaultPorts := []int{models.DefaultAPIPort, models.DefaultHTTPPort, models.DefaultHTTPSPort}
+	alternatePorts := []int{models.DefaultAPIPort + 1, models.DefaultHTTPPort + 1, models.DefaultHTTPSPort + 1}
+	names := []string{"API", "HTTP", "HTTPS"}
+
+	ports := make([]int, len(defaultPorts))
+	for i, port := range requested {
+		if port == 0 {
+			continue
+		}
+		if owner, isReserved := reserved[port]; isReserved {
+			return models.PortAssignment{}, fmt.Errorf("%s port %d is reserved by cluster %s", names[i], port, owner)
+		}
+		if m.isPortInUse(port, usedPorts) {
+			return models.PortAssignment{}, fmt.Errorf("%s port %d is already published by another k3d cluster", names[i], port)
+		}
+		if !m.isPortAvailable(port) {
+			if m.dockerHost != "" {
+				return models.PortAssignment{}, fmt.Errorf("%s port %d is not available on the Docker host %s", names[i], port, m.dockerHost)
+			}
+			return models.PortAssignment{}, fmt.Errorf("%s port %d is not available on this host", names[i], port)
+		}
+		ports[i] = port
+	}
+
+	for i := range ports {
+		if ports[i] != 0 {
+			continue
+		}
+		if previous[i] != 0 && free(previous[i]) {
+			ports[i] = previous[i]
+		} else if free(defaultPorts[i]) {
+			ports[i] = defaultPorts[i]
+		} else if free(alternatePorts[i]) {
+			ports[i] = alternatePorts[i]
+		} else {
+			for port := alternatePorts[i] + 1; port < alternatePorts[i]+1000; port++ {
+				if free(port) {
+					ports[i] = port
+					break
+				}
+			}
+			if ports[i] == 0 {
+				return models.PortAssignment{}, fmt.Errorf("could not find an available %s port", names[i])
+			}
+		}
+		taken[ports[i]] = true
+	}
+
+	return models.PortAssignment{API: ports[0], HTTP: ports[1], HTTPS: ports[2], Host: m.dockerHost}, nil
+}
+
+// savePorts records the ports of a created cluster; a failure only costs the reuse on recreate
+func (m *K3dManager) savePorts(name string, ports models.PortAssignment) {
+	if m.portStore == nil {
+		return
+	}
+	if err := m.portStore.Save(name, ports); err != nil && m.verbose {
+		fmt.Printf("DEBUG: %v\n", err)
+	}
+}
+
+// GetPortBindings returns every host port published by the nodes of a cluster,
+// the Kubernetes API port of the server included
+func (m *K3dManager) GetPortBindings(ctx context.Context, name string) ([]models.PortBinding, error) {
+	if name == "" {
+		return nil, models.NewInvalidConfigError("name", name, "cluster name cannot be empty")
+	}
+
+	result, err := m.executor.Execute(ctx, "k3d", "cluster", "list", "--output", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var k3dClusters []k3dClusterInfo
+	if err := json.Unmarshal([]byte(result.Stdout), &k3dClusters); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster list JSON: %w", err)
+	}
+
+	for _, cluster := range k3dClusters {
+		if cluster.Name != name {
+			continue
+		}
+
+		var bindings []models.PortBinding
+		published := map[int]bool{}
+		for _, node := range cluster.Nodes {
+			for containerPort, mappings := range node.PortMappings {
+				for _, mapping := range mappings {
+					port, err := strconv.Atoi(mapping.HostPort)
+					if err != nil {
+						continue
+					}
+					published[port] = true
+					bindings = append(bindings, models.PortBinding{
+						Node:          node.Name,
+						ContainerPort: containerPort,
+						HostIP:        mapping.HostIP,
+						HostPort:      port,
+					})
+				}
+			}
+		}
+
+		// Servers carry the API port as a label, listed when no load balancer publishes it
+		for _, node := range cluster.Nodes {
+			port, err := strconv.Atoi(node.RuntimeLabels["k3d.server.api.port"])
+			if err != nil || published[port] {
+				continue
+			}
+			published[port] = true
+			bindings = append(bindings, models.PortBinding{
+				Node:          node.Name,
+				ContainerPort: "6443/tcp",
+				HostIP:        node.RuntimeLabels["k3d.server.api.hostIP"],
+				HostPort:      port,
+			})
+		}
+
+		sort.Slice(bindings, func(i, j int) bool {
+			if bindings[i].HostPort != bindings[j].HostPort {
+				return bindings[i].HostPort < bindings[j].HostPort
+			}
+			return bindings[i].Node < bindings[j].Node
+		})
+		return bindings, nil
+	}
+
+	return nil, models.NewClusterNotFoundError(name)
+}
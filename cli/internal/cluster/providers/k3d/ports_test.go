@@ -0,0 +1,191 @@
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	execPkg "github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// portsClusterJSON is a running cluster "web" with a load balancer publishing 36551, 38081 and 38444
+const portsClusterJSON = `[{"name":"web","nodes":[
+  {"name":"k3d-web-server-0","role":"server","runtimeLabels":{"k3d.server.api.port":"36551","k3d.server.api.hostIP":"127.0.0.1"}},
+  {"name":"k3d-web-serverlb","role":"loadbalancer","portMappings":{
+    "6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"36551"}],
+    "80/tcp":[{"HostIp":"0.0.0.0","HostPort":"38081"}],
+    "443/tcp":[{"HostIp":"0.0.0.0","HostPort":"38444"}]}}
+]}]`
+
+func newPortsTestManager(t *testing.T, clusterList string) (*K3dManager, *PortStore) {
+	t.Helper()
+	executor := &MockExecutor{}
+	executor.On("Execute", mock.Anything, "k3d", []string{"cluster", "list", "--output", "json"}).
+		Return(&execPkg.CommandResult{Stdout: clusterList}, nil)
+	executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+
+	store := NewPortStore(filepath.Join(t.TempDir(), "clusters", "ports.json"))
+	return NewK3dManager(executor, false).WithPortStore(store).WithDockerHost(""), store
+}
+
+func TestPortStore(t *testing.T) {
+	store := NewPortStore(filepath.Join(t.TempDir(), "clusters", "ports.json"))
+
+	assignments, err := store.All()
+	require.NoError(t, err)
+	assert.Empty(t, assignments, "a missing file holds no assignments")
+
+	require.NoError(t, store.Save("dev", models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443}))
+	require.NoError(t, store.Save("qa", models.PortAssignment{API: 36560, HTTP: 38090, HTTPS: 38453}))
+
+	ports, ok, err := store.Get("dev")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443}, ports)
+
+	_, ok, err = store.Get("missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(store.Path(), []byte("{"), 0o644))
+	_, err = store.All()
+	assert.ErrorContains(t, err, "failed to parse port assignments")
+}
+
+func TestK3dManager_AllocatePorts(t *testing.T) {
+	t.Run("uses requested ports", func(t *testing.T) {
+		manager, _ := newPortsTestManager(t, "[]")
+		ports, err := manager.allocatePorts(models.ClusterConfig{
+			Name:  "dev",
+			Ports: models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443}, ports)
+	})
+
+	t.Run("reuses saved ports", func(t *testing.T) {
+		manager, store := newPortsTestManager(t, "[]")
+		require.NoError(t, store.Save("dev", models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443}))
+
+		ports, err := manager.allocatePorts(models.ClusterConfig{Name: "dev", Ports: models.PortAssignment{HTTP: 38090}})
+		require.NoError(t, err)
+		assert.Equal(t, models.PortAssignment{API: 36550, HTTP: 38090, HTTPS: 38443}, ports, "requested ports win over saved ones")
+	})
+
+	t.Run("rejects ports reserved by other clusters", func(t *testing.T) {
+		manager, store := newPortsTestManager(t, "[]")
+		require.NoError(t, store.Save("qa", models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443}))
+
+		_, err := manager.allocatePorts(models.ClusterConfig{Name: "dev", Ports: models.PortAssignment{HTTP: 38080}})
+		assert.EqualError(t, err, "HTTP port 38080 is reserved by cluster qa")
+	})
+
+	t.Run("rejects ports published by running clusters", func(t *testing.T) {
+		manager, _ := newPortsTestManager(t, portsClusterJSON)
+
+		_, err := manager.allocatePorts(models.ClusterConfig{Name: "dev", Ports: models.PortAssignment{HTTPS: 38444}})
+		assert.EqualError(t, err, "HTTPS port 38444 is already published by another k3d cluster")
+	})
+
+	t.Run("skips saved ports now published by another cluster", func(t *testing.T) {
+		manager, store := newPortsTestManager(t, portsClusterJSON)
+		require.NoError(t, store.Save("dev", models.PortAssignment{API: 36551, HTTP: 38080, HTTPS: 38443}))
+
+		ports, err := manager.allocatePorts(models.ClusterConfig{Name: "dev"})
+		require.NoError(t, err)
+		assert.NotEqual(t, 36551, ports.API)
+		assert.Equal(t, 38080, ports.HTTP)
+		assert.Equal(t, 38443, ports.HTTPS)
+	})
+
+	t.Run("rejects invalid assignments", func(t *testing.T) {
+		manager, _ := newPortsTestManager(t, "[]")
+
+		_, err := manager.allocatePorts(models.ClusterConfig{Name: "dev", Ports: models.PortAssignment{HTTP: 38080, HTTPS: 38080}})
+		assert.EqualError(t, err, "port 38080 is assigned twice, the api, http and https ports must differ")
+	})
+}
+
+func TestK3dManager_AllocatePorts_RemoteDockerHost(t *testing.T) {
+	// A listener on the loopback address stands in for a service on the remote host
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	busy := listener.Addr().(*net.TCPAddr).Port
+
+	manager, _ := newPortsTestManager(t, "[]")
+	manager.WithDockerHost("127.0.0.1")
+
+	_, err = manager.allocatePorts(models.ClusterConfig{Name: "dev", Ports: models.PortAssignment{HTTP: busy}})
+	assert.EqualError(t, err, fmt.Sprintf("HTTP port %d is not available on the Docker host 127.0.0.1", busy))
+
+	ports, err := manager.allocatePorts(models.ClusterConfig{
+		Name:  "dev",
+		Ports: models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443, Host: "127.0.0.1"}, ports)
+}
+
+func TestK3dManager_CreateK3dConfigFile_KubeAPIHost(t *testing.T) {
+	manager, _ := newPortsTestManager(t, "[]")
+	ports := models.PortAssignment{API: 6550, HTTP: 80, HTTPS: 443}
+	config := models.ClusterConfig{Name: "dev", Type: models.ClusterTypeK3d, NodeCount: 1}
+
+	path, err := manager.createK3dConfigFile(config, ports)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "kubeAPI:\n  host: \"127.0.0.1\"\n  hostIP: \"127.0.0.1\"\n  hostPort: \"6550\"")
+
+	manager.WithDockerHost("buildbox")
+	path, err = manager.createK3dConfigFile(config, ports)
+	require.NoError(t, err)
+	defer os.Remove(path)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "kubeAPI:\n  host: \"buildbox\"\n  hostIP: \"0.0.0.0\"\n  hostPort: \"6550\"",
+		"the API of a remote Docker host is published on all its interfaces")
+}
+
+func TestK3dManager_CreateCluster_SavesPorts(t *testing.T) {
+	manager, store := newPortsTestManager(t, "[]")
+	requested := models.PortAssignment{API: 36550, HTTP: 38080, HTTPS: 38443}
+
+	err := manager.CreateCluster(context.Background(), models.ClusterConfig{
+		Name:      "dev",
+		Type:      models.ClusterTypeK3d,
+		NodeCount: 1,
+		Ports:     requested,
+	})
+	require.NoError(t, err)
+
+	ports, ok, err := store.Get("dev")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, requested, ports)
+}
+
+func TestK3dManager_GetPortBindings(t *testing.T) {
+	manager, _ := newPortsTestManager(t, portsClusterJSON)
+
+	bindings, err := manager.GetPortBindings(context.Background(), "web")
+	require.NoError(t, err)
+	assert.Equal(t, []models.PortBinding{
+		{Node: "k3d-web-serverlb", ContainerPort: "6443/tcp", HostIP: "127.0.0.1", HostPort: 36551},
+		{Node: "k3d-web-serverlb", ContainerPort: "80/tcp", HostIP: "0.0.0.0", HostPort: 38081},
+		{Node: "k3d-web-serverlb", ContainerPort: "443/tcp", HostIP: "0.0.0.0", HostPort: 38444},
+	}, bindings, "the API label of the server is not repeated when the load balancer publishes it")
+
+	_, err = manager.GetPortBindings(context.Background(), "missing")
+	assert.ErrorContains(t, err, "missing")
+}
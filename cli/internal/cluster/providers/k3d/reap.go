@@ -0,0 +1,234 @@
+package k3d
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+)
+
+const (
+	// expiresAtLabel holds the time a cluster created with a TTL expires, RFC 3339 in UTC
+	expiresAtLabel = "openframe.io/expires-at"
+	// userLabelPrefix prefixes the labels given to 'cluster create --label'
+	userLabelPrefix = "openframe.io/label."
+	// reapTempFileAge spares the temporary files of commands that may still be running
+	reapTempFileAge = time.Hour
+	// reapResourceAge spares the networks and volumes of clusters that are still being created
+	reapResourceAge = 10 * time.Minute
+)
+
+// resourceCreatedFields are the docker inspect fields holding when a network or volume was created
+var resourceCreatedFields = map[string]string{"network": ".Created", "volume": ".CreatedAt"}
+
+// reapTempPatterns match the temporary files and directories that chart installs and
+// cluster creates leave behind when they are killed
+var reapTempPatterns = []string{"openframe-chart-*", "k3d-config-*.yaml"}
+
+// runtimeLabelsConfig returns the options.runtime section of a k3d config that puts the
+// expiry and the labels of a cluster on its server and agent containers
+func runtimeLabelsConfig(config models.ClusterConfig, now time.Time) string {
+	var labels []string
+	if config.TTL > 0 {
+		labels = append(labels, expiresAtLabel+"="+now.Add(config.TTL).UTC().Format(time.RFC3339))
+	}
+	keys := make([]string, 0, len(config.Labels))
+	for key := range config.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		labels = append(labels, userLabelPrefix+key+"="+config.Labels[key])
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var section strings.Builder
+	section.WriteString("\n  runtime:\n    labels:")
+	for _, label := range labels {
+		fmt.Fprintf(&section, "\n      - label: %q\n        nodeFilters:\n          - server:*\n          - agent:*", label)
+	}
+	return section.String()
+}
+
+// clusterExpiry returns when a cluster expires, zero for clusters created without a TTL
+func clusterExpiry(cluster k3dClusterInfo) time.Time {
+	for _, node := range cluster.Nodes {
+		if node.Role != "server" {
+			continue
+		}
+		if value, ok := node.RuntimeLabels[expiresAtLabel]; ok {
+			if expiresAt, err := time.Parse(time.RFC3339, value); err == nil {
+				return expiresAt
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// clusterLabels returns the labels a cluster was created with
+func clusterLabels(cluster k3dClusterInfo) map[string]string {
+	var labels map[string]string
+	for _, node := range cluster.Nodes {
+		if node.Role != "server" {
+			continue
+		}
+		for key, value := range node.RuntimeLabels {
+			if !strings.HasPrefix(key, userLabelPrefix) {
+				continue
+			}
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[strings.TrimPrefix(key, userLabelPrefix)] = value
+		}
+	}
+	return labels
+}
+
+// PlanReap finds what 'cluster reap' removes: clusters that expired by now or have no
+// server node left, k3d networks and volumes older than reapResourceAge of clusters that are
+// gone, and temporary files older than reapTempFileAge
+func (m *K3dManager) PlanReap(ctx context.Context, now time.Time) (models.ReapPlan, error) {
+	var plan models.ReapPlan
+
+	result, err := m.executor.Execute(ctx, "k3d", "cluster", "list", "--output", "json")
+	if err != nil {
+		return plan, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	var clusters []k3dClusterInfo
+	if err := json.Unmarshal([]byte(result.Stdout), &clusters); err != nil {
+		return plan, fmt.Errorf("failed to parse cluster list JSON: %w", err)
+	}
+
+	existing := map[string]bool{}
+	for _, cluster := range clusters {
+		existing[cluster.Name] = true
+		expiresAt := clusterExpiry(cluster)
+		switch {
+		case cluster.ServersCount == 0:
+			plan.Clusters = append(plan.Clusters, models.ReapCluster{Name: cluster.Name, Reason: "no server node, left by an interrupted create or delete"})
+		case !expiresAt.IsZero() && !now.Before(expiresAt):
+			plan.Clusters = append(plan.Clusters, models.ReapCluster{Name: cluster.Name, Reason: "expired " + expiresAt.Local().Format(time.RFC3339)})
+		}
+	}
+	sort.Slice(plan.Clusters, func(i, j int) bool { return plan.Clusters[i].Name < plan.Clusters[j].Name })
+
+	if plan.Networks, err = m.orphanedResources(ctx, "network", existing, now); err != nil {
+		return plan, err
+	}
+	if plan.Volumes, err = m.orphanedResources(ctx, "volume", existing, now); err != nil {
+		return plan, err
+	}
+	plan.TempFiles = staleTempFiles(os.TempDir(), now)
+	return plan, nil
+}
+
+// Reap removes what a plan lists, going on past failures, and returns what was removed
+func (m *K3dManager) Reap(ctx context.Context, plan models.ReapPlan) (models.ReapPlan, error) {
+	var reaped models.ReapPlan
+	var errs []error
+
+	for _, cluster := range plan.Clusters {
+		if err := m.DeleteCluster(ctx, cluster.Name, models.ClusterTypeK3d, true); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reaped.Clusters = append(reaped.Clusters, cluster)
+	}
+	for _, network := range plan.Networks {
+		if _, err := m.executor.Execute(ctx, "docker", "network", "rm", network); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove network %s: %w", network, err))
+			continue
+		}
+		reaped.Networks = append(reaped.Networks, network)
+	}
+	for _, volume := range plan.Volumes {
+		if _, err := m.executor.Execute(ctx, "docker", "volume", "rm", volume); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove volume %s: %w", volume, err))
+			continue
+		}
+		reaped.Volumes = append(reaped.Volumes, volume)
+	}
+	for _, path := range plan.TempFiles {
+		if err := os.RemoveAll(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", path, err))
+			continue
+		}
+		reaped.TempFiles = append(reaped.TempFiles, path)
+	}
+	return reaped, errors.Join(errs...)
+}
+
+// orphanedResources lists the k3d networks or volumes whose cluster no longer exists and that
+// were created more than reapResourceAge before now. The cluster is read from the k3d.cluster
+// label, or else from the k3d-<cluster> naming.
+func (m *K3dManager) orphanedResources(ctx context.Context, kind string, existing map[string]bool, now time.Time) ([]string, error) {
+	result, err := m.executor.Execute(ctx, "docker", kind, "ls", "--filter", "label=app=k3d", "--format", `{{.Name}}\t{{.Label "k3d.cluster"}}`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list k3d %ss: %w", kind, err)
+	}
+
+	var orphaned []string
+	for _, line := range strings.Split(strings.TrimSpace(result.Stdout), "\n") {
+		name, cluster, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if name == "" {
+			continue
+		}
+		if cluster == "" {
+			if !strings.HasPrefix(name, "k3d-") {
+				continue
+			}
+			cluster = strings.TrimSuffix(strings.TrimPrefix(name, "k3d-"), "-images")
+		}
+		if !existing[cluster] {
+			orphaned = append(orphaned, name)
+		}
+	}
+	if len(orphaned) == 0 {
+		return nil, nil
+	}
+	sort.Strings(orphaned)
+
+	// A cluster create adds its network and volume before k3d lists the cluster
+	args := append([]string{kind, "inspect", "--format", `{{.Name}}\t{{` + resourceCreatedFields[kind] + `}}`}, orphaned...)
+	result, err = m.executor.Execute(ctx, "docker", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect k3d %ss: %w", kind, err)
+	}
+
+	var stale []string
+	for _, line := range strings.Split(strings.TrimSpace(result.Stdout), "\n") {
+		name, created, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		createdAt, err := time.Parse(time.RFC3339Nano, created)
+		if err == nil && now.Sub(createdAt) > reapResourceAge {
+			stale = append(stale, name)
+		}
+	}
+	return stale, nil
+}
+
+// staleTempFiles lists the temporary files of dir matching reapTempPatterns that were last
+// changed more than reapTempFileAge before now
+func staleTempFiles(dir string, now time.Time) []string {
+	var stale []string
+	for _, pattern := range reapTempPatterns {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err == nil && now.Sub(info.ModTime()) > reapTempFileAge {
+				stale = append(stale, path)
+			}
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
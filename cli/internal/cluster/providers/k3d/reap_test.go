@@ -0,0 +1,123 @@
+package k3d
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	execPkg "github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// reapClustersJSON holds "ci-1" that expired at noon, "ci-2" that expires at two, "dev"
+// without a TTL and "broken" that lost its server
+const reapClustersJSON = `[
+  {"name":"ci-1","serversCount":1,"nodes":[{"name":"k3d-ci-1-server-0","role":"server",
+    "runtimeLabels":{"k3d.cluster":"ci-1","openframe.io/expires-at":"2026-10-19T12:00:00Z","openframe.io/label.ci-run":"123"}}]},
+  {"name":"ci-2","serversCount":1,"nodes":[{"name":"k3d-ci-2-server-0","role":"server",
+    "runtimeLabels":{"k3d.cluster":"ci-2","openframe.io/expires-at":"2026-10-19T14:00:00Z","openframe.io/label.ci-run":"124"}}]},
+  {"name":"dev","serversCount":1,"nodes":[{"name":"k3d-dev-server-0","role":"server","runtimeLabels":{"k3d.cluster":"dev"}}]},
+  {"name":"broken","serversCount":0,"agentsCount":1,"nodes":[{"name":"k3d-broken-agent-0","role":"agent"}]}
+]`
+
+func newReapTestManager() *MockExecutor {
+	executor := &MockExecutor{}
+	executor.On("Execute", mock.Anything, "k3d", []string{"cluster", "list", "--output", "json"}).
+		Return(&execPkg.CommandResult{Stdout: reapClustersJSON}, nil)
+	executor.On("Execute", mock.Anything, "docker", mock.MatchedBy(func(args []string) bool {
+		return args[0] == "network" && args[1] == "ls"
+	})).Return(&execPkg.CommandResult{Stdout: "k3d-dev\t\nk3d-gone\t\nk3d-creating\t\nbridge\t\n"}, nil)
+	executor.On("Execute", mock.Anything, "docker", []string{"network", "inspect", "--format", `{{.Name}}\t{{.Created}}`, "k3d-creating", "k3d-gone"}).
+		Return(&execPkg.CommandResult{Stdout: "k3d-creating\t2026-10-19T12:58:00.123456789Z\nk3d-gone\t2026-10-18T09:00:00.123456789Z\n"}, nil)
+	executor.On("Execute", mock.Anything, "docker", mock.MatchedBy(func(args []string) bool {
+		return args[0] == "volume" && args[1] == "ls"
+	})).Return(&execPkg.CommandResult{Stdout: "k3d-dev-images\tdev\nk3d-gone-images\tgone\n"}, nil)
+	executor.On("Execute", mock.Anything, "docker", []string{"volume", "inspect", "--format", `{{.Name}}\t{{.CreatedAt}}`, "k3d-gone-images"}).
+		Return(&execPkg.CommandResult{Stdout: "k3d-gone-images\t2026-10-18T09:00:00Z\n"}, nil)
+	executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+	return executor
+}
+
+func TestRuntimeLabelsConfig(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, runtimeLabelsConfig(models.ClusterConfig{}, now))
+
+	section := runtimeLabelsConfig(models.ClusterConfig{TTL: 2 * time.Hour, Labels: map[string]string{"ci-run": "123"}}, now)
+	assert.Contains(t, section, `- label: "openframe.io/expires-at=2026-10-19T12:00:00Z"`)
+	assert.Contains(t, section, `- label: "openframe.io/label.ci-run=123"`)
+	assert.Equal(t, 2, strings.Count(section, "- server:*"))
+}
+
+func TestK3dManager_ListClusters_Labels(t *testing.T) {
+	manager := NewK3dManager(newReapTestManager(), false)
+
+	info, err := manager.GetClusterStatus(context.Background(), "ci-1")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), info.ExpiresAt)
+	assert.Equal(t, map[string]string{"ci-run": "123"}, info.Labels, "k3d's own labels are left out")
+
+	info, err = manager.GetClusterStatus(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.True(t, info.ExpiresAt.IsZero())
+	assert.Nil(t, info.Labels)
+}
+
+func TestK3dManager_PlanReap(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	now := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+
+	stale := filepath.Join(tmp, "openframe-chart-123")
+	require.NoError(t, os.Mkdir(stale, 0o700))
+	require.NoError(t, os.Chtimes(stale, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+	fresh := filepath.Join(tmp, "k3d-config-456.yaml")
+	require.NoError(t, os.WriteFile(fresh, nil, 0o600))
+	require.NoError(t, os.Chtimes(fresh, now.Add(-time.Minute), now.Add(-time.Minute)))
+
+	manager := NewK3dManager(newReapTestManager(), false)
+	plan, err := manager.PlanReap(context.Background(), now)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Clusters, 2)
+	assert.Equal(t, "broken", plan.Clusters[0].Name)
+	assert.Equal(t, "ci-1", plan.Clusters[1].Name)
+	assert.Contains(t, plan.Clusters[1].Reason, "expired")
+	assert.Equal(t, []string{"k3d-gone"}, plan.Networks, "networks of existing clusters, of clusters being created and of other tools stay")
+	assert.Equal(t, []string{"k3d-gone-images"}, plan.Volumes)
+	assert.Equal(t, []string{stale}, plan.TempFiles, "files of commands that may still run stay")
+}
+
+func TestK3dManager_Reap(t *testing.T) {
+	stale := filepath.Join(t.TempDir(), "openframe-chart-123")
+	require.NoError(t, os.Mkdir(stale, 0o700))
+
+	executor := &MockExecutor{}
+	executor.On("Execute", mock.Anything, "docker", []string{"network", "rm", "k3d-gone"}).
+		Return(nil, errors.New("network has active endpoints"))
+	executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+	manager := NewK3dManager(executor, false)
+
+	reaped, err := manager.Reap(context.Background(), models.ReapPlan{
+		Clusters:  []models.ReapCluster{{Name: "ci-1", Reason: "expired"}},
+		Networks:  []string{"k3d-gone"},
+		Volumes:   []string{"k3d-gone-images"},
+		TempFiles: []string{stale},
+	})
+	assert.ErrorContains(t, err, "failed to remove network k3d-gone")
+	assert.Equal(t, []models.ReapCluster{{Name: "ci-1", Reason: "expired"}}, reaped.Clusters)
+	assert.Empty(t, reaped.Networks)
+	assert.Equal(t, []string{"k3d-gone-images"}, reaped.Volumes, "a failure does not stop the rest")
+	assert.NoDirExists(t, stale)
+
+	executor.AssertCalled(t, "Execute", mock.Anything, "k3d", []string{"cluster", "delete", "ci-1"})
+}
@@ -0,0 +1,474 @@
+package k3d
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+)
+
+// upgradePollInterval is how often node readiness and application health are checked
+var upgradePollInterval = 5 * time.Second
+
+// k3dContainerFiles are written into node containers by k3d after they are created and
+// are carried over to the replacement container; missing ones are skipped
+var k3dContainerFiles = []string{
+	"/bin/k3d-entrypoint.sh",
+	"/bin/k3d-entrypoint-cgroupv2.sh",
+	"/bin/k3d-entrypoint-dns.sh",
+	"/etc/rancher/k3s/registries.yaml",
+}
+
+// dockerContainer is the part of 'docker inspect' needed to recreate a node container
+type dockerContainer struct {
+	Config struct {
+		Hostname   string            `json:"Hostname"`
+		Env        []string          `json:"Env"`
+		Cmd        []string          `json:"Cmd"`
+		Entrypoint []string          `json:"Entrypoint"`
+		Labels     map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		Privileged    bool                     `json:"Privileged"`
+		Init          *bool                    `json:"Init"`
+		Tmpfs         map[string]string        `json:"Tmpfs"`
+		PortBindings  map[string][]PortMapping `json:"PortBindings"`
+		ExtraHosts    []string                 `json:"ExtraHosts"`
+		CgroupnsMode  string                   `json:"CgroupnsMode"`
+		RestartPolicy struct {
+			Name string `json:"Name"`
+		} `json:"RestartPolicy"`
+	} `json:"HostConfig"`
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAMConfig *struct {
+				IPv4Address string `json:"IPv4Address"`
+			} `json:"IPAMConfig"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// argoApplicationList is the part of 'kubectl get applications -o json' giving their health
+type argoApplicationList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Status struct {
+			Health struct {
+				Status string `json:"status"`
+			} `json:"health"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// UpgradeCluster moves every node of a cluster to another k3s release, servers first, one
+// node at a time. Each node is drained, its container replaced by one of the new image
+// that keeps the volumes, and waited for until it is Ready on the new version; the Argo
+// CD applications that were Healthy before must be Healthy again before the next node.
+// Nodes already on the target release are skipped, so a failed upgrade can be resumed.
+func (m *K3dManager) UpgradeCluster(ctx context.Context, name, version string, allowUntested bool, progress func(string)) (models.UpgradeResult, error) {
+	result := models.UpgradeResult{To: version}
+	if name == "" {
+		return result, models.NewInvalidConfigError("name", name, "cluster name cannot be empty")
+	}
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	cluster, err := m.getK3dCluster(ctx, name)
+	if err != nil {
+		return result, models.NewClusterOperationError("upgrade", name, err)
+	}
+	nodes := upgradeOrder(cluster)
+	if len(nodes) == 0 {
+		return result, models.NewClusterOperationError("upgrade", name, fmt.Errorf("cluster has no nodes"))
+	}
+
+	result.From = oldestVersion(nodes)
+	if err := checkUpgradeVersion(result.From, version, allowUntested); err != nil {
+		return result, models.NewClusterOperationError("upgrade", name, err)
+	}
+
+	result.Applications, err = m.healthyApplications(ctx, name)
+	if err != nil {
+		return result, models.NewClusterOperationError("upgrade", name, err)
+	}
+
+	image := k3sImage(version)
+	for i, node := range nodes {
+		if node.Image == image {
+			result.Skipped = append(result.Skipped, node.Name)
+			continue
+		}
+
+		progress(fmt.Sprintf("Upgrading node %s to %s (%d/%d)", node.Name, version, i+1, len(nodes)))
+		if err := m.upgradeNode(ctx, name, node.Name, version, len(nodes) > 1); err != nil {
+			return result, models.NewClusterOperationError("upgrade", name, err)
+		}
+		result.Upgraded = append(result.Upgraded, node.Name)
+
+		if len(result.Applications) > 0 {
+			progress(fmt.Sprintf("Waiting for %d Argo CD applications to be Healthy", len(result.Applications)))
+			if err := m.waitApplicationsHealthy(ctx, name, result.Applications); err != nil {
+				return result, models.NewClusterOperationError("upgrade", name, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// upgradeOrder returns the server nodes and then the agent nodes of a cluster, by name
+func upgradeOrder(cluster k3dClusterInfo) []k3dNode {
+	var servers, agents []k3dNode
+	for _, node := range cluster.Nodes {
+		switch node.Role {
+		case "server":
+			servers = append(servers, node)
+		case "agent":
+			agents = append(agents, node)
+		}
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Name < agents[j].Name })
+	return append(servers, agents...)
+}
+
+// oldestVersion returns the oldest k3s release the nodes run, which an upgrade starts from
+func oldestVersion(nodes []k3dNode) string {
+	oldest := ""
+	var oldestParsed k3sVersion
+	for _, node := range nodes {
+		version := imageVersion(node.Image)
+		parsed, err := parseK3sVersion(version)
+		if err != nil {
+			continue
+		}
+		if oldest == "" || parsed.less(oldestParsed) {
+			oldest, oldestParsed = version, parsed
+		}
+	}
+	return oldest
+}
+
+// upgradeNode replaces the container of a node by one running version and waits until the
+// node is Ready on it. Other nodes take over its pods while it is replaced when drain is set.
+func (m *K3dManager) upgradeNode(ctx context.Context, clusterName, node, version string, drain bool) error {
+	if drain {
+		if _, err := m.kubectl(ctx, clusterName, "cordon", node); err != nil {
+			return fmt.Errorf("failed to cordon node %s: %w", node, err)
+		}
+		if _, err := m.kubectl(ctx, clusterName, "drain", node, "--ignore-daemonsets", "--delete-emptydir-data", "--timeout", m.timeout); err != nil {
+			return fmt.Errorf("failed to drain node %s, it stays cordoned until 'kubectl uncordon %s': %w", node, node, err)
+		}
+	}
+
+	backup, err := m.replaceNodeContainer(ctx, node, k3sImage(version))
+	if err != nil {
+		return err
+	}
+
+	if err := m.waitNodeVersion(ctx, clusterName, node, version); err != nil {
+		return fmt.Errorf("%w; the previous container is kept as %s, 'docker rm -f %s && docker rename %s %s && docker start %s' brings it back",
+			err, backup, node, backup, node, node)
+	}
+
+	if drain {
+		if _, err := m.kubectl(ctx, clusterName, "uncordon", node); err != nil {
+			return fmt.Errorf("failed to uncordon node %s: %w", node, err)
+		}
+	}
+
+	// Without -v, the volumes now used by the new container stay
+	if _, err := m.executor.Execute(ctx, "docker", "rm", backup); err != nil {
+		return fmt.Errorf("failed to remove the previous container %s of node %s: %w", backup, node, err)
+	}
+	return nil
+}
+
+// replaceNodeContainer stops the container of a node, keeps it under another name and
+// starts a container of image in its place, with the same settings and the volumes of the
+// old one. It returns the name of the old container; when the new one cannot be started,
+// the old one is put back.
+func (m *K3dManager) replaceNodeContainer(ctx context.Context, node, image string) (string, error) {
+	result, err := m.executor.Execute(ctx, "docker", "inspect", "--type", "container", node)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect node %s: %w", node, err)
+	}
+	var containers []dockerContainer
+	if err := json.Unmarshal([]byte(result.Stdout), &containers); err != nil || len(containers) == 0 {
+		return "", fmt.Errorf("failed to parse container of node %s: %v", node, err)
+	}
+
+	backup := node + "-pre-upgrade"
+	if _, err := m.executor.Execute(ctx, "docker", "stop", node); err != nil {
+		return "", fmt.Errorf("failed to stop node %s: %w", node, err)
+	}
+	if _, err := m.executor.Execute(ctx, "docker", "rename", node, backup); err != nil {
+		_, _ = m.executor.Execute(ctx, "docker", "start", node)
+		return "", fmt.Errorf("failed to rename node %s: %w", node, err)
+	}
+
+	if err := m.startReplacement(ctx, containers[0], node, backup, image); err != nil {
+		_, _ = m.executor.Execute(ctx, "docker", "rm", "--force", node)
+		_, _ = m.executor.Execute(ctx, "docker", "rename", backup, node)
+		_, _ = m.executor.Execute(ctx, "docker", "start", node)
+		return "", err
+	}
+	return backup, nil
+}
+
+// startReplacement creates and starts the container node of image from the settings of
+// container, mounting the volumes of backup
+func (m *K3dManager) startReplacement(ctx context.Context, container dockerContainer, node, backup, image string) error {
+	networks := make([]string, 0, len(container.NetworkSettings.Networks))
+	for network := range container.NetworkSettings.Networks {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	args := replacementArgs(container, node, backup, image, networks)
+	if _, err := m.executor.Execute(ctx, "docker", args...); err != nil {
+		return fmt.Errorf("failed to create the %s container of node %s: %w", image, node, err)
+	}
+
+	for _, network := range networks[min(1, len(networks)):] {
+		connect := []string{"network", "connect"}
+		if ipam := container.NetworkSettings.Networks[network].IPAMConfig; ipam != nil && ipam.IPv4Address != "" {
+			connect = append(connect, "--ip", ipam.IPv4Address)
+		}
+		if _, err := m.executor.Execute(ctx, "docker", append(connect, network, node)...); err != nil {
+			return fmt.Errorf("failed to connect node %s to network %s: %w", node, network, err)
+		}
+	}
+
+	if err := m.copyContainerFiles(ctx, backup, node); err != nil {
+		return err
+	}
+
+	if _, err := m.executor.Execute(ctx, "docker", "start", node); err != nil {
+		return fmt.Errorf("failed to start the %s container of node %s: %w", image, node, err)
+	}
+	return nil
+}
+
+// replacementArgs returns the 'docker create' arguments recreating container as node with
+// image, attached to the first of networks
+func replacementArgs(container dockerContainer, node, backup, image string, networks []string) []string {
+	args := []string{"create", "--name", node, "--volumes-from", backup}
+	if container.Config.Hostname != "" {
+		args = append(args, "--hostname", container.Config.Hostname)
+	}
+	if container.HostConfig.Privileged {
+		args = append(args, "--privileged")
+	}
+	if container.HostConfig.Init != nil && *container.HostConfig.Init {
+		args = append(args, "--init")
+	}
+	if container.HostConfig.CgroupnsMode != "" {
+		args = append(args, "--cgroupns", container.HostConfig.CgroupnsMode)
+	}
+	if policy := container.HostConfig.RestartPolicy.Name; policy != "" && policy != "no" {
+		args = append(args, "--restart", policy)
+	}
+
+	labels := make([]string, 0, len(container.Config.Labels))
+	for key, value := range container.Config.Labels {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		args = append(args, "--label", label)
+	}
+	for _, env := range container.Config.Env {
+		args = append(args, "--env", env)
+	}
+	for _, host := range container.HostConfig.ExtraHosts {
+		args = append(args, "--add-host", host)
+	}
+
+	tmpfs := make([]string, 0, len(container.HostConfig.Tmpfs))
+	for path, options := range container.HostConfig.Tmpfs {
+		if options != "" {
+			path += ":" + options
+		}
+		tmpfs = append(tmpfs, path)
+	}
+	sort.Strings(tmpfs)
+	for _, mount := range tmpfs {
+		args = append(args, "--tmpfs", mount)
+	}
+
+	ports := make([]string, 0, len(container.HostConfig.PortBindings))
+	for port, bindings := range container.HostConfig.PortBindings {
+		for _, binding := range bindings {
+			publish := binding.HostPort + ":" + port
+			if binding.HostIP != "" {
+				publish = binding.HostIP + ":" + publish
+			}
+			ports = append(ports, publish)
+		}
+	}
+	sort.Strings(ports)
+	for _, publish := range ports {
+		args = append(args, "--publish", publish)
+	}
+
+	if len(networks) > 0 {
+		args = append(args, "--network", networks[0])
+		if ipam := container.NetworkSettings.Networks[networks[0]].IPAMConfig; ipam != nil && ipam.IPv4Address != "" {
+			args = append(args, "--ip", ipam.IPv4Address)
+		}
+	}
+
+	command := container.Config.Cmd
+	if len(container.Config.Entrypoint) > 0 {
+		args = append(args, "--entrypoint", container.Config.Entrypoint[0])
+		command = append(append([]string{}, container.Config.Entrypoint[1:]...), command...)
+	}
+	args = append(args, image)
+	return append(args, command...)
+}
+
+// copyContainerFiles copies the files k3d wrote into the container from to the container
+// to, through a temporary directory
+func (m *K3dManager) copyContainerFiles(ctx context.Context, from, to string) error {
+	dir, err := os.MkdirTemp("", "openframe-upgrade-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for i, path := range k3dContainerFiles {
+		local := filepath.Join(dir, fmt.Sprintf("%d-%s", i, filepath.Base(path)))
+		if _, err := m.executor.Execute(ctx, "docker", "cp", from+":"+path, local); err != nil {
+			continue
+		}
+		if _, err := m.executor.Execute(ctx, "docker", "cp", local, to+":"+path); err != nil {
+			return fmt.Errorf("failed to copy %s to node %s: %w", path, to, err)
+		}
+	}
+	return nil
+}
+
+// waitNodeVersion waits until a node reports Ready on the kubelet of version. The API
+// server is gone while a server node is replaced, so failing requests are retried.
+func (m *K3dManager) waitNodeVersion(ctx context.Context, clusterName, node, version string) error {
+	want := kubeletVersion(version)
+	last := "not reachable"
+	err := m.poll(ctx, func() bool {
+		result, err := m.kubectl(ctx, clusterName, "get", "node", node, "--output",
+			`jsonpath={.status.nodeInfo.kubeletVersion} {.status.conditions[?(@.type=="Ready")].status}`)
+		if err != nil {
+			return false
+		}
+		fields := strings.Fields(result.Stdout)
+		if len(fields) == 2 && fields[0] == want && fields[1] == "True" {
+			return true
+		}
+		last = strings.TrimSpace(result.Stdout)
+		return false
+	})
+	if err != nil {
+		return fmt.Errorf("node %s did not become Ready on %s (last seen: %s): %w", node, want, last, err)
+	}
+	return nil
+}
+
+// healthyApplications lists the Argo CD applications that are Healthy, none when Argo CD
+// is not installed
+func (m *K3dManager) healthyApplications(ctx context.Context, clusterName string) ([]string, error) {
+	health, err := m.applicationHealth(ctx, clusterName)
+	if err != nil {
+		if strings.Contains(err.Error(), "the server doesn't have a resource type") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var healthy []string
+	for app, status := range health {
+		if status == "Healthy" {
+			healthy = append(healthy, app)
+		}
+	}
+	sort.Strings(healthy)
+	return healthy, nil
+}
+
+// waitApplicationsHealthy waits until the given Argo CD applications are Healthy
+func (m *K3dManager) waitApplicationsHealthy(ctx context.Context, clusterName string, apps []string) error {
+	var unhealthy []string
+	err := m.poll(ctx, func() bool {
+		health, err := m.applicationHealth(ctx, clusterName)
+		if err != nil {
+			return false
+		}
+		unhealthy = nil
+		for _, app := range apps {
+			if status := health[app]; status != "Healthy" {
+				if status == "" {
+					status = "Missing"
+				}
+				unhealthy = append(unhealthy, app+" ("+status+")")
+			}
+		}
+		return len(unhealthy) == 0
+	})
+	if err != nil {
+		if len(unhealthy) == 0 {
+			return fmt.Errorf("Argo CD applications could not be checked: %w", err)
+		}
+		return fmt.Errorf("Argo CD applications did not become Healthy again: %s: %w", strings.Join(unhealthy, ", "), err)
+	}
+	return nil
+}
+
+// applicationHealth returns the health of every Argo CD application by namespace/name
+func (m *K3dManager) applicationHealth(ctx context.Context, clusterName string) (map[string]string, error) {
+	result, err := m.kubectl(ctx, clusterName, "get", "applications.argoproj.io", "--all-namespaces", "--output", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Argo CD applications: %w", err)
+	}
+	var apps argoApplicationList
+	if err := json.Unmarshal([]byte(result.Stdout), &apps); err != nil {
+		return nil, fmt.Errorf("failed to parse Argo CD applications: %w", err)
+	}
+
+	health := make(map[string]string, len(apps.Items))
+	for _, app := range apps.Items {
+		health[app.Metadata.Namespace+"/"+app.Metadata.Name] = app.Status.Health.Status
+	}
+	return health, nil
+}
+
+// poll calls done every upgradePollInterval until it returns true, giving up after the
+// timeout of the manager
+func (m *K3dManager) poll(ctx context.Context, done func() bool) error {
+	timeout, err := time.ParseDuration(m.timeout)
+	if err != nil {
+		timeout, _ = time.ParseDuration(defaultTimeout)
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if done() {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(upgradePollInterval):
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package k3d
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	execPkg "github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeClusterJSON is a cluster "dev" whose server already runs v1.32.2-k3s1, with one agent left on v1.31.5-k3s1
+const upgradeClusterJSON = `[{"name":"dev","serversCount":1,"serversRunning":1,"agentsCount":1,"nodes":[
+  {"name":"k3d-dev-agent-0","role":"agent","image":"rancher/k3s:v1.31.5-k3s1","State":{"Running":true,"Status":"running"}},
+  {"name":"k3d-dev-server-0","role":"server","image":"rancher/k3s:v1.32.2-k3s1","State":{"Running":true,"Status":"running"}},
+  {"name":"k3d-dev-serverlb","role":"loadbalancer","image":"ghcr.io/k3d-io/k3d-proxy:5.8.3","State":{"Running":true,"Status":"running"}}
+]}]`
+
+const upgradeAgentInspectJSON = `[{
+  "Config":{"Hostname":"k3d-dev-agent-0","Env":["K3S_URL=https://k3d-dev-server-0:6443"],"Cmd":["agent"],
+    "Entrypoint":["/bin/k3d-entrypoint.sh"],"Labels":{"k3d.cluster":"dev","k3d.role":"agent"}},
+  "HostConfig":{"Privileged":true,"Init":true,"Tmpfs":{"/run":"","/var/run":""},
+    "PortBindings":{"80/tcp":[{"HostIp":"0.0.0.0","HostPort":"8080"}]},"RestartPolicy":{"Name":"unless-stopped"}},
+  "NetworkSettings":{"Networks":{"k3d-dev":{"IPAMConfig":{"IPv4Address":"172.18.0.3"}}}}
+}]`
+
+const upgradeApplicationsJSON = `{"items":[
+  {"metadata":{"name":"app-of-apps","namespace":"argocd"},"status":{"health":{"status":"Healthy"}}},
+  {"metadata":{"name":"pinot","namespace":"argocd"},"status":{"health":{"status":"Degraded"}}}
+]}`
+
+func newUpgradeTestManager(t *testing.T) *MockExecutor {
+	t.Helper()
+	interval := upgradePollInterval
+	upgradePollInterval = 0
+	t.Cleanup(func() { upgradePollInterval = interval })
+
+	executor := &MockExecutor{}
+	executor.On("Execute", mock.Anything, "k3d", []string{"cluster", "list", "--output", "json"}).
+		Return(&execPkg.CommandResult{Stdout: upgradeClusterJSON}, nil)
+	executor.On("Execute", mock.Anything, "docker", []string{"inspect", "--type", "container", "k3d-dev-agent-0"}).
+		Return(&execPkg.CommandResult{Stdout: upgradeAgentInspectJSON}, nil)
+	executor.On("Execute", mock.Anything, "kubectl", mock.MatchedBy(func(args []string) bool {
+		return len(args) > 3 && args[3] == "applications.argoproj.io"
+	})).Return(&execPkg.CommandResult{Stdout: upgradeApplicationsJSON}, nil)
+	executor.On("Execute", mock.Anything, "kubectl", mock.MatchedBy(func(args []string) bool {
+		return len(args) > 3 && args[2] == "get" && args[3] == "node"
+	})).Return(&execPkg.CommandResult{Stdout: "v1.32.2+k3s1 True"}, nil)
+	executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+	return executor
+}
+
+func TestCheckUpgradeVersion(t *testing.T) {
+	assert.NoError(t, checkUpgradeVersion("v1.31.5-k3s1", "v1.32.2-k3s1", false))
+	assert.NoError(t, checkUpgradeVersion("v1.31.5-k3s1", "v1.31.6-k3s1", false), "patch releases")
+	assert.NoError(t, checkUpgradeVersion("v1.32.2-k3s1", "v1.32.2-k3s1", false), "resuming an upgrade")
+
+	assert.ErrorContains(t, checkUpgradeVersion("v1.31.5-k3s1", "v1.30.9-k3s1", false), "cannot downgrade")
+	assert.ErrorContains(t, checkUpgradeVersion("v1.30.9-k3s1", "v1.32.2-k3s1", false), "upgrade to v1.31 first")
+	assert.ErrorContains(t, checkUpgradeVersion("v1.31.5-k3s1", "1.32", false), "no k3s release")
+
+	err := checkUpgradeVersion("v1.32.2-k3s1", "v1.33.1-k3s1", false)
+	assert.ErrorContains(t, err, "supported: v1.30, v1.31, v1.32")
+	assert.NoError(t, checkUpgradeVersion("v1.32.2-k3s1", "v1.33.1-k3s1", true))
+}
+
+func TestImageVersion(t *testing.T) {
+	assert.Equal(t, "v1.31.5-k3s1", imageVersion("rancher/k3s:v1.31.5-k3s1"))
+	assert.Equal(t, "", imageVersion("registry:5000/rancher/k3s"))
+	assert.Equal(t, "v1.32.2+k3s1", kubeletVersion("v1.32.2-k3s1"))
+}
+
+func TestK3dManager_ListClusters_K8sVersion(t *testing.T) {
+	manager := NewK3dManager(newUpgradeTestManager(t), false)
+
+	info, err := manager.GetClusterStatus(context.Background(), "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.31.5-k3s1", info.K8sVersion, "the oldest node version, ignoring the load balancer")
+}
+
+func TestK3dManager_UpgradeCluster(t *testing.T) {
+	executor := newUpgradeTestManager(t)
+	manager := NewK3dManager(executor, false)
+
+	var steps []string
+	result, err := manager.UpgradeCluster(context.Background(), "dev", "v1.32.2-k3s1", false, func(step string) {
+		steps = append(steps, step)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.31.5-k3s1", result.From)
+	assert.Equal(t, []string{"k3d-dev-server-0"}, result.Skipped, "the server already runs the target version")
+	assert.Equal(t, []string{"k3d-dev-agent-0"}, result.Upgraded)
+	assert.Equal(t, []string{"argocd/app-of-apps"}, result.Applications, "degraded applications are not waited for")
+	assert.Equal(t, []string{
+		"Upgrading node k3d-dev-agent-0 to v1.32.2-k3s1 (2/2)",
+		"Waiting for 1 Argo CD applications to be Healthy",
+	}, steps)
+
+	executor.AssertCalled(t, "Execute", mock.Anything, "kubectl", []string{
+		"--context", "k3d-dev", "drain", "k3d-dev-agent-0", "--ignore-daemonsets", "--delete-emptydir-data", "--timeout", defaultTimeout,
+	})
+	executor.AssertCalled(t, "Execute", mock.Anything, "docker", []string{"rename", "k3d-dev-agent-0", "k3d-dev-agent-0-pre-upgrade"})
+	executor.AssertCalled(t, "Execute", mock.Anything, "docker", []string{
+		"create", "--name", "k3d-dev-agent-0", "--volumes-from", "k3d-dev-agent-0-pre-upgrade",
+		"--hostname", "k3d-dev-agent-0", "--privileged", "--init", "--restart", "unless-stopped",
+		"--label", "k3d.cluster=dev", "--label", "k3d.role=agent",
+		"--env", "K3S_URL=https://k3d-dev-server-0:6443",
+		"--tmpfs", "/run", "--tmpfs", "/var/run",
+		"--publish", "0.0.0.0:8080:80/tcp",
+		"--network", "k3d-dev", "--ip", "172.18.0.3",
+		"--entrypoint", "/bin/k3d-entrypoint.sh",
+		"rancher/k3s:v1.32.2-k3s1", "agent",
+	})
+	executor.AssertCalled(t, "Execute", mock.Anything, "kubectl", []string{"--context", "k3d-dev", "uncordon", "k3d-dev-agent-0"})
+	executor.AssertCalled(t, "Execute", mock.Anything, "docker", []string{"rm", "k3d-dev-agent-0-pre-upgrade"})
+	executor.AssertNotCalled(t, "Execute", mock.Anything, "docker", []string{"inspect", "--type", "container", "k3d-dev-server-0"})
+}
+
+func TestK3dManager_UpgradeCluster_Refused(t *testing.T) {
+	executor := newUpgradeTestManager(t)
+	manager := NewK3dManager(executor, false)
+
+	_, err := manager.UpgradeCluster(context.Background(), "dev", "v1.30.9-k3s1", false, nil)
+	assert.ErrorContains(t, err, "cannot downgrade")
+	executor.AssertNotCalled(t, "Execute", mock.Anything, "docker", mock.Anything)
+}
+
+func TestK3dManager_UpgradeCluster_RollsBackFailedStart(t *testing.T) {
+	executor := &MockExecutor{}
+	executor.On("Execute", mock.Anything, "k3d", []string{"cluster", "list", "--output", "json"}).
+		Return(&execPkg.CommandResult{Stdout: upgradeClusterJSON}, nil)
+	executor.On("Execute", mock.Anything, "docker", []string{"inspect", "--type", "container", "k3d-dev-agent-0"}).
+		Return(&execPkg.CommandResult{Stdout: upgradeAgentInspectJSON}, nil)
+	executor.On("Execute", mock.Anything, "kubectl", mock.MatchedBy(func(args []string) bool {
+		return len(args) > 3 && args[3] == "applications.argoproj.io"
+	})).Return(nil, errors.New(`error: the server doesn't have a resource type "applications"`))
+	executor.On("Execute", mock.Anything, "docker", mock.MatchedBy(func(args []string) bool {
+		return args[0] == "create"
+	})).Return(nil, errors.New("pull access denied"))
+	executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(&execPkg.CommandResult{Stdout: "success"}, nil)
+	manager := NewK3dManager(executor, false)
+
+	result, err := manager.UpgradeCluster(context.Background(), "dev", "v1.32.2-k3s1", false, nil)
+	assert.ErrorContains(t, err, "failed to create the rancher/k3s:v1.32.2-k3s1 container of node k3d-dev-agent-0")
+	assert.Empty(t, result.Applications, "clusters without Argo CD have no applications to wait for")
+	assert.Empty(t, result.Upgraded)
+
+	executor.AssertCalled(t, "Execute", mock.Anything, "docker", []string{"rename", "k3d-dev-agent-0-pre-upgrade", "k3d-dev-agent-0"})
+	executor.AssertCalled(t, "Execute", mock.Anything, "docker", []string{"start", "k3d-dev-agent-0"})
+}
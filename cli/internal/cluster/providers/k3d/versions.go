@@ -0,0 +1,112 @@
+package k3d
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// supportedKubernetesMinors are the Kubernetes 1.x minors the argo-cd chart 8.2.7 (Argo CD
+// v3.0) and the OpenFrame manifests are tested against. Upgrades to other minors need
+// --allow-untested.
+var supportedKubernetesMinors = []int{30, 31, 32}
+
+// SupportedKubernetesMinors returns the Kubernetes 1.x minors OpenFrame is tested against
+func SupportedKubernetesMinors() []int {
+	return append([]int(nil), supportedKubernetesMinors...)
+}
+
+// DefaultImage returns the node image of new clusters that do not choose a version
+func DefaultImage() string {
+	return defaultK3sImage
+}
+
+// k3sVersionPattern matches k3s release tags such as v1.32.2-k3s1
+var k3sVersionPattern = regexp.MustCompile(`^v1\.(\d+)\.(\d+)-k3s(\d+)$`)
+
+// k3dVersionPattern matches the first line of 'k3d version', capturing the release
+// without its v prefix, which is the tag of k3d's own images
+var k3dVersionPattern = regexp.MustCompile(`k3d version v(\d+\.\d+\.\d+\S*)`)
+
+// k3sVersion is a parsed k3s release tag
+type k3sVersion struct {
+	Minor, Patch, Build int
+}
+
+// parseK3sVersion parses a k3s release tag such as v1.32.2-k3s1
+func parseK3sVersion(version string) (k3sVersion, error) {
+	match := k3sVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return k3sVersion{}, fmt.Errorf("%q is no k3s release, use a tag such as v1.32.2-k3s1", version)
+	}
+	minor, _ := strconv.Atoi(match[1])
+	patch, _ := strconv.Atoi(match[2])
+	build, _ := strconv.Atoi(match[3])
+	return k3sVersion{Minor: minor, Patch: patch, Build: build}, nil
+}
+
+// less reports whether v is an older release than other
+func (v k3sVersion) less(other k3sVersion) bool {
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	if v.Patch != other.Patch {
+		return v.Patch < other.Patch
+	}
+	return v.Build < other.Build
+}
+
+// k3sImage returns the node image of a k3s release
+func k3sImage(version string) string {
+	return "rancher/k3s:" + version
+}
+
+// imageVersion returns the tag of a node image, the k3s release it runs
+func imageVersion(image string) string {
+	if i := strings.LastIndex(image, ":"); i >= 0 && !strings.Contains(image[i:], "/") {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// kubeletVersion returns the version a node of a k3s release reports, v1.32.2+k3s1
+func kubeletVersion(version string) string {
+	return strings.Replace(version, "-k3s", "+k3s", 1)
+}
+
+// checkUpgradeVersion refuses upgrades k3s cannot do in place: downgrades, which its
+// datastore cannot go back from, and skipping a minor, which Kubernetes does not support.
+// Minors outside supportedKubernetesMinors are refused unless allowUntested is set.
+func checkUpgradeVersion(current, target string, allowUntested bool) error {
+	to, err := parseK3sVersion(target)
+	if err != nil {
+		return err
+	}
+	from, err := parseK3sVersion(current)
+	if err != nil {
+		return fmt.Errorf("cannot tell the version the cluster runs: %w", err)
+	}
+
+	if to.less(from) {
+		return fmt.Errorf("cannot downgrade from %s to %s, recreate the cluster with --version %s instead", current, target, target)
+	}
+	if to.Minor > from.Minor+1 {
+		return fmt.Errorf("cannot skip a minor version from %s to %s, upgrade to v1.%d first", current, target, from.Minor+1)
+	}
+
+	if allowUntested {
+		return nil
+	}
+	for _, minor := range supportedKubernetesMinors {
+		if to.Minor == minor {
+			return nil
+		}
+	}
+	supported := make([]string, len(supportedKubernetesMinors))
+	for i, minor := range supportedKubernetesMinors {
+		supported[i] = fmt.Sprintf("v1.%d", minor)
+	}
+	return fmt.Errorf("Kubernetes v1.%d is not tested with OpenFrame and argo-cd chart 8.2.7 (supported: %s), use --allow-untested to upgrade anyway",
+		to.Minor, strings.Join(supported, ", "))
+}
@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/pterm/pterm"
+)
+
+// PlanReap finds the expired and broken k3d clusters, the Docker networks and volumes of
+// clusters that are gone and the stale temporary files. Registered external clusters are
+// never reaped.
+func (s *ClusterService) PlanReap() (models.ReapPlan, error) {
+	return s.manager.PlanReap(context.Background(), time.Now())
+}
+
+// Reap removes what a plan lists together with the kubeconfigs kept for the reaped
+// clusters, and returns what was removed
+func (s *ClusterService) Reap(plan models.ReapPlan) (models.ReapPlan, error) {
+	var spinner *pterm.SpinnerPrinter
+	if !s.suppressUI {
+		spinner, _ = pterm.DefaultSpinner.Start(fmt.Sprintf("Reaping %d clusters...", len(plan.Clusters)))
+	} else {
+		pterm.Info.Printf("Reaping %d clusters...\n", len(plan.Clusters))
+	}
+
+	reaped, err := s.manager.Reap(context.Background(), plan)
+	if s.kubeconfigDir != "" {
+		for _, cluster := range reaped.Clusters {
+			path := filepath.Join(s.kubeconfigDir, cluster.Name+".yaml")
+			if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) {
+				err = errors.Join(err, fmt.Errorf("failed to remove %s: %w", path, removeErr))
+			}
+		}
+	}
+
+	if err != nil {
+		if spinner != nil {
+			spinner.Warning("Some resources could not be reaped")
+		}
+		return reaped, err
+	}
+	if spinner != nil {
+		spinner.Success(fmt.Sprintf("Reaped %d clusters", len(reaped.Clusters)))
+	} else {
+		pterm.Success.Printf("Reaped %d clusters\n", len(reaped.Clusters))
+	}
+	return reaped, nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/prerequisites"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/external"
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/k3d"
 	uiCluster "github.com/flamingo-stack/openframe/openframe/internal/cluster/ui"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
@@ -19,9 +20,12 @@ import (
 // ClusterService provides cluster configuration and management operations
 // This handles cluster lifecycle operations and configuration management
 type ClusterService struct {
-	manager    *k3d.K3dManager
-	executor   executor.CommandExecutor
-	suppressUI bool // Suppress interactive UI elements for automation
+	manager       *k3d.K3dManager
+	external      *external.Manager // Optional, registered clusters created outside the CLI
+	kubeconfigDir string            // Optional, where TargetCluster writes per-cluster kubeconfigs
+	executor      executor.CommandExecutor
+	suppressUI    bool                               // Suppress interactive UI elements for automation
+	confirm       func(message string) (bool, error) // Optional, replaces the interactive confirmation in tests
 }
 
 // isTerminalEnvironment checks if we're running in a proper terminal
@@ -61,10 +65,22 @@ func NewClusterServiceWithOptions(exec executor.CommandExecutor, manager *k3d.K3
 	}
 }
 
+// WithPortStore makes the service reuse and save the host ports of the clusters it creates
+func (s *ClusterService) WithPortStore(store *k3d.PortStore) *ClusterService {
+	if store != nil {
+		s.manager.WithPortStore(store)
+	}
+	return s
+}
+
 // CreateCluster handles cluster creation operations
 func (s *ClusterService) CreateCluster(config models.ClusterConfig) error {
 	ctx := context.Background()
 
+	if s.isExternal(config.Name) {
+		return fmt.Errorf("cluster '%s' is registered as an external cluster, unregister it with 'openframe cluster delete %s' first", config.Name, config.Name)
+	}
+
 	// Check if cluster already exists
 	if existingInfo, err := s.manager.GetClusterStatus(ctx, config.Name); err == nil {
 		// Cluster already exists - show friendly message
@@ -132,7 +148,7 @@ func (s *ClusterService) CreateCluster(config models.ClusterConfig) error {
 	}
 
 	// Show next steps
-	s.showNextSteps(config.Name)
+	s.showNextSteps(config)
 
 	return nil
 }
@@ -141,6 +157,10 @@ func (s *ClusterService) CreateCluster(config models.ClusterConfig) error {
 func (s *ClusterService) DeleteCluster(name string, clusterType models.ClusterType, force bool) error {
 	ctx := context.Background()
 
+	if clusterType == models.ClusterTypeExternal {
+		return s.unregisterExternalCluster(name)
+	}
+
 	// Show deletion progress
 	var spinner *pterm.SpinnerPrinter
 	if !s.suppressUI {
@@ -166,21 +186,114 @@ func (s *ClusterService) DeleteCluster(name string, clusterType models.ClusterTy
 	return nil
 }
 
+// ScaleCluster adds or removes agent nodes of a k3d cluster until it runs the given number
+// of agents
+func (s *ClusterService) ScaleCluster(name string, agents int) (models.ScaleResult, error) {
+	ctx := context.Background()
+
+	if s.isExternal(name) {
+		return models.ScaleResult{}, fmt.Errorf("cluster '%s' is an external cluster, its nodes are not managed by OpenFrame", name)
+	}
+
+	var spinner *pterm.SpinnerPrinter
+	if !s.suppressUI {
+		spinner, _ = pterm.DefaultSpinner.Start(fmt.Sprintf("Scaling cluster '%s' to %d agents...", name, agents))
+	} else {
+		pterm.Info.Printf("Scaling cluster '%s' to %d agents...\n", name, agents)
+	}
+
+	result, err := s.manager.ScaleAgents(ctx, name, agents)
+	if err != nil {
+		if spinner != nil {
+			spinner.Fail(fmt.Sprintf("Failed to scale cluster '%s'", name))
+		}
+		return result, err
+	}
+
+	if spinner != nil {
+		spinner.Success(fmt.Sprintf("Cluster '%s' runs %d agents", name, agents))
+	} else {
+		pterm.Success.Printf("Cluster '%s' runs %d agents\n", name, agents)
+	}
+	return result, nil
+}
+
+// UpgradeCluster moves the nodes of a k3d cluster to another k3s release one at a time,
+// showing the node being replaced
+func (s *ClusterService) UpgradeCluster(name, version string, allowUntested bool) (models.UpgradeResult, error) {
+	ctx := context.Background()
+
+	if s.isExternal(name) {
+		return models.UpgradeResult{}, fmt.Errorf("cluster '%s' is an external cluster, upgrade it where it is managed", name)
+	}
+
+	var spinner *pterm.SpinnerPrinter
+	if !s.suppressUI {
+		spinner, _ = pterm.DefaultSpinner.Start(fmt.Sprintf("Upgrading cluster '%s' to %s...", name, version))
+	} else {
+		pterm.Info.Printf("Upgrading cluster '%s' to %s...\n", name, version)
+	}
+	progress := func(step string) {
+		if spinner != nil {
+			spinner.UpdateText(step + "...")
+		} else {
+			pterm.Info.Println(step)
+		}
+	}
+
+	result, err := s.manager.UpgradeCluster(ctx, name, version, allowUntested, progress)
+	if err != nil {
+		if spinner != nil {
+			spinner.Fail(fmt.Sprintf("Failed to upgrade cluster '%s'", name))
+		}
+		return result, err
+	}
+
+	if spinner != nil {
+		spinner.Success(fmt.Sprintf("Cluster '%s' runs %s", name, version))
+	} else {
+		pterm.Success.Printf("Cluster '%s' runs %s\n", name, version)
+	}
+	return result, nil
+}
+
 // ListClusters handles cluster listing business logic
 func (s *ClusterService) ListClusters() ([]models.ClusterInfo, error) {
 	ctx := context.Background()
-	return s.manager.ListAllClusters(ctx)
+	clusters, err := s.manager.ListAllClusters(ctx)
+	if s.external == nil {
+		return clusters, err
+	}
+
+	externalClusters, externalErr := s.external.ListClusters(ctx)
+	if err != nil {
+		// Registered clusters stay usable without k3d or Docker
+		if externalErr != nil || len(externalClusters) == 0 {
+			return nil, err
+		}
+		return externalClusters, nil
+	}
+	if externalErr != nil {
+		return nil, externalErr
+	}
+	return append(clusters, externalClusters...), nil
 }
 
 // GetClusterStatus handles cluster status business logic
 func (s *ClusterService) GetClusterStatus(name string) (models.ClusterInfo, error) {
 	ctx := context.Background()
+	if s.isExternal(name) {
+		return s.external.GetClusterStatus(ctx, name)
+	}
 	return s.manager.GetClusterStatus(ctx, name)
 }
 
 // DetectClusterType handles cluster type detection business logic
 func (s *ClusterService) DetectClusterType(name string) (models.ClusterType, error) {
 	ctx := context.Background()
+	if s.isExternal(name) {
+		return models.ClusterTypeExternal, nil
+	}
 	return s.manager.DetectClusterType(ctx, name)
 }
 
@@ -189,6 +302,8 @@ func (s *ClusterService) CleanupCluster(name string, clusterType models.ClusterT
 	switch clusterType {
 	case models.ClusterTypeK3d:
 		return s.cleanupK3dCluster(name, verbose, force)
+	case models.ClusterTypeExternal:
+		return s.cleanupExternalCluster(name, verbose, force)
 	default:
 		return fmt.Errorf("cleanup not supported for cluster type: %s", clusterType)
 	}
@@ -198,6 +313,10 @@ func (s *ClusterService) CleanupCluster(name string, clusterType models.ClusterT
 func (s *ClusterService) cleanupK3dCluster(clusterName string, verbose bool, force bool) error {
 	ctx := context.Background()
 
+	if err := s.TargetCluster(clusterName); err != nil {
+		return err
+	}
+
 	if verbose {
 		pterm.Info.Printf("Starting cleanup of cluster: %s\n", clusterName)
 	}
@@ -501,14 +620,20 @@ func (s *ClusterService) displayClusterCreationSummary(info models.ClusterInfo)
 			"TYPE:     %s\n"+
 			"STATUS:   %s\n"+
 			"NODES:    %d\n"+
-			"NETWORK:  k3d-%s\n"+
-			"API:      https://0.0.0.0:6550",
+			"NETWORK:  k3d-%s",
 		pterm.Bold.Sprint(info.Name),
 		strings.ToUpper(string(info.Type)),
 		pterm.Green("Ready"),
 		info.NodeCount,
 		info.Name,
 	)
+	if ports, ok := s.clusterPorts(info.Name); ok {
+		boxContent += fmt.Sprintf("\n"+
+			"API:      %s\n"+
+			"HTTP:     %s\n"+
+			"HTTPS:    %s",
+			ports.APIServerURL(), ports.HTTPURL(), ports.HTTPSURL())
+	}
 
 	pterm.DefaultBox.
 		WithTitle(" ✅ Cluster Created ").
@@ -516,19 +641,68 @@ func (s *ClusterService) displayClusterCreationSummary(info models.ClusterInfo)
 		Println(boxContent)
 }
 
+// clusterPorts returns the API, HTTP and HTTPS host ports a cluster publishes
+func (s *ClusterService) clusterPorts(name string) (models.PortAssignment, bool) {
+	bindings, err := s.manager.GetPortBindings(context.Background(), name)
+	if err != nil {
+		return s.manager.SavedPorts(name)
+	}
+
+	ports := models.PortAssignment{Host: s.manager.DockerHost()}
+	for _, binding := range bindings {
+		switch binding.Purpose() {
+		case "Kubernetes API":
+			ports.API = binding.HostPort
+		case "Ingress HTTP":
+			ports.HTTP = binding.HostPort
+		case "Ingress HTTPS":
+			ports.HTTPS = binding.HostPort
+		}
+	}
+	if !ports.IsComplete() {
+		return s.manager.SavedPorts(name)
+	}
+	return ports, true
+}
+
+// GetPortBindings returns the host ports published by the nodes of a cluster
+func (s *ClusterService) GetPortBindings(name string) ([]models.PortBinding, error) {
+	return s.manager.GetPortBindings(context.Background(), name)
+}
+
+// DockerHost returns the remote Docker host the clusters publish their ports on, empty
+// when Docker runs on this machine
+func (s *ClusterService) DockerHost() string {
+	return s.manager.DockerHost()
+}
+
+// SavedPorts returns the host ports reserved for a cluster, false when none are saved
+func (s *ClusterService) SavedPorts(name string) (models.PortAssignment, bool) {
+	return s.manager.SavedPorts(name)
+}
+
 // showNextSteps displays clean next steps after cluster creation
-func (s *ClusterService) showNextSteps(clusterName string) {
+func (s *ClusterService) showNextSteps(config models.ClusterConfig) {
 	// Skip showing next steps if UI is suppressed (e.g., during bootstrap)
 	if s.suppressUI {
 		return
 	}
 
+	// Without the context switch, kubectl needs to be told which cluster to talk to
+	kubectl := "kubectl"
+	if config.NoSwitchContext {
+		kubectl = fmt.Sprintf("kubectl --context k3d-%s", config.Name)
+	}
+
 	fmt.Println()
 	pterm.Info.Printf("🚀 Next Steps:\n")
 	pterm.Printf("  1. Bootstrap platform:   openframe bootstrap\n")
-	pterm.Printf("  2. Check cluster nodes:  kubectl get nodes\n")
-	pterm.Printf("  3. View cluster status:  openframe cluster status %s\n", clusterName)
-	pterm.Printf("  4. View running pods:    kubectl get pods -A\n")
+	pterm.Printf("  2. Check cluster nodes:  %s get nodes\n", kubectl)
+	pterm.Printf("  3. View cluster status:  openframe cluster status %s\n", config.Name)
+	pterm.Printf("  4. View running pods:    %s get pods -A\n", kubectl)
+	if config.NoSwitchContext {
+		pterm.Printf("  5. Switch to cluster:    openframe cluster use %s\n", config.Name)
+	}
 
 	fmt.Println()
 }
@@ -537,6 +711,10 @@ func (s *ClusterService) showNextSteps(clusterName string) {
 func (s *ClusterService) ShowClusterStatus(name string, detailed bool, skipApps bool, verbose bool) error {
 	ctx := context.Background()
 
+	if s.isExternal(name) {
+		return s.showExternalClusterStatus(ctx, name)
+	}
+
 	// Get cluster status
 	status, err := s.manager.GetClusterStatus(ctx, name)
 	if err != nil {
@@ -635,6 +813,14 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 		WithTitleTopCenter().
 		Println(boxContent)
 
+	if len(status.Nodes) > 0 {
+		fmt.Println()
+		pterm.Info.Printf("🖥️ Nodes:\n")
+		for _, node := range status.Nodes {
+			pterm.Printf("  %-40s %-14s %s\n", node.Name, node.Role, node.Status)
+		}
+	}
+
 	// Network information
 	fmt.Println()
 	pterm.Info.Printf("🌐 Network Information:\n")
@@ -656,6 +842,8 @@ func (s *ClusterService) displayDetailedClusterStatus(status models.ClusterInfo,
 	fmt.Println()
 	pterm.Info.Printf("⚙️ Management Commands:\n")
 	pterm.Printf("  Delete cluster:      openframe cluster delete %s\n", status.Name)
+	pterm.Printf("  Scale agents:        openframe cluster scale %s --agents <count>\n", status.Name)
+	pterm.Printf("  Upgrade Kubernetes:  openframe cluster upgrade %s --version <k3s-version>\n", status.Name)
 	pterm.Printf("  Access with kubectl: kubectl get nodes\n")
 	pterm.Printf("  View pods:           kubectl get pods -A\n")
 	pterm.Printf("  Get cluster info:    kubectl cluster-info\n")
@@ -691,6 +879,7 @@ func (s *ClusterService) DisplayClusterList(clusters []models.ClusterInfo, quiet
 			Status:    cluster.Status,
 			NodeCount: cluster.NodeCount,
 			CreatedAt: cluster.CreatedAt,
+			ExpiresAt: cluster.ExpiresAt,
 		}
 	}
 
@@ -733,6 +922,10 @@ func CreateClusterWithPrerequisitesNonInteractive(clusterName string, verbose bo
 	} else {
 		service = NewClusterService(exec)
 	}
+	service.WithPortStore(k3d.NewDefaultPortStore())
+	if dir, err := DefaultKubeconfigDir(); err == nil {
+		service.WithKubeconfigDir(dir)
+	}
 
 	// Build cluster configuration
 	config := models.ClusterConfig{
@@ -746,5 +939,10 @@ func CreateClusterWithPrerequisitesNonInteractive(clusterName string, verbose bo
 	}
 
 	// Create the cluster
-	return service.CreateCluster(config)
+	if err := service.CreateCluster(config); err != nil {
+		return err
+	}
+
+	// Point the chart install that follows at the new cluster, not the current kubectl context
+	return service.TargetCluster(config.Name)
 }
@@ -9,12 +9,16 @@ import (
 // FlagContainer holds all flag structures needed by cluster commands
 type FlagContainer struct {
 	// Flag instances
-	Global  *models.GlobalFlags  `json:"global"`
-	Create  *models.CreateFlags  `json:"create"`
-	List    *models.ListFlags    `json:"list"`
-	Status  *models.StatusFlags  `json:"status"`
-	Delete  *models.DeleteFlags  `json:"delete"`
-	Cleanup *models.CleanupFlags `json:"cleanup"`
+	Global     *models.GlobalFlags     `json:"global"`
+	Create     *models.CreateFlags     `json:"create"`
+	List       *models.ListFlags       `json:"list"`
+	Status     *models.StatusFlags     `json:"status"`
+	Delete     *models.DeleteFlags     `json:"delete"`
+	Cleanup    *models.CleanupFlags    `json:"cleanup"`
+	Register   *models.RegisterFlags   `json:"register"`
+	Kubeconfig *models.KubeconfigFlags `json:"kubeconfig"`
+	Scale      *models.ScaleFlags      `json:"scale"`
+	Upgrade    *models.UpgradeFlags    `json:"upgrade"`
 
 	// Dependencies for testing and execution
 	Executor    executor.CommandExecutor `json:"-"` // Command executor for external commands
@@ -34,12 +38,16 @@ func (f *FlagContainer) GetExecutor() executor.CommandExecutor {
 // NewFlagContainer creates a new flag container with initialized flags
 func NewFlagContainer() *FlagContainer {
 	return &FlagContainer{
-		Global:  &models.GlobalFlags{},
-		Create:  &models.CreateFlags{ClusterType: "k3d", NodeCount: 3, K8sVersion: "v1.31.5-k3s1"},
-		List:    &models.ListFlags{},
-		Status:  &models.StatusFlags{},
-		Delete:  &models.DeleteFlags{},
-		Cleanup: &models.CleanupFlags{},
+		Global:     &models.GlobalFlags{},
+		Create:     &models.CreateFlags{ClusterType: "k3d", NodeCount: 3, K8sVersion: "v1.31.5-k3s1"},
+		List:       &models.ListFlags{},
+		Status:     &models.StatusFlags{},
+		Delete:     &models.DeleteFlags{},
+		Cleanup:    &models.CleanupFlags{},
+		Register:   &models.RegisterFlags{},
+		Kubeconfig: &models.KubeconfigFlags{},
+		Scale:      &models.ScaleFlags{Agents: -1},
+		Upgrade:    &models.UpgradeFlags{},
 	}
 }
 
@@ -51,6 +59,10 @@ func (f *FlagContainer) SyncGlobalFlags() {
 		f.Status.GlobalFlags = *f.Global
 		f.Delete.GlobalFlags = *f.Global
 		f.Cleanup.GlobalFlags = *f.Global
+		f.Register.GlobalFlags = *f.Global
+		f.Kubeconfig.GlobalFlags = *f.Global
+		f.Scale.GlobalFlags = *f.Global
+		f.Upgrade.GlobalFlags = *f.Global
 	}
 }
 
@@ -62,4 +74,8 @@ func (f *FlagContainer) Reset() {
 	f.Status = &models.StatusFlags{}
 	f.Delete = &models.DeleteFlags{}
 	f.Cleanup = &models.CleanupFlags{}
+	f.Register = &models.RegisterFlags{}
+	f.Kubeconfig = &models.KubeconfigFlags{}
+	f.Scale = &models.ScaleFlags{Agents: -1}
+	f.Upgrade = &models.UpgradeFlags{}
 }
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
@@ -293,6 +294,23 @@ func (ui *OperationsUI) ShowConfigurationSummary(config models.ClusterConfig, dr
 		fmt.Printf("Version: %s\n", config.K8sVersion)
 	}
 
+	if config.Ports != (models.PortAssignment{}) {
+		fmt.Printf("  Ports: %s\n", config.Ports)
+	}
+
+	if config.TTL > 0 {
+		fmt.Printf("    TTL: %s\n", config.TTL)
+	}
+
+	if len(config.Labels) > 0 {
+		labels := make([]string, 0, len(config.Labels))
+		for key, value := range config.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+		fmt.Printf(" Labels: %s\n", strings.Join(labels, ", "))
+	}
+
 	fmt.Println()
 
 	if dryRun {
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
@@ -16,6 +17,7 @@ type ClusterDisplayInfo struct {
 	Status    string
 	NodeCount int
 	CreatedAt time.Time
+	ExpiresAt time.Time // Zero for clusters without a TTL
 	Nodes     []NodeDisplayInfo
 }
 
@@ -82,7 +84,7 @@ func (s *DisplayService) ShowClusterList(clusters []ClusterDisplayInfo, out io.W
 
 	// Create table data
 	tableData := pterm.TableData{
-		{"NAME", "TYPE", "STATUS", "NODES", "CREATED"},
+		{"NAME", "TYPE", "STATUS", "NODES", "CREATED", "EXPIRES"},
 	}
 
 	for _, clusterInfo := range clusters {
@@ -93,6 +95,7 @@ func (s *DisplayService) ShowClusterList(clusters []ClusterDisplayInfo, out io.W
 			statusColor(clusterInfo.Status),
 			fmt.Sprintf("%d", clusterInfo.NodeCount),
 			clusterInfo.CreatedAt.Format("2006-01-02 15:04"),
+			formatExpiry(clusterInfo.ExpiresAt, time.Now()),
 		})
 	}
 
@@ -103,20 +106,36 @@ func (s *DisplayService) ShowClusterList(clusters []ClusterDisplayInfo, out io.W
 		for i, row := range tableData {
 			if i == 0 {
 				// Header row
-				fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %s\n", row[0], row[1], row[2], row[3], row[4])
+				fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %-16s %s\n", row[0], row[1], row[2], row[3], row[4], row[5])
 				continue
 			}
 			// Data rows - need to account for styled text by using different spacing
-			fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %s\n",
+			fmt.Fprintf(out, "%-17s %-8s %-10s %-6s %-16s %s\n",
 				pterm.RemoveColorFromString(row[0]), // Remove color codes for alignment
 				row[1],
 				pterm.RemoveColorFromString(row[2]), // Remove color codes for alignment
 				row[3],
-				row[4])
+				row[4],
+				row[5])
 		}
 	}
 }
 
+// formatExpiry shows how long a cluster created with a TTL has left
+func formatExpiry(expiresAt, now time.Time) string {
+	switch {
+	case expiresAt.IsZero():
+		return "-"
+	case !now.Before(expiresAt):
+		return "expired"
+	}
+	left := expiresAt.Sub(now).Truncate(time.Minute)
+	if left < time.Minute {
+		return "in <1m"
+	}
+	return "in " + strings.TrimSuffix(left.String(), "0s")
+}
+
 // ShowClusterStatus displays detailed cluster status
 func (s *DisplayService) ShowClusterStatus(status *ClusterDisplayInfo, out io.Writer) {
 	fmt.Fprintf(out, "\nCluster Status:\n")
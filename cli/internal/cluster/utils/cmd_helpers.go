@@ -6,6 +6,8 @@ import (
 
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster"
 	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/external"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/k3d"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/errors"
 	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
 	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
@@ -37,7 +39,7 @@ func GetCommandService() *cluster.ClusterService {
 	dryRun := globalFlags != nil && globalFlags.Global != nil && globalFlags.Global.DryRun
 	verbose := globalFlags != nil && globalFlags.Global != nil && globalFlags.Global.Verbose
 	exec := executor.NewRealCommandExecutor(dryRun, verbose)
-	return cluster.NewClusterService(exec)
+	return withLocalState(cluster.NewClusterService(exec), dryRun)
 }
 
 // GetSuppressedCommandService creates a command service with UI suppression for automation
@@ -51,7 +53,34 @@ func GetSuppressedCommandService() *cluster.ClusterService {
 	dryRun := globalFlags != nil && globalFlags.Global != nil && globalFlags.Global.DryRun
 	verbose := globalFlags != nil && globalFlags.Global != nil && globalFlags.Global.Verbose
 	exec := executor.NewRealCommandExecutor(dryRun, verbose)
-	return cluster.NewClusterServiceSuppressed(exec)
+	return withLocalState(cluster.NewClusterServiceSuppressed(exec), dryRun)
+}
+
+// GetInspectionService creates a command service whose commands also run in dry runs, for
+// commands that only look at clusters to show what a dry run would change
+func GetInspectionService() *cluster.ClusterService {
+	// Use injected executor if available (for testing)
+	if globalFlags != nil && globalFlags.Executor != nil {
+		return cluster.NewClusterService(globalFlags.Executor)
+	}
+
+	verbose := globalFlags != nil && globalFlags.Global != nil && globalFlags.Global.Verbose
+	exec := executor.NewRealCommandExecutor(false, verbose)
+	return withLocalState(cluster.NewClusterService(exec), true)
+}
+
+// withLocalState gives the service the registered external clusters and keeps the
+// kubeconfigs and host ports of clusters in the user's config directory; dry runs create
+// nothing, so no ports are saved for them
+func withLocalState(service *cluster.ClusterService, dryRun bool) *cluster.ClusterService {
+	service.WithExternalRegistry(external.NewDefaultRegistry())
+	if dir, err := cluster.DefaultKubeconfigDir(); err == nil {
+		service.WithKubeconfigDir(dir)
+	}
+	if dryRun {
+		return service
+	}
+	return service.WithPortStore(k3d.NewDefaultPortStore())
 }
 
 // WrapCommandWithCommonSetup wraps a command function with common CLI setup and error handling
@@ -26,6 +26,47 @@ type ScaffoldFlags struct {
 	ClusterName     string // Cluster name to use
 	SkipBootstrap   bool   // Skip bootstrapping cluster
 	HelmValuesFile  string // Custom Helm values file for bootstrap
+	Services        []string // Run these skaffold services together instead of selecting one
+}
+
+// ScaffoldInitFlags holds all flags for the skaffold init command
+type ScaffoldInitFlags struct {
+	Source string // Service source directory (defaults to openframe/services/<service>)
+	Force  bool   // Overwrite an existing skaffold.yaml
+	Stdout bool   // Print the generated config instead of writing it
+}
+
+// LogsFlags holds all flags for the logs command
+type LogsFlags struct {
+	Cluster   string // Cluster to stream from (defaults to the only cluster or a prompt)
+	Namespace string // Namespace to search (defaults to all namespaces for selectors)
+	Container string // Only stream containers with this name
+	Since     string // Only return logs newer than a relative duration like 5m or 1h
+	Grep      string // Only print lines matching this regular expression
+	JSON      bool   // Emit one JSON object per log line
+	Follow    bool   // Keep streaming and pick up new pods as they appear
+}
+
+// DatabaseFlags holds all flags for the db command
+type DatabaseFlags struct {
+	Cluster   string // Cluster the datasources run in (defaults to the only cluster or a prompt)
+	Namespace string // Namespace the datasources are deployed to
+}
+
+// KafkaFlags holds all flags for the kafka commands
+type KafkaFlags struct {
+	Cluster       string // Cluster Kafka runs in (defaults to the only cluster or a prompt)
+	Namespace     string // Namespace the datasources are deployed to
+	FromBeginning bool   // Consume from the earliest offset instead of new messages only
+	MaxMessages   int    // Stop consuming after this many messages (0 means unlimited)
+	Group         string // Consumer group to join when consuming
+}
+
+// ImageFlags holds all flags for the image commands
+type ImageFlags struct {
+	Cluster   string // k3d cluster to import images into and patch (defaults to the current k3d context)
+	RepoParam string // Helm parameter holding the image repository
+	TagParam  string // Helm parameter holding the image tag
 }
 
 // AddGlobalFlags adds global flags to the dev command
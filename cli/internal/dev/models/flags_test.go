@@ -178,4 +178,23 @@ func TestFlags_StringRepresentation(t *testing.T) {
 		assert.Contains(t, str, "Image:test:latest", "String representation should contain Image")
 		assert.Contains(t, str, "Port:9000", "String representation should contain Port")
 	})
-}
\ No newline at end of file
+}
+func TestLogsFlags_DefaultValues(t *testing.T) {
+	flags := &LogsFlags{}
+
+	assert.Equal(t, "", flags.Namespace, "Namespace should default to empty string")
+	assert.Equal(t, "", flags.Container, "Container should default to empty string")
+	assert.Equal(t, "", flags.Since, "Since should default to empty string")
+	assert.Equal(t, "", flags.Grep, "Grep should default to empty string")
+	assert.False(t, flags.JSON, "JSON should default to false")
+	assert.False(t, flags.Follow, "Follow should default to false")
+}
+
+func TestKafkaFlags_DefaultValues(t *testing.T) {
+	flags := &KafkaFlags{}
+
+	assert.Equal(t, "", flags.Namespace, "Namespace should default to empty string")
+	assert.False(t, flags.FromBeginning, "FromBeginning should default to false")
+	assert.Equal(t, 0, flags.MaxMessages, "MaxMessages should default to 0")
+	assert.Equal(t, "", flags.Group, "Group should default to empty string")
+}
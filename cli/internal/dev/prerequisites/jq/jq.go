@@ -1,11 +1,14 @@
 package jq
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 )
 
 type JqInstaller struct{}
@@ -55,6 +58,10 @@ func (j *JqInstaller) GetInstallHelp() string {
 }
 
 func (j *JqInstaller) Install() error {
+	if installed, err := tools.InstallPinned(context.Background(), "jq"); installed || err != nil {
+		return err
+	}
+
 	switch runtime.GOOS {
 	case "darwin":
 		return j.installMacOS()
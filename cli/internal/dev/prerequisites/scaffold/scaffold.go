@@ -1,10 +1,13 @@
 package scaffold
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 )
 
 type ScaffoldInstaller struct{}
@@ -54,6 +57,10 @@ func (s *ScaffoldInstaller) GetInstallHelp() string {
 }
 
 func (s *ScaffoldInstaller) Install() error {
+	if installed, err := tools.InstallPinned(context.Background(), "skaffold"); installed || err != nil {
+		return err
+	}
+
 	switch runtime.GOOS {
 	case "darwin":
 		return s.installMacOS()
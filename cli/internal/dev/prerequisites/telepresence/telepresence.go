@@ -1,11 +1,14 @@
 package telepresence
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
 )
 
 type TelepresenceInstaller struct{}
@@ -61,6 +64,10 @@ func (t *TelepresenceInstaller) GetInstallHelp() string {
 }
 
 func (t *TelepresenceInstaller) Install() error {
+	if installed, err := tools.InstallPinned(context.Background(), "telepresence"); installed || err != nil {
+		return err
+	}
+
 	var err error
 	
 	switch runtime.GOOS {
@@ -0,0 +1,118 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/logs"
+)
+
+// Ensure Provider implements the application resolver
+var _ logs.ApplicationResolver = (*Provider)(nil)
+
+// argocdNamespace is where the app-of-apps chart creates Application resources
+const argocdNamespace = "argocd"
+
+// workloadKinds are the application resources whose selectors identify its pods
+var workloadKinds = map[string]string{
+	"Deployment":  "deployment",
+	"StatefulSet": "statefulset",
+	"DaemonSet":   "daemonset",
+}
+
+// notFoundPattern matches the kubectl error for a missing object, and not the one for a
+// missing resource type
+var notFoundPattern = regexp.MustCompile(`\(NotFound\)|"[^"]*" not found`)
+
+// ResolveApplication maps an ArgoCD application to its destination namespace and pod selectors
+func (p *Provider) ResolveApplication(ctx context.Context, name string) (*logs.Target, error) {
+	result, err := p.executor.Execute(ctx, "kubectl", "get", "applications.argoproj.io", name, "-n", argocdNamespace, "-o", "json")
+	if err != nil {
+		// Only a missing application may fall back to a namespace, anything else is reported
+		if result != nil && notFoundPattern.MatchString(result.Stderr) {
+			return nil, fmt.Errorf("%w: %s", logs.ErrApplicationNotFound, name)
+		}
+		if result != nil && strings.TrimSpace(result.Stderr) != "" {
+			return nil, fmt.Errorf("failed to get application %s: %w: %s", name, err, strings.TrimSpace(result.Stderr))
+		}
+		return nil, fmt.Errorf("failed to get application %s: %w", name, err)
+	}
+
+	var app applicationJSON
+	if err := json.Unmarshal([]byte(result.Stdout), &app); err != nil {
+		return nil, fmt.Errorf("failed to parse application %s: %w", name, err)
+	}
+
+	namespace := app.Spec.Destination.Namespace
+	seen := make(map[logs.Selector]bool)
+	var selectors []logs.Selector
+
+	for _, resource := range app.Status.Resources {
+		kind, ok := workloadKinds[resource.Kind]
+		if !ok {
+			continue
+		}
+
+		resourceNamespace := resource.Namespace
+		if resourceNamespace == "" {
+			resourceNamespace = namespace
+		}
+
+		selector, err := p.getWorkloadSelector(ctx, kind, resourceNamespace, resource.Name)
+		if err != nil {
+			if p.verbose {
+				fmt.Printf("DEBUG: Skipping %s %s: %v\n", kind, resource.Name, err)
+			}
+			continue
+		}
+		if selector == "" {
+			continue
+		}
+		// Workloads of an application may live outside its destination namespace
+		key := logs.Selector{Namespace: resourceNamespace, Labels: selector}
+		if !seen[key] {
+			seen[key] = true
+			selectors = append(selectors, key)
+		}
+	}
+
+	// Fall back to the tracking label ArgoCD stamps on managed resources
+	if len(selectors) == 0 {
+		selectors = []logs.Selector{{Labels: "app.kubernetes.io/instance=" + name}}
+	}
+
+	return &logs.Target{
+		Namespace:   namespace,
+		Selectors:   selectors,
+		Description: fmt.Sprintf("application '%s' in namespace '%s'", name, namespace),
+	}, nil
+}
+
+// getWorkloadSelector returns the matchLabels of a workload as a label selector string
+func (p *Provider) getWorkloadSelector(ctx context.Context, kind, namespace, name string) (string, error) {
+	result, err := p.executor.Execute(ctx, "kubectl", "get", kind, name, "-n", namespace, "-o", "json")
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+	}
+
+	var workload workloadJSON
+	if err := json.Unmarshal([]byte(result.Stdout), &workload); err != nil {
+		return "", fmt.Errorf("failed to parse %s %s: %w", kind, name, err)
+	}
+
+	return formatSelector(workload.Spec.Selector.MatchLabels), nil
+}
+
+// formatSelector renders labels as a sorted, comma separated selector
+func formatSelector(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,129 @@
+package kubectl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/logs"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_ResolveApplication(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+
+	mockExecutor.SetResponse("kubectl get applications.argoproj.io openframe-api", &executor.CommandResult{
+		ExitCode: 0,
+		Stdout: `{
+  "spec": {"destination": {"namespace": "microservices"}},
+  "status": {
+    "resources": [
+      {"kind": "Service", "name": "openframe-api", "namespace": "microservices"},
+      {"kind": "Deployment", "name": "openframe-api", "namespace": "microservices"},
+      {"kind": "Job", "name": "register", "namespace": "microservices"}
+    ]
+  }
+}`,
+	})
+	mockExecutor.SetResponse("kubectl get deployment openframe-api", &executor.CommandResult{
+		ExitCode: 0,
+		Stdout:   `{"spec": {"selector": {"matchLabels": {"tier": "backend", "app": "openframe-api"}}}}`,
+	})
+
+	target, err := provider.ResolveApplication(context.Background(), "openframe-api")
+	require.NoError(t, err)
+
+	assert.Equal(t, "microservices", target.Namespace)
+	assert.Equal(t, []logs.Selector{{Namespace: "microservices", Labels: "app=openframe-api,tier=backend"}}, target.Selectors)
+	assert.Contains(t, target.Description, "openframe-api")
+	assert.True(t, mockExecutor.WasCommandExecuted("kubectl get deployment openframe-api -n microservices -o json"))
+	assert.False(t, mockExecutor.WasCommandExecuted("kubectl get service"))
+}
+
+func TestProvider_ResolveApplication_FallbackSelector(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+
+	mockExecutor.SetResponse("kubectl get applications.argoproj.io", &executor.CommandResult{
+		ExitCode: 0,
+		Stdout:   `{"spec": {"destination": {"namespace": "datasources"}}, "status": {}}`,
+	})
+
+	target, err := provider.ResolveApplication(context.Background(), "redis")
+	require.NoError(t, err)
+	assert.Equal(t, "datasources", target.Namespace)
+	assert.Equal(t, []logs.Selector{{Labels: "app.kubernetes.io/instance=redis"}}, target.Selectors)
+}
+
+func TestProvider_ResolveApplication_NotFound(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	mockExecutor.SetShouldFail(true, `Error from server (NotFound): applications.argoproj.io "missing" not found`)
+	provider := NewProvider(mockExecutor, false)
+
+	_, err := provider.ResolveApplication(context.Background(), "missing")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, logs.ErrApplicationNotFound))
+}
+
+func TestProvider_ResolveApplication_OtherErrors(t *testing.T) {
+	stderrs := []string{
+		"The connection to the server 127.0.0.1:6550 was refused - did you specify the right host or port?",
+		`Error from server (Forbidden): applications.argoproj.io "openframe-api" is forbidden: User "dev" cannot get resource "applications"`,
+		`error: the server doesn't have a resource type "applications"`,
+	}
+	for _, stderr := range stderrs {
+		testutil.InitializeTestMode()
+		mockExecutor := testutil.NewTestMockExecutor()
+		mockExecutor.SetShouldFail(true, stderr)
+		provider := NewProvider(mockExecutor, false)
+
+		_, err := provider.ResolveApplication(context.Background(), "openframe-api")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, logs.ErrApplicationNotFound), stderr)
+		assert.Contains(t, err.Error(), stderr)
+	}
+}
+
+func TestProvider_ResolveApplication_ResourceNamespaces(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+
+	mockExecutor.SetResponse("kubectl get applications.argoproj.io kafka", &executor.CommandResult{
+		Stdout: `{
+  "spec": {"destination": {"namespace": "datasources"}},
+  "status": {
+    "resources": [
+      {"kind": "StatefulSet", "name": "kafka"},
+      {"kind": "Deployment", "name": "strimzi-operator", "namespace": "operators"}
+    ]
+  }
+}`,
+	})
+	mockExecutor.SetResponse("kubectl get statefulset kafka -n datasources", &executor.CommandResult{
+		Stdout: `{"spec": {"selector": {"matchLabels": {"app": "kafka"}}}}`,
+	})
+	mockExecutor.SetResponse("kubectl get deployment strimzi-operator -n operators", &executor.CommandResult{
+		Stdout: `{"spec": {"selector": {"matchLabels": {"app": "strimzi"}}}}`,
+	})
+
+	target, err := provider.ResolveApplication(context.Background(), "kafka")
+	require.NoError(t, err)
+	assert.Equal(t, []logs.Selector{
+		{Namespace: "datasources", Labels: "app=kafka"},
+		{Namespace: "operators", Labels: "app=strimzi"},
+	}, target.Selectors)
+}
+
+func TestFormatSelector(t *testing.T) {
+	assert.Equal(t, "", formatSelector(nil))
+	assert.Equal(t, "app=kafka", formatSelector(map[string]string{"app": "kafka"}))
+	assert.Equal(t, "a=1,b=2,c=3", formatSelector(map[string]string{"c": "3", "a": "1", "b": "2"}))
+}
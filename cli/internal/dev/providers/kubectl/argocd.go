@@ -0,0 +1,147 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/image"
+)
+
+// Ensure Provider implements the image application client
+var _ image.ApplicationClient = (*Provider)(nil)
+
+// argocdUser is recorded as the initiator of syncs triggered by the CLI
+const argocdUser = "openframe-cli"
+
+// GetApplication returns the Helm parameters, annotations and sync policy of an ArgoCD Application
+func (p *Provider) GetApplication(ctx context.Context, name string) (*image.Application, error) {
+	result, err := p.executor.Execute(ctx, "kubectl", "get", "applications.argoproj.io", name, "-n", argocdNamespace, "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application %s: %w", name, err)
+	}
+
+	var app argoApplicationJSON
+	if err := json.Unmarshal([]byte(result.Stdout), &app); err != nil {
+		return nil, fmt.Errorf("failed to parse application %s: %w", name, err)
+	}
+
+	return toImageApplication(app)
+}
+
+// ListApplications returns every ArgoCD Application in the argocd namespace
+func (p *Provider) ListApplications(ctx context.Context) ([]image.Application, error) {
+	result, err := p.executor.Execute(ctx, "kubectl", "get", "applications.argoproj.io", "-n", argocdNamespace, "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+
+	var list argoApplicationListJSON
+	if err := json.Unmarshal([]byte(result.Stdout), &list); err != nil {
+		return nil, fmt.Errorf("failed to parse applications: %w", err)
+	}
+
+	apps := make([]image.Application, 0, len(list.Items))
+	for _, item := range list.Items {
+		app, err := toImageApplication(item)
+		if err != nil {
+			if p.verbose {
+				fmt.Printf("DEBUG: Skipping application %s: %v\n", item.Metadata.Name, err)
+			}
+			continue
+		}
+		apps = append(apps, *app)
+	}
+	return apps, nil
+}
+
+// SetHelmParameters replaces the Helm parameters of the application's first source
+func (p *Provider) SetHelmParameters(ctx context.Context, app *image.Application, params []image.HelmParameter) error {
+	if params == nil {
+		params = []image.HelmParameter{}
+	}
+
+	operation := map[string]interface{}{"op": "add", "path": app.SourcePath + "/helm/parameters", "value": params}
+	if !app.HasHelm {
+		operation["path"] = app.SourcePath + "/helm"
+		operation["value"] = map[string]interface{}{"parameters": params}
+	}
+
+	return p.patchApplication(ctx, app.Name, "json", []interface{}{operation})
+}
+
+// SetAnnotations sets annotations on an application; nil values remove the annotation
+func (p *Provider) SetAnnotations(ctx context.Context, name string, annotations map[string]*string) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	}
+	return p.patchApplication(ctx, name, "merge", patch)
+}
+
+// SetAutoSync sets the automated sync policy of an application; nil disables automated sync
+func (p *Provider) SetAutoSync(ctx context.Context, name string, automated json.RawMessage) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"syncPolicy": map[string]interface{}{"automated": automated},
+		},
+	}
+	return p.patchApplication(ctx, name, "merge", patch)
+}
+
+// SyncApplication asks the ArgoCD controller to sync an application
+func (p *Provider) SyncApplication(ctx context.Context, name string) error {
+	patch := map[string]interface{}{
+		"operation": map[string]interface{}{
+			"initiatedBy": map[string]interface{}{"username": argocdUser},
+			"sync": map[string]interface{}{
+				"syncStrategy": map[string]interface{}{"hook": map[string]interface{}{}},
+			},
+		},
+	}
+	return p.patchApplication(ctx, name, "merge", patch)
+}
+
+// patchApplication applies a JSON or merge patch to an application
+func (p *Provider) patchApplication(ctx context.Context, name, patchType string, patch interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode patch for application %s: %w", name, err)
+	}
+
+	_, err = p.executor.Execute(ctx, "kubectl", "patch", "applications.argoproj.io", name, "-n", argocdNamespace, "--type", patchType, "-p", string(data))
+	if err != nil {
+		return fmt.Errorf("failed to patch application %s: %w", name, err)
+	}
+	return nil
+}
+
+// toImageApplication extracts the editable Helm source of an application
+func toImageApplication(item argoApplicationJSON) (*image.Application, error) {
+	app := &image.Application{
+		Name:        item.Metadata.Name,
+		Annotations: item.Metadata.Annotations,
+	}
+
+	var source *argoSourceJSON
+	switch {
+	case len(item.Spec.Sources) > 0:
+		source = &item.Spec.Sources[0]
+		app.SourcePath = "/spec/sources/0"
+	case item.Spec.Source != nil:
+		source = item.Spec.Source
+		app.SourcePath = "/spec/source"
+	default:
+		return nil, fmt.Errorf("application %s has no source", item.Metadata.Name)
+	}
+
+	if source.Helm != nil {
+		app.HasHelm = true
+		app.Parameters = source.Helm.Parameters
+	}
+
+	if automated := item.Spec.SyncPolicy.Automated; len(automated) > 0 && string(automated) != "null" {
+		app.AutoSync = automated
+	}
+
+	return app, nil
+}
@@ -0,0 +1,156 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/image"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_GetApplication(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	tests := []struct {
+		name         string
+		stdout       string
+		expectedPath string
+		hasHelm      bool
+		params       int
+		autoSync     bool
+		expectError  bool
+	}{
+		{
+			name: "multi-source with parameters",
+			stdout: `{"metadata":{"name":"openframe-api","annotations":{"a":"b"}},"spec":{"sources":[{"helm":{"parameters":[{"name":"replicas","value":"2"}]}}],
+				"syncPolicy":{"automated":{"prune":true,"selfHeal":true}}}}`,
+			expectedPath: "/spec/sources/0",
+			hasHelm:      true,
+			params:       1,
+			autoSync:     true,
+		},
+		{
+			name:         "single source without helm",
+			stdout:       `{"metadata":{"name":"openframe-api"},"spec":{"source":{"path":"x"},"syncPolicy":{}}}`,
+			expectedPath: "/spec/source",
+		},
+		{
+			name:         "null automated policy",
+			stdout:       `{"metadata":{"name":"openframe-api"},"spec":{"sources":[{"helm":{}}],"syncPolicy":{"automated":null}}}`,
+			expectedPath: "/spec/sources/0",
+			hasHelm:      true,
+		},
+		{name: "no source", stdout: `{"metadata":{"name":"openframe-api"},"spec":{}}`, expectError: true},
+		{name: "invalid json", stdout: "not json", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := testutil.NewTestMockExecutor()
+			mockExecutor.SetResponse("get applications.argoproj.io openframe-api", &executor.CommandResult{ExitCode: 0, Stdout: tt.stdout})
+			provider := NewProvider(mockExecutor, false)
+
+			app, err := provider.GetApplication(context.Background(), "openframe-api")
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "openframe-api", app.Name)
+			assert.Equal(t, tt.expectedPath, app.SourcePath)
+			assert.Equal(t, tt.hasHelm, app.HasHelm)
+			assert.Len(t, app.Parameters, tt.params)
+			assert.Equal(t, tt.autoSync, app.AutoSync != nil)
+		})
+	}
+}
+
+func TestProvider_ListApplications_SkipsUnsupported(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	mockExecutor.SetResponse("get applications.argoproj.io -n argocd", &executor.CommandResult{ExitCode: 0, Stdout: `{"items":[
+		{"metadata":{"name":"openframe-api"},"spec":{"sources":[{"helm":{}}]}},
+		{"metadata":{"name":"broken"},"spec":{}}]}`})
+	provider := NewProvider(mockExecutor, false)
+
+	apps, err := provider.ListApplications(context.Background())
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "openframe-api", apps[0].Name)
+}
+
+func TestProvider_SetHelmParameters(t *testing.T) {
+	testutil.InitializeTestMode()
+	params := []image.HelmParameter{{Name: "image.tag", Value: "dev-1"}}
+
+	tests := []struct {
+		name     string
+		app      image.Application
+		params   []image.HelmParameter
+		expected string
+	}{
+		{
+			name:     "replaces existing parameters",
+			app:      image.Application{Name: "openframe-api", SourcePath: "/spec/sources/0", HasHelm: true},
+			params:   params,
+			expected: `[{"op":"add","path":"/spec/sources/0/helm/parameters","value":[{"name":"image.tag","value":"dev-1"}]}]`,
+		},
+		{
+			name:     "creates helm section",
+			app:      image.Application{Name: "openframe-api", SourcePath: "/spec/source"},
+			params:   params,
+			expected: `[{"op":"add","path":"/spec/source/helm","value":{"parameters":[{"name":"image.tag","value":"dev-1"}]}}]`,
+		},
+		{
+			name:     "clears parameters",
+			app:      image.Application{Name: "openframe-api", SourcePath: "/spec/sources/0", HasHelm: true},
+			expected: `[{"op":"add","path":"/spec/sources/0/helm/parameters","value":[]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := testutil.NewTestMockExecutor()
+			provider := NewProvider(mockExecutor, false)
+
+			err := provider.SetHelmParameters(context.Background(), &tt.app, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, "kubectl patch applications.argoproj.io openframe-api -n argocd --type json -p "+tt.expected, mockExecutor.GetLastCommand())
+		})
+	}
+}
+
+func TestProvider_MergePatches(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+	ctx := context.Background()
+	value := "dev"
+
+	require.NoError(t, provider.SetAnnotations(ctx, "argocd-apps", map[string]*string{"keep": &value, "drop": nil}))
+	assert.Contains(t, mockExecutor.GetLastCommand(), `--type merge -p {"metadata":{"annotations":{"drop":null,"keep":"dev"}}}`)
+
+	require.NoError(t, provider.SetAutoSync(ctx, "argocd-apps", nil))
+	assert.Contains(t, mockExecutor.GetLastCommand(), `{"spec":{"syncPolicy":{"automated":null}}}`)
+
+	require.NoError(t, provider.SetAutoSync(ctx, "argocd-apps", json.RawMessage(`{"prune":true}`)))
+	assert.Contains(t, mockExecutor.GetLastCommand(), `{"spec":{"syncPolicy":{"automated":{"prune":true}}}}`)
+
+	require.NoError(t, provider.SyncApplication(ctx, "openframe-api"))
+	assert.Contains(t, mockExecutor.GetLastCommand(), `"initiatedBy":{"username":"openframe-cli"}`)
+}
+
+func TestProvider_PatchApplication_Failure(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	mockExecutor.SetShouldFail(true, "forbidden")
+	provider := NewProvider(mockExecutor, false)
+
+	err := provider.SyncApplication(context.Background(), "openframe-api")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to patch application openframe-api")
+}
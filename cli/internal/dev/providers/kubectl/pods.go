@@ -0,0 +1,129 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/logs"
+)
+
+// Ensure Provider implements the logs interfaces
+var (
+	_ logs.PodClient   = (*Provider)(nil)
+	_ logs.LogStreamer = (*Provider)(nil)
+)
+
+// GetPods returns the pods matching a label selector; an empty namespace searches all namespaces
+func (p *Provider) GetPods(ctx context.Context, namespace, selector string) ([]logs.PodInfo, error) {
+	args := []string{"get", "pods"}
+	if namespace == "" {
+		args = append(args, "--all-namespaces")
+	} else {
+		args = append(args, "-n", namespace)
+	}
+	if selector != "" {
+		args = append(args, "-l", selector)
+	}
+	args = append(args, "-o", "json")
+
+	result, err := p.executor.Execute(ctx, "kubectl", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+
+	var podList podListJSON
+	if err := json.Unmarshal([]byte(result.Stdout), &podList); err != nil {
+		return nil, fmt.Errorf("failed to parse pods: %w", err)
+	}
+
+	pods := make([]logs.PodInfo, 0, len(podList.Items))
+	for _, item := range podList.Items {
+		pod := logs.PodInfo{
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			Phase:     item.Status.Phase,
+		}
+		if pod.Namespace == "" {
+			pod.Namespace = namespace
+		}
+		for _, container := range item.Spec.Containers {
+			pod.Containers = append(pod.Containers, container.Name)
+		}
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// StreamLogs starts kubectl logs for one container and returns its output stream
+// The stream is read directly from the process, so it bypasses the command executor
+func (p *Provider) StreamLogs(ctx context.Context, pod logs.PodInfo, opts logs.StreamOptions) (io.ReadCloser, error) {
+	args := buildLogsArgs(pod, opts)
+	if p.verbose {
+		fmt.Printf("DEBUG: Streaming: kubectl %v\n", args)
+	}
+
+	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log stream for %s: %w", pod.Name, err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start log stream for %s: %w", pod.Name, err)
+	}
+
+	return &commandStream{ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// buildLogsArgs builds the kubectl logs arguments for a single container
+func buildLogsArgs(pod logs.PodInfo, opts logs.StreamOptions) []string {
+	args := []string{"logs", pod.Name, "-n", pod.Namespace}
+	if opts.Container != "" {
+		args = append(args, "-c", opts.Container)
+	}
+	if opts.Follow {
+		args = append(args, "--follow")
+	}
+
+	// kubectl rejects --since together with --since-time
+	if !opts.SinceTime.IsZero() {
+		args = append(args, "--since-time="+opts.SinceTime.UTC().Format(time.RFC3339))
+	} else if opts.Since != "" {
+		args = append(args, "--since="+opts.Since)
+	}
+
+	return args
+}
+
+// commandStream closes the pipe and reaps the kubectl process together
+type commandStream struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+// Close implements io.Closer
+func (s *commandStream) Close() error {
+	s.ReadCloser.Close()
+	return s.cmd.Wait()
+}
+
+// FindRunningPod returns the name of the first running pod matching a selector
+func (p *Provider) FindRunningPod(ctx context.Context, namespace, selector string) (string, error) {
+	pods, err := p.GetPods(ctx, namespace, selector)
+	if err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods {
+		if pod.Phase == "Running" {
+			return pod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no running pod matches selector '%s' in namespace '%s'", selector, namespace)
+}
@@ -0,0 +1,122 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/logs"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_GetPods(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+
+	mockExecutor.SetResponse("kubectl get pods", &executor.CommandResult{
+		ExitCode: 0,
+		Stdout: `{
+  "items": [
+    {
+      "metadata": {"name": "openframe-api-7d9f", "namespace": "microservices"},
+      "spec": {"containers": [{"name": "openframe-api"}]},
+      "status": {"phase": "Running"}
+    },
+    {
+      "metadata": {"name": "kafka-0"},
+      "spec": {"containers": [{"name": "kafka"}, {"name": "metrics"}]},
+      "status": {"phase": "Pending"}
+    }
+  ]
+}`,
+	})
+
+	pods, err := provider.GetPods(context.Background(), "microservices", "app=openframe-api")
+	require.NoError(t, err)
+	require.Len(t, pods, 2)
+
+	assert.Equal(t, logs.PodInfo{
+		Name:       "openframe-api-7d9f",
+		Namespace:  "microservices",
+		Phase:      "Running",
+		Containers: []string{"openframe-api"},
+	}, pods[0])
+	assert.Equal(t, "microservices", pods[1].Namespace, "missing namespace should default to the requested one")
+	assert.Equal(t, []string{"kafka", "metrics"}, pods[1].Containers)
+
+	assert.True(t, mockExecutor.WasCommandExecuted("kubectl get pods -n microservices -l app=openframe-api -o json"))
+}
+
+func TestProvider_GetPods_AllNamespaces(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+
+	mockExecutor.SetResponse("kubectl get pods", &executor.CommandResult{ExitCode: 0, Stdout: `{"items": []}`})
+
+	pods, err := provider.GetPods(context.Background(), "", "")
+	require.NoError(t, err)
+	assert.Empty(t, pods)
+	assert.Equal(t, "kubectl get pods --all-namespaces -o json", mockExecutor.GetLastCommand())
+}
+
+func TestProvider_GetPods_Errors(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	t.Run("kubectl failure", func(t *testing.T) {
+		mockExecutor := testutil.NewTestMockExecutor()
+		mockExecutor.SetShouldFail(true, "connection refused")
+		provider := NewProvider(mockExecutor, false)
+
+		_, err := provider.GetPods(context.Background(), "default", "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get pods")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		mockExecutor := testutil.NewTestMockExecutor()
+		mockExecutor.SetResponse("kubectl get pods", &executor.CommandResult{ExitCode: 0, Stdout: "not json"})
+		provider := NewProvider(mockExecutor, false)
+
+		_, err := provider.GetPods(context.Background(), "default", "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse pods")
+	})
+}
+
+func TestBuildLogsArgs(t *testing.T) {
+	pod := logs.PodInfo{Name: "openframe-api-0", Namespace: "microservices"}
+	sinceTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		opts     logs.StreamOptions
+		expected []string
+	}{
+		{
+			name:     "minimal",
+			opts:     logs.StreamOptions{},
+			expected: []string{"logs", "openframe-api-0", "-n", "microservices"},
+		},
+		{
+			name:     "follow with container and since",
+			opts:     logs.StreamOptions{Container: "openframe-api", Follow: true, Since: "5m"},
+			expected: []string{"logs", "openframe-api-0", "-n", "microservices", "-c", "openframe-api", "--follow", "--since=5m"},
+		},
+		{
+			name:     "since-time takes precedence",
+			opts:     logs.StreamOptions{Since: "5m", SinceTime: sinceTime},
+			expected: []string{"logs", "openframe-api-0", "-n", "microservices", "--since-time=2025-01-02T03:04:05Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, buildLogsArgs(pod, tt.opts))
+		})
+	}
+}
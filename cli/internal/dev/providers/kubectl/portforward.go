@@ -0,0 +1,64 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/datasource"
+)
+
+// Ensure Provider implements the datasource cluster client
+var _ datasource.ClusterClient = (*Provider)(nil)
+
+// portForwardTimeout bounds how long to wait for kubectl port-forward to start listening
+const portForwardTimeout = 15 * time.Second
+
+// PortForward starts kubectl port-forward in the background and waits until the local port accepts connections
+// The returned function stops the forward
+func (p *Provider) PortForward(ctx context.Context, namespace, resource string, localPort, remotePort int) (func(), error) {
+	args := []string{"port-forward", "-n", namespace, resource, fmt.Sprintf("%d:%d", localPort, remotePort)}
+	if p.verbose {
+		fmt.Printf("DEBUG: Starting: kubectl %v\n", args)
+	}
+
+	forwardCtx, cancel := context.WithCancel(ctx)
+	cmd := exec.CommandContext(forwardCtx, "kubectl", args...)
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start port-forward to %s: %w", resource, err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	stop := func() {
+		cancel()
+		<-exited
+	}
+
+	address := fmt.Sprintf("127.0.0.1:%d", localPort)
+	deadline := time.Now().Add(portForwardTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case err := <-exited:
+			cancel()
+			return nil, fmt.Errorf("port-forward to %s exited: %v", resource, err)
+		default:
+		}
+
+		conn, err := net.DialTimeout("tcp", address, 500*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			return stop, nil
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	stop()
+	return nil, fmt.Errorf("timed out waiting for port-forward to %s", resource)
+}
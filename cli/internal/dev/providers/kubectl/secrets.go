@@ -0,0 +1,29 @@
+package kubectl
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// GetSecretValue returns a decoded value from a Kubernetes secret
+func (p *Provider) GetSecretValue(ctx context.Context, namespace, name, key string) (string, error) {
+	jsonPath := fmt.Sprintf("jsonpath={.data.%s}", strings.ReplaceAll(key, ".", "\\."))
+	result, err := p.executor.Execute(ctx, "kubectl", "get", "secret", name, "-n", namespace, "-o", jsonPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+
+	encoded := strings.TrimSpace(result.Stdout)
+	if encoded == "" {
+		return "", fmt.Errorf("key %s not found in secret %s", key, name)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode key %s of secret %s: %w", key, name, err)
+	}
+
+	return string(decoded), nil
+}
@@ -0,0 +1,82 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_GetSecretValue(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	tests := []struct {
+		name        string
+		key         string
+		stdout      string
+		fail        bool
+		expected    string
+		expectError bool
+	}{
+		{name: "decodes value", key: "MONGO_INITDB_ROOT_USERNAME", stdout: "b3BlbmZyYW1l", expected: "openframe"},
+		{name: "missing key", key: "redis-password", stdout: "", expectError: true},
+		{name: "invalid base64", key: "cassandra-password", stdout: "!!!", expectError: true},
+		{name: "kubectl failure", key: "any", fail: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExecutor := testutil.NewTestMockExecutor()
+			if tt.fail {
+				mockExecutor.SetShouldFail(true, "NotFound")
+			} else {
+				mockExecutor.SetResponse("kubectl get secret", &executor.CommandResult{ExitCode: 0, Stdout: tt.stdout})
+			}
+			provider := NewProvider(mockExecutor, false)
+
+			value, err := provider.GetSecretValue(context.Background(), "datasources", "mongodb", tt.key)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestProvider_GetSecretValue_EscapesDottedKeys(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	mockExecutor.SetResponse("kubectl get secret", &executor.CommandResult{ExitCode: 0, Stdout: "dmFsdWU="})
+	provider := NewProvider(mockExecutor, false)
+
+	_, err := provider.GetSecretValue(context.Background(), "argocd", "repo", "config.json")
+	require.NoError(t, err)
+	assert.Contains(t, mockExecutor.GetLastCommand(), `jsonpath={.data.config\.json}`)
+}
+
+func TestProvider_FindRunningPod(t *testing.T) {
+	testutil.InitializeTestMode()
+	mockExecutor := testutil.NewTestMockExecutor()
+	provider := NewProvider(mockExecutor, false)
+
+	mockExecutor.SetResponse("kubectl get pods", &executor.CommandResult{
+		ExitCode: 0,
+		Stdout: `{"items": [
+  {"metadata": {"name": "mongodb-0", "namespace": "datasources"}, "status": {"phase": "Pending"}},
+  {"metadata": {"name": "mongodb-1", "namespace": "datasources"}, "status": {"phase": "Running"}}
+]}`,
+	})
+
+	pod, err := provider.FindRunningPod(context.Background(), "datasources", "app=mongodb")
+	require.NoError(t, err)
+	assert.Equal(t, "mongodb-1", pod)
+
+	mockExecutor.SetResponse("kubectl get pods", &executor.CommandResult{ExitCode: 0, Stdout: `{"items": []}`})
+	_, err = provider.FindRunningPod(context.Background(), "datasources", "app=mongodb")
+	assert.Error(t, err)
+}
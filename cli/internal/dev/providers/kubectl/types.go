@@ -1,5 +1,11 @@
 package kubectl
 
+import (
+	"encoding/json"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/image"
+)
+
 // JSON structures for parsing kubectl output
 
 type serviceJSON struct {
@@ -20,4 +26,69 @@ type serviceJSON struct {
 
 type serviceListJSON struct {
 	Items []serviceJSON `json:"items"`
-}
\ No newline at end of file
+}
+type podJSON struct {
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		Containers []struct {
+			Name string `json:"name"`
+		} `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+type podListJSON struct {
+	Items []podJSON `json:"items"`
+}
+
+type applicationJSON struct {
+	Spec struct {
+		Destination struct {
+			Namespace string `json:"namespace"`
+		} `json:"destination"`
+	} `json:"spec"`
+	Status struct {
+		Resources []struct {
+			Kind      string `json:"kind"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"resources"`
+	} `json:"status"`
+}
+
+type workloadJSON struct {
+	Spec struct {
+		Selector struct {
+			MatchLabels map[string]string `json:"matchLabels"`
+		} `json:"selector"`
+	} `json:"spec"`
+}
+
+type argoApplicationJSON struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Source     *argoSourceJSON  `json:"source"`
+		Sources    []argoSourceJSON `json:"sources"`
+		SyncPolicy struct {
+			Automated json.RawMessage `json:"automated"`
+		} `json:"syncPolicy"`
+	} `json:"spec"`
+}
+
+type argoSourceJSON struct {
+	Helm *struct {
+		Parameters []image.HelmParameter `json:"parameters"`
+	} `json:"helm"`
+}
+
+type argoApplicationListJSON struct {
+	Items []argoApplicationJSON `json:"items"`
+}
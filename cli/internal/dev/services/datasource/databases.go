@@ -0,0 +1,96 @@
+package datasource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultNamespace is where the datasources ArgoCD project deploys databases and brokers
+const DefaultNamespace = "datasources"
+
+// Database describes how to reach the client shell of a chart-deployed database
+type Database struct {
+	Name                string
+	Aliases             []string
+	Selector            string // Label selector of the pods running the database
+	Container           string
+	Secret              string // Chart-generated secret holding the credentials
+	UserKey             string // Secret key of the user name (empty uses DefaultUser)
+	DefaultUser         string
+	PasswordKey         string
+	RequiresCredentials bool // Fail instead of connecting anonymously when the secret is missing
+	Client              func(user, password string) []string
+}
+
+// databases lists the databases deployed by the datasources charts
+var databases = []Database{
+	{
+		Name:                "mongo",
+		Aliases:             []string{"mongodb", "mongosh"},
+		Selector:            "app=mongodb",
+		Container:           "mongodb",
+		Secret:              "mongodb",
+		UserKey:             "MONGO_INITDB_ROOT_USERNAME",
+		PasswordKey:         "MONGO_INITDB_ROOT_PASSWORD",
+		RequiresCredentials: true,
+		Client: func(user, password string) []string {
+			return []string{"mongosh", "--quiet", "-u", user, "-p", password, "--authenticationDatabase", "admin"}
+		},
+	},
+	{
+		Name:        "redis",
+		Aliases:     []string{"redis-cli"},
+		Selector:    "app.kubernetes.io/name=redis,app.kubernetes.io/component=master",
+		Container:   "redis",
+		Secret:      "redis",
+		PasswordKey: "redis-password",
+		Client: func(user, password string) []string {
+			if password == "" {
+				return []string{"redis-cli"}
+			}
+			return []string{"redis-cli", "--no-auth-warning", "-a", password}
+		},
+	},
+	{
+		Name:        "cassandra",
+		Aliases:     []string{"cqlsh"},
+		Selector:    "app.kubernetes.io/name=cassandra",
+		Container:   "cassandra",
+		Secret:      "cassandra",
+		DefaultUser: "cassandra",
+		PasswordKey: "cassandra-password",
+		Client: func(user, password string) []string {
+			if password == "" {
+				return []string{"cqlsh"}
+			}
+			return []string{"cqlsh", "-u", user, "-p", password}
+		},
+	},
+}
+
+// FindDatabase returns the database matching a name or alias
+func FindDatabase(name string) (*Database, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i := range databases {
+		if databases[i].Name == name {
+			return &databases[i], nil
+		}
+		for _, alias := range databases[i].Aliases {
+			if alias == name {
+				return &databases[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unsupported database: %s (supported: %s)", name, strings.Join(DatabaseNames(), ", "))
+}
+
+// DatabaseNames returns the primary names of the supported databases
+func DatabaseNames() []string {
+	names := make([]string, 0, len(databases))
+	for _, db := range databases {
+		names = append(names, db.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,48 @@
+package datasource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDatabase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"mongo", "mongo"},
+		{"mongodb", "mongo"},
+		{" MONGOSH ", "mongo"},
+		{"redis", "redis"},
+		{"cassandra", "cassandra"},
+		{"cqlsh", "cassandra"},
+	}
+
+	for _, tt := range tests {
+		db, err := FindDatabase(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, db.Name)
+	}
+
+	_, err := FindDatabase("postgres")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cassandra, mongo, redis")
+}
+
+func TestDatabaseClients(t *testing.T) {
+	redis, err := FindDatabase("redis")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"redis-cli"}, redis.Client("", ""))
+	assert.Equal(t, []string{"redis-cli", "--no-auth-warning", "-a", "pw"}, redis.Client("", "pw"))
+
+	cassandra, err := FindDatabase("cassandra")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cqlsh"}, cassandra.Client("cassandra", ""))
+	assert.False(t, cassandra.RequiresCredentials)
+
+	mongo, err := FindDatabase("mongo")
+	require.NoError(t, err)
+	assert.True(t, mongo.RequiresCredentials)
+}
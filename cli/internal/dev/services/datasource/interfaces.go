@@ -0,0 +1,36 @@
+package datasource
+
+import (
+	"context"
+	"strings"
+)
+
+// ClusterClient interface for the cluster operations the datasource tools need
+type ClusterClient interface {
+	FindRunningPod(ctx context.Context, namespace, selector string) (string, error)
+	GetSecretValue(ctx context.Context, namespace, name, key string) (string, error)
+	PortForward(ctx context.Context, namespace, resource string, localPort, remotePort int) (func(), error)
+}
+
+// CommandRunner interface for running commands attached to the user's terminal
+type CommandRunner interface {
+	RunAttached(ctx context.Context, command Command) error
+}
+
+// Command is an external command whose stdio is connected to the terminal
+type Command struct {
+	Name    string
+	Args    []string
+	Secrets []string // Values masked whenever the command is printed
+}
+
+// String returns the command line with secret values masked
+func (c Command) String() string {
+	line := strings.Join(append([]string{c.Name}, c.Args...), " ")
+	for _, secret := range c.Secrets {
+		if secret != "" {
+			line = strings.ReplaceAll(line, secret, "****")
+		}
+	}
+	return line
+}
@@ -0,0 +1,243 @@
+package datasource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	sharedUI "github.com/flamingo-stack/openframe/openframe/internal/shared/ui"
+	"github.com/pterm/pterm"
+)
+
+const (
+	kafkaSelector         = "app.kubernetes.io/name=kafka"
+	kafkaContainer        = "kafka"
+	kafkaBootstrapServer  = "kafka:9092"
+	connectService        = "svc/debezium-connect"
+	connectPort           = 8083
+	connectorStatusPath   = "/connectors?expand=status"
+	connectorStateRunning = "RUNNING"
+)
+
+// ConnectorStatus is the state of a Kafka Connect connector and its tasks
+type ConnectorStatus struct {
+	Name  string
+	Type  string
+	State string
+	Tasks []TaskStatus
+}
+
+// TaskStatus is the state of a single connector task
+type TaskStatus struct {
+	ID    int
+	State string
+	Trace string
+}
+
+// connectorStatusJSON mirrors the Kafka Connect REST API response
+type connectorStatusJSON struct {
+	Status struct {
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		Connector struct {
+			State string `json:"state"`
+		} `json:"connector"`
+		Tasks []struct {
+			ID    int    `json:"id"`
+			State string `json:"state"`
+			Trace string `json:"trace"`
+		} `json:"tasks"`
+	} `json:"status"`
+}
+
+// KafkaTopics lists the topics of the in-cluster broker
+func (s *Service) KafkaTopics(ctx context.Context, flags *models.KafkaFlags) error {
+	return s.runKafkaTool(ctx, flags, false, "kafka-topics.sh", "--bootstrap-server", kafkaBootstrapServer, "--list")
+}
+
+// KafkaConsume prints messages of a topic until interrupted or the message limit is reached
+func (s *Service) KafkaConsume(ctx context.Context, topic string, flags *models.KafkaFlags) error {
+	if strings.TrimSpace(topic) == "" {
+		return errors.New("topic cannot be empty")
+	}
+	if flags.MaxMessages < 0 {
+		return fmt.Errorf("invalid max messages: %d (must be 0 or greater)", flags.MaxMessages)
+	}
+
+	args := []string{"kafka-console-consumer.sh", "--bootstrap-server", kafkaBootstrapServer, "--topic", topic}
+	if flags.FromBeginning {
+		args = append(args, "--from-beginning")
+	}
+	if flags.MaxMessages > 0 {
+		args = append(args, "--max-messages", strconv.Itoa(flags.MaxMessages))
+	}
+	if flags.Group != "" {
+		args = append(args, "--group", flags.Group)
+	}
+
+	pterm.Info.Printf("Consuming from topic %s. Press Ctrl+C to stop\n", topic)
+	return s.runKafkaTool(ctx, flags, false, args...)
+}
+
+// KafkaProduce sends lines read from stdin to a topic
+func (s *Service) KafkaProduce(ctx context.Context, topic string, flags *models.KafkaFlags) error {
+	if strings.TrimSpace(topic) == "" {
+		return errors.New("topic cannot be empty")
+	}
+
+	pterm.Info.Printf("Producing to topic %s. Enter one message per line, Ctrl+D to finish\n", topic)
+	return s.runKafkaTool(ctx, flags, true, "kafka-console-producer.sh", "--bootstrap-server", kafkaBootstrapServer, "--topic", topic)
+}
+
+// KafkaConnectors shows Debezium Connect connector status through a temporary port-forward
+func (s *Service) KafkaConnectors(ctx context.Context, flags *models.KafkaFlags) error {
+	namespace := namespaceOrDefault(flags.Namespace)
+
+	localPort, err := freeLocalPort()
+	if err != nil {
+		return fmt.Errorf("failed to find a free local port: %w", err)
+	}
+
+	stop, err := s.client.PortForward(ctx, namespace, connectService, localPort, connectPort)
+	if err != nil {
+		return fmt.Errorf("failed to port-forward to Debezium Connect: %w", err)
+	}
+	defer stop()
+
+	connectors, err := s.FetchConnectorStatus(ctx, fmt.Sprintf("http://127.0.0.1:%d", localPort))
+	if err != nil {
+		return err
+	}
+
+	s.displayConnectors(connectors)
+	return nil
+}
+
+// FetchConnectorStatus queries the Kafka Connect REST API for every connector
+func (s *Service) FetchConnectorStatus(ctx context.Context, baseURL string) ([]ConnectorStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+connectorStatusPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build connector status request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Debezium Connect: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("debezium Connect returned HTTP %d", resp.StatusCode)
+	}
+
+	var payload map[string]connectorStatusJSON
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to parse connector status: %w", err)
+	}
+
+	connectors := make([]ConnectorStatus, 0, len(payload))
+	for name, item := range payload {
+		connector := ConnectorStatus{
+			Name:  name,
+			Type:  item.Status.Type,
+			State: item.Status.Connector.State,
+		}
+		for _, task := range item.Status.Tasks {
+			connector.Tasks = append(connector.Tasks, TaskStatus{ID: task.ID, State: task.State, Trace: task.Trace})
+		}
+		connectors = append(connectors, connector)
+	}
+
+	sort.Slice(connectors, func(i, j int) bool {
+		return connectors[i].Name < connectors[j].Name
+	})
+	return connectors, nil
+}
+
+// displayConnectors renders the connector table and the first line of any task failure
+func (s *Service) displayConnectors(connectors []ConnectorStatus) {
+	if len(connectors) == 0 {
+		pterm.Info.Println("No connectors registered in Debezium Connect")
+		return
+	}
+
+	tableData := pterm.TableData{{"CONNECTOR", "TYPE", "STATE", "TASKS"}}
+	for _, connector := range connectors {
+		running := 0
+		for _, task := range connector.Tasks {
+			if task.State == connectorStateRunning {
+				running++
+			}
+		}
+		tableData = append(tableData, []string{
+			connector.Name,
+			connector.Type,
+			stateColor(connector.State),
+			fmt.Sprintf("%d/%d running", running, len(connector.Tasks)),
+		})
+	}
+
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		for _, row := range tableData[1:] {
+			fmt.Printf("%s\t%s\t%s\t%s\n", row[0], row[1], row[2], row[3])
+		}
+	}
+
+	for _, connector := range connectors {
+		for _, task := range connector.Tasks {
+			if task.Trace == "" {
+				continue
+			}
+			firstLine := strings.SplitN(task.Trace, "\n", 2)[0]
+			pterm.Error.Printf("%s task %d: %s\n", connector.Name, task.ID, firstLine)
+		}
+	}
+}
+
+// runKafkaTool runs a Kafka CLI script inside a broker pod
+func (s *Service) runKafkaTool(ctx context.Context, flags *models.KafkaFlags, stdin bool, tool ...string) error {
+	namespace := namespaceOrDefault(flags.Namespace)
+
+	pod, err := s.client.FindRunningPod(ctx, namespace, kafkaSelector)
+	if err != nil {
+		return fmt.Errorf("failed to find kafka pod in namespace %s: %w", namespace, err)
+	}
+
+	command := s.execCommand(namespace, pod, kafkaContainer, stdin, tool)
+	if s.verbose {
+		pterm.Debug.Printf("Running: %s\n", command.String())
+	}
+
+	return s.runner.RunAttached(ctx, command)
+}
+
+// stateColor colours Kafka Connect states using the shared status palette
+func stateColor(state string) string {
+	switch state {
+	case connectorStateRunning:
+		return sharedUI.GetStatusColor("running")(state)
+	case "PAUSED", "UNASSIGNED":
+		return sharedUI.GetStatusColor("pending")(state)
+	case "FAILED":
+		return sharedUI.GetStatusColor("failed")(state)
+	default:
+		return state
+	}
+}
+
+// freeLocalPort asks the OS for an unused local TCP port
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
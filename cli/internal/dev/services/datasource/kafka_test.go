@@ -0,0 +1,79 @@
+package datasource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_FetchConnectorStatus(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/connectors", r.URL.Path)
+		assert.Equal(t, "status", r.URL.Query().Get("expand"))
+		w.Write([]byte(`{
+  "mongo-connector": {"status": {"name": "mongo-connector", "type": "source",
+    "connector": {"state": "RUNNING"},
+    "tasks": [{"id": 0, "state": "FAILED", "trace": "org.apache.kafka.connect.errors.ConnectException: boom\n\tat x"}]}},
+  "cassandra-sink": {"status": {"name": "cassandra-sink", "type": "sink",
+    "connector": {"state": "RUNNING"},
+    "tasks": [{"id": 0, "state": "RUNNING"}]}}
+}`))
+	}))
+	defer server.Close()
+
+	service := newTestService(&fakeClusterClient{}, &fakeRunner{}, false)
+	connectors, err := service.FetchConnectorStatus(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Len(t, connectors, 2)
+
+	assert.Equal(t, "cassandra-sink", connectors[0].Name)
+	assert.Equal(t, "sink", connectors[0].Type)
+	assert.Equal(t, []TaskStatus{{ID: 0, State: "RUNNING"}}, connectors[0].Tasks)
+
+	assert.Equal(t, "mongo-connector", connectors[1].Name)
+	assert.Equal(t, "FAILED", connectors[1].Tasks[0].State)
+	assert.Contains(t, connectors[1].Tasks[0].Trace, "ConnectException")
+
+	// Rendering must not panic, including failed task traces
+	service.displayConnectors(connectors)
+	service.displayConnectors(nil)
+}
+
+func TestService_FetchConnectorStatus_Errors(t *testing.T) {
+	testutil.InitializeTestMode()
+	service := newTestService(&fakeClusterClient{}, &fakeRunner{}, false)
+
+	t.Run("http error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := service.FetchConnectorStatus(context.Background(), server.URL)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("not json"))
+		}))
+		defer server.Close()
+
+		_, err := service.FetchConnectorStatus(context.Background(), server.URL)
+		assert.Error(t, err)
+	})
+}
+
+func TestFreeLocalPort(t *testing.T) {
+	port, err := freeLocalPort()
+	require.NoError(t, err)
+	assert.Greater(t, port, 0)
+}
@@ -0,0 +1,40 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// AttachedRunner runs commands with the terminal's stdin, stdout and stderr
+type AttachedRunner struct {
+	dryRun bool
+}
+
+// NewAttachedRunner creates a runner; in dry-run mode commands are printed instead of run
+func NewAttachedRunner(dryRun bool) *AttachedRunner {
+	return &AttachedRunner{dryRun: dryRun}
+}
+
+// RunAttached implements CommandRunner
+func (r *AttachedRunner) RunAttached(ctx context.Context, command Command) error {
+	if r.dryRun {
+		fmt.Printf("Would run: %s\n", command.String())
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, command.Name, command.Args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		// Interrupting an interactive client is how users normally leave it
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("%s exited with error: %w", command.Name, err)
+	}
+	return nil
+}
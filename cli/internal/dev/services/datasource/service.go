@@ -0,0 +1,112 @@
+package datasource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+)
+
+// Service opens database shells and runs Kafka tools inside the cluster
+type Service struct {
+	client     ClusterClient
+	runner     CommandRunner
+	verbose    bool
+	httpClient *http.Client
+	isTerminal func() bool
+}
+
+// NewService creates a new datasource service
+func NewService(client ClusterClient, runner CommandRunner, verbose bool) *Service {
+	return &Service{
+		client:     client,
+		runner:     runner,
+		verbose:    verbose,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		isTerminal: func() bool { return term.IsTerminal(int(os.Stdin.Fd())) },
+	}
+}
+
+// OpenShell execs the database client inside its pod, passing any extra client arguments
+func (s *Service) OpenShell(ctx context.Context, name string, extraArgs []string, flags *models.DatabaseFlags) error {
+	db, err := FindDatabase(name)
+	if err != nil {
+		return err
+	}
+
+	namespace := namespaceOrDefault(flags.Namespace)
+
+	pod, err := s.client.FindRunningPod(ctx, namespace, db.Selector)
+	if err != nil {
+		return fmt.Errorf("failed to find %s pod in namespace %s: %w", db.Name, namespace, err)
+	}
+
+	user, password, err := s.readCredentials(ctx, namespace, db)
+	if err != nil {
+		return err
+	}
+
+	client := append(db.Client(user, password), extraArgs...)
+	command := s.execCommand(namespace, pod, db.Container, true, client)
+	command.Secrets = []string{password}
+
+	pterm.Info.Printf("Connecting to %s in pod %s/%s\n", db.Name, namespace, pod)
+	if s.verbose {
+		pterm.Debug.Printf("Running: %s\n", command.String())
+	}
+
+	return s.runner.RunAttached(ctx, command)
+}
+
+// readCredentials reads the user and password from the chart-generated secret
+func (s *Service) readCredentials(ctx context.Context, namespace string, db *Database) (string, string, error) {
+	user := db.DefaultUser
+	if db.UserKey != "" {
+		value, err := s.client.GetSecretValue(ctx, namespace, db.Secret, db.UserKey)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read %s user from secret %s: %w", db.Name, db.Secret, err)
+		}
+		user = value
+	}
+
+	password, err := s.client.GetSecretValue(ctx, namespace, db.Secret, db.PasswordKey)
+	if err != nil {
+		if db.RequiresCredentials {
+			return "", "", fmt.Errorf("failed to read %s password from secret %s: %w", db.Name, db.Secret, err)
+		}
+		if s.verbose {
+			pterm.Debug.Printf("No %s credentials found in secret %s, connecting without authentication\n", db.Name, db.Secret)
+		}
+		return user, "", nil
+	}
+
+	return user, password, nil
+}
+
+// execCommand builds kubectl exec for a container, allocating a TTY only when stdin is a terminal
+func (s *Service) execCommand(namespace, pod, container string, stdin bool, client []string) Command {
+	args := []string{"exec"}
+	if stdin {
+		args = append(args, "-i")
+		if s.isTerminal() {
+			args = append(args, "-t")
+		}
+	}
+	args = append(args, "-n", namespace, pod, "-c", container, "--")
+	args = append(args, client...)
+
+	return Command{Name: "kubectl", Args: args}
+}
+
+// namespaceOrDefault falls back to the datasources namespace
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return DefaultNamespace
+	}
+	return namespace
+}
@@ -0,0 +1,222 @@
+package datasource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClusterClient serves pods and secrets from maps
+type fakeClusterClient struct {
+	pods        map[string]string // selector -> pod name
+	secrets     map[string]string // secret/key -> value
+	forwarded   []string
+	forwardErr  error
+	stopCalled  bool
+	podSelector string
+}
+
+func (f *fakeClusterClient) FindRunningPod(ctx context.Context, namespace, selector string) (string, error) {
+	f.podSelector = selector
+	if pod, ok := f.pods[selector]; ok {
+		return pod, nil
+	}
+	return "", errors.New("no running pod")
+}
+
+func (f *fakeClusterClient) GetSecretValue(ctx context.Context, namespace, name, key string) (string, error) {
+	if value, ok := f.secrets[name+"/"+key]; ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("secret %s not found", name)
+}
+
+func (f *fakeClusterClient) PortForward(ctx context.Context, namespace, resource string, localPort, remotePort int) (func(), error) {
+	f.forwarded = append(f.forwarded, fmt.Sprintf("%s/%s:%d", namespace, resource, remotePort))
+	if f.forwardErr != nil {
+		return nil, f.forwardErr
+	}
+	return func() { f.stopCalled = true }, nil
+}
+
+// fakeRunner records attached commands instead of running them
+type fakeRunner struct {
+	commands []Command
+	err      error
+}
+
+func (f *fakeRunner) RunAttached(ctx context.Context, command Command) error {
+	f.commands = append(f.commands, command)
+	return f.err
+}
+
+func newTestService(client *fakeClusterClient, runner *fakeRunner, tty bool) *Service {
+	service := NewService(client, runner, false)
+	service.isTerminal = func() bool { return tty }
+	return service
+}
+
+func TestService_OpenShell(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	client := &fakeClusterClient{
+		pods: map[string]string{
+			"app=mongodb": "mongodb-0",
+			"app.kubernetes.io/name=redis,app.kubernetes.io/component=master": "redis-master-0",
+			"app.kubernetes.io/name=cassandra":                                "cassandra-0",
+		},
+		secrets: map[string]string{
+			"mongodb/MONGO_INITDB_ROOT_USERNAME": "openframe",
+			"mongodb/MONGO_INITDB_ROOT_PASSWORD": "s3cret",
+			"cassandra/cassandra-password":       "cass-pw",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		database     string
+		extraArgs    []string
+		tty          bool
+		expectedArgs []string
+		secret       string
+	}{
+		{
+			name:     "mongo with credentials",
+			database: "mongo",
+			tty:      true,
+			expectedArgs: []string{"exec", "-i", "-t", "-n", "datasources", "mongodb-0", "-c", "mongodb", "--",
+				"mongosh", "--quiet", "-u", "openframe", "-p", "s3cret", "--authenticationDatabase", "admin"},
+			secret: "s3cret",
+		},
+		{
+			name:      "redis without auth and extra args",
+			database:  "redis",
+			extraArgs: []string{"KEYS", "*"},
+			expectedArgs: []string{"exec", "-i", "-n", "datasources", "redis-master-0", "-c", "redis", "--",
+				"redis-cli", "KEYS", "*"},
+		},
+		{
+			name:     "cassandra alias with default user",
+			database: "cqlsh",
+			tty:      true,
+			expectedArgs: []string{"exec", "-i", "-t", "-n", "datasources", "cassandra-0", "-c", "cassandra", "--",
+				"cqlsh", "-u", "cassandra", "-p", "cass-pw"},
+			secret: "cass-pw",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{}
+			service := newTestService(client, runner, tt.tty)
+
+			err := service.OpenShell(context.Background(), tt.database, tt.extraArgs, &models.DatabaseFlags{})
+			require.NoError(t, err)
+			require.Len(t, runner.commands, 1)
+
+			command := runner.commands[0]
+			assert.Equal(t, "kubectl", command.Name)
+			assert.Equal(t, tt.expectedArgs, command.Args)
+			if tt.secret != "" {
+				assert.NotContains(t, command.String(), tt.secret)
+			}
+		})
+	}
+}
+
+func TestService_OpenShell_Errors(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	t.Run("unsupported database", func(t *testing.T) {
+		service := newTestService(&fakeClusterClient{}, &fakeRunner{}, false)
+		err := service.OpenShell(context.Background(), "postgres", nil, &models.DatabaseFlags{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported database")
+	})
+
+	t.Run("no running pod", func(t *testing.T) {
+		service := newTestService(&fakeClusterClient{}, &fakeRunner{}, false)
+		err := service.OpenShell(context.Background(), "mongo", nil, &models.DatabaseFlags{Namespace: "custom"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "namespace custom")
+	})
+
+	t.Run("mongo secret missing", func(t *testing.T) {
+		client := &fakeClusterClient{pods: map[string]string{"app=mongodb": "mongodb-0"}}
+		runner := &fakeRunner{}
+		service := newTestService(client, runner, false)
+
+		err := service.OpenShell(context.Background(), "mongo", nil, &models.DatabaseFlags{})
+		assert.Error(t, err)
+		assert.Empty(t, runner.commands)
+	})
+}
+
+func TestService_KafkaCommands(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	client := &fakeClusterClient{pods: map[string]string{kafkaSelector: "kafka-controller-0"}}
+
+	t.Run("topics", func(t *testing.T) {
+		runner := &fakeRunner{}
+		service := newTestService(client, runner, true)
+
+		require.NoError(t, service.KafkaTopics(context.Background(), &models.KafkaFlags{}))
+		require.Len(t, runner.commands, 1)
+		assert.Equal(t, []string{"exec", "-n", "datasources", "kafka-controller-0", "-c", "kafka", "--",
+			"kafka-topics.sh", "--bootstrap-server", "kafka:9092", "--list"}, runner.commands[0].Args)
+	})
+
+	t.Run("consume with options", func(t *testing.T) {
+		runner := &fakeRunner{}
+		service := newTestService(client, runner, true)
+
+		flags := &models.KafkaFlags{FromBeginning: true, MaxMessages: 10, Group: "debug"}
+		require.NoError(t, service.KafkaConsume(context.Background(), "devices-topic", flags))
+		require.Len(t, runner.commands, 1)
+		assert.Equal(t, []string{"exec", "-n", "datasources", "kafka-controller-0", "-c", "kafka", "--",
+			"kafka-console-consumer.sh", "--bootstrap-server", "kafka:9092", "--topic", "devices-topic",
+			"--from-beginning", "--max-messages", "10", "--group", "debug"}, runner.commands[0].Args)
+	})
+
+	t.Run("produce attaches stdin", func(t *testing.T) {
+		runner := &fakeRunner{}
+		service := newTestService(client, runner, false)
+
+		require.NoError(t, service.KafkaProduce(context.Background(), "devices-topic", &models.KafkaFlags{Namespace: "kafka-ns"}))
+		require.Len(t, runner.commands, 1)
+		assert.Equal(t, []string{"exec", "-i", "-n", "kafka-ns", "kafka-controller-0", "-c", "kafka", "--",
+			"kafka-console-producer.sh", "--bootstrap-server", "kafka:9092", "--topic", "devices-topic"}, runner.commands[0].Args)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		service := newTestService(client, &fakeRunner{}, false)
+
+		assert.Error(t, service.KafkaConsume(context.Background(), "", &models.KafkaFlags{}))
+		assert.Error(t, service.KafkaConsume(context.Background(), "topic", &models.KafkaFlags{MaxMessages: -1}))
+		assert.Error(t, service.KafkaProduce(context.Background(), " ", &models.KafkaFlags{}))
+	})
+}
+
+func TestService_KafkaConnectors_PortForwardFailure(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	client := &fakeClusterClient{forwardErr: errors.New("service not found")}
+	service := newTestService(client, &fakeRunner{}, false)
+
+	err := service.KafkaConnectors(context.Background(), &models.KafkaFlags{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Debezium Connect")
+	assert.Equal(t, []string{"datasources/svc/debezium-connect:8083"}, client.forwarded)
+}
+
+func TestCommand_String(t *testing.T) {
+	command := Command{Name: "kubectl", Args: []string{"exec", "--", "mongosh", "-p", "hunter2"}, Secrets: []string{"hunter2", ""}}
+	assert.Equal(t, "kubectl exec -- mongosh -p ****", command.String())
+}
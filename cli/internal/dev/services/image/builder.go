@@ -0,0 +1,64 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// DockerBuilder builds images with docker and imports them with k3d
+type DockerBuilder struct {
+	executor executor.CommandExecutor
+}
+
+// NewDockerBuilder creates a builder backed by the docker and k3d CLIs
+func NewDockerBuilder(exec executor.CommandExecutor) *DockerBuilder {
+	return &DockerBuilder{executor: exec}
+}
+
+// ImageExists reports whether the image is present in the local docker daemon
+func (b *DockerBuilder) ImageExists(ctx context.Context, ref string) bool {
+	_, err := b.executor.Execute(ctx, "docker", "image", "inspect", "--format", "{{.Id}}", ref)
+	return err == nil
+}
+
+// Build builds the Dockerfile with its directory as the build context unless one is given
+func (b *DockerBuilder) Build(ctx context.Context, ref, dockerfile, contextDir string) error {
+	if contextDir == "" {
+		contextDir = filepath.Dir(dockerfile)
+	}
+
+	result, err := b.executor.Execute(ctx, "docker", "build", "-t", ref, "-f", dockerfile, contextDir)
+	if err != nil {
+		return fmt.Errorf("docker build failed: %w%s", err, stderrSuffix(result))
+	}
+	return nil
+}
+
+// Tag adds a new tag to an existing local image
+func (b *DockerBuilder) Tag(ctx context.Context, source, target string) error {
+	result, err := b.executor.Execute(ctx, "docker", "tag", source, target)
+	if err != nil {
+		return fmt.Errorf("failed to tag %s as %s: %w%s", source, target, err, stderrSuffix(result))
+	}
+	return nil
+}
+
+// Import copies a local image into every node of a k3d cluster
+func (b *DockerBuilder) Import(ctx context.Context, ref, cluster string) error {
+	result, err := b.executor.Execute(ctx, "k3d", "image", "import", ref, "-c", cluster)
+	if err != nil {
+		return fmt.Errorf("failed to import %s into cluster %s: %w%s", ref, cluster, err, stderrSuffix(result))
+	}
+	return nil
+}
+
+// stderrSuffix appends the command's stderr to an error message when there is any
+func stderrSuffix(result *executor.CommandResult) string {
+	if result == nil || result.Stderr == "" {
+		return ""
+	}
+	return "\n" + result.Stderr
+}
@@ -0,0 +1,42 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// HelmParameter is a single Helm parameter override of an ArgoCD Application
+type HelmParameter struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	ForceString bool   `json:"forceString,omitempty"`
+}
+
+// Application is the part of an ArgoCD Application the image swap reads and edits
+type Application struct {
+	Name        string
+	Annotations map[string]string
+	Parameters  []HelmParameter
+	SourcePath  string          // JSON pointer of the Helm source, e.g. /spec/sources/0
+	HasHelm     bool            // Whether the source already has a helm section
+	AutoSync    json.RawMessage // Raw spec.syncPolicy.automated, nil when syncing manually
+}
+
+// ApplicationClient reads and patches ArgoCD Applications
+type ApplicationClient interface {
+	GetCurrentContext(ctx context.Context) (string, error)
+	GetApplication(ctx context.Context, name string) (*Application, error)
+	ListApplications(ctx context.Context) ([]Application, error)
+	SetHelmParameters(ctx context.Context, app *Application, params []HelmParameter) error
+	SetAnnotations(ctx context.Context, name string, annotations map[string]*string) error
+	SetAutoSync(ctx context.Context, name string, automated json.RawMessage) error
+	SyncApplication(ctx context.Context, name string) error
+}
+
+// ImageBuilder builds local images and loads them into the cluster nodes
+type ImageBuilder interface {
+	ImageExists(ctx context.Context, ref string) bool
+	Build(ctx context.Context, ref, dockerfile, contextDir string) error
+	Tag(ctx context.Context, source, target string) error
+	Import(ctx context.Context, ref, cluster string) error
+}
@@ -0,0 +1,365 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/pterm/pterm"
+)
+
+const (
+	// ParentApplication is the app-of-apps whose self-heal would revert child Application edits
+	ParentApplication = "argocd-apps"
+	// DefaultRepoParam is the image repository value used by the OpenFrame charts
+	DefaultRepoParam = "image.repo"
+	// DefaultTagParam is the image tag value used by the OpenFrame charts
+	DefaultTagParam = "image.tag"
+
+	devRepositoryPrefix = "openframe-dev/"
+	devTagPrefix        = "dev-"
+	k3dContextPrefix    = "k3d-"
+
+	// Annotations keep the swap state on the cluster so reset works from any shell
+	originalParamsAnnotation = "openframe.io/dev-image-original-parameters"
+	imageAnnotation          = "openframe.io/dev-image"
+	pausedSyncAnnotation     = "openframe.io/dev-image-paused-sync"
+)
+
+// Service swaps ArgoCD application images for locally built ones
+type Service struct {
+	apps    ApplicationClient
+	builder ImageBuilder
+	verbose bool
+	dryRun  bool
+	now     func() time.Time
+}
+
+// NewService creates a new image service
+func NewService(apps ApplicationClient, builder ImageBuilder, verbose, dryRun bool) *Service {
+	return &Service{
+		apps:    apps,
+		builder: builder,
+		verbose: verbose,
+		dryRun:  dryRun,
+		now:     time.Now,
+	}
+}
+
+// swapPlan is a validated swap ready to be applied
+type swapPlan struct {
+	app    *Application
+	source *Source
+}
+
+// Set builds or retags each image, imports it into the cluster and points the application at it
+func (s *Service) Set(ctx context.Context, args []string, flags *models.ImageFlags) error {
+	swaps, err := ParseSwaps(args)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := s.resolveCluster(ctx, flags.Cluster)
+	if err != nil {
+		return err
+	}
+
+	// Validate every swap before touching the cluster so a typo does not leave a partial result
+	plans := make([]swapPlan, 0, len(swaps))
+	for _, swap := range swaps {
+		app, err := s.apps.GetApplication(ctx, swap.App)
+		if err != nil {
+			return fmt.Errorf("application %s not found: %w", swap.App, err)
+		}
+
+		source, err := ResolveSource(swap.Source)
+		if err != nil {
+			return err
+		}
+		if source.Image != "" && !s.builder.ImageExists(ctx, source.Image) {
+			return fmt.Errorf("image %s not found in the local docker daemon", source.Image)
+		}
+
+		plans = append(plans, swapPlan{app: app, source: source})
+	}
+
+	// A unique tag makes the Deployment roll and keeps the default IfNotPresent pull policy
+	tag := devTagPrefix + s.now().Format("20060102150405")
+	repoParam, tagParam := parameterNames(flags)
+
+	if s.dryRun {
+		for _, plan := range plans {
+			ref := devImageRef(plan.app.Name, tag)
+			if plan.source.Dockerfile != "" {
+				fmt.Printf("Would build %s from %s\n", ref, plan.source.Dockerfile)
+			} else {
+				fmt.Printf("Would tag %s as %s\n", plan.source.Image, ref)
+			}
+			fmt.Printf("Would import %s into k3d cluster %s\n", ref, cluster)
+			fmt.Printf("Would set %s=%s and %s=%s on application %s\n", repoParam, devRepositoryPrefix+plan.app.Name, tagParam, tag, plan.app.Name)
+		}
+		return nil
+	}
+
+	if err := s.pauseParentSync(ctx); err != nil {
+		return err
+	}
+
+	for _, plan := range plans {
+		if err := s.apply(ctx, plan, cluster, tag, repoParam, tagParam); err != nil {
+			return err
+		}
+	}
+
+	pterm.Info.Println("Run 'openframe dev image reset' to restore the original images")
+	return nil
+}
+
+// apply builds, imports and deploys a single swap
+func (s *Service) apply(ctx context.Context, plan swapPlan, cluster, tag, repoParam, tagParam string) error {
+	app := plan.app
+	ref := devImageRef(app.Name, tag)
+
+	if plan.source.Dockerfile != "" {
+		pterm.Info.Printf("Building %s from %s\n", ref, plan.source.Dockerfile)
+		if err := s.builder.Build(ctx, ref, plan.source.Dockerfile, plan.source.ContextDir); err != nil {
+			return err
+		}
+	} else if err := s.builder.Tag(ctx, plan.source.Image, ref); err != nil {
+		return err
+	}
+
+	pterm.Info.Printf("Importing %s into k3d cluster %s\n", ref, cluster)
+	if err := s.builder.Import(ctx, ref, cluster); err != nil {
+		return err
+	}
+
+	// Only the first swap records the parameters, later swaps must not overwrite the original
+	annotations := map[string]*string{imageAnnotation: &ref}
+	if _, saved := app.Annotations[originalParamsAnnotation]; !saved {
+		original, err := json.Marshal(app.Parameters)
+		if err != nil {
+			return fmt.Errorf("failed to record original parameters of %s: %w", app.Name, err)
+		}
+		value := string(original)
+		annotations[originalParamsAnnotation] = &value
+	}
+	if err := s.apps.SetAnnotations(ctx, app.Name, annotations); err != nil {
+		return fmt.Errorf("failed to annotate application %s: %w", app.Name, err)
+	}
+
+	params := withParameter(app.Parameters, repoParam, devRepositoryPrefix+app.Name)
+	params = withParameter(params, tagParam, tag)
+	if err := s.apps.SetHelmParameters(ctx, app, params); err != nil {
+		return fmt.Errorf("failed to update application %s: %w", app.Name, err)
+	}
+
+	if err := s.syncIfManual(ctx, app); err != nil {
+		return err
+	}
+
+	pterm.Success.Printf("%s now runs %s\n", app.Name, ref)
+	return nil
+}
+
+// Reset restores the original parameters of the given applications, or of all swapped ones
+func (s *Service) Reset(ctx context.Context, names []string) error {
+	apps, err := s.swappedApplications(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	if s.dryRun {
+		for _, app := range apps {
+			fmt.Printf("Would restore the original image of application %s\n", app.Name)
+		}
+		fmt.Printf("Would resume automated sync of %s once no application uses a local image\n", ParentApplication)
+		return nil
+	}
+
+	if len(apps) == 0 {
+		pterm.Info.Println("No applications are using a local image")
+	}
+
+	for i := range apps {
+		app := &apps[i]
+
+		var original []HelmParameter
+		if err := json.Unmarshal([]byte(app.Annotations[originalParamsAnnotation]), &original); err != nil {
+			return fmt.Errorf("failed to read original parameters of %s: %w", app.Name, err)
+		}
+
+		if err := s.apps.SetHelmParameters(ctx, app, original); err != nil {
+			return fmt.Errorf("failed to restore application %s: %w", app.Name, err)
+		}
+		if err := s.apps.SetAnnotations(ctx, app.Name, map[string]*string{originalParamsAnnotation: nil, imageAnnotation: nil}); err != nil {
+			return fmt.Errorf("failed to clear annotations of application %s: %w", app.Name, err)
+		}
+		if err := s.syncIfManual(ctx, app); err != nil {
+			return err
+		}
+
+		pterm.Success.Printf("%s restored to its original image\n", app.Name)
+	}
+
+	remaining, err := s.swappedApplications(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		if s.verbose {
+			pterm.Debug.Printf("%d application(s) still use a local image, keeping %s paused\n", len(remaining), ParentApplication)
+		}
+		return nil
+	}
+
+	return s.resumeParentSync(ctx)
+}
+
+// swappedApplications returns the named applications, or every application carrying a swap
+func (s *Service) swappedApplications(ctx context.Context, names []string) ([]Application, error) {
+	if len(names) == 0 {
+		all, err := s.apps.ListApplications(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list applications: %w", err)
+		}
+
+		var swapped []Application
+		for _, app := range all {
+			if _, ok := app.Annotations[originalParamsAnnotation]; ok {
+				swapped = append(swapped, app)
+			}
+		}
+		return swapped, nil
+	}
+
+	apps := make([]Application, 0, len(names))
+	for _, name := range names {
+		app, err := s.apps.GetApplication(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("application %s not found: %w", name, err)
+		}
+		if _, ok := app.Annotations[originalParamsAnnotation]; !ok {
+			return nil, fmt.Errorf("application %s is not using a local image", name)
+		}
+		apps = append(apps, *app)
+	}
+	return apps, nil
+}
+
+// pauseParentSync stops the app-of-apps from reverting the patched child Applications
+func (s *Service) pauseParentSync(ctx context.Context) error {
+	parent, err := s.apps.GetApplication(ctx, ParentApplication)
+	if err != nil {
+		if s.verbose {
+			pterm.Debug.Printf("No %s application found, nothing to pause: %v\n", ParentApplication, err)
+		}
+		return nil
+	}
+	if len(parent.AutoSync) == 0 {
+		return nil
+	}
+
+	saved := string(parent.AutoSync)
+	if err := s.apps.SetAnnotations(ctx, ParentApplication, map[string]*string{pausedSyncAnnotation: &saved}); err != nil {
+		return fmt.Errorf("failed to record sync policy of %s: %w", ParentApplication, err)
+	}
+	if err := s.apps.SetAutoSync(ctx, ParentApplication, nil); err != nil {
+		return fmt.Errorf("failed to pause automated sync of %s: %w", ParentApplication, err)
+	}
+
+	pterm.Warning.Printf("Paused automated sync of %s until the images are reset\n", ParentApplication)
+	return nil
+}
+
+// resumeParentSync restores the app-of-apps sync policy recorded by pauseParentSync
+func (s *Service) resumeParentSync(ctx context.Context) error {
+	parent, err := s.apps.GetApplication(ctx, ParentApplication)
+	if err != nil {
+		return nil
+	}
+	saved, ok := parent.Annotations[pausedSyncAnnotation]
+	if !ok {
+		return nil
+	}
+
+	if err := s.apps.SetAutoSync(ctx, ParentApplication, json.RawMessage(saved)); err != nil {
+		return fmt.Errorf("failed to resume automated sync of %s: %w", ParentApplication, err)
+	}
+	if err := s.apps.SetAnnotations(ctx, ParentApplication, map[string]*string{pausedSyncAnnotation: nil}); err != nil {
+		return fmt.Errorf("failed to clear annotations of %s: %w", ParentApplication, err)
+	}
+
+	pterm.Success.Printf("Resumed automated sync of %s\n", ParentApplication)
+	return nil
+}
+
+// syncIfManual triggers a sync when ArgoCD would not pick up the change on its own
+func (s *Service) syncIfManual(ctx context.Context, app *Application) error {
+	if len(app.AutoSync) > 0 {
+		return nil
+	}
+	if err := s.apps.SyncApplication(ctx, app.Name); err != nil {
+		return fmt.Errorf("failed to sync application %s: %w", app.Name, err)
+	}
+	return nil
+}
+
+// resolveCluster returns the k3d cluster name from the flag or the current kubectl context
+func (s *Service) resolveCluster(ctx context.Context, cluster string) (string, error) {
+	current, err := s.apps.GetCurrentContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The command targets --cluster, so any other context would patch one cluster and
+	// import into another
+	if cluster != "" {
+		if current != k3dContextPrefix+cluster {
+			return "", fmt.Errorf("kubectl context is %s, not the one of k3d cluster %s", current, cluster)
+		}
+		return cluster, nil
+	}
+
+	if !strings.HasPrefix(current, k3dContextPrefix) {
+		return "", fmt.Errorf("current kubectl context %s is not a k3d cluster, use --cluster to name it", current)
+	}
+	return strings.TrimPrefix(current, k3dContextPrefix), nil
+}
+
+// parameterNames returns the Helm parameters holding the image, falling back to the chart defaults
+func parameterNames(flags *models.ImageFlags) (string, string) {
+	repoParam, tagParam := flags.RepoParam, flags.TagParam
+	if repoParam == "" {
+		repoParam = DefaultRepoParam
+	}
+	if tagParam == "" {
+		tagParam = DefaultTagParam
+	}
+	return repoParam, tagParam
+}
+
+// withParameter returns a copy of params with name set to value
+func withParameter(params []HelmParameter, name, value string) []HelmParameter {
+	result := make([]HelmParameter, 0, len(params)+1)
+	found := false
+	for _, param := range params {
+		if param.Name == name {
+			param.Value = value
+			found = true
+		}
+		result = append(result, param)
+	}
+	if !found {
+		result = append(result, HelmParameter{Name: name, Value: value})
+	}
+	return result
+}
+
+// devImageRef is the image reference a swapped application runs
+func devImageRef(app, tag string) string {
+	return devRepositoryPrefix + app + ":" + tag
+}
@@ -0,0 +1,273 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeApps keeps Applications in memory and applies patches to them
+type fakeApps struct {
+	context string
+	apps    map[string]*Application
+	synced  []string
+}
+
+func newFakeApps() *fakeApps {
+	return &fakeApps{
+		context: "k3d-openframe-dev",
+		apps: map[string]*Application{
+			ParentApplication: {Name: ParentApplication, AutoSync: json.RawMessage(`{"prune":true,"selfHeal":true}`)},
+			"openframe-api": {
+				Name:       "openframe-api",
+				Parameters: []HelmParameter{{Name: "replicas", Value: "2"}},
+				SourcePath: "/spec/sources/0",
+				HasHelm:    true,
+				AutoSync:   json.RawMessage(`{"prune":true}`),
+			},
+			"openframe-gateway": {Name: "openframe-gateway", SourcePath: "/spec/sources/0"},
+		},
+	}
+}
+
+func (f *fakeApps) GetCurrentContext(ctx context.Context) (string, error) {
+	return f.context, nil
+}
+
+func (f *fakeApps) GetApplication(ctx context.Context, name string) (*Application, error) {
+	app, ok := f.apps[name]
+	if !ok {
+		return nil, errors.New("NotFound")
+	}
+	copied := *app
+	copied.Annotations = make(map[string]string)
+	for k, v := range app.Annotations {
+		copied.Annotations[k] = v
+	}
+	return &copied, nil
+}
+
+func (f *fakeApps) ListApplications(ctx context.Context) ([]Application, error) {
+	var apps []Application
+	for name := range f.apps {
+		app, _ := f.GetApplication(ctx, name)
+		apps = append(apps, *app)
+	}
+	return apps, nil
+}
+
+func (f *fakeApps) SetHelmParameters(ctx context.Context, app *Application, params []HelmParameter) error {
+	f.apps[app.Name].Parameters = params
+	f.apps[app.Name].HasHelm = true
+	return nil
+}
+
+func (f *fakeApps) SetAnnotations(ctx context.Context, name string, annotations map[string]*string) error {
+	app := f.apps[name]
+	if app.Annotations == nil {
+		app.Annotations = make(map[string]string)
+	}
+	for k, v := range annotations {
+		if v == nil {
+			delete(app.Annotations, k)
+		} else {
+			app.Annotations[k] = *v
+		}
+	}
+	return nil
+}
+
+func (f *fakeApps) SetAutoSync(ctx context.Context, name string, automated json.RawMessage) error {
+	f.apps[name].AutoSync = automated
+	return nil
+}
+
+func (f *fakeApps) SyncApplication(ctx context.Context, name string) error {
+	f.synced = append(f.synced, name)
+	return nil
+}
+
+// fakeBuilder records builds and imports
+type fakeBuilder struct {
+	images   map[string]bool
+	built    []string
+	tagged   []string
+	imported []string
+	fail     error
+}
+
+func (b *fakeBuilder) ImageExists(ctx context.Context, ref string) bool {
+	return b.images[ref]
+}
+
+func (b *fakeBuilder) Build(ctx context.Context, ref, dockerfile, contextDir string) error {
+	b.built = append(b.built, ref+" "+dockerfile)
+	return b.fail
+}
+
+func (b *fakeBuilder) Tag(ctx context.Context, source, target string) error {
+	b.tagged = append(b.tagged, source+" "+target)
+	return b.fail
+}
+
+func (b *fakeBuilder) Import(ctx context.Context, ref, cluster string) error {
+	b.imported = append(b.imported, ref+" "+cluster)
+	return nil
+}
+
+func newTestService(apps *fakeApps, builder *fakeBuilder, dryRun bool) *Service {
+	service := NewService(apps, builder, false, dryRun)
+	service.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return service
+}
+
+func parameter(params []HelmParameter, name string) string {
+	for _, param := range params {
+		if param.Name == name {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+func TestService_SetAndReset(t *testing.T) {
+	testutil.InitializeTestMode()
+	apps := newFakeApps()
+	builder := &fakeBuilder{images: map[string]bool{"my-gateway:test": true}}
+	service := newTestService(apps, builder, false)
+	dockerfile := writeDockerfile(t)
+
+	err := service.Set(context.Background(), []string{"openframe-api=" + dockerfile, "openframe-gateway=my-gateway:test"}, &models.ImageFlags{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"openframe-dev/openframe-api:dev-20260102030405 " + dockerfile}, builder.built)
+	assert.Equal(t, []string{"my-gateway:test openframe-dev/openframe-gateway:dev-20260102030405"}, builder.tagged)
+	assert.Len(t, builder.imported, 2)
+	assert.Contains(t, builder.imported[0], " openframe-dev")
+
+	api := apps.apps["openframe-api"]
+	assert.Equal(t, "openframe-dev/openframe-api", parameter(api.Parameters, "image.repo"))
+	assert.Equal(t, "dev-20260102030405", parameter(api.Parameters, "image.tag"))
+	assert.Equal(t, "2", parameter(api.Parameters, "replicas"))
+	assert.Equal(t, `[{"name":"replicas","value":"2"}]`, api.Annotations[originalParamsAnnotation])
+
+	// The parent is paused and only the manually synced application gets an explicit sync
+	parent := apps.apps[ParentApplication]
+	assert.Nil(t, parent.AutoSync)
+	assert.Equal(t, `{"prune":true,"selfHeal":true}`, parent.Annotations[pausedSyncAnnotation])
+	assert.Equal(t, []string{"openframe-gateway"}, apps.synced)
+
+	// Resetting one application keeps the parent paused
+	require.NoError(t, service.Reset(context.Background(), []string{"openframe-api"}))
+	assert.Equal(t, []HelmParameter{{Name: "replicas", Value: "2"}}, api.Parameters)
+	assert.NotContains(t, api.Annotations, originalParamsAnnotation)
+	assert.Nil(t, parent.AutoSync)
+
+	// Resetting the rest resumes the parent
+	require.NoError(t, service.Reset(context.Background(), nil))
+	assert.Empty(t, apps.apps["openframe-gateway"].Parameters)
+	assert.Equal(t, `{"prune":true,"selfHeal":true}`, string(parent.AutoSync))
+	assert.NotContains(t, parent.Annotations, pausedSyncAnnotation)
+}
+
+func TestService_Set_KeepsFirstOriginal(t *testing.T) {
+	testutil.InitializeTestMode()
+	apps := newFakeApps()
+	builder := &fakeBuilder{images: map[string]bool{"api:v1": true}}
+	service := newTestService(apps, builder, false)
+
+	require.NoError(t, service.Set(context.Background(), []string{"openframe-api=api:v1"}, &models.ImageFlags{}))
+	require.NoError(t, service.Set(context.Background(), []string{"openframe-api=api:v1"}, &models.ImageFlags{}))
+
+	assert.Equal(t, `[{"name":"replicas","value":"2"}]`, apps.apps["openframe-api"].Annotations[originalParamsAnnotation])
+	assert.Len(t, apps.apps["openframe-api"].Parameters, 3)
+}
+
+func TestService_Set_ValidatesBeforeChanges(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	tests := []struct {
+		name    string
+		args    []string
+		context string
+		errMsg  string
+	}{
+		{name: "unknown application", args: []string{"missing=api:v1"}, errMsg: "application missing not found"},
+		{name: "missing local image", args: []string{"openframe-api=unknown:v1"}, errMsg: "not found in the local docker daemon"},
+		{name: "missing Dockerfile", args: []string{"openframe-api=./nope/Dockerfile"}, errMsg: "build source not found"},
+		{name: "not a k3d context", args: []string{"openframe-api=api:v1"}, context: "kind-dev", errMsg: "is not a k3d cluster"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apps := newFakeApps()
+			if tt.context != "" {
+				apps.context = tt.context
+			}
+			builder := &fakeBuilder{images: map[string]bool{"api:v1": true}}
+			service := newTestService(apps, builder, false)
+
+			err := service.Set(context.Background(), tt.args, &models.ImageFlags{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			assert.Empty(t, builder.imported)
+			assert.NotNil(t, apps.apps[ParentApplication].AutoSync)
+		})
+	}
+}
+
+func TestService_Set_DryRun(t *testing.T) {
+	testutil.InitializeTestMode()
+	apps := newFakeApps()
+	builder := &fakeBuilder{images: map[string]bool{"api:v1": true}}
+	service := newTestService(apps, builder, true)
+
+	require.NoError(t, service.Set(context.Background(), []string{"openframe-api=api:v1"}, &models.ImageFlags{Cluster: "openframe-dev"}))
+	assert.Empty(t, builder.tagged)
+	assert.Empty(t, builder.imported)
+	assert.Len(t, apps.apps["openframe-api"].Parameters, 1)
+}
+
+func TestService_Set_ClusterContextMismatch(t *testing.T) {
+	testutil.InitializeTestMode()
+	apps := newFakeApps()
+	apps.context = "k3d-other"
+	builder := &fakeBuilder{images: map[string]bool{"api:v1": true}}
+	service := newTestService(apps, builder, false)
+
+	err := service.Set(context.Background(), []string{"openframe-api=api:v1"}, &models.ImageFlags{Cluster: "openframe-dev"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not the one of k3d cluster openframe-dev")
+	assert.Empty(t, builder.imported, "nothing is imported into a cluster that is not patched")
+	assert.Len(t, apps.apps["openframe-api"].Parameters, 1)
+}
+
+func TestService_Set_CustomParameters(t *testing.T) {
+	testutil.InitializeTestMode()
+	apps := newFakeApps()
+	builder := &fakeBuilder{images: map[string]bool{"api:v1": true}}
+	service := newTestService(apps, builder, false)
+
+	flags := &models.ImageFlags{RepoParam: "image.repository", TagParam: "image.version"}
+	require.NoError(t, service.Set(context.Background(), []string{"openframe-gateway=api:v1"}, flags))
+
+	params := apps.apps["openframe-gateway"].Parameters
+	assert.Equal(t, "openframe-dev/openframe-gateway", parameter(params, "image.repository"))
+	assert.Equal(t, "dev-20260102030405", parameter(params, "image.version"))
+}
+
+func TestService_Reset_NotSwapped(t *testing.T) {
+	testutil.InitializeTestMode()
+	service := newTestService(newFakeApps(), &fakeBuilder{}, false)
+
+	err := service.Reset(context.Background(), []string{"openframe-api"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not using a local image")
+}
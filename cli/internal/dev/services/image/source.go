@@ -0,0 +1,75 @@
+package image
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Swap is a single app=source argument of the image set command
+type Swap struct {
+	App    string
+	Source string
+}
+
+// Source is where the replacement image comes from
+type Source struct {
+	Dockerfile string // Dockerfile to build; empty when using an existing image
+	ContextDir string // Build context of the Dockerfile
+	Image      string // Existing local image reference; empty when building
+}
+
+// ParseSwaps parses app=./Dockerfile or app=image:tag arguments
+func ParseSwaps(args []string) ([]Swap, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("at least one app=<Dockerfile|directory|image> argument is required")
+	}
+
+	seen := make(map[string]bool)
+	swaps := make([]Swap, 0, len(args))
+	for _, arg := range args {
+		app, source, found := strings.Cut(arg, "=")
+		app = strings.TrimSpace(app)
+		source = strings.TrimSpace(source)
+		if !found || app == "" || source == "" {
+			return nil, fmt.Errorf("invalid argument '%s': expected app=<Dockerfile|directory|image>", arg)
+		}
+		if seen[app] {
+			return nil, fmt.Errorf("application '%s' is specified more than once", app)
+		}
+		seen[app] = true
+		swaps = append(swaps, Swap{App: app, Source: source})
+	}
+
+	return swaps, nil
+}
+
+// ResolveSource classifies a source as a Dockerfile, a directory with a Dockerfile or an image reference
+func ResolveSource(source string) (*Source, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		if looksLikePath(source) {
+			return nil, fmt.Errorf("build source not found: %s", source)
+		}
+		return &Source{Image: source}, nil
+	}
+
+	if info.IsDir() {
+		dockerfile := filepath.Join(source, "Dockerfile")
+		if _, err := os.Stat(dockerfile); err != nil {
+			return nil, fmt.Errorf("no Dockerfile found in %s", source)
+		}
+		return &Source{Dockerfile: dockerfile, ContextDir: source}, nil
+	}
+
+	return &Source{Dockerfile: source, ContextDir: filepath.Dir(source)}, nil
+}
+
+// looksLikePath reports whether a missing source was meant as a file path rather than an image
+func looksLikePath(source string) bool {
+	return strings.HasPrefix(source, ".") ||
+		strings.HasPrefix(source, "/") ||
+		strings.HasPrefix(source, "~") ||
+		strings.Contains(filepath.Base(source), "Dockerfile")
+}
@@ -0,0 +1,61 @@
+package image
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDockerfile creates a Dockerfile in a temporary directory and returns its path
+func writeDockerfile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(path, []byte("FROM scratch\n"), 0644))
+	return path
+}
+
+func TestParseSwaps(t *testing.T) {
+	swaps, err := ParseSwaps([]string{"openframe-api=./Dockerfile", " openframe-gateway = gateway:test "})
+	require.NoError(t, err)
+	assert.Equal(t, []Swap{
+		{App: "openframe-api", Source: "./Dockerfile"},
+		{App: "openframe-gateway", Source: "gateway:test"},
+	}, swaps)
+
+	for _, args := range [][]string{
+		{},
+		{"openframe-api"},
+		{"=./Dockerfile"},
+		{"openframe-api="},
+		{"openframe-api=a:1", "openframe-api=b:1"},
+	} {
+		_, err := ParseSwaps(args)
+		assert.Error(t, err, "args %v", args)
+	}
+}
+
+func TestResolveSource(t *testing.T) {
+	dockerfile := writeDockerfile(t)
+	dir := filepath.Dir(dockerfile)
+
+	source, err := ResolveSource(dockerfile)
+	require.NoError(t, err)
+	assert.Equal(t, &Source{Dockerfile: dockerfile, ContextDir: dir}, source)
+
+	source, err = ResolveSource(dir)
+	require.NoError(t, err)
+	assert.Equal(t, &Source{Dockerfile: dockerfile, ContextDir: dir}, source)
+
+	source, err = ResolveSource("ghcr.io/org/openframe-api:test")
+	require.NoError(t, err)
+	assert.Equal(t, &Source{Image: "ghcr.io/org/openframe-api:test"}, source)
+
+	_, err = ResolveSource(t.TempDir())
+	assert.ErrorContains(t, err, "no Dockerfile found")
+
+	_, err = ResolveSource("./missing/Dockerfile")
+	assert.ErrorContains(t, err, "build source not found")
+}
@@ -0,0 +1,66 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pterm/pterm"
+)
+
+// PrefixColors is the palette used to tell pods, and the services of dev skaffold
+// --services, apart in combined output
+var PrefixColors = []pterm.Color{
+	pterm.FgCyan,
+	pterm.FgGreen,
+	pterm.FgYellow,
+	pterm.FgMagenta,
+	pterm.FgBlue,
+	pterm.FgLightCyan,
+	pterm.FgLightGreen,
+	pterm.FgLightYellow,
+	pterm.FgLightMagenta,
+	pterm.FgLightBlue,
+}
+
+// logLine is the JSON representation of a single log line
+type logLine struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Message   string `json:"message"`
+}
+
+// colorForPod picks a stable colour so a pod keeps its prefix colour across reconnects
+func colorForPod(pod PodInfo) pterm.Color {
+	hash := fnv.New32a()
+	hash.Write([]byte(pod.Namespace + "/" + pod.Name))
+	return PrefixColors[hash.Sum32()%uint32(len(PrefixColors))]
+}
+
+// formatPrefix builds the pod prefix, adding the container name for multi-container pods
+func formatPrefix(pod PodInfo, container string) string {
+	label := pod.Name
+	if len(pod.Containers) > 1 {
+		label = fmt.Sprintf("%s/%s", pod.Name, container)
+	}
+	return colorForPod(pod).Sprint(label)
+}
+
+// formatLine renders a log line either as prefixed text or as a JSON object
+func formatLine(pod PodInfo, container, line string, asJSON bool) (string, error) {
+	if !asJSON {
+		return fmt.Sprintf("%s %s %s", formatPrefix(pod, container), pterm.Gray("|"), line), nil
+	}
+
+	data, err := json.Marshal(logLine{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Container: container,
+		Message:   line,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
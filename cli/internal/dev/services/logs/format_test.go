@@ -0,0 +1,37 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColorForPod_IsStable(t *testing.T) {
+	pod := PodInfo{Name: "openframe-api-7d9f", Namespace: "microservices"}
+
+	assert.Equal(t, colorForPod(pod), colorForPod(pod))
+	assert.Contains(t, PrefixColors, colorForPod(pod))
+}
+
+func TestFormatPrefix(t *testing.T) {
+	single := PodInfo{Name: "openframe-api-0", Containers: []string{"openframe-api"}}
+	multi := PodInfo{Name: "kafka-0", Containers: []string{"kafka", "metrics"}}
+
+	assert.Contains(t, formatPrefix(single, "openframe-api"), "openframe-api-0")
+	assert.NotContains(t, formatPrefix(single, "openframe-api"), "/openframe-api")
+	assert.Contains(t, formatPrefix(multi, "metrics"), "kafka-0/metrics")
+}
+
+func TestFormatLine(t *testing.T) {
+	pod := PodInfo{Name: "openframe-api-0", Namespace: "microservices", Containers: []string{"openframe-api"}}
+
+	text, err := formatLine(pod, "openframe-api", "hello", false)
+	require.NoError(t, err)
+	assert.Contains(t, text, "openframe-api-0")
+	assert.Contains(t, text, "hello")
+
+	data, err := formatLine(pod, "openframe-api", `quote " here`, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"namespace":"microservices","pod":"openframe-api-0","container":"openframe-api","message":"quote \" here"}`, data)
+}
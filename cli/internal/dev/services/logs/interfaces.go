@@ -0,0 +1,56 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrApplicationNotFound is returned when no ArgoCD application matches a name
+var ErrApplicationNotFound = errors.New("argocd application not found")
+
+// PodInfo represents a pod whose containers can be streamed
+type PodInfo struct {
+	Name       string
+	Namespace  string
+	Phase      string
+	Containers []string
+}
+
+// Target describes which pods a logs session should follow
+type Target struct {
+	Namespace   string     // Empty means all namespaces
+	Selectors   []Selector // Label selectors; empty means every pod in Namespace
+	Description string     // Human readable summary of what is being followed
+}
+
+// Selector selects pods by label, in its own namespace when an application spans several
+type Selector struct {
+	Namespace string // Empty means the namespace of the target
+	Labels    string
+}
+
+// StreamOptions controls a single container log stream
+type StreamOptions struct {
+	Container string
+	Since     string    // Relative duration passed to kubectl --since
+	SinceTime time.Time // Absolute start time, takes precedence over Since
+	Follow    bool
+}
+
+// PodClient interface for pod discovery
+type PodClient interface {
+	GetPods(ctx context.Context, namespace, selector string) ([]PodInfo, error)
+	ValidateNamespace(ctx context.Context, namespace string) error
+}
+
+// ApplicationResolver interface for mapping ArgoCD applications to pods
+type ApplicationResolver interface {
+	ResolveApplication(ctx context.Context, name string) (*Target, error)
+}
+
+// LogStreamer interface for reading container logs
+type LogStreamer interface {
+	StreamLogs(ctx context.Context, pod PodInfo, opts StreamOptions) (io.ReadCloser, error)
+}
@@ -0,0 +1,350 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/pterm/pterm"
+)
+
+// defaultPollInterval is how often the pod list is refreshed to pick up new pods
+const defaultPollInterval = 3 * time.Second
+
+// Service streams logs from every pod matching an application, namespace or selector
+type Service struct {
+	pods         PodClient
+	apps         ApplicationResolver
+	streamer     LogStreamer
+	verbose      bool
+	out          io.Writer
+	pollInterval time.Duration
+	writeMu      sync.Mutex
+}
+
+// NewService creates a new logs service
+func NewService(pods PodClient, apps ApplicationResolver, streamer LogStreamer, verbose bool) *Service {
+	return &Service{
+		pods:         pods,
+		apps:         apps,
+		streamer:     streamer,
+		verbose:      verbose,
+		out:          os.Stdout,
+		pollInterval: defaultPollInterval,
+	}
+}
+
+// StreamLogs resolves the target and streams logs until the context is cancelled
+// (or until every stream ends when follow is disabled)
+func (s *Service) StreamLogs(ctx context.Context, arg string, flags *models.LogsFlags) error {
+	if flags == nil {
+		return errors.New("flags cannot be nil")
+	}
+
+	filter, err := s.validateFlags(flags)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	target, err := s.ResolveTarget(ctx, arg, flags.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if !flags.JSON {
+		pterm.Info.Printf("Streaming logs for %s\n", target.Description)
+		if flags.Follow {
+			pterm.Info.Println("Press Ctrl+C to stop")
+		}
+	}
+
+	session := &session{
+		service: s,
+		target:  target,
+		flags:   flags,
+		filter:  filter,
+		streams: make(map[string]*streamState),
+	}
+	return session.run(ctx)
+}
+
+// ResolveTarget maps the user argument to an ArgoCD application, namespace or label selector
+func (s *Service) ResolveTarget(ctx context.Context, arg, namespace string) (*Target, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return nil, errors.New("target cannot be empty")
+	}
+
+	if isSelector(arg) {
+		description := fmt.Sprintf("selector '%s'", arg)
+		if namespace != "" {
+			description += fmt.Sprintf(" in namespace '%s'", namespace)
+		}
+		return &Target{
+			Namespace:   namespace,
+			Selectors:   []Selector{{Labels: arg}},
+			Description: description,
+		}, nil
+	}
+
+	target, err := s.apps.ResolveApplication(ctx, arg)
+	if err == nil {
+		return target, nil
+	}
+	if !errors.Is(err, ErrApplicationNotFound) {
+		return nil, fmt.Errorf("failed to resolve application %s: %w", arg, err)
+	}
+	if s.verbose {
+		pterm.Debug.Printf("No ArgoCD application named %s, trying namespace\n", arg)
+	}
+
+	if err := s.pods.ValidateNamespace(ctx, arg); err == nil {
+		return &Target{
+			Namespace:   arg,
+			Description: fmt.Sprintf("namespace '%s'", arg),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("'%s' is not an ArgoCD application, namespace or label selector", arg)
+}
+
+// validateFlags checks the flag values and compiles the grep filter
+func (s *Service) validateFlags(flags *models.LogsFlags) (*regexp.Regexp, error) {
+	if flags.Since != "" {
+		duration, err := time.ParseDuration(flags.Since)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid --since value: %s (expected a duration like 30s, 5m or 1h)", flags.Since)
+		}
+	}
+
+	if flags.Grep == "" {
+		return nil, nil
+	}
+
+	filter, err := regexp.Compile(flags.Grep)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+	}
+	return filter, nil
+}
+
+// listPods returns the pods matching every selector of the target, deduplicated and sorted
+func (s *Service) listPods(ctx context.Context, target *Target) ([]PodInfo, error) {
+	selectors := target.Selectors
+	if len(selectors) == 0 {
+		selectors = []Selector{{}}
+	}
+
+	seen := make(map[string]bool)
+	var pods []PodInfo
+	for _, selector := range selectors {
+		namespace := selector.Namespace
+		if namespace == "" {
+			namespace = target.Namespace
+		}
+		matched, err := s.pods.GetPods(ctx, namespace, selector.Labels)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range matched {
+			key := pod.Namespace + "/" + pod.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			pods = append(pods, pod)
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	return pods, nil
+}
+
+// copyLines writes every matching line of a stream to the output with the pod prefix
+func (s *Service) copyLines(stream io.Reader, pod PodInfo, container string, filter *regexp.Regexp, asJSON bool) {
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if filter != nil && !filter.MatchString(line) {
+			continue
+		}
+
+		formatted, err := formatLine(pod, container, line, asJSON)
+		if err != nil {
+			continue
+		}
+
+		s.writeMu.Lock()
+		fmt.Fprintln(s.out, formatted)
+		s.writeMu.Unlock()
+	}
+}
+
+// isSelector reports whether the argument looks like a Kubernetes label selector
+func isSelector(arg string) bool {
+	return strings.ContainsAny(arg, "=!,") || strings.Contains(arg, " in ") || strings.Contains(arg, " notin ")
+}
+
+// streamState tracks a single pod container stream
+type streamState struct {
+	active  bool
+	endedAt time.Time
+}
+
+// session holds the state of one logs invocation
+type session struct {
+	service     *Service
+	target      *Target
+	flags       *models.LogsFlags
+	filter      *regexp.Regexp
+	mu          sync.Mutex
+	streams     map[string]*streamState
+	wg          sync.WaitGroup
+	initialized bool
+}
+
+// run performs the initial pod discovery and, in follow mode, keeps polling for new pods
+func (ss *session) run(ctx context.Context) error {
+	found, err := ss.sync(ctx)
+	if err != nil {
+		return err
+	}
+	ss.initialized = true
+
+	if !ss.flags.Follow {
+		ss.wg.Wait()
+		if found == 0 {
+			return fmt.Errorf("no pods found for %s", ss.target.Description)
+		}
+		return nil
+	}
+
+	if found == 0 && !ss.flags.JSON {
+		pterm.Info.Printf("Waiting for pods matching %s...\n", ss.target.Description)
+	}
+
+	ticker := time.NewTicker(ss.service.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ss.wg.Wait()
+			return nil
+		case <-ticker.C:
+			if _, err := ss.sync(ctx); err != nil && ss.service.verbose {
+				pterm.Warning.Printf("Failed to refresh pods: %v\n", err)
+			}
+		}
+	}
+}
+
+// sync starts streams for pods that are not yet followed and forgets pods that are gone
+func (ss *session) sync(ctx context.Context) (int, error) {
+	pods, err := ss.service.listPods(ctx, ss.target)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool)
+	found := 0
+	for _, pod := range pods {
+		if !ss.isStreamable(pod) {
+			continue
+		}
+		found++
+
+		for _, container := range pod.Containers {
+			if ss.flags.Container != "" && container != ss.flags.Container {
+				continue
+			}
+			key := pod.Namespace + "/" + pod.Name + "/" + container
+			seen[key] = true
+			ss.start(ctx, pod, container, key)
+		}
+	}
+
+	ss.mu.Lock()
+	for key, state := range ss.streams {
+		if !seen[key] && !state.active {
+			delete(ss.streams, key)
+		}
+	}
+	ss.mu.Unlock()
+
+	return found, nil
+}
+
+// isStreamable reports whether logs can be read from the pod in the current mode
+func (ss *session) isStreamable(pod PodInfo) bool {
+	if ss.flags.Follow {
+		return pod.Phase == "Running"
+	}
+	return pod.Phase == "Running" || pod.Phase == "Succeeded" || pod.Phase == "Failed"
+}
+
+// start launches a stream for the container unless one is already active
+func (ss *session) start(ctx context.Context, pod PodInfo, container, key string) {
+	ss.mu.Lock()
+	previous, exists := ss.streams[key]
+	if exists && previous.active {
+		ss.mu.Unlock()
+		return
+	}
+
+	opts := StreamOptions{
+		Container: container,
+		Since:     ss.flags.Since,
+		Follow:    ss.flags.Follow,
+	}
+	if exists {
+		// Resume where the previous stream stopped, e.g. after a container restart
+		opts.Since = ""
+		opts.SinceTime = previous.endedAt
+	}
+	ss.streams[key] = &streamState{active: true}
+	ss.mu.Unlock()
+
+	if ss.initialized && !exists && !ss.flags.JSON {
+		pterm.Info.Printf("Following new pod %s\n", pod.Name)
+	}
+
+	ss.wg.Add(1)
+	go func() {
+		defer ss.wg.Done()
+		ss.follow(ctx, pod, container, key, opts)
+	}()
+}
+
+// follow copies a single container stream to the output until it ends
+func (ss *session) follow(ctx context.Context, pod PodInfo, container, key string, opts StreamOptions) {
+	stream, err := ss.service.streamer.StreamLogs(ctx, pod, opts)
+	if err != nil {
+		if ss.service.verbose {
+			pterm.Warning.Printf("Failed to stream logs for %s/%s: %v\n", pod.Name, container, err)
+		}
+	} else {
+		ss.service.copyLines(stream, pod, container, ss.filter, ss.flags.JSON)
+		stream.Close()
+	}
+
+	ss.mu.Lock()
+	ss.streams[key] = &streamState{endedAt: time.Now()}
+	ss.mu.Unlock()
+}
@@ -0,0 +1,372 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePodClient returns pods per selector and records the calls it receives
+type fakePodClient struct {
+	mu         sync.Mutex
+	pods       map[string][]PodInfo
+	namespaces []string
+	calls      []string
+}
+
+func (f *fakePodClient) GetPods(ctx context.Context, namespace, selector string) ([]PodInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, namespace+"|"+selector)
+	return f.pods[selector], nil
+}
+
+func (f *fakePodClient) ValidateNamespace(ctx context.Context, namespace string) error {
+	for _, ns := range f.namespaces {
+		if ns == namespace {
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+// fakeResolver resolves a fixed set of applications
+type fakeResolver struct {
+	apps map[string]*Target
+	err  error
+}
+
+func (f *fakeResolver) ResolveApplication(ctx context.Context, name string) (*Target, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if target, ok := f.apps[name]; ok {
+		return target, nil
+	}
+	return nil, ErrApplicationNotFound
+}
+
+// fakeStreamer returns canned log output per pod and records stream options
+type fakeStreamer struct {
+	mu      sync.Mutex
+	output  map[string]string
+	options []StreamOptions
+}
+
+func (f *fakeStreamer) StreamLogs(ctx context.Context, pod PodInfo, opts StreamOptions) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.options = append(f.options, opts)
+	return io.NopCloser(strings.NewReader(f.output[pod.Name])), nil
+}
+
+func newTestService(pods *fakePodClient, resolver *fakeResolver, streamer *fakeStreamer) (*Service, *bytes.Buffer) {
+	service := NewService(pods, resolver, streamer, false)
+	out := &bytes.Buffer{}
+	service.out = out
+	service.pollInterval = 10 * time.Millisecond
+	return service, out
+}
+
+func TestService_ResolveTarget(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	resolver := &fakeResolver{apps: map[string]*Target{
+		"openframe-api": {Namespace: "microservices", Selectors: []Selector{{Labels: "app=openframe-api"}}, Description: "application 'openframe-api'"},
+	}}
+	pods := &fakePodClient{namespaces: []string{"datasources"}}
+	service, _ := newTestService(pods, resolver, &fakeStreamer{})
+
+	tests := []struct {
+		name              string
+		arg               string
+		namespace         string
+		expectedNamespace string
+		expectedSelectors []Selector
+		expectError       bool
+	}{
+		{
+			name:              "label selector across all namespaces",
+			arg:               "app=openframe-stream",
+			expectedSelectors: []Selector{{Labels: "app=openframe-stream"}},
+		},
+		{
+			name:              "label selector in namespace",
+			arg:               "app in (kafka,nats)",
+			namespace:         "datasources",
+			expectedNamespace: "datasources",
+			expectedSelectors: []Selector{{Labels: "app in (kafka,nats)"}},
+		},
+		{
+			name:              "argocd application",
+			arg:               "openframe-api",
+			expectedNamespace: "microservices",
+			expectedSelectors: []Selector{{Labels: "app=openframe-api"}},
+		},
+		{
+			name:              "namespace",
+			arg:               "datasources",
+			expectedNamespace: "datasources",
+		},
+		{
+			name:        "unknown target",
+			arg:         "does-not-exist",
+			expectError: true,
+		},
+		{
+			name:        "empty target",
+			arg:         "  ",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := service.ResolveTarget(context.Background(), tt.arg, tt.namespace)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNamespace, target.Namespace)
+			assert.Equal(t, tt.expectedSelectors, target.Selectors)
+			assert.NotEmpty(t, target.Description)
+		})
+	}
+}
+
+func TestService_ResolveTarget_ResolverError(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	resolver := &fakeResolver{err: errors.New("connection refused")}
+	service, _ := newTestService(&fakePodClient{}, resolver, &fakeStreamer{})
+
+	_, err := service.ResolveTarget(context.Background(), "openframe-api", "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestService_StreamLogs_NoFollow(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	pods := &fakePodClient{pods: map[string][]PodInfo{
+		"app=openframe-api": {
+			{Name: "openframe-api-b", Namespace: "microservices", Phase: "Running", Containers: []string{"openframe-api"}},
+			{Name: "openframe-api-a", Namespace: "microservices", Phase: "Running", Containers: []string{"openframe-api"}},
+			{Name: "openframe-api-pending", Namespace: "microservices", Phase: "Pending", Containers: []string{"openframe-api"}},
+		},
+	}}
+	streamer := &fakeStreamer{output: map[string]string{
+		"openframe-api-a":       "started\nERROR boom\n",
+		"openframe-api-b":       "ready\n",
+		"openframe-api-pending": "should not be read\n",
+	}}
+	service, out := newTestService(pods, &fakeResolver{}, streamer)
+
+	err := service.StreamLogs(context.Background(), "app=openframe-api", &models.LogsFlags{Since: "5m"})
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "started")
+	assert.Contains(t, output, "ERROR boom")
+	assert.Contains(t, output, "ready")
+	assert.NotContains(t, output, "should not be read")
+
+	require.Len(t, streamer.options, 2)
+	for _, opts := range streamer.options {
+		assert.Equal(t, "5m", opts.Since)
+		assert.False(t, opts.Follow)
+		assert.True(t, opts.SinceTime.IsZero())
+	}
+}
+
+func TestService_StreamLogs_GrepAndJSON(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	pods := &fakePodClient{pods: map[string][]PodInfo{
+		"app=openframe-stream": {
+			{Name: "openframe-stream-0", Namespace: "microservices", Phase: "Running", Containers: []string{"stream"}},
+		},
+	}}
+	streamer := &fakeStreamer{output: map[string]string{
+		"openframe-stream-0": "INFO consumer started\nERROR lag too high\n",
+	}}
+	service, out := newTestService(pods, &fakeResolver{}, streamer)
+
+	err := service.StreamLogs(context.Background(), "app=openframe-stream", &models.LogsFlags{Grep: "ERROR", JSON: true})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 1)
+	assert.JSONEq(t, `{"namespace":"microservices","pod":"openframe-stream-0","container":"stream","message":"ERROR lag too high"}`, lines[0])
+}
+
+func TestService_StreamLogs_ContainerFilter(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	pods := &fakePodClient{pods: map[string][]PodInfo{
+		"app=kafka": {
+			{Name: "kafka-0", Namespace: "datasources", Phase: "Running", Containers: []string{"kafka", "metrics"}},
+		},
+	}}
+	streamer := &fakeStreamer{output: map[string]string{"kafka-0": "line\n"}}
+	service, _ := newTestService(pods, &fakeResolver{}, streamer)
+
+	err := service.StreamLogs(context.Background(), "app=kafka", &models.LogsFlags{Container: "metrics"})
+	require.NoError(t, err)
+
+	require.Len(t, streamer.options, 1)
+	assert.Equal(t, "metrics", streamer.options[0].Container)
+}
+
+func TestService_StreamLogs_NoPods(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	service, _ := newTestService(&fakePodClient{}, &fakeResolver{}, &fakeStreamer{})
+
+	err := service.StreamLogs(context.Background(), "app=missing", &models.LogsFlags{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no pods found")
+}
+
+func TestService_StreamLogs_InvalidFlags(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	service, _ := newTestService(&fakePodClient{}, &fakeResolver{}, &fakeStreamer{})
+
+	tests := []struct {
+		name  string
+		flags *models.LogsFlags
+	}{
+		{name: "nil flags", flags: nil},
+		{name: "invalid since", flags: &models.LogsFlags{Since: "yesterday"}},
+		{name: "negative since", flags: &models.LogsFlags{Since: "-5m"}},
+		{name: "invalid grep", flags: &models.LogsFlags{Grep: "("}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.StreamLogs(context.Background(), "app=openframe-api", tt.flags)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestService_StreamLogs_FollowPicksUpNewPods(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	pods := &fakePodClient{pods: map[string][]PodInfo{
+		"app=openframe-gateway": {
+			{Name: "openframe-gateway-old", Namespace: "microservices", Phase: "Running", Containers: []string{"gateway"}},
+		},
+	}}
+	streamer := &fakeStreamer{output: map[string]string{
+		"openframe-gateway-old": "old pod\n",
+		"openframe-gateway-new": "new pod\n",
+	}}
+	service, out := newTestService(pods, &fakeResolver{}, streamer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- service.StreamLogs(ctx, "app=openframe-gateway", &models.LogsFlags{Follow: true})
+	}()
+
+	// Simulate a rollout replacing the pod
+	time.Sleep(30 * time.Millisecond)
+	pods.mu.Lock()
+	pods.pods["app=openframe-gateway"] = []PodInfo{
+		{Name: "openframe-gateway-new", Namespace: "microservices", Phase: "Running", Containers: []string{"gateway"}},
+	}
+	pods.mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	service.writeMu.Lock()
+	output := out.String()
+	service.writeMu.Unlock()
+	assert.Contains(t, output, "old pod")
+	assert.Contains(t, output, "new pod")
+}
+
+func TestService_StreamLogs_FollowResumesEndedStream(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	pods := &fakePodClient{pods: map[string][]PodInfo{
+		"app=openframe-api": {
+			{Name: "openframe-api-0", Namespace: "microservices", Phase: "Running", Containers: []string{"openframe-api"}},
+		},
+	}}
+	streamer := &fakeStreamer{output: map[string]string{"openframe-api-0": "line\n"}}
+	service, _ := newTestService(pods, &fakeResolver{}, streamer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- service.StreamLogs(ctx, "app=openframe-api", &models.LogsFlags{Follow: true, Since: "1h"})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	streamer.mu.Lock()
+	defer streamer.mu.Unlock()
+	require.GreaterOrEqual(t, len(streamer.options), 2, "ended stream should be restarted")
+	assert.Equal(t, "1h", streamer.options[0].Since)
+	assert.True(t, streamer.options[0].SinceTime.IsZero())
+	assert.Equal(t, "", streamer.options[1].Since)
+	assert.False(t, streamer.options[1].SinceTime.IsZero())
+}
+
+func TestService_ListPods_Deduplicates(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	shared := PodInfo{Name: "shared", Namespace: "microservices", Phase: "Running"}
+	pods := &fakePodClient{pods: map[string][]PodInfo{
+		"app=a": {shared, {Name: "only-a", Namespace: "microservices"}},
+		"app=b": {shared},
+	}}
+	service, _ := newTestService(pods, &fakeResolver{}, &fakeStreamer{})
+
+	result, err := service.listPods(context.Background(), &Target{Namespace: "microservices", Selectors: []Selector{{Labels: "app=a"}, {Labels: "app=b"}}})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "only-a", result[0].Name)
+	assert.Equal(t, "shared", result[1].Name)
+}
+
+func TestService_ListPods_SelectorNamespaces(t *testing.T) {
+	testutil.InitializeTestMode()
+
+	pods := &fakePodClient{}
+	service, _ := newTestService(pods, &fakeResolver{}, &fakeStreamer{})
+
+	_, err := service.listPods(context.Background(), &Target{Namespace: "datasources", Selectors: []Selector{
+		{Labels: "app=kafka"},
+		{Namespace: "operators", Labels: "app=strimzi"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"datasources|app=kafka", "operators|app=strimzi"}, pods.calls)
+}
+
+func TestIsSelector(t *testing.T) {
+	assert.True(t, isSelector("app=openframe-api"))
+	assert.True(t, isSelector("app!=kafka"))
+	assert.True(t, isSelector("tier in (backend)"))
+	assert.True(t, isSelector("a=b,c=d"))
+	assert.False(t, isSelector("openframe-api"))
+	assert.False(t, isSelector("microservices"))
+}
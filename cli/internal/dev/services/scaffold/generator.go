@@ -0,0 +1,427 @@
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	skaffoldAPIVersion = "skaffold/v4beta13"
+	microservicesDir   = "manifests/microservices"
+	servicesSourceDir  = "openframe/services"
+)
+
+// BuildTool is the build system detected in a service source directory
+type BuildTool string
+
+const (
+	BuildToolMaven   BuildTool = "maven"
+	BuildToolGradle  BuildTool = "gradle"
+	BuildToolNpm     BuildTool = "npm"
+	BuildToolUnknown BuildTool = ""
+)
+
+// ChartInfo is what the skaffold config needs from a service's Helm chart
+type ChartInfo struct {
+	Dir        string
+	Namespace  string // Namespace the app-of-apps deploys the chart to, taken from its parent directory
+	Image      string // image.repo from values.yaml
+	Deployment string
+	Ports      []int
+}
+
+// ProjectInfo is what the skaffold config needs from a service's source directory
+type ProjectInfo struct {
+	Dir        string
+	Dockerfile string
+	BuildTool  BuildTool
+	ContextDir string   // Docker build context; the repository root for multi-module Maven builds
+	BuildArgs  []string // Dockerfile ARGs without a default, passed through from the environment
+}
+
+// FindRepoRoot walks up from start to the directory containing the microservice charts
+func FindRepoRoot(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if info, err := os.Stat(filepath.Join(dir, microservicesDir)); err == nil && info.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("could not find %s above %s", microservicesDir, start)
+		}
+		dir = parent
+	}
+}
+
+// Chart templates are Go templates, so they are scanned with patterns instead of parsed as YAML
+var (
+	kindPattern          = regexp.MustCompile(`(?m)^kind:\s*(\S+)`)
+	containerPortPattern = regexp.MustCompile(`containerPort:\s*(\d+)`)
+)
+
+// InspectChart reads the image repository, Deployment name and container ports of a chart
+func InspectChart(chartDir string) (*ChartInfo, error) {
+	info := &ChartInfo{
+		Dir:       chartDir,
+		Namespace: filepath.Base(filepath.Dir(chartDir)),
+	}
+
+	valuesData, err := os.ReadFile(filepath.Join(chartDir, "values.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart values: %w", err)
+	}
+
+	var values struct {
+		Image struct {
+			Repo string `yaml:"repo"`
+		} `yaml:"image"`
+	}
+	if err := yaml.Unmarshal(valuesData, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse chart values: %w", err)
+	}
+	if values.Image.Repo == "" {
+		return nil, fmt.Errorf("chart %s has no image.repo value", chartDir)
+	}
+	info.Image = values.Image.Repo
+
+	templates, err := filepath.Glob(filepath.Join(chartDir, "templates", "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(templates)
+
+	seenPorts := make(map[int]bool)
+	for _, template := range templates {
+		data, err := os.ReadFile(template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chart template: %w", err)
+		}
+
+		for _, doc := range splitDocuments(data) {
+			kind := kindPattern.FindStringSubmatch(doc)
+			if kind == nil || kind[1] != "Deployment" {
+				continue
+			}
+
+			if name := metadataName(doc); name != "" && info.Deployment == "" {
+				info.Deployment = name
+			}
+			for _, match := range containerPortPattern.FindAllStringSubmatch(doc, -1) {
+				port, _ := strconv.Atoi(match[1])
+				if !seenPorts[port] {
+					seenPorts[port] = true
+					info.Ports = append(info.Ports, port)
+				}
+			}
+		}
+	}
+
+	if info.Deployment == "" {
+		return nil, fmt.Errorf("no Deployment found in chart %s", chartDir)
+	}
+
+	return info, nil
+}
+
+// splitDocuments splits a manifest template on YAML document separators
+func splitDocuments(data []byte) []string {
+	var docs []string
+	var current strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			docs = append(docs, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	return append(docs, current.String())
+}
+
+// metadataName returns the literal metadata.name of a manifest, ignoring templated names
+func metadataName(doc string) string {
+	inMetadata := false
+	for _, line := range strings.Split(doc, "\n") {
+		switch {
+		case strings.HasPrefix(line, "metadata:"):
+			inMetadata = true
+		case inMetadata && strings.HasPrefix(line, "  name:"):
+			name := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "  name:")), `"'`)
+			if name == "" || strings.Contains(name, "{{") {
+				return ""
+			}
+			return name
+		case inMetadata && line != "" && !strings.HasPrefix(line, " "):
+			return ""
+		}
+	}
+	return ""
+}
+
+// InspectProject detects the Dockerfile and build tool of a service source directory
+func InspectProject(sourceDir, repoRoot string) (*ProjectInfo, error) {
+	dockerfile := filepath.Join(sourceDir, "Dockerfile")
+	_, err := os.Stat(dockerfile)
+	if err != nil {
+		return nil, fmt.Errorf("no Dockerfile found in %s", sourceDir)
+	}
+
+	info := &ProjectInfo{
+		Dir:        sourceDir,
+		Dockerfile: dockerfile,
+		BuildTool:  detectBuildTool(sourceDir),
+		ContextDir: sourceDir,
+	}
+
+	info.BuildArgs, err = requiredBuildArgs(dockerfile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Maven modules build against the parent pom, so their Dockerfiles copy from the repository root
+	if info.BuildTool == BuildToolMaven && fileExists(filepath.Join(repoRoot, "pom.xml")) && sourceDir != repoRoot {
+		info.ContextDir = repoRoot
+	}
+
+	return info, nil
+}
+
+// argPattern matches Dockerfile ARG instructions that have no default value
+var argPattern = regexp.MustCompile(`(?im)^\s*ARG\s+([A-Za-z_][A-Za-z0-9_]*)\s*$`)
+
+// requiredBuildArgs returns the Dockerfile ARGs that must be supplied at build time
+func requiredBuildArgs(dockerfile string) ([]string, error) {
+	data, err := os.ReadFile(dockerfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+
+	var args []string
+	seen := make(map[string]bool)
+	for _, match := range argPattern.FindAllStringSubmatch(string(data), -1) {
+		name := match[1]
+		// BuildKit fills the platform ARGs automatically
+		if seen[name] || strings.HasPrefix(name, "BUILD") || strings.HasPrefix(name, "TARGET") {
+			continue
+		}
+		seen[name] = true
+		args = append(args, name)
+	}
+	return args, nil
+}
+
+// detectBuildTool returns the first build system found in a directory
+func detectBuildTool(dir string) BuildTool {
+	switch {
+	case fileExists(filepath.Join(dir, "pom.xml")):
+		return BuildToolMaven
+	case fileExists(filepath.Join(dir, "build.gradle")), fileExists(filepath.Join(dir, "build.gradle.kts")):
+		return BuildToolGradle
+	case fileExists(filepath.Join(dir, "package.json")):
+		return BuildToolNpm
+	default:
+		return BuildToolUnknown
+	}
+}
+
+// skaffoldConfig is the subset of the skaffold schema the generator writes
+type skaffoldConfig struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Build struct {
+		Artifacts []skaffoldArtifact `yaml:"artifacts"`
+		Local     struct {
+			UseDockerCLI bool `yaml:"useDockerCLI"`
+			UseBuildkit  bool `yaml:"useBuildkit"`
+			Push         bool `yaml:"push"`
+		} `yaml:"local"`
+	} `yaml:"build"`
+	Manifests struct {
+		Helm struct {
+			Releases []skaffoldRelease `yaml:"releases"`
+		} `yaml:"helm"`
+	} `yaml:"manifests"`
+	PortForward []skaffoldPortForward `yaml:"portForward,omitempty"`
+}
+
+type skaffoldArtifact struct {
+	Image   string `yaml:"image"`
+	Context string `yaml:"context"`
+	Sync    struct {
+		Manual []skaffoldSyncRule `yaml:"manual"`
+	} `yaml:"sync"`
+	Docker struct {
+		Dockerfile string            `yaml:"dockerfile"`
+		BuildArgs  map[string]string `yaml:"buildArgs,omitempty"`
+	} `yaml:"docker"`
+	Hooks *skaffoldHooks `yaml:"hooks,omitempty"`
+}
+
+type skaffoldSyncRule struct {
+	Src   string `yaml:"src"`
+	Dest  string `yaml:"dest"`
+	Strip string `yaml:"strip,omitempty"`
+}
+
+type skaffoldHooks struct {
+	Before []skaffoldHookCommand `yaml:"before"`
+}
+
+type skaffoldHookCommand struct {
+	Command []string `yaml:"command,flow"`
+}
+
+type skaffoldRelease struct {
+	Name        string   `yaml:"name"`
+	ChartPath   string   `yaml:"chartPath"`
+	ValuesFiles []string `yaml:"valuesFiles"`
+}
+
+type skaffoldPortForward struct {
+	ResourceType string `yaml:"resourceType"`
+	ResourceName string `yaml:"resourceName"`
+	Namespace    string `yaml:"namespace"`
+	Port         int    `yaml:"port"`
+	LocalPort    int    `yaml:"localPort"`
+}
+
+// GenerateSkaffoldConfig renders a skaffold.yaml for a service, with paths relative to its source directory
+func GenerateSkaffoldConfig(service string, chart *ChartInfo, project *ProjectInfo) ([]byte, error) {
+	var config skaffoldConfig
+	config.APIVersion = skaffoldAPIVersion
+	config.Kind = "Config"
+	config.Metadata.Name = service
+
+	contextPath, err := relativePath(project.Dir, project.ContextDir)
+	if err != nil {
+		return nil, err
+	}
+	dockerfile, err := relativePath(project.ContextDir, project.Dockerfile)
+	if err != nil {
+		return nil, err
+	}
+	modulePath, err := relativePath(project.ContextDir, project.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := skaffoldArtifact{Image: chart.Image, Context: contextPath}
+	artifact.Docker.Dockerfile = dockerfile
+	for _, arg := range project.BuildArgs {
+		if artifact.Docker.BuildArgs == nil {
+			artifact.Docker.BuildArgs = make(map[string]string)
+		}
+		artifact.Docker.BuildArgs[arg] = "{{." + arg + "}}"
+	}
+	artifact.Sync.Manual = syncRules(project.BuildTool, modulePath)
+	if command := buildHook(project.BuildTool); command != nil {
+		artifact.Hooks = &skaffoldHooks{Before: []skaffoldHookCommand{{Command: command}}}
+	}
+	config.Build.Artifacts = []skaffoldArtifact{artifact}
+	config.Build.Local.UseBuildkit = true
+
+	chartPath, err := relativePath(project.Dir, chart.Dir)
+	if err != nil {
+		return nil, err
+	}
+	config.Manifests.Helm.Releases = []skaffoldRelease{{
+		Name:        service,
+		ChartPath:   chartPath,
+		ValuesFiles: []string{chartPath + "/values.yaml"},
+	}}
+
+	for _, port := range chart.Ports {
+		config.PortForward = append(config.PortForward, skaffoldPortForward{
+			ResourceType: "deployment",
+			ResourceName: chart.Deployment,
+			Namespace:    chart.Namespace,
+			Port:         port,
+			LocalPort:    port,
+		})
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&config); err != nil {
+		return nil, fmt.Errorf("failed to render skaffold config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// syncRules returns the files copied into the running container instead of rebuilding
+func syncRules(tool BuildTool, modulePath string) []skaffoldSyncRule {
+	prefix := ""
+	if modulePath != "." {
+		prefix = modulePath + "/"
+	}
+
+	switch tool {
+	case BuildToolMaven, BuildToolGradle:
+		// Spring Boot reads ./config from the working directory, so resource edits apply on restart
+		return []skaffoldSyncRule{{
+			Src:   prefix + "src/main/resources/**/*",
+			Dest:  "/app/config",
+			Strip: prefix + "src/main/resources/",
+		}}
+	case BuildToolNpm:
+		return []skaffoldSyncRule{
+			{Src: prefix + "src/**/*", Dest: "/app", Strip: prefix},
+			{Src: prefix + "public/**/*", Dest: "/app", Strip: prefix},
+		}
+	default:
+		return []skaffoldSyncRule{{Src: prefix + "**/*", Dest: "/app", Strip: prefix}}
+	}
+}
+
+// buildHook returns the local build command run before the docker build
+func buildHook(tool BuildTool) []string {
+	switch tool {
+	case BuildToolMaven:
+		return []string{"mvn", "clean", "package", "-DskipTests"}
+	case BuildToolGradle:
+		return []string{"./gradlew", "build", "-x", "test"}
+	default:
+		return nil
+	}
+}
+
+// relativePath returns target relative to base using forward slashes
+func relativePath(base, target string) (string, error) {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s relative to %s: %w", target, base, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// fileExists reports whether a regular file exists
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
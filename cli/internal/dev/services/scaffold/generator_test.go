@@ -0,0 +1,211 @@
+package scaffold
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/flamingo-stack/openframe/openframe/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: openframe-api
+spec:
+  template:
+    spec:
+      containers:
+        - name: openframe-api
+          image: "{{ .Values.image.repo }}:{{ .Values.image.tag }}"
+          ports:
+            - containerPort: 8090
+            - containerPort: 8091
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: register
+spec:
+  template:
+    spec:
+      containers:
+        - name: register
+          ports:
+            - containerPort: 9999
+`
+
+// writeFile creates a file and its parent directories
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// newTestRepo lays out a chart and a service source directory like the monorepo
+func newTestRepo(t *testing.T, service string, sourceFiles map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	chart := filepath.Join(root, microservicesDir, service)
+	writeFile(t, filepath.Join(chart, "values.yaml"), "image:\n  repo: ghcr.io/org/"+service+"\n  tag: latest\n")
+	writeFile(t, filepath.Join(chart, "templates", "deployment.yaml"), testDeployment)
+	for name, content := range sourceFiles {
+		writeFile(t, filepath.Join(root, servicesSourceDir, service, name), content)
+	}
+	return root
+}
+
+func TestInspectChart(t *testing.T) {
+	root := newTestRepo(t, "openframe-api", nil)
+
+	chart, err := InspectChart(filepath.Join(root, microservicesDir, "openframe-api"))
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/openframe-api", chart.Image)
+	assert.Equal(t, "openframe-api", chart.Deployment)
+	assert.Equal(t, "microservices", chart.Namespace)
+	assert.Equal(t, []int{8090, 8091}, chart.Ports, "ports of non-Deployment documents are ignored")
+}
+
+func TestInspectChart_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := InspectChart(dir)
+	assert.ErrorContains(t, err, "failed to read chart values")
+
+	writeFile(t, filepath.Join(dir, "values.yaml"), "replicas: 1\n")
+	_, err = InspectChart(dir)
+	assert.ErrorContains(t, err, "no image.repo value")
+
+	writeFile(t, filepath.Join(dir, "values.yaml"), "image:\n  repo: x\n")
+	writeFile(t, filepath.Join(dir, "templates", "deployment.yaml"), "kind: Deployment\nmetadata:\n  name: {{ .Release.Name }}\n")
+	_, err = InspectChart(dir)
+	assert.ErrorContains(t, err, "no Deployment found")
+}
+
+func TestInspectProject(t *testing.T) {
+	root := newTestRepo(t, "openframe-api", map[string]string{
+		"Dockerfile": "FROM --platform=$BUILDPLATFORM alpine\nARG BUILDPLATFORM\nARG GITHUB_ACTOR\nARG VERSION=1.0\n",
+		"pom.xml":    "<project/>",
+	})
+	writeFile(t, filepath.Join(root, "pom.xml"), "<project/>")
+	source := filepath.Join(root, servicesSourceDir, "openframe-api")
+
+	project, err := InspectProject(source, root)
+	require.NoError(t, err)
+	assert.Equal(t, BuildToolMaven, project.BuildTool)
+	assert.Equal(t, root, project.ContextDir, "maven modules build from the repository root")
+	assert.Equal(t, []string{"GITHUB_ACTOR"}, project.BuildArgs)
+
+	_, err = InspectProject(t.TempDir(), root)
+	assert.ErrorContains(t, err, "no Dockerfile found")
+}
+
+func TestDetectBuildTool(t *testing.T) {
+	tests := map[string]BuildTool{
+		"pom.xml":          BuildToolMaven,
+		"build.gradle.kts": BuildToolGradle,
+		"package.json":     BuildToolNpm,
+		"Makefile":         BuildToolUnknown,
+	}
+	for file, expected := range tests {
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, file), "")
+		assert.Equal(t, expected, detectBuildTool(dir), file)
+	}
+}
+
+func TestGenerateSkaffoldConfig(t *testing.T) {
+	root := newTestRepo(t, "openframe-frontend", map[string]string{
+		"Dockerfile":   "FROM node:22-alpine\n",
+		"package.json": "{}",
+	})
+	chart, err := InspectChart(filepath.Join(root, microservicesDir, "openframe-frontend"))
+	require.NoError(t, err)
+	project, err := InspectProject(filepath.Join(root, servicesSourceDir, "openframe-frontend"), root)
+	require.NoError(t, err)
+
+	data, err := GenerateSkaffoldConfig("openframe-frontend", chart, project)
+	require.NoError(t, err)
+
+	var config skaffoldConfig
+	require.NoError(t, yaml.Unmarshal(data, &config))
+
+	assert.Equal(t, skaffoldAPIVersion, config.APIVersion)
+	require.Len(t, config.Build.Artifacts, 1)
+	artifact := config.Build.Artifacts[0]
+	assert.Equal(t, "ghcr.io/org/openframe-frontend", artifact.Image)
+	assert.Equal(t, ".", artifact.Context)
+	assert.Equal(t, "Dockerfile", artifact.Docker.Dockerfile)
+	assert.Nil(t, artifact.Hooks, "npm builds run inside the Dockerfile")
+	assert.Equal(t, "src/**/*", artifact.Sync.Manual[0].Src)
+
+	require.Len(t, config.Manifests.Helm.Releases, 1)
+	assert.Equal(t, "../../../manifests/microservices/openframe-frontend", config.Manifests.Helm.Releases[0].ChartPath)
+
+	require.Len(t, config.PortForward, 2)
+	assert.Equal(t, skaffoldPortForward{
+		ResourceType: "deployment",
+		ResourceName: "openframe-api",
+		Namespace:    "microservices",
+		Port:         8090,
+		LocalPort:    8090,
+	}, config.PortForward[0])
+}
+
+func TestSyncRules_MavenModule(t *testing.T) {
+	rules := syncRules(BuildToolMaven, "openframe/services/openframe-api")
+	require.Len(t, rules, 1)
+	assert.Equal(t, "openframe/services/openframe-api/src/main/resources/**/*", rules[0].Src)
+	assert.Equal(t, "openframe/services/openframe-api/src/main/resources/", rules[0].Strip)
+	assert.Equal(t, "/app/config", rules[0].Dest)
+}
+
+func TestService_InitSkaffoldConfig(t *testing.T) {
+	testutil.InitializeTestMode()
+	root := newTestRepo(t, "openframe-api", map[string]string{"Dockerfile": "FROM alpine\n"})
+	source := filepath.Join(root, servicesSourceDir, "openframe-api")
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(source))
+	defer os.Chdir(wd)
+
+	mockExecutor := testutil.NewTestMockExecutor()
+	service := NewService(mockExecutor, false)
+
+	err = service.InitSkaffoldConfig(context.Background(), "openframe-api", &models.ScaffoldInitFlags{})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(source, "skaffold.yaml"))
+	assert.True(t, mockExecutor.WasCommandExecuted("skaffold diagnose -f skaffold.yaml"))
+
+	// An existing config is only replaced with --force
+	err = service.InitSkaffoldConfig(context.Background(), "openframe-api", &models.ScaffoldInitFlags{})
+	assert.ErrorContains(t, err, "already exists")
+	assert.NoError(t, service.InitSkaffoldConfig(context.Background(), "openframe-api", &models.ScaffoldInitFlags{Force: true}))
+
+	err = service.InitSkaffoldConfig(context.Background(), "missing", &models.ScaffoldInitFlags{})
+	assert.ErrorContains(t, err, "no chart found")
+}
+
+func TestService_InitSkaffoldConfig_DiagnoseFailure(t *testing.T) {
+	testutil.InitializeTestMode()
+	root := newTestRepo(t, "openframe-api", map[string]string{"Dockerfile": "FROM alpine\n"})
+
+	mockExecutor := testutil.NewTestMockExecutor()
+	mockExecutor.SetResponse("skaffold diagnose", &executor.CommandResult{ExitCode: 1, Stderr: "parsing skaffold config"})
+	service := NewService(mockExecutor, false)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(root))
+	defer os.Chdir(wd)
+
+	err = service.InitSkaffoldConfig(context.Background(), "openframe-api", &models.ScaffoldInitFlags{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing skaffold config")
+}
@@ -0,0 +1,102 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/pterm/pterm"
+)
+
+// InitSkaffoldConfig generates a skaffold.yaml for a service from its chart and source directory
+func (s *Service) InitSkaffoldConfig(ctx context.Context, serviceName string, flags *models.ScaffoldInitFlags) error {
+	root, err := FindRepoRoot(".")
+	if err != nil {
+		return err
+	}
+
+	chartDir := filepath.Join(root, microservicesDir, serviceName)
+	if info, err := os.Stat(chartDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("no chart found for service %s in %s", serviceName, filepath.Join(root, microservicesDir))
+	}
+
+	sourceDir := flags.Source
+	if sourceDir == "" {
+		sourceDir = filepath.Join(root, servicesSourceDir, serviceName)
+	}
+	sourceDir, err = filepath.Abs(sourceDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve source directory: %w", err)
+	}
+
+	chart, err := InspectChart(chartDir)
+	if err != nil {
+		return err
+	}
+	project, err := InspectProject(sourceDir, root)
+	if err != nil {
+		return err
+	}
+
+	config, err := GenerateSkaffoldConfig(serviceName, chart, project)
+	if err != nil {
+		return err
+	}
+
+	if flags.Stdout {
+		fmt.Print(string(config))
+		return nil
+	}
+
+	target := filepath.Join(sourceDir, "skaffold.yaml")
+	if _, err := os.Stat(target); err == nil && !flags.Force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", target)
+	}
+	if err := os.WriteFile(target, config, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+
+	pterm.Success.Printf("Generated %s\n", target)
+	if s.verbose {
+		pterm.Debug.Printf("Image: %s, build tool: %s, deployment: %s/%s, ports: %v\n",
+			chart.Image, buildToolName(project.BuildTool), chart.Namespace, chart.Deployment, chart.Ports)
+	}
+
+	return s.validateSkaffoldConfig(ctx, sourceDir)
+}
+
+// validateSkaffoldConfig runs skaffold diagnose on the generated config when skaffold is installed
+func (s *Service) validateSkaffoldConfig(ctx context.Context, dir string) error {
+	if _, err := s.executor.Execute(ctx, "skaffold", "version"); err != nil {
+		pterm.Warning.Println("Skaffold is not installed, skipping validation with 'skaffold diagnose'")
+		return nil
+	}
+
+	result, err := s.executor.ExecuteWithOptions(ctx, executor.ExecuteOptions{
+		Command: "skaffold",
+		Args:    []string{"diagnose", "-f", "skaffold.yaml"},
+		Dir:     dir,
+	})
+	if err != nil {
+		output := ""
+		if result != nil {
+			output = strings.TrimSpace(result.Stderr)
+		}
+		return fmt.Errorf("generated config failed 'skaffold diagnose': %w\n%s", err, output)
+	}
+
+	pterm.Success.Println("Validated with 'skaffold diagnose'")
+	return nil
+}
+
+// buildToolName returns a printable name for a detected build tool
+func buildToolName(tool BuildTool) string {
+	if tool == BuildToolUnknown {
+		return "none"
+	}
+	return string(tool)
+}
@@ -0,0 +1,259 @@
+package scaffold
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/services/logs"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/ui"
+	"github.com/pterm/pterm"
+)
+
+const (
+	maxSkaffoldAttempts = 3
+	skaffoldRetryDelay  = 3 * time.Second
+	// skaffoldStopTimeout is how long skaffold gets to delete its deployments after an interrupt
+	skaffoldStopTimeout = 30 * time.Second
+)
+
+// sessionService is one skaffold module of a multi-service session
+type sessionService struct {
+	Name      string
+	Dir       string
+	Namespace string
+	prefix    string
+}
+
+// sessionResult is the outcome of one service once the session ends
+type sessionResult struct {
+	Name     string
+	Attempts int
+	Err      error
+}
+
+// multiSession supervises one skaffold dev process per service
+type multiSession struct {
+	services   []sessionService
+	args       []string
+	out        io.Writer
+	writeMu    sync.Mutex
+	retryDelay time.Duration
+	newCommand func(ctx context.Context, service sessionService, args []string) *exec.Cmd
+}
+
+// runSkaffoldDevMulti runs skaffold dev for several services in parallel until interrupted
+func (s *Service) runSkaffoldDevMulti(ctx context.Context, selected []*ui.ServiceSelection, flags *models.ScaffoldFlags) error {
+	services := make([]sessionService, 0, len(selected))
+	for _, selection := range selected {
+		namespace, err := s.determineNamespace(ctx, selection.ServiceName, flags)
+		if err != nil {
+			return fmt.Errorf("failed to determine namespace for %s: %w", selection.ServiceName, err)
+		}
+
+		absDir, err := filepath.Abs(selection.Directory)
+		if err != nil {
+			return fmt.Errorf("failed to resolve directory path: %w", err)
+		}
+
+		services = append(services, sessionService{Name: selection.ServiceName, Dir: absDir, Namespace: namespace})
+	}
+
+	args := []string{"dev", "--cache-artifacts=false"}
+	if s.verbose {
+		args = append(args, "--verbosity", "info")
+	}
+
+	ctx, stop := signalContext(ctx)
+	defer stop()
+
+	pterm.Println()
+	pterm.Info.Printf("Running Skaffold for %d services. Press Ctrl+C to stop all of them\n", len(services))
+
+	session := newMultiSession(services, args, os.Stdout)
+	results := session.Run(ctx)
+
+	return reportSessionResults(results, ctx.Err() != nil)
+}
+
+// signalContext cancels on the first interrupt so every skaffold process is stopped once
+func signalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			pterm.Info.Println("Received interrupt signal, stopping all Skaffold sessions...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// newMultiSession creates a session with colour-coded prefixes padded to the longest name
+func newMultiSession(services []sessionService, args []string, out io.Writer) *multiSession {
+	width := 0
+	for _, service := range services {
+		if len(service.Name) > width {
+			width = len(service.Name)
+		}
+	}
+	for i := range services {
+		color := logs.PrefixColors[i%len(logs.PrefixColors)]
+		services[i].prefix = color.Sprint(fmt.Sprintf("%-*s |", width, services[i].Name))
+	}
+
+	return &multiSession{
+		services:   services,
+		args:       args,
+		out:        out,
+		retryDelay: skaffoldRetryDelay,
+		newCommand: newSkaffoldCommand,
+	}
+}
+
+// Run starts every service and waits until all of them have exited
+func (m *multiSession) Run(ctx context.Context) []sessionResult {
+	results := make([]sessionResult, len(m.services))
+
+	var wg sync.WaitGroup
+	for i, service := range m.services {
+		wg.Add(1)
+		go func(i int, service sessionService) {
+			defer wg.Done()
+			results[i] = m.runService(ctx, service)
+		}(i, service)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runService runs one skaffold process with retries; its failure never affects the other services
+func (m *multiSession) runService(ctx context.Context, service sessionService) sessionResult {
+	result := sessionResult{Name: service.Name}
+	args := append(append([]string{}, m.args...), "-n", service.Namespace)
+
+	for attempt := 1; attempt <= maxSkaffoldAttempts; attempt++ {
+		result.Attempts = attempt
+		if attempt > 1 {
+			m.printLine(service, fmt.Sprintf("Skaffold attempt %d/%d (retrying after error)...", attempt, maxSkaffoldAttempts))
+			select {
+			case <-time.After(m.retryDelay):
+			case <-ctx.Done():
+				return result
+			}
+		}
+
+		err := m.runOnce(ctx, service, args)
+		if err == nil || ctx.Err() != nil {
+			result.Err = nil
+			return result
+		}
+
+		result.Err = err
+		m.printLine(service, pterm.Red(fmt.Sprintf("Skaffold attempt %d failed: %v", attempt, err)))
+	}
+
+	return result
+}
+
+// runOnce runs a single skaffold process and streams its prefixed output
+func (m *multiSession) runOnce(ctx context.Context, service sessionService, args []string) error {
+	cmd := m.newCommand(ctx, service, args)
+
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			m.printLine(service, scanner.Text())
+		}
+		// Drain anything left after a scanner error so the process never blocks on the pipe
+		io.Copy(io.Discard, reader)
+	}()
+
+	err := cmd.Run()
+	writer.Close()
+	<-done
+
+	return err
+}
+
+// printLine writes one prefixed line without interleaving with other services
+func (m *multiSession) printLine(service sessionService, line string) {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	fmt.Fprintf(m.out, "%s %s\n", service.prefix, strings.TrimRight(line, "\r"))
+}
+
+// newSkaffoldCommand runs skaffold in the service directory and interrupts it on cancellation
+func newSkaffoldCommand(ctx context.Context, service sessionService, args []string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "skaffold", args...)
+	cmd.Dir = service.Dir
+	// Skaffold should see a single interrupt, sent by Cancel, so it cleans up its deployments once
+	isolateProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = skaffoldStopTimeout
+	return cmd
+}
+
+// reportSessionResults prints a summary and fails only when a service failed on its own
+func reportSessionResults(results []sessionResult, interrupted bool) error {
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Name)
+		}
+	}
+
+	tableData := pterm.TableData{{"SERVICE", "STATUS", "ATTEMPTS"}}
+	for _, result := range results {
+		status := pterm.Green("stopped")
+		if result.Err != nil {
+			status = pterm.Red("failed: " + result.Err.Error())
+		}
+		tableData = append(tableData, []string{result.Name, status, fmt.Sprintf("%d", result.Attempts)})
+	}
+
+	pterm.Println()
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		for _, row := range tableData[1:] {
+			fmt.Printf("%s\t%s\t%s\n", row[0], row[1], row[2])
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("skaffold failed for %d of %d services: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	if interrupted {
+		pterm.Info.Println("Skaffold development session stopped")
+	} else {
+		pterm.Info.Println("Skaffold development session completed")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer is a bytes.Buffer safe for the concurrent writers of a session
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newShellSession runs a shell script per service instead of skaffold
+func newShellSession(out *syncBuffer, scripts map[string]string, names ...string) *multiSession {
+	services := make([]sessionService, 0, len(names))
+	for _, name := range names {
+		services = append(services, sessionService{Name: name, Dir: os.TempDir(), Namespace: "microservices"})
+	}
+
+	session := newMultiSession(services, []string{"dev"}, out)
+	session.retryDelay = 0
+	session.newCommand = func(ctx context.Context, service sessionService, args []string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, "sh", "-c", scripts[service.Name])
+		cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+		cmd.WaitDelay = 5 * time.Second
+		return cmd
+	}
+	return session
+}
+
+func TestMultiSession_FailureIsIsolated(t *testing.T) {
+	out := &syncBuffer{}
+	session := newShellSession(out, map[string]string{
+		"openframe-api":     "echo deployed api",
+		"openframe-gateway": "echo build failed >&2; exit 1",
+	}, "openframe-api", "openframe-gateway")
+
+	results := session.Run(context.Background())
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "openframe-api", results[0].Name)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, 1, results[0].Attempts)
+
+	assert.Equal(t, "openframe-gateway", results[1].Name)
+	assert.Error(t, results[1].Err)
+	assert.Equal(t, maxSkaffoldAttempts, results[1].Attempts)
+
+	output := pterm.RemoveColorFromString(out.String())
+	assert.Contains(t, output, "openframe-api     | deployed api")
+	assert.Contains(t, output, "openframe-gateway | build failed")
+	assert.Contains(t, output, "retrying after error")
+}
+
+func TestMultiSession_CancelStopsAll(t *testing.T) {
+	out := &syncBuffer{}
+	session := newShellSession(out, map[string]string{
+		"openframe-api":     "echo started; exec sleep 30",
+		"openframe-gateway": "echo started; exec sleep 30",
+	}, "openframe-api", "openframe-gateway")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(300*time.Millisecond, cancel)
+
+	start := time.Now()
+	results := session.Run(ctx)
+
+	assert.Less(t, time.Since(start), 10*time.Second)
+	for _, result := range results {
+		assert.NoError(t, result.Err, "an interrupted service is not a failure")
+		assert.Equal(t, 1, result.Attempts)
+	}
+}
+
+func TestMultiSession_PassesNamespace(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+
+	session := newShellSession(&syncBuffer{}, nil, "openframe-api")
+	session.newCommand = func(ctx context.Context, service sessionService, args []string) *exec.Cmd {
+		mu.Lock()
+		received = append(received, strings.Join(args, " "))
+		mu.Unlock()
+		return exec.CommandContext(ctx, "true")
+	}
+
+	session.Run(context.Background())
+	assert.Equal(t, []string{"dev -n microservices"}, received)
+}
+
+func TestReportSessionResults(t *testing.T) {
+	err := reportSessionResults([]sessionResult{
+		{Name: "openframe-api", Attempts: 1},
+		{Name: "openframe-gateway", Attempts: 3, Err: errors.New("exit status 1")},
+	}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 services: openframe-gateway")
+
+	assert.NoError(t, reportSessionResults([]sessionResult{{Name: "openframe-api", Attempts: 1}}, true))
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package scaffold
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// isolateProcessGroup keeps the terminal's Ctrl+C from reaching the process directly
+func isolateProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package scaffold
+
+import "os/exec"
+
+// isolateProcessGroup is a no-op on Windows, where console interrupts are delivered differently
+func isolateProcessGroup(cmd *exec.Cmd) {}
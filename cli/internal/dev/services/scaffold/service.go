@@ -47,8 +47,8 @@ func NewService(executor executor.CommandExecutor, verbose bool) *Service {
 func (s *Service) RunScaffoldWorkflow(ctx context.Context, args []string, flags *models.ScaffoldFlags) error {
 	// Prerequisites are checked in PersistentPreRunE, so we can proceed directly
 
-	// Step 1: Select skaffold configuration
-	selectedService, err := s.ShowSkaffoldConfigInfoAndSelectService()
+	// Step 1: Select skaffold configurations
+	selectedServices, err := s.selectServices(flags)
 	if err != nil {
 		if errors.Is(err, ui.ErrNoSkaffoldFiles) {
 			os.Exit(1) // Exit silently with error code
@@ -56,14 +56,22 @@ func (s *Service) RunScaffoldWorkflow(ctx context.Context, args []string, flags
 		return err // Return other errors normally
 	}
 
-	pterm.Info.Printf("Using skaffold configuration: %s\n", selectedService.FilePath)
+	for _, selected := range selectedServices {
+		pterm.Info.Printf("Using skaffold configuration: %s\n", selected.FilePath)
+	}
 
 	// Step 2: Get cluster name (from args or interactive selection)
 	clusterName, err := s.getClusterName(args)
-	if err != nil {
+	if err != nil || clusterName == "" {
 		return err
 	}
 
+	// Skaffold, kubectl and the chart install all run against the selected cluster, even
+	// with --skip-bootstrap, instead of the current kubectl context
+	if err := clusterUtils.GetCommandService().TargetCluster(clusterName); err != nil {
+		return fmt.Errorf("failed to target cluster %s: %w", clusterName, err)
+	}
+
 	// Step 3: Install charts on the cluster and wait for completion
 	if !flags.SkipBootstrap {
 
@@ -109,7 +117,10 @@ func (s *Service) RunScaffoldWorkflow(ctx context.Context, args []string, flags
 	}
 
 	// Step 4: Run Skaffold development workflow
-	if err := s.runSkaffoldDev(ctx, selectedService, flags); err != nil {
+	if len(selectedServices) > 1 {
+		return s.runSkaffoldDevMulti(ctx, selectedServices, flags)
+	}
+	if err := s.runSkaffoldDev(ctx, selectedServices[0], flags); err != nil {
 		return fmt.Errorf("skaffold dev failed: %w", err)
 	}
 
@@ -267,12 +278,12 @@ func (s *Service) runSkaffoldDev(ctx context.Context, selectedService *ui.Servic
 	}
 
 	// Retry logic: run up to 3 times with 3 second delays
-	maxRetries := 3
+	maxRetries := maxSkaffoldAttempts
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		if attempt > 1 {
 			pterm.Warning.Printf("Skaffold attempt %d/%d (retrying after error)...\n", attempt, maxRetries)
 			// Wait 3 seconds between retries
-			time.Sleep(3 * time.Second)
+			time.Sleep(skaffoldRetryDelay)
 		}
 
 		// Execute the command directly and let it inherit stdout/stderr
@@ -361,6 +372,19 @@ func (s *Service) Stop() error {
 	return nil
 }
 
+// selectServices resolves --services or falls back to the interactive single selection
+func (s *Service) selectServices(flags *models.ScaffoldFlags) ([]*ui.ServiceSelection, error) {
+	if len(flags.Services) > 0 {
+		return ui.NewSkaffoldUI(s.verbose).SelectServicesByName(flags.Services)
+	}
+
+	selected, err := s.ShowSkaffoldConfigInfoAndSelectService()
+	if err != nil {
+		return nil, err
+	}
+	return []*ui.ServiceSelection{selected}, nil
+}
+
 // ShowSkaffoldConfigInfoAndSelectService displays skaffold files and prompts user to select one
 func (s *Service) ShowSkaffoldConfigInfoAndSelectService() (*ui.ServiceSelection, error) {
 	skaffoldUI := ui.NewSkaffoldUI(s.verbose)
@@ -53,7 +53,7 @@ func (su *SkaffoldUI) DiscoverAndSelectService() (*ServiceSelection, error) {
 
 	if len(skaffoldFiles) == 0 {
 		pterm.Warning.Println("No skaffold.yaml files found in project directory")
-		pterm.Info.Println("Create a skaffold.yaml file in your service directory to get started.")
+		pterm.Info.Println("Generate one with 'openframe dev skaffold init <service>' or create a skaffold.yaml file in your service directory.")
 		pterm.Info.Println("Examples: https://skaffold.dev/docs/references/yaml/")
 		return nil, ErrNoSkaffoldFiles
 	}
@@ -106,6 +106,48 @@ func (su *SkaffoldUI) DiscoverAndSelectService() (*ServiceSelection, error) {
 	}, nil
 }
 
+// SelectServicesByName resolves service names to their discovered skaffold configurations
+func (su *SkaffoldUI) SelectServicesByName(names []string) ([]*ServiceSelection, error) {
+	files := su.findSkaffoldYamlFiles("../")
+	if len(files) == 0 {
+		return nil, ErrNoSkaffoldFiles
+	}
+
+	byName := make(map[string]SkaffoldFile, len(files))
+	available := make([]string, 0, len(files))
+	for _, file := range files {
+		if _, exists := byName[file.ServiceName]; !exists {
+			available = append(available, file.ServiceName)
+		}
+		byName[file.ServiceName] = file
+	}
+
+	seen := make(map[string]bool)
+	var selections []*ServiceSelection
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		file, exists := byName[name]
+		if !exists {
+			return nil, fmt.Errorf("no skaffold configuration found for service %s (available: %s)", name, strings.Join(available, ", "))
+		}
+		selections = append(selections, &ServiceSelection{
+			ServiceName: file.ServiceName,
+			FilePath:    file.FilePath,
+			Directory:   filepath.Dir(file.FilePath),
+		})
+	}
+
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("no services specified")
+	}
+	return selections, nil
+}
+
 // findSkaffoldYamlFiles recursively searches for skaffold.yaml files
 func (su *SkaffoldUI) findSkaffoldYamlFiles(rootPath string) []SkaffoldFile {
 	var files []SkaffoldFile
@@ -379,3 +379,37 @@ func TestSkaffoldUI_categorizeSkaffoldFiles_Sorting(t *testing.T) {
 	assert.Equal(t, "openframe-frontend", openframeFiles[1].ServiceName)
 	assert.Equal(t, "openframe-gateway", openframeFiles[2].ServiceName)
 }
+
+func TestSkaffoldUI_SelectServicesByName(t *testing.T) {
+	// Discovery searches the parent directory, so run from a sibling of openframe/
+	tmpDir := t.TempDir()
+	for _, service := range []string{"openframe-api", "openframe-gateway"} {
+		dir := filepath.Join(tmpDir, "openframe", "services", service)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "skaffold.yaml"), []byte("# Test skaffold file"), 0644))
+	}
+	cliDir := filepath.Join(tmpDir, "cli")
+	require.NoError(t, os.MkdirAll(cliDir, 0755))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(cliDir))
+
+	ui := NewSkaffoldUI(false)
+
+	selections, err := ui.SelectServicesByName([]string{"openframe-gateway", " openframe-api", "openframe-gateway"})
+	require.NoError(t, err)
+	require.Len(t, selections, 2, "duplicates are ignored")
+	assert.Equal(t, "openframe-gateway", selections[0].ServiceName)
+	assert.Equal(t, filepath.Join("..", "openframe", "services", "openframe-gateway"), selections[0].Directory)
+	assert.Equal(t, "openframe-api", selections[1].ServiceName)
+
+	_, err = ui.SelectServicesByName([]string{"openframe-api", "unknown"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no skaffold configuration found for service unknown")
+	assert.Contains(t, err.Error(), "openframe-api, openframe-gateway")
+
+	_, err = ui.SelectServicesByName([]string{" "})
+	assert.Error(t, err)
+}
@@ -0,0 +1,408 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/certificates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/helm"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/memory"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/models"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/prerequisites/docker"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/prerequisites/k3d"
+	"github.com/flamingo-stack/openframe/openframe/internal/cluster/prerequisites/kubectl"
+	k3dProvider "github.com/flamingo-stack/openframe/openframe/internal/cluster/providers/k3d"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/prerequisites/jq"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/prerequisites/scaffold"
+	"github.com/flamingo-stack/openframe/openframe/internal/dev/prerequisites/telepresence"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/tools"
+)
+
+const (
+	// certificateExpiryWarning is how close to expiry the certificates check starts warning
+	certificateExpiryWarning = 30 * 24 * time.Hour
+	// recommendedDockerCPUs is the number of CPUs the OpenFrame workloads need to start in time
+	recommendedDockerCPUs = 4
+	// recommendedInotifyWatches and recommendedInotifyInstances are what the k3s nodes and
+	// the file watchers of skaffold need to not run out of inotify resources
+	recommendedInotifyWatches   = 524288
+	recommendedInotifyInstances = 512
+)
+
+// versionPattern finds the first version number in the output of a version command
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// version is a major.minor.patch version
+type version [3]int
+
+func parseVersion(output string) (version, bool) {
+	match := versionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return version{}, false
+	}
+	var v version
+	for i := range v {
+		v[i], _ = strconv.Atoi(match[i+1])
+	}
+	return v, true
+}
+
+func (v version) less(other version) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+// toolRequirement is a tool the CLI runs and the versions it supports
+type toolRequirement struct {
+	name   string
+	args   []string // Arguments printing the version
+	min    version  // Oldest supported version
+	below  version  // First unsupported newer version, zero for no bound
+	usedBy string   // Commands needing an optional tool, empty for required tools
+	help   func() string
+}
+
+// supportedRange formats the supported versions of a tool like ">= 3.12, < 4.0"
+func (t toolRequirement) supportedRange() string {
+	text := ">= " + strings.TrimSuffix(t.min.String(), ".0")
+	if t.below != (version{}) {
+		text += ", < " + strings.TrimSuffix(t.below.String(), ".0")
+	}
+	return text
+}
+
+// toolRequirements lists the tools of the cluster, chart and dev commands
+func toolRequirements() []toolRequirement {
+	// kubectl supports one minor of skew to each side of the tested Kubernetes minors
+	minors := k3dProvider.SupportedKubernetesMinors()
+	kubectlMin := version{1, minors[0] - 1, 0}
+	kubectlBelow := version{1, minors[len(minors)-1] + 2, 0}
+
+	return []toolRequirement{
+		{name: "docker", args: []string{"version", "--format", "{{.Client.Version}}"}, min: version{20, 10, 0},
+			help: docker.NewDockerInstaller().GetInstallHelp},
+		{name: "k3d", args: []string{"version"}, min: version{5, 6, 0}, below: version{6, 0, 0},
+			help: k3d.NewK3dInstaller().GetInstallHelp},
+		{name: "kubectl", args: []string{"version", "--client"}, min: kubectlMin, below: kubectlBelow,
+			help: kubectl.NewKubectlInstaller().GetInstallHelp},
+		{name: "helm", args: []string{"version", "--short"}, min: version{3, 12, 0}, below: version{4, 0, 0},
+			help: helm.NewHelmInstaller().GetInstallHelp},
+		{name: "git", args: []string{"--version"}, min: version{2, 20, 0},
+			help: func() string {
+				return "git: Install it with your package manager or from https://git-scm.com/downloads"
+			}},
+		{name: "telepresence", args: []string{"version"}, min: version{2, 20, 0}, below: version{3, 0, 0},
+			usedBy: "'openframe dev intercept'", help: telepresence.NewTelepresenceInstaller().GetInstallHelp},
+		{name: "skaffold", args: []string{"version"}, min: version{2, 0, 0}, below: version{3, 0, 0},
+			usedBy: "'openframe dev skaffold'", help: scaffold.NewScaffoldInstaller().GetInstallHelp},
+		{name: "jq", args: []string{"--version"}, min: version{1, 6, 0},
+			usedBy: "'openframe dev intercept'", help: jq.NewJqInstaller().GetInstallHelp},
+	}
+}
+
+// installHint points at 'openframe tools install' when the tool manifest pins the tool
+// for this platform, and at the tool's own installation otherwise
+func installHint(tool toolRequirement) string {
+	if manager, err := tools.NewDefaultManager(""); err == nil && manager.Installable(tool.name) {
+		return fmt.Sprintf("Run 'openframe tools install %s'", tool.name)
+	}
+	return tool.help()
+}
+
+func (d *Doctor) checkTool(ctx context.Context, tool toolRequirement) Result {
+	if _, err := d.lookPath(tool.name); err != nil {
+		if tool.usedBy != "" {
+			return warn("not installed, needed by "+tool.usedBy, installHint(tool))
+		}
+		return fail("not installed", installHint(tool))
+	}
+
+	result, err := d.executor.Execute(ctx, tool.name, tool.args...)
+	if err != nil {
+		return warn(fmt.Sprintf("installed, but '%s %s' failed: %v", tool.name, strings.Join(tool.args, " "), err),
+			fmt.Sprintf("Check that %s runs, or reinstall it", tool.name))
+	}
+	installed, ok := parseVersion(result.Stdout)
+	if !ok {
+		return warn("installed, version unknown", fmt.Sprintf("Supported versions: %s", tool.supportedRange()))
+	}
+
+	switch {
+	case installed.less(tool.min):
+		message := fmt.Sprintf("%s is older than supported (%s)", installed, tool.supportedRange())
+		if tool.usedBy != "" {
+			return warn(message, installHint(tool))
+		}
+		return fail(message, installHint(tool))
+	case tool.below != (version{}) && !installed.less(tool.below):
+		return warn(fmt.Sprintf("%s is newer than tested (%s)", installed, tool.supportedRange()), installHint(tool))
+	}
+	return pass(installed.String())
+}
+
+// dockerInfo is the part of 'docker info' the doctor reads
+type dockerInfo struct {
+	ServerVersion   string `json:"ServerVersion"`
+	OperatingSystem string `json:"OperatingSystem"`
+	NCPU            int    `json:"NCPU"`
+	MemTotal        int64  `json:"MemTotal"`
+}
+
+func (d *Doctor) checkDocker(ctx context.Context) Result {
+	if _, err := d.lookPath("docker"); err != nil {
+		return fail("docker is not installed", "")
+	}
+	result, err := d.executor.Execute(ctx, "docker", "info", "--format", "{{json .}}")
+	if err != nil {
+		return fail("the Docker daemon is not reachable", "Start Docker Desktop or the Docker service, and check DOCKER_HOST and the current docker context")
+	}
+	var info dockerInfo
+	if err := json.Unmarshal([]byte(strings.TrimSpace(result.Stdout)), &info); err != nil {
+		return warn("the Docker daemon answered, but its info could not be read", "Run 'docker info' to check the daemon")
+	}
+
+	memoryMB := int(info.MemTotal / 1024 / 1024)
+	message := fmt.Sprintf("%s on %s, %d CPUs, %.1f GB memory", info.ServerVersion, info.OperatingSystem, info.NCPU, float64(memoryMB)/1024)
+	var short []string
+	if info.NCPU < recommendedDockerCPUs {
+		short = append(short, fmt.Sprintf("%d CPUs recommended", recommendedDockerCPUs))
+	}
+	if memoryMB < memory.RecommendedMemoryMB {
+		short = append(short, fmt.Sprintf("%.0f GB memory recommended", float64(memory.RecommendedMemoryMB)/1024))
+	}
+	if len(short) > 0 {
+		return warn(message+"; "+strings.Join(short, ", "), "Give Docker more resources, in Docker Desktop under Settings > Resources")
+	}
+	return pass(message)
+}
+
+func (d *Doctor) checkPorts(ctx context.Context) Result {
+	var busy []string
+	for _, port := range []int{models.DefaultAPIPort, models.DefaultHTTPPort, models.DefaultHTTPSPort} {
+		if d.dockerHost != "" {
+			if !d.remotePort(d.dockerHost, port) {
+				busy = append(busy, strconv.Itoa(port))
+			}
+			continue
+		}
+		listener, err := d.listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			busy = append(busy, strconv.Itoa(port))
+			continue
+		}
+		listener.Close()
+	}
+
+	// With a remote Docker host the clusters publish their ports there
+	where := ""
+	if d.dockerHost != "" {
+		where = " on " + d.dockerHost
+	}
+	if len(busy) > 0 {
+		return warn(fmt.Sprintf("%s in use%s, by a running cluster or another service", strings.Join(busy, ", "), where),
+			"New clusters get other ports, 'openframe cluster ports' shows them. Stop the other service to keep the defaults")
+	}
+	return pass(fmt.Sprintf("%d, %d and %d are free%s", models.DefaultAPIPort, models.DefaultHTTPPort, models.DefaultHTTPSPort, where))
+}
+
+func (d *Doctor) checkMemory(ctx context.Context) Result {
+	total := d.memoryMB()
+	if total == 0 {
+		return warn("total memory unknown", "")
+	}
+	message := fmt.Sprintf("%.1f GB", float64(total)/1024)
+	if total < memory.RecommendedMemoryMB {
+		return warn(fmt.Sprintf("%s, %.0f GB recommended", message, float64(memory.RecommendedMemoryMB)/1024),
+			"Close other applications or use a machine with more memory, OpenFrame may not start all of its services")
+	}
+	return pass(message)
+}
+
+func (d *Doctor) checkInotify(ctx context.Context) Result {
+	limits := []struct {
+		key         string
+		recommended int
+	}{
+		{"max_user_watches", recommendedInotifyWatches},
+		{"max_user_instances", recommendedInotifyInstances},
+	}
+
+	var values, low, fixes []string
+	for _, limit := range limits {
+		data, err := d.readFile("/proc/sys/fs/inotify/" + limit.key)
+		if err != nil {
+			return warn("inotify limits unknown: "+err.Error(), "")
+		}
+		value, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+		values = append(values, fmt.Sprintf("%s %d", limit.key, value))
+		if value < limit.recommended {
+			low = append(low, fmt.Sprintf("%s %d < %d", limit.key, value, limit.recommended))
+			fixes = append(fixes, fmt.Sprintf("fs.inotify.%s=%d", limit.key, limit.recommended))
+		}
+	}
+	if len(low) > 0 {
+		return warn(strings.Join(low, ", "),
+			fmt.Sprintf("Run 'sudo sysctl %s' and add the settings to /etc/sysctl.d to keep them", strings.Join(fixes, " ")))
+	}
+	return pass(strings.Join(values, ", "))
+}
+
+func (d *Doctor) checkCertificates(ctx context.Context) Result {
+	status, err := d.certificates()
+	if err != nil {
+		return fail("the local CA could not be read: "+err.Error(), "Run 'openframe chart certificates renew' to issue new certificates")
+	}
+	if status.CA == nil {
+		return warn("no local CA yet", "'openframe chart install' creates it, or run 'openframe chart certificates renew'")
+	}
+
+	now := d.now()
+	for _, cert := range []struct {
+		name string
+		info *certificates.CertificateInfo
+	}{{"root CA", status.CA}, {"ingress certificate", status.Leaf}} {
+		if cert.info == nil {
+			continue
+		}
+		if !now.Before(cert.info.NotAfter) {
+			return fail(fmt.Sprintf("the %s expired on %s", cert.name, cert.info.NotAfter.Format("2006-01-02")),
+				"Run 'openframe chart certificates renew'")
+		}
+		if cert.info.ExpiresWithin(now, certificateExpiryWarning) {
+			return warn(fmt.Sprintf("the %s expires on %s", cert.name, cert.info.NotAfter.Format("2006-01-02")),
+				"Run 'openframe chart certificates renew'")
+		}
+	}
+	if status.Leaf == nil {
+		return pass(fmt.Sprintf("root CA valid until %s, no ingress certificate issued yet", status.CA.NotAfter.Format("2006-01-02")))
+	}
+	return pass(fmt.Sprintf("valid until %s for %s", status.Leaf.NotAfter.Format("2006-01-02"), strings.Join(status.Leaf.Hosts, ", ")))
+}
+
+func (d *Doctor) checkKubeContext(ctx context.Context) Result {
+	if _, err := d.lookPath("kubectl"); err != nil {
+		return warn("kubectl is not installed", "")
+	}
+	result, err := d.executor.Execute(ctx, "kubectl", "config", "current-context")
+	current := ""
+	if err == nil {
+		current = strings.TrimSpace(result.Stdout)
+	}
+	if current == "" {
+		return warn("no current context", "Create a cluster with 'openframe cluster create', or switch to one with 'openframe cluster use'")
+	}
+
+	if _, err := d.executor.Execute(ctx, "kubectl", "--context", current, "get", "--raw", "/readyz", "--request-timeout=5s"); err != nil {
+		return warn(fmt.Sprintf("%s: the API server is not reachable", current),
+			"Start the cluster, or switch to a running one with 'openframe cluster use'")
+	}
+	return pass(current + ", API server ready")
+}
+
+// telepresenceDaemon is the part of a daemon in 'telepresence status --output json'
+type telepresenceDaemon struct {
+	Running bool   `json:"running"`
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+// telepresenceStatus is the output of 'telepresence status --output json', which nests
+// the daemons under "stdout" in older releases
+type telepresenceStatus struct {
+	UserDaemon *telepresenceDaemon `json:"user_daemon"`
+	RootDaemon *telepresenceDaemon `json:"root_daemon"`
+	Stdout     *telepresenceStatus `json:"stdout"`
+}
+
+func (d *Doctor) checkTelepresence(ctx context.Context) Result {
+	if _, err := d.lookPath("telepresence"); err != nil {
+		return pass("telepresence is not installed, nothing to check")
+	}
+	result, err := d.executor.Execute(ctx, "telepresence", "status", "--output", "json")
+	if err != nil {
+		return warn("the daemon status could not be read", "Run 'telepresence quit -s' to stop stale daemons, 'openframe dev intercept' starts them again")
+	}
+	var status telepresenceStatus
+	if err := json.Unmarshal([]byte(result.Stdout), &status); err != nil {
+		return warn("the daemon status could not be parsed", "Run 'telepresence status' to check the daemons")
+	}
+	if status.Stdout != nil {
+		status = *status.Stdout
+	}
+
+	user := status.UserDaemon != nil && status.UserDaemon.Running
+	root := status.RootDaemon != nil && status.RootDaemon.Running
+	switch {
+	case !user && !root:
+		return pass("not running, 'openframe dev intercept' starts it")
+	case user != root:
+		return warn("only one of the user and root daemons is running",
+			"Run 'telepresence quit -s' to stop stale daemons, 'openframe dev intercept' starts them again")
+	case status.UserDaemon.Version != "" && status.RootDaemon.Version != "" && status.UserDaemon.Version != status.RootDaemon.Version:
+		return warn(fmt.Sprintf("the user daemon runs %s, the root daemon %s", status.UserDaemon.Version, status.RootDaemon.Version),
+			"Run 'telepresence quit -s' so the daemons of the installed version start")
+	}
+	return pass(fmt.Sprintf("running, %s", strings.ToLower(status.UserDaemon.Status)))
+}
+
+// ingressHosts returns the host names of the ingress: localhost, the names of the
+// issued certificate and the hosts given to the doctor, without IP addresses and wildcards
+func (d *Doctor) ingressHosts() []string {
+	hosts := append([]string{"localhost"}, d.hosts...)
+	if status, err := d.certificates(); err == nil && status.Leaf != nil {
+		hosts = append(hosts, status.Leaf.Hosts...)
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	for _, host := range hosts {
+		host = strings.ToLower(host)
+		if host == "" || seen[host] || strings.HasPrefix(host, "*.") || net.ParseIP(host) != nil {
+			continue
+		}
+		seen[host] = true
+		names = append(names, host)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d *Doctor) checkDNS(ctx context.Context) Result {
+	var resolved, unresolved []string
+	for _, host := range d.ingressHosts() {
+		addresses, err := d.lookupHost(ctx, host)
+		if err != nil || len(addresses) == 0 {
+			unresolved = append(unresolved, host)
+			continue
+		}
+		resolved = append(resolved, fmt.Sprintf("%s -> %s", host, strings.Join(addresses, ", ")))
+	}
+	if len(unresolved) > 0 {
+		// The ingress listens where Docker publishes the cluster ports
+		address := "127.0.0.1"
+		if d.dockerHost != "" {
+			address = d.dockerHost
+			if net.ParseIP(address) == nil {
+				address = "<address of " + d.dockerHost + ">"
+			}
+		}
+		return fail(fmt.Sprintf("%s do not resolve", strings.Join(unresolved, ", ")),
+			fmt.Sprintf("Add '%s %s' to /etc/hosts, or point the names at the cluster in your DNS", address, strings.Join(unresolved, " ")))
+	}
+	return pass(strings.Join(resolved, "; "))
+}
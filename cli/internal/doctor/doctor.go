@@ -0,0 +1,175 @@
+package doctor
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/certificates"
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/memory"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/docker"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+)
+
+// checkTimeout bounds every check, so one hanging daemon does not stall the report
+const checkTimeout = 20 * time.Second
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Result is the outcome of one check
+type Result struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Status   Status `json:"status"`
+	Message  string `json:"message"`
+	Hint     string `json:"hint,omitempty"` // How to fix a warning or failure
+}
+
+// Report is the outcome of every check, in the order the checks are listed
+type Report struct {
+	Version  string    `json:"version,omitempty"` // CLI version
+	Platform string    `json:"platform"`
+	Time     time.Time `json:"time"`
+	Checks   []Result  `json:"checks"`
+}
+
+// Count returns the number of checks with status
+func (r Report) Count(status Status) int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Check is one health check of the environment
+type Check struct {
+	Category string
+	Name     string
+	Run      func(ctx context.Context) Result // Category and Name are filled in by the doctor
+}
+
+// Doctor checks the environment the CLI runs in. System access goes through its fields,
+// so that tests can replace it.
+type Doctor struct {
+	executor     executor.CommandExecutor
+	lookPath     func(file string) (string, error)
+	listen       func(network, address string) (net.Listener, error)
+	dockerHost   string // Remote Docker host the cluster ports are published on
+	remotePort   func(host string, port int) bool
+	lookupHost   func(ctx context.Context, host string) ([]string, error)
+	readFile     func(name string) ([]byte, error)
+	memoryMB     func() int
+	certificates func() (*certificates.CertificateStatus, error)
+	now          func() time.Time
+	goos         string
+	hosts        []string
+}
+
+// NewDoctor creates a doctor running its commands through commandExecutor
+func NewDoctor(commandExecutor executor.CommandExecutor) *Doctor {
+	return &Doctor{
+		executor:   commandExecutor,
+		lookPath:   exec.LookPath,
+		listen:     net.Listen,
+		dockerHost: docker.RemoteHost(),
+		remotePort: docker.IsPortAvailable,
+		lookupHost: net.DefaultResolver.LookupHost,
+		readFile:   os.ReadFile,
+		memoryMB: func() int {
+			current, _, _ := memory.NewMemoryChecker().GetMemoryInfo()
+			return current
+		},
+		certificates: func() (*certificates.CertificateStatus, error) {
+			return certificates.NewCertificateInstaller().Status()
+		},
+		now:  time.Now,
+		goos: runtime.GOOS,
+	}
+}
+
+// WithHosts adds ingress hosts to the DNS check
+func (d *Doctor) WithHosts(hosts ...string) *Doctor {
+	d.hosts = append(d.hosts, hosts...)
+	return d
+}
+
+// Run runs every check concurrently and returns the report
+func (d *Doctor) Run(ctx context.Context) Report {
+	checks := d.Checks()
+	results := make([]Result, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			result := check.Run(checkCtx)
+			result.Category = check.Category
+			result.Name = check.Name
+			results[i] = result
+		}(i, check)
+	}
+	wg.Wait()
+
+	return Report{
+		Platform: d.goos + "/" + runtime.GOARCH,
+		Time:     d.now(),
+		Checks:   results,
+	}
+}
+
+// Checks returns the checks of the environment, tools first
+func (d *Doctor) Checks() []Check {
+	var checks []Check
+	for _, tool := range toolRequirements() {
+		tool := tool
+		checks = append(checks, Check{Category: "Tools", Name: tool.name, Run: func(ctx context.Context) Result {
+			return d.checkTool(ctx, tool)
+		}})
+	}
+
+	checks = append(checks,
+		Check{Category: "Docker", Name: "Daemon", Run: d.checkDocker},
+		Check{Category: "Host", Name: "Ports", Run: d.checkPorts},
+		Check{Category: "Host", Name: "Memory", Run: d.checkMemory},
+	)
+	if d.goos == "linux" {
+		checks = append(checks, Check{Category: "Host", Name: "inotify", Run: d.checkInotify})
+	}
+	checks = append(checks,
+		Check{Category: "Certificates", Name: "Local CA", Run: d.checkCertificates},
+		Check{Category: "Kubernetes", Name: "Context", Run: d.checkKubeContext},
+		Check{Category: "Dev", Name: "Telepresence daemon", Run: d.checkTelepresence},
+		Check{Category: "Network", Name: "Ingress DNS", Run: d.checkDNS},
+	)
+	return checks
+}
+
+func pass(message string) Result {
+	return Result{Status: StatusPass, Message: message}
+}
+
+func warn(message, hint string) Result {
+	return Result{Status: StatusWarn, Message: message, Hint: hint}
+}
+
+func fail(message, hint string) Result {
+	return Result{Status: StatusFail, Message: message, Hint: hint}
+}
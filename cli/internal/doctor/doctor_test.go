@@ -0,0 +1,327 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flamingo-stack/openframe/openframe/internal/chart/prerequisites/certificates"
+	"github.com/flamingo-stack/openframe/openframe/internal/shared/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutor answers commands by their full command line and is safe for the
+// concurrent checks
+type fakeExecutor struct {
+	mu        sync.Mutex
+	responses map[string]string
+}
+
+func (f *fakeExecutor) Execute(ctx context.Context, name string, args ...string) (*executor.CommandResult, error) {
+	command := strings.Join(append([]string{name}, args...), " ")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stdout, ok := f.responses[command]
+	if !ok {
+		return &executor.CommandResult{ExitCode: 1}, errors.New("command failed: " + command)
+	}
+	return &executor.CommandResult{Stdout: stdout}, nil
+}
+
+func (f *fakeExecutor) ExecuteWithOptions(ctx context.Context, options executor.ExecuteOptions) (*executor.CommandResult, error) {
+	return f.Execute(ctx, options.Command, options.Args...)
+}
+
+type fakeListener struct{ net.Listener }
+
+func (fakeListener) Close() error { return nil }
+
+var testNow = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// newTestDoctor returns a doctor for a healthy linux machine
+func newTestDoctor() (*Doctor, *fakeExecutor) {
+	exec := &fakeExecutor{responses: map[string]string{
+		"docker version --format {{.Client.Version}}": "27.5.1",
+		"k3d version":                     "k3d version v5.8.3\nk3s version v1.31.5-k3s1 (default)",
+		"kubectl version --client":        "Client Version: v1.32.2\nKustomize Version: v5.5.0",
+		"helm version --short":            "v3.17.1+g980d8ac",
+		"git --version":                   "git version 2.43.0",
+		"telepresence version":            "OSS Client : v2.22.4\nRoot Daemon: not running",
+		"skaffold version":                "v2.14.1",
+		"jq --version":                    "jq-1.7.1",
+		"docker info --format {{json .}}": `{"ServerVersion":"27.5.1","OperatingSystem":"Ubuntu 24.04","NCPU":8,"MemTotal":34359738368}`,
+		"kubectl config current-context":  "k3d-dev\n",
+		"kubectl --context k3d-dev get --raw /readyz --request-timeout=5s": "ok",
+		"telepresence status --output json":                                `{"user_daemon":{"running":false},"root_daemon":{"running":false}}`,
+	}}
+
+	d := NewDoctor(exec)
+	d.lookPath = func(file string) (string, error) { return "/usr/bin/" + file, nil }
+	d.listen = func(network, address string) (net.Listener, error) { return fakeListener{}, nil }
+	d.dockerHost = ""
+	d.lookupHost = func(ctx context.Context, host string) ([]string, error) { return []string{"127.0.0.1"}, nil }
+	d.readFile = func(name string) ([]byte, error) {
+		if strings.HasSuffix(name, "max_user_watches") {
+			return []byte("524288\n"), nil
+		}
+		return []byte("8192\n"), nil
+	}
+	d.memoryMB = func() int { return 32768 }
+	d.certificates = func() (*certificates.CertificateStatus, error) {
+		return &certificates.CertificateStatus{
+			CA:   &certificates.CertificateInfo{NotAfter: testNow.AddDate(10, 0, 0)},
+			Leaf: &certificates.CertificateInfo{NotAfter: testNow.AddDate(1, 0, 0), Hosts: []string{"localhost", "127.0.0.1", "openframe.test", "*.openframe.test"}},
+		}, nil
+	}
+	d.now = func() time.Time { return testNow }
+	d.goos = "linux"
+	return d, exec
+}
+
+func TestDoctor_Run_Healthy(t *testing.T) {
+	d, _ := newTestDoctor()
+
+	report := d.Run(context.Background())
+
+	require.Len(t, report.Checks, 16)
+	assert.Equal(t, "Tools", report.Checks[0].Category)
+	assert.Equal(t, "docker", report.Checks[0].Name, "results keep the order of the checks")
+	assert.Equal(t, "Network", report.Checks[15].Category)
+	for _, check := range report.Checks {
+		assert.Equal(t, StatusPass, check.Status, "%s / %s: %s", check.Category, check.Name, check.Message)
+	}
+	assert.Equal(t, 16, report.Count(StatusPass))
+	assert.Equal(t, testNow, report.Time)
+}
+
+func TestDoctor_Checks_InotifyOnlyOnLinux(t *testing.T) {
+	d, _ := newTestDoctor()
+	d.goos = "darwin"
+
+	for _, check := range d.Checks() {
+		assert.NotEqual(t, "inotify", check.Name)
+	}
+}
+
+func TestDoctor_CheckTool(t *testing.T) {
+	tools := map[string]toolRequirement{}
+	for _, tool := range toolRequirements() {
+		tools[tool.name] = tool
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		tool    string
+		output  string
+		missing bool
+		status  Status
+		message string
+	}{
+		{"supported", "kubectl", "Client Version: v1.31.0", false, StatusPass, "1.31.0"},
+		{"required and missing", "helm", "", true, StatusFail, "not installed"},
+		{"optional and missing", "skaffold", "", true, StatusWarn, "needed by 'openframe dev skaffold'"},
+		{"too old", "kubectl", "Client Version: v1.27.4", false, StatusFail, "1.27.4 is older than supported (>= 1.29, < 1.34)"},
+		{"optional and too old", "jq", "jq-1.5", false, StatusWarn, "older than supported"},
+		{"newer than tested", "helm", "v4.0.0+gabc", false, StatusWarn, "4.0.0 is newer than tested (>= 3.12, < 4.0)"},
+		{"version unknown", "git", "git", false, StatusWarn, "version unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, exec := newTestDoctor()
+			tool := tools[tt.tool]
+			exec.responses[tt.tool+" "+strings.Join(tool.args, " ")] = tt.output
+			if tt.missing {
+				d.lookPath = func(file string) (string, error) { return "", errors.New("not found") }
+			}
+
+			result := d.checkTool(ctx, tool)
+			assert.Equal(t, tt.status, result.Status)
+			assert.Contains(t, result.Message, tt.message)
+			if tt.status != StatusPass {
+				assert.NotEmpty(t, result.Hint)
+			}
+		})
+	}
+}
+
+func TestDoctor_CheckDocker(t *testing.T) {
+	d, exec := newTestDoctor()
+	ctx := context.Background()
+
+	result := d.checkDocker(ctx)
+	assert.Equal(t, StatusPass, result.Status)
+	assert.Equal(t, "27.5.1 on Ubuntu 24.04, 8 CPUs, 32.0 GB memory", result.Message)
+
+	exec.responses["docker info --format {{json .}}"] = `{"ServerVersion":"27.5.1","OperatingSystem":"Docker Desktop","NCPU":2,"MemTotal":8589934592}`
+	result = d.checkDocker(ctx)
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Contains(t, result.Message, "4 CPUs recommended, 15 GB memory recommended")
+
+	delete(exec.responses, "docker info --format {{json .}}")
+	result = d.checkDocker(ctx)
+	assert.Equal(t, StatusFail, result.Status)
+	assert.Contains(t, result.Hint, "DOCKER_HOST")
+}
+
+func TestDoctor_CheckPorts(t *testing.T) {
+	d, _ := newTestDoctor()
+	d.listen = func(network, address string) (net.Listener, error) {
+		if address == ":80" {
+			return nil, errors.New("address already in use")
+		}
+		return fakeListener{}, nil
+	}
+
+	result := d.checkPorts(context.Background())
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "80 in use, by a running cluster or another service", result.Message)
+}
+
+func TestDoctor_CheckPorts_RemoteDockerHost(t *testing.T) {
+	d, _ := newTestDoctor()
+	d.listen = func(network, address string) (net.Listener, error) {
+		return nil, errors.New("address already in use")
+	}
+	d.dockerHost = "buildbox"
+	d.remotePort = func(host string, port int) bool { return host == "buildbox" && port != 443 }
+
+	result := d.checkPorts(context.Background())
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "443 in use on buildbox, by a running cluster or another service", result.Message, "local ports do not matter")
+}
+
+func TestDoctor_CheckInotify(t *testing.T) {
+	d, _ := newTestDoctor()
+	ctx := context.Background()
+
+	assert.Equal(t, pass("max_user_watches 524288, max_user_instances 8192"), d.checkInotify(ctx))
+
+	d.readFile = func(name string) ([]byte, error) {
+		if strings.HasSuffix(name, "max_user_watches") {
+			return []byte("524288\n"), nil
+		}
+		return []byte("128\n"), nil
+	}
+	result := d.checkInotify(ctx)
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "max_user_instances 128 < 512", result.Message, "only the low limit is reported")
+	assert.Contains(t, result.Hint, "sudo sysctl fs.inotify.max_user_instances=512")
+}
+
+func TestDoctor_CheckCertificates(t *testing.T) {
+	d, _ := newTestDoctor()
+	ctx := context.Background()
+
+	result := d.checkCertificates(ctx)
+	assert.Equal(t, StatusPass, result.Status)
+	assert.Contains(t, result.Message, "valid until 2027-10-19")
+
+	d.certificates = func() (*certificates.CertificateStatus, error) {
+		return &certificates.CertificateStatus{
+			CA:   &certificates.CertificateInfo{NotAfter: testNow.AddDate(10, 0, 0)},
+			Leaf: &certificates.CertificateInfo{NotAfter: testNow.AddDate(0, 0, 10)},
+		}, nil
+	}
+	result = d.checkCertificates(ctx)
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "the ingress certificate expires on 2026-10-29", result.Message)
+
+	d.certificates = func() (*certificates.CertificateStatus, error) {
+		return &certificates.CertificateStatus{CA: &certificates.CertificateInfo{NotAfter: testNow.Add(-time.Hour)}}, nil
+	}
+	result = d.checkCertificates(ctx)
+	assert.Equal(t, StatusFail, result.Status)
+
+	d.certificates = func() (*certificates.CertificateStatus, error) { return &certificates.CertificateStatus{}, nil }
+	result = d.checkCertificates(ctx)
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "no local CA yet", result.Message)
+}
+
+func TestDoctor_CheckKubeContext(t *testing.T) {
+	d, exec := newTestDoctor()
+	ctx := context.Background()
+
+	assert.Equal(t, pass("k3d-dev, API server ready"), d.checkKubeContext(ctx))
+
+	delete(exec.responses, "kubectl --context k3d-dev get --raw /readyz --request-timeout=5s")
+	result := d.checkKubeContext(ctx)
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Equal(t, "k3d-dev: the API server is not reachable", result.Message)
+
+	delete(exec.responses, "kubectl config current-context")
+	result = d.checkKubeContext(ctx)
+	assert.Equal(t, "no current context", result.Message)
+}
+
+func TestDoctor_CheckTelepresence(t *testing.T) {
+	d, exec := newTestDoctor()
+	ctx := context.Background()
+
+	assert.Equal(t, StatusPass, d.checkTelepresence(ctx).Status)
+
+	exec.responses["telepresence status --output json"] = `{"cmd":"status","stdout":{"user_daemon":{"running":true,"status":"Connected","version":"v2.22.4"},"root_daemon":{"running":true,"version":"v2.22.4"}}}`
+	assert.Equal(t, pass("running, connected"), d.checkTelepresence(ctx))
+
+	exec.responses["telepresence status --output json"] = `{"user_daemon":{"running":true,"version":"v2.22.4"},"root_daemon":{"running":true,"version":"v2.20.0"}}`
+	result := d.checkTelepresence(ctx)
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Contains(t, result.Message, "the root daemon v2.20.0")
+
+	exec.responses["telepresence status --output json"] = `{"user_daemon":{"running":true},"root_daemon":{"running":false}}`
+	assert.Equal(t, StatusWarn, d.checkTelepresence(ctx).Status)
+}
+
+func TestDoctor_CheckDNS(t *testing.T) {
+	d, _ := newTestDoctor()
+	d.WithHosts("OpenFrame.local")
+	var looked []string
+	var mu sync.Mutex
+	d.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		mu.Lock()
+		looked = append(looked, host)
+		mu.Unlock()
+		if host == "openframe.test" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"127.0.0.1"}, nil
+	}
+	result := d.checkDNS(context.Background())
+	assert.Equal(t, []string{"localhost", "openframe.local", "openframe.test"}, looked, "IP addresses and wildcards are skipped")
+	assert.Equal(t, StatusFail, result.Status)
+	assert.Equal(t, "openframe.test do not resolve", result.Message)
+	assert.Contains(t, result.Hint, "127.0.0.1 openframe.test")
+
+	d.dockerHost = "10.0.0.5"
+	assert.Contains(t, d.checkDNS(context.Background()).Hint, "Add '10.0.0.5 openframe.test' to /etc/hosts")
+}
+
+func TestDoctor_Run_ChecksRunConcurrently(t *testing.T) {
+	d, _ := newTestDoctor()
+	started := make(chan struct{})
+	var once sync.Once
+	d.lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		once.Do(func() { close(started) })
+		return []string{"127.0.0.1"}, nil
+	}
+	d.memoryMB = func() int {
+		// Blocks until the DNS check, listed after it, has started
+		<-started
+		return 32768
+	}
+
+	done := make(chan Report)
+	go func() { done <- d.Run(context.Background()) }()
+	select {
+	case report := <-done:
+		assert.Equal(t, 0, report.Count(StatusFail))
+	case <-time.After(5 * time.Second):
+		t.Fatal("checks ran one after the other")
+	}
+}
@@ -0,0 +1,77 @@
+package docker
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HostEnv selects the Docker daemon, for docker, k3d and the CLI alike
+const HostEnv = "DOCKER_HOST"
+
+// dialTimeout bounds the probe of a port on a remote Docker host
+const dialTimeout = 2 * time.Second
+
+// RemoteHost returns the address of the Docker host DOCKER_HOST points at, empty when
+// Docker runs on this machine
+func RemoteHost() string {
+	host, err := ParseRemoteHost(os.Getenv(HostEnv))
+	if err != nil {
+		return ""
+	}
+	return host
+}
+
+// ParseRemoteHost returns the host of a DOCKER_HOST value such as tcp://10.0.0.5:2376 or
+// ssh://user@devbox. It is empty for unix sockets, named pipes and loopback addresses,
+// whose containers publish their ports on this machine.
+func ParseRemoteHost(dockerHost string) (string, error) {
+	if dockerHost == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(dockerHost)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s %q: %w", HostEnv, dockerHost, err)
+	}
+	switch u.Scheme {
+	case "unix", "npipe", "fd":
+		return "", nil
+	case "tcp", "ssh", "http", "https":
+	default:
+		return "", fmt.Errorf("invalid %s %q: unsupported scheme %q", HostEnv, dockerHost, u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return "", fmt.Errorf("invalid %s %q: no host", HostEnv, dockerHost)
+	}
+	if IsLocalHost(host) {
+		return "", nil
+	}
+	return host, nil
+}
+
+// IsLocalHost reports whether host names this machine
+func IsLocalHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// IsPortAvailable reports whether nothing accepts connections on a TCP port of a remote
+// host. Ports the host filters look available; publishing them fails later in Docker.
+func IsPortAvailable(host string, port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), dialTimeout)
+	if err != nil {
+		return true
+	}
+	conn.Close()
+	return false
+}
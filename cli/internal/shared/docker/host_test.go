@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRemoteHost(t *testing.T) {
+	tests := []struct {
+		dockerHost string
+		want       string
+		wantErr    string
+	}{
+		{"", "", ""},
+		{"unix:///var/run/docker.sock", "", ""},
+		{"npipe:////./pipe/docker_engine", "", ""},
+		{"tcp://127.0.0.1:2375", "", ""},
+		{"tcp://localhost:2375", "", ""},
+		{"tcp://[::1]:2375", "", ""},
+		{"tcp://10.0.0.5:2376", "10.0.0.5", ""},
+		{"ssh://dev@BuildBox.example.com", "buildbox.example.com", ""},
+		{"ssh://dev@buildbox:2222", "buildbox", ""},
+		{"tcp://[fd00::5]:2375", "fd00::5", ""},
+		{"ftp://buildbox", "", `invalid DOCKER_HOST "ftp://buildbox": unsupported scheme "ftp"`},
+		{"tcp://", "", `invalid DOCKER_HOST "tcp://": no host`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dockerHost, func(t *testing.T) {
+			host, err := ParseRemoteHost(tt.dockerHost)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, host)
+		})
+	}
+}
+
+func TestRemoteHost(t *testing.T) {
+	t.Setenv(HostEnv, "ssh://dev@buildbox")
+	assert.Equal(t, "buildbox", RemoteHost())
+
+	t.Setenv(HostEnv, "ftp://buildbox")
+	assert.Empty(t, RemoteHost(), "an invalid DOCKER_HOST is left to docker to report")
+}
+
+func TestIsPortAvailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	assert.False(t, IsPortAvailable("127.0.0.1", port))
+
+	listener.Close()
+	assert.True(t, IsPortAvailable("127.0.0.1", port))
+}
@@ -39,6 +39,7 @@ type ExecuteOptions struct {
 	Dir     string            // Working directory
 	Env     map[string]string // Environment variables
 	Timeout time.Duration     // Execution timeout
+	Stdin   string            // Data written to the command's standard input
 }
 
 // RealCommandExecutor implements CommandExecutor using actual system commands
@@ -122,6 +123,11 @@ func (e *RealCommandExecutor) ExecuteWithOptions(ctx context.Context, options Ex
 		}
 	}
 	
+	// Feed standard input, kept in memory so that secrets never touch the disk
+	if options.Stdin != "" {
+		cmd.Stdin = strings.NewReader(options.Stdin)
+	}
+	
 	// Log command execution in verbose mode
 	if e.verbose {
 		fmt.Printf("Executing: %s\n", fullCommand)
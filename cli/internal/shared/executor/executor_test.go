@@ -193,6 +193,22 @@ func TestRealCommandExecutor_ExecuteWithOptions_WithTimeout(t *testing.T) {
 	assert.Contains(t, result.Stdout, "hello")
 }
 
+func TestRealCommandExecutor_ExecuteWithOptions_WithStdin(t *testing.T) {
+	executor := NewRealCommandExecutor(false, false)
+
+	options := ExecuteOptions{
+		Command: "cat",
+		Stdin:   "from stdin",
+		Timeout: 5 * time.Second,
+	}
+
+	ctx := context.Background()
+	result, err := executor.ExecuteWithOptions(ctx, options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "from stdin", result.Stdout)
+}
+
 func TestRealCommandExecutor_ExecuteWithOptions_WithEnv(t *testing.T) {
 	executor := NewRealCommandExecutor(false, false)
 
@@ -0,0 +1,438 @@
+package tools
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// stateFileName records what was installed into the bin directory
+const stateFileName = ".tools.json"
+
+// State is the state of a managed tool
+type State string
+
+const (
+	StateInstalled   State = "installed"   // The pinned version is installed
+	StateMissing     State = "missing"     // Not installed yet
+	StateOutdated    State = "outdated"    // Installed from an older pin
+	StateModified    State = "modified"    // The installed binary no longer matches what was installed
+	StateUnpinned    State = "unpinned"    // The manifest has no checksum for this platform
+	StateUnsupported State = "unsupported" // The manifest has no build for this platform
+)
+
+// Status describes a managed tool
+type Status struct {
+	Name      string
+	Version   string // Pinned version
+	Installed string // Installed version, empty when not installed
+	Path      string
+	State     State
+}
+
+// installRecord is what the state file keeps of an installed tool
+type installRecord struct {
+	Version      string `json:"version"`
+	SHA256       string `json:"sha256"`       // Checksum of the downloaded artifact
+	BinarySHA256 string `json:"binarySha256"` // Checksum of the installed binary
+}
+
+// Manager installs the tools of a manifest into a bin directory
+type Manager struct {
+	manifest Manifest
+	binDir   string
+	baseURL  string
+	platform string
+	client   *http.Client
+}
+
+// NewManager creates a manager installing into binDir. A non-empty baseURL replaces the
+// download location of every tool with <baseURL>/<tool>.
+func NewManager(manifest Manifest, binDir, baseURL string) *Manager {
+	return &Manager{
+		manifest: manifest,
+		binDir:   binDir,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		platform: currentPlatform(),
+		client:   http.DefaultClient,
+	}
+}
+
+// NewDefaultManager creates a manager for the configured manifest installing into
+// DefaultBinDir. An empty baseURL falls back to $OPENFRAME_TOOLS_BASE_URL.
+func NewDefaultManager(baseURL string) (*Manager, error) {
+	if baseURL == "" {
+		baseURL = os.Getenv(BaseURLEnv)
+	}
+	manifest, err := LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+	binDir, err := DefaultBinDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewManager(manifest, binDir, baseURL), nil
+}
+
+// BinDir returns the directory the tools are installed into
+func (m *Manager) BinDir() string {
+	return m.binDir
+}
+
+// Manifest returns the manifest the manager installs from
+func (m *Manager) Manifest() Manifest {
+	return m.manifest
+}
+
+// Status returns the state of every tool of the manifest, in manifest order. Installed
+// binaries are not hashed, Verify does that.
+func (m *Manager) Status() ([]Status, error) {
+	records, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.manifest.Tools))
+	for _, tool := range m.manifest.Tools {
+		statuses = append(statuses, m.status(tool, records))
+	}
+	return statuses, nil
+}
+
+// Verify returns the state of every tool like Status, hashing the installed binaries to
+// find the ones changed since they were installed
+func (m *Manager) Verify() ([]Status, error) {
+	records, err := m.loadState()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(m.manifest.Tools))
+	for _, tool := range m.manifest.Tools {
+		status := m.status(tool, records)
+		if status.Installed != "" {
+			sum, err := fileSHA256(status.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash %s: %w", status.Path, err)
+			}
+			if sum != records[tool.Name].BinarySHA256 {
+				status.State = StateModified
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Install downloads the pinned build of a tool, checks its SHA-256 and installs it into
+// the bin directory. A tool already installed at its pin is left alone unless force is set.
+func (m *Manager) Install(ctx context.Context, name string, force bool) (Status, error) {
+	tool, ok := m.manifest.Tool(name)
+	if !ok {
+		return Status{}, fmt.Errorf("unknown tool %s, the tool manifest lists %s", name, strings.Join(m.names(), ", "))
+	}
+	records, err := m.loadState()
+	if err != nil {
+		return Status{}, err
+	}
+	status := m.status(tool, records)
+	artifact, ok := tool.Artifact(m.platform)
+	if !ok {
+		return status, fmt.Errorf("the tool manifest has no %s build for %s", name, m.platform)
+	}
+	if artifact.SHA256 == "" {
+		return status, fmt.Errorf("the tool manifest has no SHA-256 for %s %s on %s, refusing to install an unverified download", name, tool.Version, m.platform)
+	}
+	if status.State == StateInstalled && !force {
+		return status, nil
+	}
+
+	if err := os.MkdirAll(m.binDir, 0755); err != nil {
+		return status, fmt.Errorf("failed to create %s: %w", m.binDir, err)
+	}
+	download, _, err := m.download(ctx, m.artifactURL(tool, artifact), artifact.SHA256)
+	if err != nil {
+		return status, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	defer os.Remove(download)
+
+	binarySum, err := m.installBinary(download, artifact, status.Path)
+	if err != nil {
+		return status, fmt.Errorf("failed to install %s: %w", name, err)
+	}
+
+	records[name] = installRecord{Version: tool.Version, SHA256: artifact.SHA256, BinarySHA256: binarySum}
+	if err := m.saveState(records); err != nil {
+		return status, err
+	}
+	return m.status(tool, records), nil
+}
+
+// Installable reports whether the manifest pins a checksummed build of a tool for this
+// platform
+func (m *Manager) Installable(name string) bool {
+	tool, ok := m.manifest.Tool(name)
+	if !ok {
+		return false
+	}
+	artifact, ok := tool.Artifact(m.platform)
+	return ok && artifact.SHA256 != ""
+}
+
+// Checksums downloads the artifacts of every tool that have no checksum yet, or all of
+// them with all, and returns their SHA-256 keyed by tool and platform
+func (m *Manager) Checksums(ctx context.Context, all bool) (map[string]map[string]string, error) {
+	checksums := map[string]map[string]string{}
+	for _, tool := range m.manifest.Tools {
+		platforms := make([]string, 0, len(tool.Platforms))
+		for platform := range tool.Platforms {
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
+
+		for _, platform := range platforms {
+			artifact := tool.Platforms[platform]
+			if artifact.SHA256 != "" && !all {
+				continue
+			}
+			download, sum, err := m.download(ctx, m.artifactURL(tool, artifact), "")
+			if err != nil {
+				return checksums, fmt.Errorf("failed to download %s for %s: %w", tool.Name, platform, err)
+			}
+			os.Remove(download)
+			if checksums[tool.Name] == nil {
+				checksums[tool.Name] = map[string]string{}
+			}
+			checksums[tool.Name][platform] = sum
+		}
+	}
+	return checksums, nil
+}
+
+// status returns the state of a tool from the state file records, without hashing
+func (m *Manager) status(tool Tool, records map[string]installRecord) Status {
+	status := Status{Name: tool.Name, Version: tool.Version, Path: m.binaryPath(tool.Name)}
+	artifact, supported := tool.Artifact(m.platform)
+
+	record, recorded := records[tool.Name]
+	if _, err := os.Stat(status.Path); recorded && err == nil {
+		status.Installed = record.Version
+		if record.Version != tool.Version || (artifact.SHA256 != "" && record.SHA256 != artifact.SHA256) {
+			status.State = StateOutdated
+		} else {
+			status.State = StateInstalled
+		}
+		return status
+	}
+
+	switch {
+	case !supported:
+		status.State = StateUnsupported
+	case artifact.SHA256 == "":
+		status.State = StateUnpinned
+	default:
+		status.State = StateMissing
+	}
+	return status
+}
+
+// artifactURL returns where an artifact is downloaded from
+func (m *Manager) artifactURL(tool Tool, artifact Artifact) string {
+	base := strings.TrimSuffix(tool.BaseURL, "/")
+	if m.baseURL != "" {
+		base = m.baseURL + "/" + tool.Name
+	}
+	return base + "/" + strings.TrimPrefix(artifact.Path, "/")
+}
+
+// download fetches url into a temporary file, checking it against sha256 unless that is
+// empty, and returns the file's path and checksum
+func (m *Manager) download(ctx context.Context, url, sha256 string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	file, err := os.CreateTemp("", "openframe-tool-*")
+	if err != nil {
+		return "", "", err
+	}
+	sum, err := copyHashed(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", "", fmt.Errorf("GET %s: %w", url, err)
+	}
+	if sha256 != "" && sum != sha256 {
+		os.Remove(file.Name())
+		return "", "", fmt.Errorf("checksum mismatch for %s: expected sha256 %s, got %s", url, sha256, sum)
+	}
+	return file.Name(), sum, nil
+}
+
+// installBinary moves the binary of a verified download to path, taking it out of the
+// archive first, and returns the binary's checksum
+func (m *Manager) installBinary(download string, artifact Artifact, path string) (string, error) {
+	source, err := os.Open(download)
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+
+	var binary io.Reader = source
+	switch archiveFormat(artifact.Path) {
+	case "tar.gz":
+		if binary, err = tarMember(source, artifact.Binary); err != nil {
+			return "", err
+		}
+	case "zip":
+		info, err := source.Stat()
+		if err != nil {
+			return "", err
+		}
+		member, err := zipMember(source, info.Size(), artifact.Binary)
+		if err != nil {
+			return "", err
+		}
+		defer member.Close()
+		binary = member
+	}
+
+	// Write next to the target and rename, so a running binary is never half written
+	file, err := os.CreateTemp(m.binDir, ".install-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	sum, err := copyHashed(file, binary)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := os.Chmod(file.Name(), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+// binaryPath returns where a tool is installed
+func (m *Manager) binaryPath(name string) string {
+	if strings.HasPrefix(m.platform, "windows/") {
+		name += ".exe"
+	}
+	return filepath.Join(m.binDir, name)
+}
+
+// names returns the names of the tools of the manifest
+func (m *Manager) names() []string {
+	names := make([]string, 0, len(m.manifest.Tools))
+	for _, tool := range m.manifest.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func (m *Manager) loadState() (map[string]installRecord, error) {
+	records := map[string]installRecord{}
+	data, err := os.ReadFile(filepath.Join(m.binDir, stateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read installed tools: %w", err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(m.binDir, stateFileName), err)
+	}
+	return records, nil
+}
+
+func (m *Manager) saveState(records map[string]installRecord) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.binDir, stateFileName), data, 0644); err != nil {
+		return fmt.Errorf("failed to record installed tools: %w", err)
+	}
+	return nil
+}
+
+// tarMember returns the reader of a file inside a tar.gz archive
+func tarMember(archive io.Reader, name string) (io.Reader, error) {
+	gz, err := gzip.NewReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%s not found in the archive", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeReg && strings.TrimPrefix(header.Name, "./") == name {
+			return tr, nil
+		}
+	}
+}
+
+// zipMember opens a file inside a zip archive
+func zipMember(archive io.ReaderAt, size int64, name string) (io.ReadCloser, error) {
+	zr, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range zr.File {
+		if file.Name == name {
+			return file.Open()
+		}
+	}
+	return nil, fmt.Errorf("%s not found in the archive", name)
+}
+
+// copyHashed copies src to dst and returns the SHA-256 of what was copied
+func copyHashed(dst io.Writer, src io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, hash), src); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return copyHashed(io.Discard, file)
+}
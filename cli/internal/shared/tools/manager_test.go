@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func tarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: 2, Typeflag: tar.TypeReg}))
+	_, _ = tw.Write([]byte("hi"))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, _ = tw.Write(content)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func zipped(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	require.NoError(t, err)
+	_, _ = w.Write(content)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// newTestManager serves the artifacts of a kubectl binary, a helm tar.gz and a docker zip
+// from a local file server, laid out as a mirror for --base-url
+func newTestManager(t *testing.T) (*Manager, *int) {
+	t.Helper()
+	kubectl := []byte("kubectl binary")
+	helm := tarGz(t, "linux-amd64/helm", []byte("helm binary"))
+	docker := zipped(t, "docker/docker", []byte("docker binary"))
+
+	files := map[string][]byte{
+		"/kubectl/v1.32.2/kubectl":              kubectl,
+		"/helm/helm-v3.17.1-linux-amd64.tar.gz": helm,
+		"/docker/docker-27.5.1.zip":             docker,
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	manifest := Manifest{Tools: []Tool{
+		{Name: "kubectl", Version: "v1.32.2", BaseURL: "https://dl.k8s.io/release", Platforms: map[string]Artifact{
+			"linux/amd64": {Path: "v1.32.2/kubectl", SHA256: sha(kubectl)},
+		}},
+		{Name: "helm", Version: "v3.17.1", BaseURL: "https://get.helm.sh", Platforms: map[string]Artifact{
+			"linux/amd64": {Path: "helm-v3.17.1-linux-amd64.tar.gz", SHA256: sha(helm), Binary: "linux-amd64/helm"},
+		}},
+		{Name: "docker", Version: "27.5.1", BaseURL: "https://download.docker.com", Platforms: map[string]Artifact{
+			"linux/amd64": {Path: "docker-27.5.1.zip", SHA256: sha(docker), Binary: "docker/docker"},
+		}},
+		{Name: "jq", Version: "1.7.1", BaseURL: "https://github.com/jqlang/jq/releases/download", Platforms: map[string]Artifact{
+			"linux/amd64": {Path: "jq-1.7.1/jq-linux-amd64"},
+		}},
+		{Name: "telepresence", Version: "v2.22.4", BaseURL: "https://github.com", Platforms: map[string]Artifact{
+			"darwin/arm64": {Path: "telepresence-darwin-arm64"},
+		}},
+	}}
+	manager := NewManager(manifest, filepath.Join(t.TempDir(), "bin"), server.URL+"/")
+	manager.platform = "linux/amd64"
+	return manager, &requests
+}
+
+func TestManager_Install(t *testing.T) {
+	manager, requests := newTestManager(t)
+	ctx := context.Background()
+
+	for name, content := range map[string]string{"kubectl": "kubectl binary", "helm": "helm binary", "docker": "docker binary"} {
+		status, err := manager.Install(ctx, name, false)
+		require.NoError(t, err, name)
+		assert.Equal(t, StateInstalled, status.State, name)
+		assert.Equal(t, filepath.Join(manager.BinDir(), name), status.Path)
+
+		data, err := os.ReadFile(status.Path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data), "%s is taken out of its archive", name)
+		info, err := os.Stat(status.Path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	}
+	assert.Equal(t, 3, *requests)
+
+	_, err := manager.Install(ctx, "kubectl", false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, *requests, "a tool installed at its pin is not downloaded again")
+	_, err = manager.Install(ctx, "kubectl", true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, *requests)
+
+	entries, err := os.ReadDir(manager.BinDir())
+	require.NoError(t, err)
+	assert.Len(t, entries, 4, "no temporary files are left next to the tools and the state file")
+}
+
+func TestManager_Install_Refused(t *testing.T) {
+	manager, requests := newTestManager(t)
+	ctx := context.Background()
+
+	_, err := manager.Install(ctx, "jq", false)
+	assert.ErrorContains(t, err, "no SHA-256 for jq 1.7.1 on linux/amd64")
+	_, err = manager.Install(ctx, "telepresence", false)
+	assert.ErrorContains(t, err, "no telepresence build for linux/amd64")
+	_, err = manager.Install(ctx, "yq", false)
+	assert.ErrorContains(t, err, "unknown tool yq, the tool manifest lists kubectl, helm, docker, jq, telepresence")
+	assert.Zero(t, *requests)
+
+	tool := manager.manifest.Tools[0]
+	tool.Platforms = map[string]Artifact{"linux/amd64": {Path: "v1.32.2/kubectl", SHA256: sha([]byte("something else"))}}
+	manager.manifest.Tools[0] = tool
+	_, err = manager.Install(ctx, "kubectl", false)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	assert.NoFileExists(t, filepath.Join(manager.BinDir(), "kubectl"))
+}
+
+func TestManager_StatusAndVerify(t *testing.T) {
+	manager, _ := newTestManager(t)
+	_, err := manager.Install(context.Background(), "kubectl", false)
+	require.NoError(t, err)
+	_, err = manager.Install(context.Background(), "helm", false)
+	require.NoError(t, err)
+
+	states := func(statuses []Status) map[string]State {
+		result := map[string]State{}
+		for _, status := range statuses {
+			result[status.Name] = status.State
+		}
+		return result
+	}
+
+	statuses, err := manager.Status()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]State{
+		"kubectl":      StateInstalled,
+		"helm":         StateInstalled,
+		"docker":       StateMissing,
+		"jq":           StateUnpinned,
+		"telepresence": StateUnsupported,
+	}, states(statuses))
+
+	require.NoError(t, os.WriteFile(filepath.Join(manager.BinDir(), "helm"), []byte("tampered"), 0755))
+	manager.manifest.Tools[0].Version = "v1.33.0"
+
+	statuses, err = manager.Status()
+	require.NoError(t, err)
+	assert.Equal(t, StateInstalled, states(statuses)["helm"], "status does not hash the binaries")
+	assert.Equal(t, StateOutdated, states(statuses)["kubectl"])
+	assert.Equal(t, "v1.32.2", statuses[0].Installed)
+
+	statuses, err = manager.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, StateModified, states(statuses)["helm"])
+	assert.Equal(t, StateOutdated, states(statuses)["kubectl"])
+}
+
+func TestManager_ArtifactURL(t *testing.T) {
+	tool := Tool{Name: "k3d", BaseURL: "https://github.com/k3d-io/k3d/releases/download/"}
+	artifact := Artifact{Path: "v5.8.3/k3d-linux-amd64"}
+
+	assert.Equal(t, "https://github.com/k3d-io/k3d/releases/download/v5.8.3/k3d-linux-amd64",
+		NewManager(Manifest{}, "", "").artifactURL(tool, artifact))
+	assert.Equal(t, "http://localhost:8080/tools/k3d/v5.8.3/k3d-linux-amd64",
+		NewManager(Manifest{}, "", "http://localhost:8080/tools/").artifactURL(tool, artifact))
+}
+
+func TestManager_Checksums(t *testing.T) {
+	manager, _ := newTestManager(t)
+	manager.manifest.Tools = manager.manifest.Tools[:1]
+	manager.manifest.Tools = append(manager.manifest.Tools, Tool{Name: "helm", Version: "v3.17.1", BaseURL: "https://get.helm.sh", Platforms: map[string]Artifact{
+		"linux/amd64": {Path: "helm-v3.17.1-linux-amd64.tar.gz", Binary: "linux-amd64/helm"},
+	}})
+
+	checksums, err := manager.Checksums(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, checksums, 1, "pinned artifacts are not downloaded again")
+	assert.Len(t, checksums["helm"]["linux/amd64"], 64)
+
+	checksums, err = manager.Checksums(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, sha([]byte("kubectl binary")), checksums["kubectl"]["linux/amd64"])
+}
+
+func TestPrependToPath(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	t.Setenv("PATH", "/usr/bin"+sep+"/opt/bin"+sep+"/usr/local/bin")
+
+	require.NoError(t, PrependToPath("/opt/bin"))
+	assert.Equal(t, "/opt/bin"+sep+"/usr/bin"+sep+"/usr/local/bin", os.Getenv("PATH"))
+
+	require.NoError(t, PrependToPath("/opt/bin"))
+	assert.Equal(t, "/opt/bin"+sep+"/usr/bin"+sep+"/usr/local/bin", os.Getenv("PATH"), "the directory is listed once")
+}
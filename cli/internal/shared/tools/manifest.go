@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ManifestEnv names a tool manifest file to use instead of the one built into the CLI
+	ManifestEnv = "OPENFRAME_TOOLS_MANIFEST"
+	// BaseURLEnv names a mirror serving every artifact as <mirror>/<tool>/<path>
+	BaseURLEnv = "OPENFRAME_TOOLS_BASE_URL"
+)
+
+//go:embed manifest.yaml
+var defaultManifest []byte
+
+// Manifest pins the version, download and checksum of every managed tool
+type Manifest struct {
+	Tools []Tool `yaml:"tools"`
+}
+
+// Tool is one managed tool and its builds
+type Tool struct {
+	Name        string              `yaml:"name"`
+	Version     string              `yaml:"version"`
+	Description string              `yaml:"description,omitempty"`
+	BaseURL     string              `yaml:"baseURL"`
+	Platforms   map[string]Artifact `yaml:"platforms"` // Keyed by "<os>/<arch>"
+}
+
+// Artifact is the download of a tool for one platform
+type Artifact struct {
+	Path   string `yaml:"path"`
+	SHA256 string `yaml:"sha256,omitempty"`
+	Binary string `yaml:"binary,omitempty"` // Executable inside an archive, empty for a bare binary
+}
+
+// ParseManifest parses and checks a tool manifest
+func ParseManifest(data []byte) (Manifest, error) {
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse tool manifest: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for _, tool := range manifest.Tools {
+		if tool.Name == "" || tool.Version == "" || tool.BaseURL == "" {
+			return manifest, fmt.Errorf("tool manifest entry %q needs a name, version and baseURL", tool.Name)
+		}
+		if seen[tool.Name] {
+			return manifest, fmt.Errorf("tool %s is listed twice in the tool manifest", tool.Name)
+		}
+		seen[tool.Name] = true
+		for platform, artifact := range tool.Platforms {
+			if artifact.Path == "" {
+				return manifest, fmt.Errorf("tool %s has no path for %s", tool.Name, platform)
+			}
+			if artifact.SHA256 != "" && !isSHA256(artifact.SHA256) {
+				return manifest, fmt.Errorf("tool %s has an invalid sha256 for %s", tool.Name, platform)
+			}
+			if archiveFormat(artifact.Path) != "" && artifact.Binary == "" {
+				return manifest, fmt.Errorf("tool %s needs the binary inside %s for %s", tool.Name, artifact.Path, platform)
+			}
+		}
+	}
+	return manifest, nil
+}
+
+// LoadManifest returns the manifest named by $OPENFRAME_TOOLS_MANIFEST, or else the one
+// built into the CLI
+func LoadManifest() (Manifest, error) {
+	path := os.Getenv(ManifestEnv)
+	if path == "" {
+		return ParseManifest(defaultManifest)
+	}
+	return LoadManifestFile(path)
+}
+
+// LoadManifestFile reads a tool manifest file
+func LoadManifestFile(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read tool manifest: %w", err)
+	}
+	return ParseManifest(data)
+}
+
+// Tool returns the named tool
+func (m Manifest) Tool(name string) (Tool, bool) {
+	for _, tool := range m.Tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+// Artifact returns the build of a tool for a platform such as "linux/amd64"
+func (t Tool) Artifact(platform string) (Artifact, bool) {
+	artifact, ok := t.Platforms[platform]
+	return artifact, ok
+}
+
+// currentPlatform returns the "<os>/<arch>" of the running CLI
+func currentPlatform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
+}
+
+// archiveFormat returns "tar.gz" or "zip" for archives, empty for a bare binary
+func archiveFormat(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(path, ".zip"):
+		return "zip"
+	default:
+		return ""
+	}
+}
+
+// isSHA256 reports whether value is a lower case hex SHA-256
+func isSHA256(value string) bool {
+	if len(value) != 64 {
+		return false
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// SetChecksums writes checksums, keyed by tool and then platform, into the manifest file
+// at path, keeping its comments and layout
+func SetChecksums(path string, checksums map[string]map[string]string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read tool manifest: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse tool manifest: %w", err)
+	}
+	var tools *yaml.Node
+	if len(doc.Content) > 0 {
+		tools = mappingValue(doc.Content[0], "tools")
+	}
+	if tools == nil {
+		return fmt.Errorf("tool manifest %s has no tools", path)
+	}
+
+	for _, toolNode := range tools.Content {
+		name := mappingValue(toolNode, "name")
+		if name == nil {
+			continue
+		}
+		platforms := mappingValue(toolNode, "platforms")
+		if platforms == nil {
+			continue
+		}
+		for i := 0; i+1 < len(platforms.Content); i += 2 {
+			sum, ok := checksums[name.Value][platforms.Content[i].Value]
+			if !ok {
+				continue
+			}
+			artifact := platforms.Content[i+1]
+			if node := mappingValue(artifact, "sha256"); node != nil {
+				node.Value = sum
+				continue
+			}
+			artifact.Content = append(artifact.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "sha256"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: sum})
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode tool manifest: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode tool manifest: %w", err)
+	}
+	return os.WriteFile(path, out.Bytes(), 0644)
+}
+
+// mappingValue returns the value of key in a YAML mapping node, nil when it is missing
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultManifest(t *testing.T) {
+	manifest, err := ParseManifest(defaultManifest)
+	require.NoError(t, err)
+
+	for _, name := range []string{"docker", "k3d", "kubectl", "helm", "telepresence", "skaffold", "jq"} {
+		tool, ok := manifest.Tool(name)
+		require.True(t, ok, name)
+		for _, platform := range []string{"linux/amd64", "linux/arm64", "darwin/amd64", "darwin/arm64"} {
+			artifact, ok := tool.Artifact(platform)
+			require.True(t, ok, "%s has a %s build", name, platform)
+			assert.Contains(t, artifact.Path, strings.TrimPrefix(tool.Version, "v"), "%s %s path follows the pinned version", name, platform)
+		}
+	}
+}
+
+func TestDefaultManifest_Checksums(t *testing.T) {
+	manifest, err := ParseManifest(defaultManifest)
+	require.NoError(t, err)
+
+	// Artifacts without a checksum are refused, so every one of them must be pinned
+	var missing []string
+	for _, tool := range manifest.Tools {
+		for platform, artifact := range tool.Platforms {
+			if artifact.SHA256 == "" {
+				missing = append(missing, tool.Name+" "+platform)
+			}
+		}
+	}
+	sort.Strings(missing)
+	assert.Empty(t, missing, "artifacts without a sha256, record them with 'make tools-pin'")
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{"missing version", "tools:\n  - name: jq\n    baseURL: https://x\n", `"jq" needs a name, version and baseURL`},
+		{"duplicate", "tools:\n  - {name: jq, version: '1', baseURL: https://x}\n  - {name: jq, version: '2', baseURL: https://x}\n", "jq is listed twice"},
+		{"bad checksum", "tools:\n  - name: jq\n    version: '1'\n    baseURL: https://x\n    platforms:\n      linux/amd64: {path: jq, sha256: abc}\n", "invalid sha256 for linux/amd64"},
+		{"archive without binary", "tools:\n  - name: helm\n    version: '1'\n    baseURL: https://x\n    platforms:\n      linux/amd64: {path: helm.tar.gz}\n", "needs the binary inside helm.tar.gz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.manifest))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoadManifest_Env(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tools.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("tools:\n  - {name: jq, version: '1.7.1', baseURL: https://x}\n"), 0644))
+	t.Setenv(ManifestEnv, path)
+
+	manifest, err := LoadManifest()
+	require.NoError(t, err)
+	require.Len(t, manifest.Tools, 1)
+	assert.Equal(t, "jq", manifest.Tools[0].Name)
+}
+
+func TestSetChecksums(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	require.NoError(t, os.WriteFile(path, defaultManifest, 0644))
+	kubectlSum := strings.Repeat("a", 64)
+	jqSum := strings.Repeat("b", 64)
+
+	require.NoError(t, SetChecksums(path, map[string]map[string]string{
+		"kubectl": {"linux/amd64": kubectlSum},
+		"jq":      {"darwin/arm64": jqSum},
+	}))
+	require.NoError(t, SetChecksums(path, map[string]map[string]string{
+		"kubectl": {"linux/amd64": jqSum},
+	}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Tools installed by 'openframe tools install'", "comments are kept")
+
+	manifest, err := ParseManifest(data)
+	require.NoError(t, err)
+	kubectl, _ := manifest.Tool("kubectl")
+	assert.Equal(t, jqSum, kubectl.Platforms["linux/amd64"].SHA256, "an existing checksum is replaced")
+	assert.Empty(t, kubectl.Platforms["linux/arm64"].SHA256)
+	jq, _ := manifest.Tool("jq")
+	assert.Equal(t, jqSum, jq.Platforms["darwin/arm64"].SHA256)
+}
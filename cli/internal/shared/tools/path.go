@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultBinDir returns ~/.local/share/openframe/bin
+func DefaultBinDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".local", "share", "openframe", "bin"), nil
+}
+
+// PrependToPath puts dir first on the PATH of the CLI, so the tools installed there are
+// found before the system ones, by the CLI and by every command it runs
+func PrependToPath(dir string) error {
+	path := os.Getenv("PATH")
+	entries := []string{dir}
+	for _, entry := range filepath.SplitList(path) {
+		if entry != dir && entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return os.Setenv("PATH", strings.Join(entries, string(os.PathListSeparator)))
+}
+
+// UseBinDir puts the default bin directory first on the PATH of the CLI
+func UseBinDir() error {
+	binDir, err := DefaultBinDir()
+	if err != nil {
+		return err
+	}
+	return PrependToPath(binDir)
+}
+
+// InstallPinned installs a tool from the tool manifest when the manifest pins a
+// checksummed build of it for this platform. installed is false when it does not, and
+// the caller falls back to its own install. The pinned, checksummed build from the tool
+// manifest wins over package managers, so prerequisite installers call this first.
+func InstallPinned(ctx context.Context, name string) (installed bool, err error) {
+	manager, err := NewDefaultManager("")
+	if err != nil {
+		return false, err
+	}
+	if !manager.Installable(name) {
+		return false, nil
+	}
+	if _, err := manager.Install(ctx, name, false); err != nil {
+		return true, err
+	}
+	return true, nil
+}
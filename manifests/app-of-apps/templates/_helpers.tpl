@@ -52,3 +52,24 @@ Complete validation: validates both deployment and ingress configuration
 {{- include "chart.validateDeployment" . -}}
 {{- include "chart.validateIngress" . -}}
 {{- end -}}
+
+{{/*
+Tenant helpers: a release installed with tenant.id (openframe chart install --tenant) suffixes
+its resource names with the tenant ID and deploys into the tenant project, so that several
+tenants can share one ArgoCD. Without a tenant the names are unchanged.
+*/}}
+{{- define "chart.tenantID" -}}
+{{- (.Values.tenant | default dict).id | default "" -}}
+{{- end -}}
+
+{{- define "chart.nameSuffix" -}}
+{{- with include "chart.tenantID" . }}-{{ . }}{{ end -}}
+{{- end -}}
+
+{{- define "chart.project" -}}
+{{- if include "chart.tenantID" . -}}
+{{- required "tenant.project is required when tenant.id is set" .Values.tenant.project -}}
+{{- else -}}
+default
+{{- end -}}
+{{- end -}}
@@ -18,6 +18,7 @@ Rules:
 4. If deployment.oss.enabled and ingress.ngrok.enabled → skip "ingress-nginx"
 5. If deployment.saas.enabled → skip "openframe-config" "openframe-client" "openframe-api" "openframe-authorization-server" and "ngrok-operator"
 6. If deployment.saas.enabled and ingress.gcp.enabled → skip "ingress-nginx"
+7. If tenant.id is set → skip the "namespace-*" apps, ArgoCD creates the tenant namespaces
 */}}
 
 {{- define "app.skip" -}}
@@ -54,6 +55,8 @@ Rules:
   true
 {{- else if and $saas $saasGcp (eq $name "ingress-nginx") }}
   true
+{{- else if and ($vals.tenant | default dict).id (hasPrefix "namespace-" $name) }}
+  true
 {{- else }}
   false
 {{- end }}